@@ -211,10 +211,10 @@ export default class Session {
     }
   }
 
-  login(username, password, token) {
+  login(username, password, token, passcode) {
     this.deleteId();
 
-    return Api.post("session", { username, password, token }).then((resp) => {
+    return Api.post("session", { username, password, token, passcode }).then((resp) => {
       this.setConfig(resp.data.config);
       this.setId(resp.data.id);
       this.setData(resp.data.data);
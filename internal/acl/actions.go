@@ -19,4 +19,5 @@ const (
 	ActionComment    Action = "comment"
 	ActionExport     Action = "export"
 	ActionImport     Action = "import"
+	ActionIndex      Action = "index"
 )
@@ -8,6 +8,8 @@ const (
 	ResourceConfigOptions Resource = "config_options"
 	ResourceSettings      Resource = "settings"
 	ResourceLogs          Resource = "logs"
+	ResourceAudit         Resource = "audit"
+	ResourceDuplicates    Resource = "duplicates"
 	ResourceAccounts      Resource = "accounts"
 	ResourceSubjects      Resource = "subjects"
 	ResourceAlbums        Resource = "albums"
@@ -22,6 +24,7 @@ const (
 	ResourceGeo           Resource = "geo"
 	ResourcePasswords     Resource = "passwords"
 	ResourceUsers         Resource = "users"
+	ResourceSessions      Resource = "sessions"
 	ResourcePhotos        Resource = "photos"
 	ResourcePrivate       Resource = "private"
 	ResourcePlaces        Resource = "places"
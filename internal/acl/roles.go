@@ -15,6 +15,7 @@ const (
 	RoleViewer  Role = "viewer"
 	RoleGuest   Role = "guest"
 	RoleDefault Role = "*"
+	RoleNone    Role = "none"
 )
 
 // String returns the type as string.
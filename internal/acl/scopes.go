@@ -0,0 +1,114 @@
+package acl
+
+import (
+	"strings"
+)
+
+// Scope represents a permission that can be granted to an access token.
+type Scope string
+type Scopes []Scope
+
+const (
+	ScopeIndex    Scope = "index"
+	ScopeImport   Scope = "import"
+	ScopeUpload   Scope = "upload"
+	ScopeDownload Scope = "download"
+	ScopeSearch   Scope = "search"
+	ScopeShare    Scope = "share"
+	ScopeEdit     Scope = "edit"
+	ScopeComment  Scope = "comment"
+	ScopeDelete   Scope = "delete"
+)
+
+// AllScopes lists all supported access token scopes.
+var AllScopes = Scopes{ScopeIndex, ScopeImport, ScopeUpload, ScopeDownload, ScopeSearch, ScopeShare, ScopeEdit, ScopeComment, ScopeDelete}
+
+// ScopeActions maps actions to the scope an access token requires to perform them.
+var ScopeActions = map[Action]Scope{
+	ActionSearch:     ScopeSearch,
+	ActionRead:       ScopeSearch,
+	ActionIndex:      ScopeIndex,
+	ActionImport:     ScopeImport,
+	ActionUpload:     ScopeUpload,
+	ActionDownload:   ScopeDownload,
+	ActionExport:     ScopeDownload,
+	ActionShare:      ScopeShare,
+	ActionCreate:     ScopeEdit,
+	ActionUpdate:     ScopeEdit,
+	ActionUpdateSelf: ScopeEdit,
+	ActionLike:       ScopeEdit,
+	ActionPrivate:    ScopeEdit,
+	ActionComment:    ScopeComment,
+	ActionDelete:     ScopeDelete,
+}
+
+// ProtectedResources cannot be accessed with access tokens, so that tokens cannot be used to change credentials.
+var ProtectedResources = map[Resource]bool{
+	ResourceUsers:         true,
+	ResourcePasswords:     true,
+	ResourceAccounts:      true,
+	ResourceConfigOptions: true,
+}
+
+// ParseScope returns the scope matching the string, or an empty scope if it is unknown.
+func ParseScope(s string) Scope {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	for _, scope := range AllScopes {
+		if string(scope) == s {
+			return scope
+		}
+	}
+
+	return ""
+}
+
+// ParseScopes returns the scopes contained in a comma or space separated list.
+func ParseScopes(s string) (result Scopes) {
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if scope := ParseScope(v); scope != "" {
+			result = append(result, scope)
+		}
+	}
+
+	return result
+}
+
+// String returns the scopes as comma separated string.
+func (s Scopes) String() string {
+	result := make([]string, len(s))
+
+	for i, scope := range s {
+		result[i] = string(scope)
+	}
+
+	return strings.Join(result, ",")
+}
+
+// Contains checks if the list contains the scope.
+func (s Scopes) Contains(scope Scope) bool {
+	for _, v := range s {
+		if v == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allow checks if the scopes permit the action on the resource.
+func (s Scopes) Allow(resource Resource, action Action) bool {
+	if ProtectedResources[resource] {
+		return false
+	} else if resource == ResourceSettings && action != ActionRead {
+		return false
+	} else if resource == ResourceConfig && action == ActionRead {
+		return true
+	}
+
+	if scope, ok := ScopeActions[action]; !ok {
+		return false
+	} else {
+		return s.Contains(scope)
+	}
+}
@@ -0,0 +1,40 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopes(t *testing.T) {
+	assert.Equal(t, Scopes{ScopeSearch, ScopeDownload}, ParseScopes("search, Download foo"))
+	assert.Equal(t, "search,download", ParseScopes("search,download").String())
+	assert.Nil(t, ParseScopes(""))
+	assert.Equal(t, Scope(""), ParseScope("admin"))
+}
+
+func TestScopes_Allow(t *testing.T) {
+	s := Scopes{ScopeSearch, ScopeUpload}
+
+	t.Run("photos/search", func(t *testing.T) {
+		assert.True(t, s.Allow(ResourcePhotos, ActionSearch))
+		assert.True(t, s.Allow(ResourcePhotos, ActionRead))
+	})
+	t.Run("photos/upload", func(t *testing.T) {
+		assert.True(t, s.Allow(ResourcePhotos, ActionUpload))
+	})
+	t.Run("photos/update", func(t *testing.T) {
+		assert.False(t, s.Allow(ResourcePhotos, ActionUpdate))
+		assert.False(t, s.Allow(ResourcePhotos, ActionDefault))
+	})
+	t.Run("config/read", func(t *testing.T) {
+		assert.True(t, Scopes{}.Allow(ResourceConfig, ActionRead))
+	})
+	t.Run("protected", func(t *testing.T) {
+		all := AllScopes
+		assert.False(t, all.Allow(ResourcePasswords, ActionUpdate))
+		assert.False(t, all.Allow(ResourceUsers, ActionSearch))
+		assert.False(t, all.Allow(ResourceSettings, ActionUpdate))
+		assert.True(t, all.Allow(ResourceSettings, ActionRead))
+	})
+}
@@ -52,6 +52,12 @@ func GetAlbum(router *gin.RouterGroup) {
 		}
 
 		id := clean.IdString(c.Param("uid"))
+
+		if AlbumOutOfScope(s, id) {
+			AbortAlbumNotFound(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(id)
 
 		if err != nil {
@@ -63,6 +69,41 @@ func GetAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// GetAlbumTrip returns the start and end date, countries visited, and route of a trip album as JSON.
+//
+// GET /api/v1/albums/:uid/trip
+func GetAlbumTrip(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/trip", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		id := clean.IdString(c.Param("uid"))
+
+		if AlbumOutOfScope(s, id) {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		if _, err := query.AlbumByUID(id); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		trip := entity.FindTrip(id)
+
+		if trip == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, trip)
+	})
+}
+
 // CreateAlbum adds a new album.
 //
 // POST /api/v1/albums
@@ -82,15 +123,28 @@ func CreateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		folder, ok := UserScope(s)
+
+		if !ok {
+			AbortUnauthorized(c)
+			return
+		}
+
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
 		a := entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
 		a.AlbumFavorite = f.AlbumFavorite
 
+		// Users with their own originals folder may only restore albums they created.
+		if folder != "" {
+			a.CreatedBy = s.User.UserUID
+		}
+
 		// Existing album?
 		if err := a.Find(); err != nil {
 			// Not found, create new album.
+			a.CreatedBy = s.User.UserUID
 			err = a.Create()
 
 			// Should never happen.
@@ -118,6 +172,8 @@ func CreateAlbum(router *gin.RouterGroup) {
 			}
 		}
 
+		Audit(c, s, acl.ResourceAlbums, entity.AuditCreate, nil, a.AlbumUID)
+
 		// Publish event and create/update YAML backup.
 		UpdateClientConfig()
 		PublishAlbumEvent(EntityCreated, a.AlbumUID, c)
@@ -141,6 +197,16 @@ func UpdateAlbum(router *gin.RouterGroup) {
 		}
 
 		uid := clean.IdString(c.Param("uid"))
+
+		if AlbumOutOfScope(s, uid) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, uid) {
+			AuditDenied(c, s, acl.ResourceAlbums, entity.AuditUpdate, uid)
+			AbortForbidden(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(uid)
 
 		if err != nil {
@@ -165,7 +231,11 @@ func UpdateAlbum(router *gin.RouterGroup) {
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
-		if err = a.SaveForm(f); err != nil {
+		err = a.SaveForm(f)
+
+		Audit(c, s, acl.ResourceAlbums, entity.AuditUpdate, err, uid)
+
+		if err != nil {
 			log.Error(err)
 			AbortSaveFailed(c)
 			return
@@ -197,6 +267,15 @@ func DeleteAlbum(router *gin.RouterGroup) {
 
 		id := clean.IdString(c.Param("uid"))
 
+		if AlbumOutOfScope(s, id) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, id) {
+			AuditDenied(c, s, acl.ResourceAlbums, entity.AuditDelete, id)
+			AbortForbidden(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(id)
 
 		if err != nil {
@@ -216,6 +295,8 @@ func DeleteAlbum(router *gin.RouterGroup) {
 			err = a.DeletePermanently()
 		}
 
+		Audit(c, s, acl.ResourceAlbums, entity.AuditDelete, err, a.AlbumUID)
+
 		if err != nil {
 			log.Errorf("album: %s (delete)", err)
 			AbortDeleteFailed(c)
@@ -251,6 +332,15 @@ func LikeAlbum(router *gin.RouterGroup) {
 		}
 
 		id := clean.IdString(c.Param("uid"))
+
+		if AlbumOutOfScope(s, id) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, id) {
+			AbortForbidden(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(id)
 
 		if err != nil {
@@ -290,6 +380,15 @@ func DislikeAlbum(router *gin.RouterGroup) {
 		}
 
 		id := clean.IdString(c.Param("uid"))
+
+		if AlbumOutOfScope(s, id) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, id) {
+			AbortForbidden(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(id)
 
 		if err != nil {
@@ -324,6 +423,15 @@ func CloneAlbums(router *gin.RouterGroup) {
 			return
 		}
 
+		if AlbumOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AuditDenied(c, s, acl.ResourceAlbums, entity.AuditAddPhotos, clean.IdString(c.Param("uid")))
+			AbortForbidden(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(clean.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -340,7 +448,14 @@ func CloneAlbums(router *gin.RouterGroup) {
 
 		var added []entity.PhotoAlbum
 
+		folder, _ := UserScope(s)
+
 		for _, uid := range f.Albums {
+			if AlbumOutOfScope(s, uid) {
+				log.Warnf("album: %s is out of scope", clean.Log(uid))
+				continue
+			}
+
 			cloneAlbum, err := query.AlbumByUID(uid)
 
 			if err != nil {
@@ -348,7 +463,7 @@ func CloneAlbums(router *gin.RouterGroup) {
 				continue
 			}
 
-			photos, err := search.AlbumPhotos(cloneAlbum, 10000, false)
+			photos, err := search.ScopedAlbumPhotos(cloneAlbum, 10000, false, folder, s.Shares)
 
 			if err != nil {
 				log.Errorf("album: %s", err)
@@ -358,6 +473,8 @@ func CloneAlbums(router *gin.RouterGroup) {
 			added = append(added, a.AddPhotos(photos.UIDs())...)
 		}
 
+		Audit(c, s, acl.ResourceAlbums, entity.AuditAddPhotos, nil, a.AlbumUID)
+
 		if len(added) > 0 {
 			event.SuccessMsg(i18n.MsgSelectionAddedTo, clean.Log(a.Title()))
 
@@ -382,6 +499,15 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		if AlbumOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AuditDenied(c, s, acl.ResourceAlbums, entity.AuditAddPhotos, clean.IdString(c.Param("uid")))
+			AbortForbidden(c)
+			return
+		}
+
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -395,6 +521,9 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		// Fetch selection from index.
@@ -408,6 +537,8 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 
 		added := a.AddPhotos(photos.UIDs())
 
+		Audit(c, s, acl.ResourceAlbums, entity.AuditAddPhotos, nil, a.AlbumUID)
+
 		if len(added) > 0 {
 			if len(added) == 1 {
 				event.SuccessMsg(i18n.MsgEntryAddedTo, clean.Log(a.Title()))
@@ -438,6 +569,15 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		if AlbumOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortAlbumNotFound(c)
+			return
+		} else if AlbumWriteOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AuditDenied(c, s, acl.ResourceAlbums, entity.AuditRemovePhotos, clean.IdString(c.Param("uid")))
+			AbortForbidden(c)
+			return
+		}
+
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -450,6 +590,11 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		a, err := query.AlbumByUID(clean.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -459,6 +604,8 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 
 		removed := a.RemovePhotos(f.Photos)
 
+		Audit(c, s, acl.ResourceAlbums, entity.AuditRemovePhotos, nil, a.AlbumUID)
+
 		if len(removed) > 0 {
 			if len(removed) == 1 {
 				event.SuccessMsg(i18n.MsgEntryRemovedFrom, clean.Log(a.Title()))
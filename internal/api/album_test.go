@@ -3,11 +3,14 @@ package api
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/geo"
 )
 
 func TestGetAlbum(t *testing.T) {
@@ -29,6 +32,53 @@ func TestGetAlbum(t *testing.T) {
 	})
 }
 
+func TestGetAlbumTrip(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := entity.NewTripAlbum("Italy / June 2021", "italy-june-2021-2021-06-01", time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC))
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		trip := entity.NewTrip(album.AlbumUID)
+		trip.StartedAt = time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+		trip.EndedAt = time.Date(2021, 6, 8, 18, 0, 0, 0, time.UTC)
+		trip.AddCountries("it")
+		trip.SetRoute([]geo.Position{{Lat: 41.9, Lng: 12.49}, {Lat: 43.77, Lng: 11.25}})
+
+		if err := trip.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		GetAlbumTrip(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/"+album.AlbumUID+"/trip")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, album.AlbumUID, gjson.Get(r.Body.String(), "AlbumUID").String())
+		assert.Equal(t, "it", gjson.Get(r.Body.String(), "Countries.0").String())
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "Route.#").Int())
+
+		// Confined users can only see trips with pictures in scope.
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		sessId := confinedSession(t, "2016")
+		r = AuthenticatedRequest(app, "GET", "/api/v1/albums/"+album.AlbumUID+"/trip", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NoTrip", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumTrip(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/trip")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumTrip(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/999000/trip")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestCreateAlbum(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
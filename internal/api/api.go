@@ -79,6 +79,10 @@ func AbortUnauthorized(c *gin.Context) {
 	Abort(c, http.StatusUnauthorized, i18n.ErrUnauthorized)
 }
 
+func AbortForbidden(c *gin.Context) {
+	Abort(c, http.StatusForbidden, i18n.ErrUnauthorized)
+}
+
 func AbortEntityNotFound(c *gin.Context) {
 	Abort(c, http.StatusNotFound, i18n.ErrEntityNotFound)
 }
@@ -65,6 +65,15 @@ func AuthenticatedRequest(r http.Handler, method, path, sess string) *httptest.R
 	return w
 }
 
+// Performs an API request with a bearer token and empty request body.
+func BearerRequest(r http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 // Executes an API request with the request body as a string.
 func PerformRequestWithBody(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
 	reader := strings.NewReader(body)
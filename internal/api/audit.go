@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Audit records a security-relevant or destructive action in the audit log, one entry is created per resource uid.
+// The outcome is a failure if err is not nil, see entity.AuditCreate and the following constants for actions.
+func Audit(c *gin.Context, s session.Data, resource acl.Resource, action string, err error, uids ...string) {
+	outcome := entity.AuditSuccess
+
+	if err != nil {
+		outcome = entity.AuditFailure
+	}
+
+	auditLog(s, c.ClientIP(), resource, action, outcome, err, uids...)
+}
+
+// AuditDenied records in the audit log that the session user was not allowed to perform the action,
+// one entry is created per resource uid.
+func AuditDenied(c *gin.Context, s session.Data, resource acl.Resource, action string, uids ...string) {
+	auditLog(s, c.ClientIP(), resource, action, entity.AuditDenied, nil, uids...)
+}
+
+// auditLog creates an audit log entry with the specified outcome for each resource uid.
+func auditLog(s session.Data, clientIP string, resource acl.Resource, action, outcome string, err error, uids ...string) {
+	if len(uids) == 0 {
+		uids = []string{""}
+	}
+
+	sessUID := s.UID
+
+	if s.AccessToken != nil {
+		sessUID = s.AccessToken.TokenUID
+	}
+
+	for _, uid := range uids {
+		m := entity.NewAuditLog(action, string(resource), uid, outcome)
+		m.SetClient(sessUID, clientIP)
+
+		if s.User.IsRegistered() {
+			m.SetActor(&s.User)
+		}
+
+		if err != nil {
+			m.SetMessage(err.Error())
+		}
+
+		m.Save()
+	}
+}
+
+// GetAuditLogs searches the audit log and returns the results as JSON.
+//
+// GET /api/v1/audit
+func GetAuditLogs(router *gin.RouterGroup) {
+	router.GET("/audit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAudit, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SearchAudit
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := search.AuditLogs(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetAuditLogs(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLogs(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit?count=10&action=login&resource=sessions", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		entries := gjson.Parse(r.Body.String()).Array()
+		assert.NotEmpty(t, entries)
+
+		for _, e := range entries {
+			assert.Equal(t, entity.AuditLogin, e.Get("Action").String())
+		}
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLogs(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit?count=10", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Denied", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLogs(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		// Denied requests are recorded, see Unauthorized.
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit?count=10&outcome=denied&resource=audit&user="+entity.UserFixtures.Get("bob").UserUID, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		entries := gjson.Parse(r.Body.String()).Array()
+		assert.NotEmpty(t, entries)
+
+		for _, e := range entries {
+			assert.Equal(t, entity.AuditDenied, e.Get("Outcome").String())
+		}
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLogs(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit", sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
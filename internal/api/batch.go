@@ -16,6 +16,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
@@ -44,6 +45,14 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		} else if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		log.Infof("photos: archiving %s", clean.Log(f.String()))
 
 		if service.Config().BackupYaml() {
@@ -56,18 +65,35 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 			}
 
 			for _, p := range photos {
-				if err := p.Archive(); err != nil {
+				err = p.Archive()
+
+				Audit(c, s, acl.ResourcePhotos, entity.AuditArchive, err, p.PhotoUID)
+
+				if err != nil {
 					log.Errorf("archive: %s", err)
 				} else {
 					SavePhotoAsYaml(p)
 				}
 			}
-		} else if err := entity.Db().Where("photo_uid IN (?)", f.Photos).Delete(&entity.Photo{}).Error; err != nil {
-			log.Errorf("archive: %s", err)
-			AbortSaveFailed(c)
-			return
-		} else if err := entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("hidden", true).Error; err != nil {
+		} else {
+			err := entity.Db().Where("photo_uid IN (?)", f.Photos).Delete(&entity.Photo{}).Error
+
+			Audit(c, s, acl.ResourcePhotos, entity.AuditArchive, err, f.Photos...)
+
+			if err != nil {
+				log.Errorf("archive: %s", err)
+				AbortSaveFailed(c)
+				return
+			} else if err = entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("hidden", true).Error; err != nil {
+				log.Errorf("archive: %s", err)
+			}
+		}
+
+		// Remove archived photos from semantic search.
+		if ids, err := query.PhotoIDs(f.Photos); err != nil {
 			log.Errorf("archive: %s", err)
+		} else {
+			search.SemanticRemove(ids...)
 		}
 
 		// Update precalculated photo and file counts.
@@ -108,6 +134,14 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		} else if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		log.Infof("photos: restoring %s", clean.Log(f.String()))
 
 		if service.Config().BackupYaml() {
@@ -120,17 +154,34 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			}
 
 			for _, p := range photos {
-				if err := p.Restore(); err != nil {
+				err = p.Restore()
+
+				Audit(c, s, acl.ResourcePhotos, entity.AuditRestore, err, p.PhotoUID)
+
+				if err != nil {
 					log.Errorf("restore: %s", err)
 				} else {
 					SavePhotoAsYaml(p)
 				}
 			}
-		} else if err := entity.Db().Unscoped().Model(&entity.Photo{}).Where("photo_uid IN (?)", f.Photos).
-			UpdateColumn("deleted_at", gorm.Expr("NULL")).Error; err != nil {
+		} else {
+			err := entity.Db().Unscoped().Model(&entity.Photo{}).Where("photo_uid IN (?)", f.Photos).
+				UpdateColumn("deleted_at", gorm.Expr("NULL")).Error
+
+			Audit(c, s, acl.ResourcePhotos, entity.AuditRestore, err, f.Photos...)
+
+			if err != nil {
+				log.Errorf("restore: %s", err)
+				AbortSaveFailed(c)
+				return
+			}
+		}
+
+		// Find restored photos with semantic search again.
+		if ids, err := query.PhotoIDs(f.Photos); err != nil {
+			log.Errorf("restore: %s", err)
+		} else if err = search.SemanticRestore(ids...); err != nil {
 			log.Errorf("restore: %s", err)
-			AbortSaveFailed(c)
-			return
 		}
 
 		// Update precalculated photo and file counts.
@@ -171,6 +222,14 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		} else if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		log.Infof("photos: approving %s", clean.Log(f.String()))
 
 		// Fetch selection from index.
@@ -224,10 +283,23 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		for _, uid := range f.Albums {
+			if AlbumOutOfScope(s, uid) {
+				AbortAlbumNotFound(c)
+				return
+			} else if AlbumWriteOutOfScope(s, uid) {
+				AuditDenied(c, s, acl.ResourceAlbums, entity.AuditDelete, uid)
+				AbortForbidden(c)
+				return
+			}
+		}
+
 		log.Infof("albums: deleting %s", clean.Log(f.String()))
 
 		// Soft delete albums, can be restored.
-		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
+		err := entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{}).Error
+
+		Audit(c, s, acl.ResourceAlbums, entity.AuditDelete, err, f.Albums...)
 
 		/*
 			KEEP ENTRIES AS ALBUMS MAY NOW BE RESTORED BY NAME
@@ -266,6 +338,14 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		} else if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		log.Infof("photos: updating private flag for %s", clean.Log(f.String()))
 
 		if err := entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
@@ -295,6 +375,79 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 	})
 }
 
+// BatchPhotosRating sets the star rating and/or color label of multiple photos.
+//
+// POST /api/v1/batch/photos/rating
+func BatchPhotosRating(router *gin.RouterGroup) {
+	router.POST("/batch/photos/rating", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Rating
+
+		if err := c.BindJSON(&f); err != nil || f.Empty() {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		} else if !ScopeSelection(s, &f.Selection) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		log.Infof("photos: updating rating for %s", clean.Log(f.String()))
+
+		photos, err := query.SelectedPhotos(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		for i := range photos {
+			p := &photos[i]
+
+			if f.Rating != nil {
+				p.SetRating(*f.Rating, entity.SrcManual)
+			}
+
+			if f.ColorLabel != nil {
+				p.SetColorLabel(*f.ColorLabel, entity.SrcManual)
+			}
+
+			if err = p.Updates(entity.Values{
+				"PhotoRating":     p.PhotoRating,
+				"RatingSrc":       p.RatingSrc,
+				"PhotoColorLabel": p.PhotoColorLabel,
+				"ColorLabelSrc":   p.ColorLabelSrc,
+			}); err != nil {
+				log.Errorf("rating: %s", err)
+				AbortSaveFailed(c)
+				return
+			}
+
+			SavePhotoAsYaml(*p)
+			SavePhotoAsXmp(p.PhotoUID)
+		}
+
+		event.EntitiesUpdated("photos", photos)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
+
 // BatchLabelsDelete deletes multiple labels.
 //
 // POST /api/v1/batch/labels/delete
@@ -307,6 +460,11 @@ func BatchLabelsDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -372,6 +530,14 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		} else if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		log.Infof("photos: deleting %s", clean.Log(f.String()))
 
 		// Fetch selection from index and record time.
@@ -398,6 +564,8 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 			} else {
 				deleted = append(deleted, p)
 			}
+
+			Audit(c, s, acl.ResourcePhotos, entity.AuditDelete, err, p.PhotoUID)
 		}
 
 		if numFiles > 0 {
@@ -175,6 +175,48 @@ func TestBatchPhotosPrivate(t *testing.T) {
 	})
 }
 
+func TestBatchPhotosRating(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Register routes.
+		GetPhoto(router)
+		BatchPhotosRating(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0yh8"], "rating": 4, "colorLabel": "Green"}`)
+		val := gjson.Get(r.Body.String(), "message")
+		assert.Contains(t, val.String(), "Changes successfully saved")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8")
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, int64(4), gjson.Get(r2.Body.String(), "Rating").Int())
+		assert.Equal(t, "green", gjson.Get(r2.Body.String(), "ColorLabel").String())
+		assert.Equal(t, "manual", gjson.Get(r2.Body.String(), "RatingSrc").String())
+
+		r3 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0yh8"], "rating": 0}`)
+		assert.Equal(t, http.StatusOK, r3.Code)
+
+		r4 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8")
+		assert.Equal(t, int64(0), gjson.Get(r4.Body.String(), "Rating").Int())
+		assert.Equal(t, "green", gjson.Get(r4.Body.String(), "ColorLabel").String())
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": [], "rating": 3}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestBatchLabelsDelete(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -33,7 +33,7 @@ func GetConfig(router *gin.RouterGroup) {
 		if s.User.IsGuest() {
 			c.JSON(http.StatusOK, conf.GuestConfig())
 		} else if s.User.IsRegistered() {
-			c.JSON(http.StatusOK, conf.UserConfig())
+			c.JSON(http.StatusOK, UserConfig(&s.User))
 		} else {
 			c.JSON(http.StatusOK, conf.PublicConfig())
 		}
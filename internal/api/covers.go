@@ -42,6 +42,12 @@ func AlbumCover(router *gin.RouterGroup) {
 		thumbName := thumb.Name(clean.Token(c.Param("size")))
 		uid := clean.IdString(c.Param("uid"))
 
+		// Users with their own originals folder may only view albums in scope.
+		if s, ok := PreviewSession(c); !ok || AlbumOutOfScope(s, uid) {
+			c.Data(http.StatusForbidden, "image/svg+xml", albumIconSvg)
+			return
+		}
+
 		size, ok := thumb.Sizes[thumbName]
 
 		if !ok {
@@ -155,6 +161,12 @@ func LabelCover(router *gin.RouterGroup) {
 		thumbName := thumb.Name(clean.Token(c.Param("size")))
 		uid := clean.IdString(c.Param("uid"))
 
+		// Users with their own originals folder may only view labels in scope.
+		if s, ok := PreviewSession(c); !ok || LabelOutOfScope(s, uid) {
+			c.Data(http.StatusForbidden, "image/svg+xml", labelIconSvg)
+			return
+		}
+
 		size, ok := thumb.Sizes[thumbName]
 
 		if !ok {
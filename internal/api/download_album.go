@@ -35,15 +35,30 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		s, ok := DownloadSession(c)
+
+		if !ok {
+			AbortUnauthorized(c)
+			return
+		}
+
+		folder, ok := UserScope(s)
+
+		if !ok {
+			AbortUnauthorized(c)
+			return
+		}
+
 		start := time.Now()
-		a, err := query.AlbumByUID(clean.IdString(c.Param("uid")))
+		uid := clean.IdString(c.Param("uid"))
+		a, err := query.AlbumByUID(uid)
 
-		if err != nil {
+		if err != nil || AlbumOutOfScope(s, uid) {
 			AbortAlbumNotFound(c)
 			return
 		}
 
-		files, err := search.AlbumPhotos(a, 10000, true)
+		files, err := search.ScopedAlbumPhotos(a, 10000, true, folder, s.Shares)
 
 		if err != nil {
 			AbortEntityNotFound(c)
@@ -55,6 +55,11 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		if s, ok := DownloadSession(c); !ok || PhotosOutOfScope(s, f.PhotoUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
 		if !fs.FileExists(fileName) {
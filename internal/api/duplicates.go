@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DuplicatesMaxDistance is the maximum hash distance that can be requested.
+const DuplicatesMaxDistance = 12
+
+// GetDuplicates returns groups of photos whose primary files are near-duplicates as JSON.
+//
+// GET /api/v1/duplicates
+//
+// Query:
+//
+//	dist: maximum number of different perceptual hash bits (optional, default 6)
+//	count: maximum number of groups (optional)
+//	offset: number of groups to skip (optional)
+func GetDuplicates(router *gin.RouterGroup) {
+	router.GET("/duplicates", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceDuplicates, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		dist := query.DuplicateDistance
+
+		if v := c.Query("dist"); v != "" {
+			dist = txt.Int(v)
+		}
+
+		if dist < 0 || dist > DuplicatesMaxDistance {
+			AbortBadRequest(c)
+			return
+		}
+
+		count, offset := txt.Int(c.Query("count")), txt.Int(c.Query("offset"))
+
+		if count < 0 || offset < 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Limit results to the originals folder and shared albums of confined users.
+		scope, ok := UserScope(s)
+
+		if !ok {
+			AbortUnauthorized(c)
+			return
+		}
+
+		groups, total, err := query.NearDuplicates(dist, count, offset, scope, s.Shares)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, total)
+		AddLimitHeader(c, count)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, groups)
+	})
+}
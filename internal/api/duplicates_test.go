@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDuplicates(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetDuplicates(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/duplicates?dist=4&count=10", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Confined", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetDuplicates(router)
+		sessId := confinedSession(t, "2016")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/duplicates", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "0", r.Header().Get("X-Count"))
+
+		sessId = confinedSession(t, "../etc")
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/duplicates", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetDuplicates(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/duplicates", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetDuplicates(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/duplicates?dist=64", sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -28,6 +28,12 @@ func GetFace(router *gin.RouterGroup) {
 			return
 		}
 
+		// Faces are clustered across all pictures and users.
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		f := form.SearchFaces{UID: c.Param("id"), Markers: true}
 
 		if results, err := search.Faces(f); err != nil || len(results) < 1 {
@@ -51,6 +57,11 @@ func UpdateFace(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		var f form.Face
 
 		if err := c.BindJSON(&f); err != nil {
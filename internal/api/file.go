@@ -26,7 +26,7 @@ func GetFile(router *gin.RouterGroup) {
 
 		p, err := query.FileByHash(clean.Token(c.Param("hash")))
 
-		if err != nil {
+		if err != nil || PhotosOutOfScope(s, p.PhotoUID) {
 			AbortEntityNotFound(c)
 			return
 		}
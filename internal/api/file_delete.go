@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -31,6 +32,11 @@ func DeleteFile(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		conf := service.Config()
 
 		if conf.ReadOnly() || !conf.Settings().Features.Edit {
@@ -48,6 +54,9 @@ func DeleteFile(router *gin.RouterGroup) {
 			log.Errorf("files: %s (delete)", err)
 			AbortEntityNotFound(c)
 			return
+		} else if PhotosOutOfScope(s, file.PhotoUID) {
+			AbortEntityNotFound(c)
+			return
 		}
 
 		// Primary file?
@@ -77,7 +86,11 @@ func DeleteFile(router *gin.RouterGroup) {
 		}
 
 		// Remove file from index.
-		if err = file.Delete(true); err != nil {
+		err = file.Delete(true)
+
+		Audit(c, s, acl.ResourceFiles, entity.AuditDelete, err, fileUID)
+
+		if err != nil {
 			log.Errorf("files: %s (delete %s from index)", err, clean.Log(baseName))
 			AbortDeleteFailed(c)
 			return
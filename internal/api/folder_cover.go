@@ -41,6 +41,17 @@ func FolderCover(router *gin.RouterGroup) {
 		thumbName := thumb.Name(clean.Token(c.Param("size")))
 		download := c.Query("download") != ""
 
+		// Users with their own originals folder may only view folders in scope.
+		if s, ok := PreviewSession(c); !ok {
+			c.Data(http.StatusForbidden, "image/svg+xml", folderIconSvg)
+			return
+		} else if s.User.Confined() {
+			if f, err := query.FolderCoverByUID(uid); err != nil || PhotosOutOfScope(s, f.PhotoUID) {
+				c.Data(http.StatusForbidden, "image/svg+xml", folderIconSvg)
+				return
+			}
+		}
+
 		size, ok := thumb.Sizes[thumbName]
 
 		if !ok {
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 
 	"github.com/gin-gonic/gin"
 )
@@ -64,8 +64,8 @@ func AddFileCountHeaders(c *gin.Context, filesCount, foldersCount int) {
 	c.Header("X-Folders", strconv.Itoa(foldersCount))
 }
 
-// AddTokenHeaders adds preview token headers to the response.
-func AddTokenHeaders(c *gin.Context) {
-	c.Header("X-Preview-Token", service.Config().PreviewToken())
-	c.Header("X-Download-Token", service.Config().DownloadToken())
+// AddTokenHeaders adds preview and download token headers to the response.
+func AddTokenHeaders(c *gin.Context, s session.Data) {
+	c.Header("X-Preview-Token", PreviewToken(&s.User))
+	c.Header("X-Download-Token", DownloadToken(&s.User))
 }
@@ -63,6 +63,21 @@ func StartImport(router *gin.RouterGroup) {
 
 		path = filepath.Clean(path)
 
+		// Users with their own originals folder may only import their uploads and files from their import folder.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if folder != "" {
+			rel := strings.Trim(fs.RelName(path, conf.ImportPath()), "/") + "/"
+
+			if strings.HasPrefix(rel, "upload/") {
+				path = filepath.Join(conf.ImportPath(), UploadPath(s, strings.TrimPrefix(rel, "upload/")))
+			} else if !strings.HasPrefix(rel, folder+"/") {
+				AbortUnauthorized(c)
+				return
+			}
+		}
+
 		imp := service.Import()
 
 		RemoveFromFolderCache(entity.RootImport)
@@ -77,6 +92,8 @@ func StartImport(router *gin.RouterGroup) {
 			opt = photoprism.ImportOptionsCopy(path)
 		}
 
+		opt.DestFolder = s.User.UserFolder()
+
 		if len(f.Albums) > 0 {
 			log.Debugf("import: adding files to album %s", clean.Log(strings.Join(f.Albums, " and ")))
 			opt.Albums = f.Albums
@@ -23,7 +23,7 @@ import (
 // POST /api/v1/index
 func StartIndexing(router *gin.RouterGroup) {
 	router.POST("/index", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionIndex)
 
 		if s.Invalid() {
 			AbortUnauthorized(c)
@@ -52,6 +52,14 @@ func StartIndexing(router *gin.RouterGroup) {
 		convert := settings.Index.Convert && conf.SidecarWritable()
 		skipArchived := settings.Index.SkipArchived
 
+		// Users with their own originals folder can only index this folder.
+		if _, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if s.User.Confined() {
+			f.Path = s.User.UserPath(f.Path)
+		}
+
 		indOpt := photoprism.NewIndexOptions(filepath.Clean(f.Path), f.Rescan, convert, true, false, skipArchived)
 
 		if len(indOpt.Path) > 1 {
@@ -110,7 +118,7 @@ func StartIndexing(router *gin.RouterGroup) {
 // DELETE /api/v1/index
 func CancelIndexing(router *gin.RouterGroup) {
 	router.DELETE("/index", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionIndex)
 
 		if s.Invalid() {
 			AbortUnauthorized(c)
@@ -16,6 +16,176 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// labelParent returns the label with the specified UID, or nil if the UID is empty.
+func labelParent(uid string) (*entity.Label, error) {
+	if uid = clean.IdString(uid); uid == "" {
+		return nil, nil
+	}
+
+	parent, err := query.LabelByUID(uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &parent, nil
+}
+
+// CreateLabel creates a new label, names such as "Places|Europe|Germany" create nested labels.
+//
+// POST /api/v1/labels
+func CreateLabel(router *gin.RouterGroup) {
+	router.POST("/labels", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Confined users cannot change labels, as they are shared by all users.
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.Label
+
+		if err := c.BindJSON(&f); err != nil || clean.Name(f.LabelName) == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		path := f.LabelName
+
+		if f.ParentUID != nil {
+			if parent, err := labelParent(*f.ParentUID); err != nil {
+				Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+				return
+			} else if parent != nil {
+				path = parent.Path() + entity.LabelPathSeparator + path
+			}
+		}
+
+		m := entity.FirstOrCreateLabelPath(path, f.LabelPriority)
+
+		if m == nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		PublishLabelEvent(EntityCreated, m.LabelUID, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteLabel deletes a label, nested labels are moved to its parent.
+//
+// DELETE /api/v1/labels/:uid
+func DeleteLabel(router *gin.RouterGroup) {
+	router.DELETE("/labels/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
+		id := clean.IdString(c.Param("uid"))
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		if err = m.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		UpdateClientConfig()
+
+		event.EntitiesDeleted("labels", []string{id})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetLabelChildren returns the labels nested below a label.
+//
+// GET /api/v1/labels/:uid/children
+func GetLabelChildren(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/children", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		folder, ok := UserScope(s)
+
+		if !ok || LabelOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		m, err := query.LabelByUID(clean.IdString(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		result, err := query.LabelChildren(m.ID, folder, s.Shares)
+
+		if err != nil {
+			log.Errorf("label: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetLabelTree returns all nested labels as tree.
+//
+// GET /api/v1/labels/tree
+func GetLabelTree(router *gin.RouterGroup) {
+	router.GET("/labels/tree", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
+		result, err := query.LabelTree()
+
+		if err != nil {
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
 // UpdateLabel updates label properties.
 //
 // PUT /api/v1/labels/:uid
@@ -28,6 +198,11 @@ func UpdateLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		var f form.Label
 
 		if err := c.BindJSON(&f); err != nil {
@@ -43,6 +218,16 @@ func UpdateLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if f.ParentUID != nil {
+			if parent, err := labelParent(*f.ParentUID); err != nil {
+				Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+				return
+			} else if err = m.SetParent(parent); err != nil {
+				Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+				return
+			}
+		}
+
 		m.SetName(f.LabelName)
 		entity.Db().Save(&m)
 
@@ -70,6 +255,11 @@ func LikeLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		id := clean.IdString(c.Param("uid"))
 		label, err := query.LabelByUID(id)
 
@@ -111,6 +301,11 @@ func DislikeLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		id := clean.IdString(c.Param("uid"))
 		label, err := query.LabelByUID(id)
 
@@ -21,6 +21,18 @@ func TestUpdateLabel(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("parent", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabel(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c7", `{"Name": "Updated01", "ParentUID": "lt9k3pw1wowuy3c2"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEqual(t, int64(0), gjson.Get(r.Body.String(), "ParentID").Int())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c7", `{"Name": "Updated01", "ParentUID": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "ParentID").Int())
+	})
+
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		UpdateLabel(router)
@@ -112,3 +124,59 @@ func TestDislikeLabel(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestCreateLabel(t *testing.T) {
+	t.Run("Nested", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateLabel(router)
+		GetLabelChildren(router)
+		GetLabelTree(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels", `{"Name": "Api Places|Api Europe"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Api Europe", gjson.Get(r.Body.String(), "Name").String())
+		parentUID := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/labels", `{"Name": "Api Germany", "ParentUID": "`+parentUID+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Api Germany", gjson.Get(r.Body.String(), "Name").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/labels/"+parentUID+"/children")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Api Germany", gjson.Get(r.Body.String(), "0.Name").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/labels/tree")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "Api Places")
+	})
+	t.Run("parent not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateLabel(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels", `{"Name": "Api Orphan", "ParentUID": "lt9k3pw1wowuyxxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateLabel(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels", `{"Name": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDeleteLabel(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateLabel(router)
+		DeleteLabel(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels", `{"Name": "Api Delete Label"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequest(app, "DELETE", "/api/v1/labels/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/labels/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
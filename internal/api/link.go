@@ -35,6 +35,11 @@ func UpdateLink(c *gin.Context) {
 
 	link := entity.FindLink(clean.Token(c.Param("link")))
 
+	if ShareOutOfScope(s, link.ShareUID) {
+		AbortEntityNotFound(c)
+		return
+	}
+
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
@@ -75,7 +80,16 @@ func DeleteLink(c *gin.Context) {
 
 	link := entity.FindLink(clean.Token(c.Param("link")))
 
-	if err := link.Delete(); err != nil {
+	if ShareOutOfScope(s, link.ShareUID) {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	err := link.Delete()
+
+	Audit(c, s, acl.ResourceLinks, entity.AuditDelete, err, link.ShareUID)
+
+	if err != nil {
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UpperFirst(err.Error())})
 		return
 	}
@@ -105,7 +119,14 @@ func CreateLink(c *gin.Context) {
 		return
 	}
 
-	link := entity.NewLink(clean.IdString(c.Param("uid")), f.CanComment, f.CanEdit)
+	uid := clean.IdString(c.Param("uid"))
+
+	if ShareOutOfScope(s, uid) {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	link := entity.NewLink(uid, f.CanComment, f.CanEdit)
 
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
@@ -118,7 +139,11 @@ func CreateLink(c *gin.Context) {
 		}
 	}
 
-	if err := link.Save(); err != nil {
+	err := link.Save()
+
+	Audit(c, s, acl.ResourceLinks, entity.AuditCreate, err, link.ShareUID)
+
+	if err != nil {
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UpperFirst(err.Error())})
 		return
 	}
@@ -161,7 +186,21 @@ func DeleteAlbumLink(router *gin.RouterGroup) {
 // GET /api/v1/albums/:uid/links
 func GetAlbumLinks(router *gin.RouterGroup) {
 	router.GET("/albums/:uid/links", func(c *gin.Context) {
-		m, err := query.AlbumByUID(clean.IdString(c.Param("uid")))
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := clean.IdString(c.Param("uid"))
+
+		if ShareOutOfScope(s, uid) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		m, err := query.AlbumByUID(uid)
 
 		if err != nil {
 			AbortAlbumNotFound(c)
@@ -201,7 +240,21 @@ func DeletePhotoLink(router *gin.RouterGroup) {
 // GET /api/v1/photos/:uid/links
 func GetPhotoLinks(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/links", func(c *gin.Context) {
-		m, err := query.PhotoByUID(clean.IdString(c.Param("uid")))
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := clean.IdString(c.Param("uid"))
+
+		if ShareOutOfScope(s, uid) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		m, err := query.PhotoByUID(uid)
 
 		if err != nil {
 			AbortAlbumNotFound(c)
@@ -241,7 +294,21 @@ func DeleteLabelLink(router *gin.RouterGroup) {
 // GET /api/v1/labels/:uid/links
 func GetLabelLinks(router *gin.RouterGroup) {
 	router.GET("/labels/:uid/links", func(c *gin.Context) {
-		m, err := query.LabelByUID(clean.IdString(c.Param("uid")))
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := clean.IdString(c.Param("uid"))
+
+		if ShareOutOfScope(s, uid) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		m, err := query.LabelByUID(uid)
 
 		if err != nil {
 			AbortAlbumNotFound(c)
@@ -63,6 +63,9 @@ func findFileMarker(c *gin.Context) (file *entity.File, marker *entity.Marker, e
 	if file, err = query.FileByUID(marker.FileUID); err != nil {
 		AbortEntityNotFound(c)
 		return file, marker, fmt.Errorf("file %s %s", marker.FileUID, err)
+	} else if PhotosOutOfScope(s, file.PhotoUID) {
+		AbortEntityNotFound(c)
+		return file, marker, fmt.Errorf("file %s out of scope", marker.FileUID)
 	}
 
 	return file, marker, nil
@@ -143,6 +146,9 @@ func UpdateMarker(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			// Update XMP sidecar file (optional).
+			SavePhotoAsXmp(file.PhotoUID)
+
 			// Notify clients.
 			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
 		}
@@ -202,6 +208,9 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			// Update XMP sidecar file (optional).
+			SavePhotoAsXmp(file.PhotoUID)
+
 			// Notify clients.
 			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
 		}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/metrics"
+)
+
+// MetricsCountsInterval is the minimum interval between two updates of the index counts.
+var MetricsCountsInterval = time.Minute
+
+var metricsCounts = metrics.NewGauge("photoprism_index_items", "Number of indexed items by type.", "type")
+var metricsLabelMaxPhotos = metrics.NewGauge("photoprism_label_max_photos", "Highest number of photos with the same label.")
+var metricsCountsUpdated time.Time
+var metricsMutex = sync.Mutex{}
+
+// updateMetricsCounts updates the index counts unless they have been updated recently.
+func updateMetricsCounts() {
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+
+	if time.Since(metricsCountsUpdated) < MetricsCountsInterval {
+		return
+	}
+
+	metricsCountsUpdated = time.Now()
+
+	counts := query.Counts{}
+	counts.Refresh()
+
+	// The label photo count is not a number of items, so it is exported separately.
+	metricsLabelMaxPhotos.Set(float64(counts.LabelMaxPhotos))
+
+	v := reflect.ValueOf(counts)
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Name == "LabelMaxPhotos" {
+			continue
+		}
+
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		metricsCounts.Set(float64(v.Field(i).Int()), name)
+	}
+}
+
+// GetMetrics returns metrics in the Prometheus text format, it requires the configured metrics token.
+//
+// GET /metrics
+func GetMetrics(router *gin.RouterGroup) {
+	router.GET("/metrics", func(c *gin.Context) {
+		if service.Config().InvalidMetricsToken(BearerToken(c)) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		updateMetricsCounts()
+
+		var b bytes.Buffer
+
+		if err := metrics.Default.Write(&b); err != nil {
+			log.Errorf("metrics: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.Data(http.StatusOK, metrics.ContentType, b.Bytes())
+	})
+}
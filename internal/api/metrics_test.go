@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/metrics"
+)
+
+func TestGetMetrics(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMetrics(router)
+		r := PerformRequest(app, "GET", "/api/v1/metrics")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().MetricsToken = "s3cr3t"
+		defer func() { conf.Options().MetricsToken = "" }()
+		GetMetrics(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/metrics")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		req, _ := http.NewRequest("GET", "/api/v1/metrics", nil)
+		req.Header.Set("Authorization", "Bearer s3cr3t")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, metrics.ContentType, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "# TYPE photoprism_index_items gauge")
+		assert.Contains(t, w.Body.String(), `photoprism_index_items{type="photos"}`)
+		assert.Contains(t, w.Body.String(), "# TYPE photoprism_label_max_photos gauge")
+		assert.NotContains(t, w.Body.String(), `photoprism_index_items{type="labelMaxPhotos"}`)
+	})
+}
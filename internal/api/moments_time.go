@@ -21,9 +21,16 @@ func GetMomentsTime(router *gin.RouterGroup) {
 			return
 		}
 
+		folder, ok := UserScope(s)
+
+		if !ok {
+			AbortUnauthorized(c)
+			return
+		}
+
 		conf := service.Config()
 
-		result, err := query.MomentsTime(1, conf.Settings().Features.Private)
+		result, err := query.ScopedMomentsTime(1, conf.Settings().Features.Private, folder, s.Shares)
 
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UpperFirst(err.Error())})
@@ -0,0 +1,348 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/oidc"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// oidcLogin represents a pending OpenID Connect login.
+type oidcLogin struct {
+	Nonce    string
+	Verifier string
+}
+
+// oidcStateCookie binds a pending login to the browser that started it.
+const oidcStateCookie = "oidc_state"
+
+var oidcLoginExpires = 10 * time.Minute
+var oidcLogins = gc.New(oidcLoginExpires, time.Minute)
+var oidcProvider *oidc.Provider
+var oidcIssuer string
+var oidcMutex = sync.Mutex{}
+
+// OIDCProvider returns the configured identity provider, the provider configuration is fetched on first use.
+func OIDCProvider() (*oidc.Provider, error) {
+	oidcMutex.Lock()
+	defer oidcMutex.Unlock()
+
+	conf := service.Config()
+
+	// The discovery document may list the issuer with a trailing slash, so the normalized
+	// issuer URL from the config is used as cache key.
+	issuer := strings.TrimRight(conf.OIDCUri(), "/")
+
+	if oidcProvider != nil && oidcIssuer == issuer {
+		return oidcProvider, nil
+	}
+
+	p, err := oidc.NewProvider(issuer, conf.OIDCClient(), conf.OIDCSecret(), conf.OIDCRedirectURL(), conf.OIDCScopes())
+
+	if err != nil {
+		return nil, err
+	}
+
+	oidcProvider = p
+	oidcIssuer = issuer
+
+	return p, nil
+}
+
+// GET /api/v1/oidc/login
+func OIDCLogin(router *gin.RouterGroup) {
+	router.GET("/oidc/login", func(c *gin.Context) {
+		if !service.Config().OIDCEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		p, err := OIDCProvider()
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		state := oidc.RandomString()
+		login := oidcLogin{Nonce: oidc.RandomString(), Verifier: oidc.RandomString()}
+
+		oidcLogins.SetDefault(state, login)
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(oidcStateCookie, oidcStateHash(state), int(oidcLoginExpires.Seconds()), oidcCookiePath(), "", oidcCookieSecure(c), true)
+
+		c.Redirect(http.StatusTemporaryRedirect, p.AuthCodeURL(state, login.Nonce, login.Verifier))
+	})
+}
+
+// GET /api/v1/oidc/redirect
+func OIDCRedirect(router *gin.RouterGroup) {
+	router.GET("/oidc/redirect", func(c *gin.Context) {
+		conf := service.Config()
+
+		if !conf.OIDCEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		if e := c.Query("error"); e != "" {
+			log.Warnf("oidc: %s (%s)", clean.Log(e), clean.Log(c.Query("error_description")))
+			AbortUnauthorized(c)
+			return
+		}
+
+		state := c.Query("state")
+		cached, found := oidcLogins.Get(state)
+
+		if state == "" || !found {
+			log.Warnf("oidc: invalid login state")
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Only the browser that started the login may complete it.
+		cookie, _ := c.Cookie(oidcStateCookie)
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(oidcStateCookie, "", -1, oidcCookiePath(), "", oidcCookieSecure(c), true)
+
+		if subtle.ConstantTimeCompare([]byte(cookie), []byte(oidcStateHash(state))) != 1 {
+			log.Warnf("oidc: login state does not match browser")
+			AbortUnauthorized(c)
+			return
+		}
+
+		oidcLogins.Delete(state)
+		login := cached.(oidcLogin)
+
+		p, err := OIDCProvider()
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		token, err := p.Exchange(c.Query("code"), login.Verifier)
+
+		if err != nil {
+			log.Warn(err)
+			AbortUnauthorized(c)
+			return
+		}
+
+		claims, err := p.Verify(token.IDToken, login.Nonce)
+
+		if err != nil {
+			log.Warn(err)
+			AbortUnauthorized(c)
+			return
+		}
+
+		user := oidcUser(claims)
+
+		if user == nil {
+			Audit(c, session.Data{}, acl.ResourceSessions, entity.AuditLogin, fmt.Errorf("no user for %s", claims.ID()))
+			AbortUnauthorized(c)
+			return
+		}
+
+		data := session.Data{User: *user, AuthMethod: entity.AuthSrcOIDC}
+		data.SetClient(c.ClientIP(), c.Request.UserAgent())
+
+		id, err := service.Session().Create(data)
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddSessionHeader(c, id)
+		Audit(c, service.Session().Get(id), acl.ResourceSessions, entity.AuditLogin, nil, user.UserUID)
+
+		log.Infof("oidc: user %s logged in", user.String())
+
+		if strings.Contains(c.GetHeader("Accept"), "application/json") {
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": UserConfig(user)})
+			return
+		}
+
+		dataJson, err := json.Marshal(data)
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.Status(http.StatusOK)
+
+		if err = oidcRedirectTemplate.Execute(c.Writer, gin.H{"id": id, "data": string(dataJson), "url": conf.BaseUri("/library/browse")}); err != nil {
+			log.Errorf("oidc: %s", err)
+		}
+	})
+}
+
+// oidcUser returns the user account that matches the claims, or nil if login is not allowed.
+func oidcUser(claims *oidc.Claims) *entity.User {
+	conf := service.Config()
+	role := claims.Role(conf.OIDCRoleClaim(), conf.OIDCRoles(), conf.OIDCDefaultRole())
+
+	// Find user linked to this account.
+	if user := entity.FindUserByAuth(entity.AuthSrcOIDC, claims.ID()); user != nil {
+		if user.Deleted() {
+			log.Warnf("oidc: user %s has been deleted", user.String())
+			return nil
+		} else if !user.CanLogin {
+			log.Warnf("oidc: user %s is not allowed to log in", user.String())
+			return nil
+		} else if !oidcUpdateRole(user, role) {
+			return nil
+		}
+
+		return user
+	}
+
+	// Link existing user with the same, verified email address.
+	if email := clean.Email(claims.Email); email != "" && claims.EmailVerified {
+		if user := entity.FindUserByLogin(email); user != nil && user.AuthSrc == "" {
+			if user.Deleted() || !user.CanLogin {
+				log.Warnf("oidc: cannot link disabled user %s", user.String())
+				return nil
+			} else if (user.SuperAdmin || user.IsAdmin()) && !conf.OIDCLinkAdmin() {
+				log.Warnf("oidc: cannot link admin user %s, enable oidc-link-admin to allow this", user.String())
+				return nil
+			}
+
+			if err := user.LinkAuth(entity.AuthSrcOIDC, claims.ID()); err != nil {
+				log.Errorf("oidc: %s", err)
+				return nil
+			}
+
+			log.Infof("oidc: linked user %s with %s", user.String(), clean.Log(claims.Issuer))
+
+			if !oidcUpdateRole(user, role) {
+				return nil
+			}
+
+			return user
+		}
+	}
+
+	if !conf.OIDCRegister() {
+		log.Warnf("oidc: no user found for %s", clean.LogQuote(claims.ID()))
+		return nil
+	} else if acl.RoleNone.Equal(role) {
+		log.Warnf("oidc: %s has no role", clean.LogQuote(claims.ID()))
+		return nil
+	}
+
+	name := claims.PreferredUsername
+
+	if name == "" {
+		name = strings.Split(claims.Email, "@")[0]
+	}
+
+	user := &entity.User{
+		Username:    entity.UniqueUsername(name),
+		FullName:    clean.Name(claims.Name),
+		UserRole:    role,
+		CanLogin:    true,
+		AuthSrc:     entity.AuthSrcOIDC,
+		AuthUID:     claims.ID(),
+		ConfirmedAt: entity.TimePointer(),
+	}
+
+	if claims.EmailVerified {
+		user.Email = clean.Email(claims.Email)
+	}
+
+	if err := user.Validate(); err != nil {
+		log.Errorf("oidc: %s", err)
+		return nil
+	} else if err = user.Create(); err != nil {
+		log.Errorf("oidc: %s", err)
+		return nil
+	}
+
+	log.Infof("oidc: created user %s with role %s", user.String(), clean.Log(role))
+
+	return user
+}
+
+// oidcStateHash returns the hash of a login state that is stored in the state cookie.
+func oidcStateHash(state string) string {
+	h := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(h[:])
+}
+
+// oidcCookiePath returns the path of the state cookie, so that it is only sent to the OpenID Connect endpoints.
+func oidcCookiePath() string {
+	return service.Config().ApiUri() + "/oidc"
+}
+
+// oidcCookieSecure checks if the state cookie may only be sent over HTTPS, browsers would otherwise
+// drop it on sites that are served over plain HTTP.
+func oidcCookieSecure(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.HasPrefix(service.Config().SiteUrl(), "https://")
+}
+
+// oidcUpdateRole applies the role mapped from the ID token claims to a linked user on each login, so that
+// role changes at the identity provider take effect. Roles are left unchanged if no mapping is configured,
+// and false is returned if the user must not log in.
+func oidcUpdateRole(user *entity.User, role string) bool {
+	if len(service.Config().OIDCRoles()) == 0 {
+		return true
+	} else if acl.RoleNone.Equal(role) {
+		log.Warnf("oidc: user %s has no role", user.String())
+		return false
+	} else if user.UserRole == role {
+		return true
+	}
+
+	if err := user.Updates(entity.Values{"UserRole": role}); err != nil {
+		log.Errorf("oidc: %s", err)
+		return false
+	}
+
+	user.UserRole = role
+
+	log.Infof("oidc: changed role of user %s to %s", user.String(), clean.Log(role))
+
+	return true
+}
+
+// oidcRedirectTemplate stores the new session in the browser and opens the library.
+var oidcRedirectTemplate = template.Must(template.New("oidc").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Login</title></head>
+<body>
+<script>
+window.localStorage.setItem("session_storage", "false");
+window.localStorage.setItem("session_id", {{.id}});
+window.localStorage.setItem("data", {{.data}});
+window.location.replace({{.url}});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCLogin(router)
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestOIDCRedirect(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCRedirect(router)
+		r := PerformRequest(app, "GET", "/api/v1/oidc/redirect?state=foo&code=bar")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidState", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		conf.Options().OIDCUri = "https://accounts.example.com"
+		conf.Options().OIDCClient = "photoprism"
+		defer func() {
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+		}()
+		OIDCRedirect(router)
+		r := PerformRequest(app, "GET", "/api/v1/oidc/redirect?state=foo&code=bar")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("StateCookie", func(t *testing.T) {
+		testOIDCStateCookie(t, "https://photos.example.com/", true)
+	})
+	t.Run("StateCookieHttp", func(t *testing.T) {
+		testOIDCStateCookie(t, "http://photos.example.com/", false)
+	})
+}
+
+// testOIDCStateCookie checks that only the browser that started a login can complete it,
+// and that the state cookie is only marked secure if the site is served over HTTPS.
+func testOIDCStateCookie(t *testing.T, siteUrl string, secure bool) {
+	app, router, conf := NewApiTest()
+
+	siteUrlBefore := conf.Options().SiteUrl
+	conf.Options().SiteUrl = siteUrl
+	defer func() { conf.Options().SiteUrl = siteUrlBefore }()
+
+	tokenRequests := 0
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenRequests++
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+			"jwks_uri":               srv.URL + "/jwks",
+		})
+	}))
+
+	defer srv.Close()
+
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+	conf.Options().OIDCUri = srv.URL
+	conf.Options().OIDCClient = "photoprism"
+	defer func() {
+		conf.Options().OIDCUri = ""
+		conf.Options().OIDCClient = ""
+	}()
+
+	OIDCLogin(router)
+	OIDCRedirect(router)
+
+	login := func() (state string, cookie *http.Cookie) {
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+
+		u, err := url.Parse(r.Header().Get("Location"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range r.Result().Cookies() {
+			if c.Name == oidcStateCookie {
+				cookie = c
+			}
+		}
+
+		if cookie == nil {
+			t.Fatal("state cookie should not be nil")
+		}
+
+		assert.True(t, cookie.HttpOnly)
+		assert.Equal(t, secure, cookie.Secure)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+		return u.Query().Get("state"), cookie
+	}
+
+	// A valid state without the matching cookie must be rejected.
+	state, _ := login()
+	r := PerformRequest(app, "GET", "/api/v1/oidc/redirect?code=bar&state="+url.QueryEscape(state))
+	assert.Equal(t, http.StatusUnauthorized, r.Code)
+	assert.Equal(t, 0, tokenRequests)
+
+	// The browser that started the login may exchange the code.
+	state, cookie := login()
+	req, _ := http.NewRequest("GET", "/api/v1/oidc/redirect?code=bar&state="+url.QueryEscape(state), nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, 1, tokenRequests)
+
+	// The cookie is cleared with the same flags it was set with.
+	for _, c := range w.Result().Cookies() {
+		if c.Name == oidcStateCookie {
+			assert.Equal(t, secure, c.Secure)
+			assert.Less(t, c.MaxAge, 0)
+		}
+	}
+}
+
+func TestOIDCUser(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		conf.Options().OIDCRegister = false
+		claims := &oidc.Claims{Issuer: "https://accounts.example.com", Subject: "unknown", Email: "unknown@example.com"}
+		assert.Nil(t, oidcUser(claims))
+	})
+	t.Run("Register", func(t *testing.T) {
+		conf.Options().OIDCRegister = true
+		conf.Options().OIDCRoles = "photo-editors=editor"
+		defer func() {
+			conf.Options().OIDCRegister = false
+			conf.Options().OIDCRoles = ""
+		}()
+
+		claims := &oidc.Claims{
+			Issuer:            "https://accounts.example.com",
+			Subject:           "4711",
+			Email:             "oidc@example.com",
+			EmailVerified:     true,
+			Name:              "Jane Doe",
+			PreferredUsername: "jane",
+			Raw:               map[string]interface{}{"groups": []interface{}{"photo-editors"}},
+		}
+
+		user := oidcUser(claims)
+
+		if user == nil {
+			t.Fatal("user should not be nil")
+		}
+
+		assert.Equal(t, "jane", user.Username)
+		assert.Equal(t, "editor", user.UserRole)
+		assert.Equal(t, "oidc@example.com", user.Email)
+		assert.True(t, user.CanLogin)
+
+		again := oidcUser(claims)
+
+		if again == nil {
+			t.Fatal("user should not be nil")
+		}
+
+		assert.Equal(t, user.UserUID, again.UserUID)
+		assert.Equal(t, user.UserUID, entity.FindUserByAuth(entity.AuthSrcOIDC, claims.ID()).UserUID)
+	})
+	t.Run("RoleChanged", func(t *testing.T) {
+		conf.Options().OIDCRegister = true
+		conf.Options().OIDCRoles = "photo-editors=editor,family=viewer"
+		conf.Options().OIDCDefaultRole = "none"
+		defer func() {
+			conf.Options().OIDCRegister = false
+			conf.Options().OIDCRoles = ""
+			conf.Options().OIDCDefaultRole = ""
+		}()
+
+		claims := &oidc.Claims{
+			Issuer:            "https://accounts.example.com",
+			Subject:           "4712",
+			Name:              "John Doe",
+			PreferredUsername: "john",
+			Raw:               map[string]interface{}{"groups": []interface{}{"photo-editors"}},
+		}
+
+		user := oidcUser(claims)
+
+		if user == nil {
+			t.Fatal("user should not be nil")
+		}
+
+		assert.Equal(t, "editor", user.UserRole)
+
+		// Role changes at the identity provider are applied on the next login.
+		claims.Raw["groups"] = []interface{}{"family"}
+
+		if user = oidcUser(claims); user == nil {
+			t.Fatal("user should not be nil")
+		}
+
+		assert.Equal(t, "viewer", user.UserRole)
+		assert.Equal(t, "viewer", entity.FindUserByAuth(entity.AuthSrcOIDC, claims.ID()).UserRole)
+
+		// Users without a matching role can no longer log in.
+		claims.Raw["groups"] = []interface{}{"friends"}
+
+		assert.Nil(t, oidcUser(claims))
+		assert.Equal(t, "viewer", entity.FindUserByAuth(entity.AuthSrcOIDC, claims.ID()).UserRole)
+	})
+}
+
+func TestOIDCUser_Link(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		claims := &oidc.Claims{Issuer: "https://accounts.example.com", Subject: "4720", Email: "alice@example.com", EmailVerified: true}
+
+		assert.Nil(t, oidcUser(claims))
+		assert.Equal(t, "", entity.FindUserByLogin("alice").AuthSrc)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		user := &entity.User{Username: "oidc-link", Email: "oidc-link@example.com", UserRole: "viewer", CanLogin: false}
+
+		if err := user.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		claims := &oidc.Claims{Issuer: "https://accounts.example.com", Subject: "4721", Email: "oidc-link@example.com", EmailVerified: true}
+
+		assert.Nil(t, oidcUser(claims))
+		assert.Nil(t, entity.FindUserByAuth(entity.AuthSrcOIDC, claims.ID()))
+
+		if err := user.Updates(entity.Values{"CanLogin": true}); err != nil {
+			t.Fatal(err)
+		}
+
+		if linked := oidcUser(claims); linked == nil {
+			t.Fatal("user should not be nil")
+		} else {
+			assert.Equal(t, user.UserUID, linked.UserUID)
+		}
+	})
+}
+
+func TestOIDCProvider(t *testing.T) {
+	t.Run("TrailingSlash", func(t *testing.T) {
+		_, _, conf := NewApiTest()
+
+		requests := 0
+
+		var srv *httptest.Server
+
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 srv.URL + "/",
+				"authorization_endpoint": srv.URL + "/authorize",
+				"token_endpoint":         srv.URL + "/token",
+				"jwks_uri":               srv.URL + "/jwks",
+			})
+		}))
+
+		defer srv.Close()
+
+		conf.Options().OIDCUri = srv.URL + "/"
+		conf.Options().OIDCClient = "photoprism"
+		defer func() {
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+		}()
+
+		p, err := OIDCProvider()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cached, err := OIDCProvider()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Same(t, p, cached)
+		assert.Equal(t, 1, requests)
+	})
+}
@@ -37,6 +37,31 @@ func SavePhotoAsYaml(p entity.Photo) {
 	}
 }
 
+// SavePhotoAsXmp writes photo metadata to an XMP sidecar file.
+func SavePhotoAsXmp(uid string) {
+	c := service.Config()
+
+	// Write XMP sidecar file (optional).
+	if !c.WriteXmp() {
+		return
+	}
+
+	p, err := query.PhotoPreloadByUID(uid)
+
+	if err != nil {
+		log.Errorf("photo: %s (update xmp)", err)
+		return
+	}
+
+	fileName := p.XmpFileName(c.OriginalsPath(), c.SidecarPath())
+
+	if err := p.SaveAsXmp(fileName); err != nil {
+		log.Errorf("photo: %s (update xmp)", err)
+	} else {
+		log.Debugf("photo: updated xmp file %s", clean.Log(filepath.Base(fileName)))
+	}
+}
+
 // GetPhoto returns photo details as JSON.
 //
 // Route : GET /api/v1/photos/:uid
@@ -51,6 +76,11 @@ func GetPhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		p, err := query.PhotoPreloadByUID(clean.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -75,6 +105,12 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		uid := clean.IdString(c.Param("uid"))
+
+		if PhotosOutOfScope(s, uid) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(uid)
 
 		if err != nil {
@@ -117,6 +153,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(p)
+		SavePhotoAsXmp(uid)
 
 		UpdateClientConfig()
 
@@ -136,7 +173,14 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		f, err := query.FileByPhotoUID(clean.IdString(c.Param("uid")))
+		uid := clean.IdString(c.Param("uid"))
+
+		if s, ok := DownloadSession(c); !ok || PhotosOutOfScope(s, uid) {
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		f, err := query.FileByPhotoUID(uid)
 
 		if err != nil {
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
@@ -173,6 +217,11 @@ func GetPhotoYaml(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		p, err := query.PhotoPreloadByUID(clean.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -210,6 +259,12 @@ func ApprovePhoto(router *gin.RouterGroup) {
 		}
 
 		id := clean.IdString(c.Param("uid"))
+
+		if PhotosOutOfScope(s, id) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(id)
 
 		if err != nil {
@@ -246,6 +301,12 @@ func LikePhoto(router *gin.RouterGroup) {
 		}
 
 		id := clean.IdString(c.Param("uid"))
+
+		if PhotosOutOfScope(s, id) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(id)
 
 		if err != nil {
@@ -260,6 +321,7 @@ func LikePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(m)
+		SavePhotoAsXmp(id)
 
 		PublishPhotoEvent(EntityUpdated, id, c)
 
@@ -282,6 +344,12 @@ func DislikePhoto(router *gin.RouterGroup) {
 		}
 
 		id := clean.IdString(c.Param("uid"))
+
+		if PhotosOutOfScope(s, id) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(id)
 
 		if err != nil {
@@ -296,6 +364,7 @@ func DislikePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(m)
+		SavePhotoAsXmp(id)
 
 		PublishPhotoEvent(EntityUpdated, id, c)
 
@@ -319,6 +388,12 @@ func PhotoPrimary(router *gin.RouterGroup) {
 		}
 
 		uid := clean.IdString(c.Param("uid"))
+
+		if PhotosOutOfScope(s, uid) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		fileUID := clean.IdString(c.Param("file_uid"))
 		err := query.SetPhotoPrimary(uid, fileUID)
 
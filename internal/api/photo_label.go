@@ -30,6 +30,11 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(clean.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -83,6 +88,8 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsXmp(p.PhotoUID)
+
 		PublishPhotoEvent(EntityUpdated, c.Param("uid"), c)
 
 		event.Success("label updated")
@@ -106,6 +113,11 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(clean.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -148,6 +160,8 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsXmp(p.PhotoUID)
+
 		PublishPhotoEvent(EntityUpdated, clean.IdString(c.Param("uid")), c)
 
 		event.Success("label removed")
@@ -171,6 +185,11 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		// TODO: Code clean-up, simplify
 
 		m, err := query.PhotoByUID(clean.IdString(c.Param("uid")))
@@ -216,6 +235,8 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsXmp(p.PhotoUID)
+
 		PublishPhotoEvent(EntityUpdated, clean.IdString(c.Param("uid")), c)
 
 		event.Success("label saved")
@@ -34,6 +34,11 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		conf := service.Config()
 		fileUID := clean.IdString(c.Param("file_uid"))
 		file, err := query.FileByUID(fileUID)
@@ -44,6 +49,11 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			return
 		}
 
+		if PhotosOutOfScope(s, file.PhotoUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		if file.FilePrimary {
 			log.Errorf("photo: cannot unstack primary file")
 			AbortBadRequest(c)
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"path"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// UserScope returns the originals folder the session user is confined to, or an empty string if the
+// user has access to all originals. Access must be denied if ok is false, e.g. because the stored
+// folder is invalid.
+func UserScope(s session.Data) (folder string, ok bool) {
+	if !s.User.Confined() {
+		return "", true
+	} else if folder = s.User.UserFolder(); folder == "" {
+		log.Warnf("api-v1: user %s has an invalid originals folder", clean.Log(s.User.UserName()))
+		return "", false
+	}
+
+	return folder, true
+}
+
+// PhotosOutOfScope checks if the session user must not access the pictures with the specified UIDs,
+// confined users may only access pictures in their originals folder and shared albums.
+func PhotosOutOfScope(s session.Data, uids ...string) bool {
+	if folder, ok := UserScope(s); !ok {
+		return true
+	} else if folder == "" {
+		return false
+	} else {
+		return !query.PhotosInScope(uids, folder, s.Shares)
+	}
+}
+
+// FileOutOfScope checks if the session user must not access the file with the specified hash,
+// confined users may only access files of pictures in their originals folder and shared albums.
+func FileOutOfScope(s session.Data, fileHash string) bool {
+	if folder, ok := UserScope(s); !ok {
+		return true
+	} else if folder == "" {
+		return false
+	} else if f, err := query.FileByHash(fileHash); err != nil {
+		return true
+	} else {
+		return !query.PhotosInScope([]string{f.PhotoUID}, folder, s.Shares)
+	}
+}
+
+// AlbumOutOfScope checks if the session user must not access the album with the specified UID,
+// confined users may only access shared albums, their folders and albums, and albums with their pictures.
+func AlbumOutOfScope(s session.Data, uid string) bool {
+	if folder, ok := UserScope(s); !ok {
+		return true
+	} else if folder == "" {
+		return false
+	} else {
+		return !query.AlbumInScope(uid, folder, s.Shares, s.User.UserUID)
+	}
+}
+
+// AlbumWriteOutOfScope checks if the session user must not change the album with the specified UID,
+// confined users may only change their folders and the albums they created.
+func AlbumWriteOutOfScope(s session.Data, uid string) bool {
+	if folder, ok := UserScope(s); !ok {
+		return true
+	} else if folder == "" {
+		return false
+	} else {
+		return !query.AlbumOwnedBy(uid, folder, s.User.UserUID)
+	}
+}
+
+// LabelOutOfScope checks if the session user must not access the label with the specified UID,
+// confined users may only access labels of pictures in scope.
+func LabelOutOfScope(s session.Data, uid string) bool {
+	if folder, ok := UserScope(s); !ok {
+		return true
+	} else if folder == "" {
+		return false
+	} else {
+		return !query.LabelInScope(uid, folder, s.Shares)
+	}
+}
+
+// SubjectOutOfScope checks if the session user must not access the subject with the specified UID,
+// confined users may only access subjects marked in pictures in scope.
+func SubjectOutOfScope(s session.Data, uid string) bool {
+	if folder, ok := UserScope(s); !ok {
+		return true
+	} else if folder == "" {
+		return false
+	} else {
+		return !query.SubjectInScope(uid, folder, s.Shares)
+	}
+}
+
+// ScopeSelection limits the selection to content the session user has access to,
+// access must be denied if it returns false.
+func ScopeSelection(s session.Data, f *form.Selection) bool {
+	folder, ok := UserScope(s)
+
+	if !ok {
+		return false
+	} else if folder != "" {
+		f.Scope = folder
+		f.Shared = s.Shares
+	}
+
+	return true
+}
+
+// ShareOutOfScope checks if the session user must not access the picture, album, or label with the
+// specified UID, e.g. to create or change share links. Albums can only be shared if the user may change them.
+func ShareOutOfScope(s session.Data, uid string) bool {
+	switch {
+	case rnd.EntityUID(uid, 'p'):
+		return PhotosOutOfScope(s, uid)
+	case rnd.EntityUID(uid, 'a'):
+		return AlbumWriteOutOfScope(s, uid)
+	case rnd.EntityUID(uid, 'l'):
+		return LabelOutOfScope(s, uid)
+	default:
+		return s.User.Confined()
+	}
+}
+
+// UploadPath returns the import subfolder for uploads, confined users have their own upload folder.
+func UploadPath(s session.Data, subPath string) string {
+	if s.User.Confined() {
+		return path.Join("upload", s.User.UserUID, subPath)
+	}
+
+	return path.Join("upload", subPath)
+}
+
+// DownloadToken returns the download token for the user, confined users get a token of their own
+// so that downloads can be limited to their originals folder.
+func DownloadToken(u *entity.User) string {
+	if u != nil && u.Confined() {
+		return service.Config().UserDownloadToken(u.UserUID)
+	}
+
+	return service.Config().DownloadToken()
+}
+
+// DownloadSession returns the session data for the user the download token of the request was issued
+// for, or empty session data for the shared download token. Access must be denied if ok is false.
+func DownloadSession(c *gin.Context) (s session.Data, ok bool) {
+	return tokenSession(service.Config().DownloadTokenUser(clean.Token(c.Query("t"))))
+}
+
+// PreviewToken returns the preview token for the user, confined users get a token of their own
+// so that thumbnails and videos can be limited to their originals folder.
+func PreviewToken(u *entity.User) string {
+	if u != nil && u.Confined() {
+		return service.Config().UserPreviewToken(u.UserUID)
+	}
+
+	return service.Config().PreviewToken()
+}
+
+// PreviewSession returns the session data for the user the preview token of the request was issued
+// for, or empty session data for the shared preview token. Access must be denied if ok is false.
+func PreviewSession(c *gin.Context) (s session.Data, ok bool) {
+	token := clean.Token(c.Param("token"))
+
+	if token == "" {
+		token = clean.Token(c.Query("t"))
+	}
+
+	return tokenSession(service.Config().PreviewTokenUser(token))
+}
+
+// tokenSession returns the session data for the user with the specified UID, or empty session
+// data if the UID is empty. Access must be denied if ok is false.
+func tokenSession(uid string) (s session.Data, ok bool) {
+	if uid == "" {
+		return s, true
+	} else if u := entity.FindUserByUID(uid); u == nil {
+		return s, false
+	} else {
+		s.User = *u
+	}
+
+	return s, true
+}
+
+// UserConfig returns the client config for a registered user, including the user's preview and download tokens.
+func UserConfig(u *entity.User) config.ClientConfig {
+	cfg := service.Config().UserConfig()
+	cfg.PreviewToken = PreviewToken(u)
+	cfg.DownloadToken = DownloadToken(u)
+
+	return cfg
+}
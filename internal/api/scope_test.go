@@ -0,0 +1,198 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// confinedUser returns the fixture user whose access is limited to the "2016" originals folder,
+// with the originals folder changed to the specified one.
+func confinedUser(folder string) entity.User {
+	user := entity.UserFixtures.Get("confined")
+	user.FilePath = folder
+
+	return user
+}
+
+// confinedSession creates a session for the confined fixture user with the specified share tokens.
+// The stored originals folder is changed until the test is complete if it does not match folder.
+func confinedSession(t *testing.T, folder string, tokens ...string) string {
+	user := entity.UserFixtures.Get("confined")
+
+	if folder != user.FilePath {
+		if err := entity.UnscopedDb().Model(&user).Update("FilePath", folder).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			entity.UnscopedDb().Model(&user).Update("FilePath", entity.UserFixtures.Get("confined").FilePath)
+		})
+	}
+
+	id, err := service.Session().Create(session.Data{User: user, Tokens: tokens})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func TestUserScope(t *testing.T) {
+	t.Run("Unconfined", func(t *testing.T) {
+		folder, ok := UserScope(session.Data{User: entity.Admin})
+		assert.True(t, ok)
+		assert.Equal(t, "", folder)
+	})
+	t.Run("Confined", func(t *testing.T) {
+		folder, ok := UserScope(session.Data{User: confinedUser("2016")})
+		assert.True(t, ok)
+		assert.Equal(t, "2016", folder)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		folder, ok := UserScope(session.Data{User: confinedUser("../etc")})
+		assert.False(t, ok)
+		assert.Equal(t, "", folder)
+	})
+}
+
+func TestPhotosOutOfScope(t *testing.T) {
+	s := session.Data{User: confinedUser("2016")}
+
+	assert.False(t, PhotosOutOfScope(session.Data{User: entity.Admin}, "pt9jtdre2lvl0yh7"))
+	assert.False(t, PhotosOutOfScope(s, "pt9jtdre2lvl0y13"))
+	assert.True(t, PhotosOutOfScope(s, "pt9jtdre2lvl0yh7"))
+	assert.True(t, PhotosOutOfScope(s, "pt9jtdre2lvl0y13", "pt9jtdre2lvl0yh7"))
+	assert.True(t, PhotosOutOfScope(session.Data{User: confinedUser("..")}, "pt9jtdre2lvl0y13"))
+
+	s.Shares = session.UIDs{"at9lxuqxpogaaba8"}
+	assert.False(t, PhotosOutOfScope(s, "pt9jtdre2lvl0yh0"))
+}
+
+func TestUploadPath(t *testing.T) {
+	assert.Equal(t, "upload/abc", UploadPath(session.Data{User: entity.Admin}, "abc"))
+	assert.Equal(t, "upload/uqxetse3cy5eo9z9/abc", UploadPath(session.Data{User: confinedUser("2016")}, "abc"))
+	assert.Equal(t, "upload/uqxetse3cy5eo9z9", UploadPath(session.Data{User: confinedUser("2016")}, ""))
+}
+
+func TestConfinedUser(t *testing.T) {
+	t.Run("GetPhoto", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetPhoto(router)
+		sessId := confinedSession(t, "2016")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidFolder", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetPhoto(router)
+		sessId := confinedSession(t, "../etc")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("LikePhoto", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		LikePhoto(router)
+		sessId := confinedSession(t, "2016")
+
+		r := AuthenticatedRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/like", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("GetAlbum", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAlbum(router)
+		sessId := confinedSession(t, "2016")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		shared := confinedSession(t, "2016", "1jxf3jfn2k")
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8", shared)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("UpdateAlbum", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		UpdateAlbum(router)
+		sessId := confinedSession(t, "2016", "1jxf3jfn2k")
+
+		r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8", `{"Title": "Confined"}`, sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("GetFile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetFile(router)
+		sessId := confinedSession(t, "2016")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/files/pcad9a68fa6acc5c5ba965adf6ec465ca42fd917", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/files/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("GetThumb", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumb(router)
+		token := conf.UserPreviewToken(confinedUser("2016").UserUID)
+
+		r := PerformRequest(app, "GET", "/api/v1/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+token+"/tile_500")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/tile_500")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("DeleteLabel", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		DeleteLabel(router)
+		sessId := confinedSession(t, "2016")
+
+		r := AuthenticatedRequest(app, "DELETE", "/api/v1/labels/lt9k3pw1wowuy3c2", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("DownloadAlbum", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadAlbum(router)
+		user := confinedUser("2016")
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+conf.UserDownloadToken(user.UserUID))
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("DownloadToken", func(t *testing.T) {
+		user := confinedUser("2016")
+
+		assert.Equal(t, service.Config().UserDownloadToken(user.UserUID), DownloadToken(&user))
+		assert.Equal(t, service.Config().DownloadToken(), DownloadToken(&entity.Admin))
+		assert.Equal(t, DownloadToken(&user), UserConfig(&user).DownloadToken)
+		assert.Equal(t, service.Config().UserPreviewToken(user.UserUID), PreviewToken(&user))
+		assert.Equal(t, service.Config().PreviewToken(), PreviewToken(&entity.Admin))
+		assert.Equal(t, PreviewToken(&user), UserConfig(&user).PreviewToken)
+	})
+}
@@ -44,6 +44,16 @@ func SearchAlbums(router *gin.RouterGroup) {
 			f.Public = conf.Settings().Features.Private
 		}
 
+		// Users with their own originals folder only see shared albums, their own albums, and albums with their pictures.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if folder != "" {
+			f.Scope = folder
+			f.Shared = s.Shares
+			f.User = s.User.UserUID
+		}
+
 		result, err := search.Albums(f)
 
 		if err != nil {
@@ -54,7 +64,7 @@ func SearchAlbums(router *gin.RouterGroup) {
 		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		c.JSON(http.StatusOK, result)
 	})
@@ -24,6 +24,12 @@ func SearchFaces(router *gin.RouterGroup) {
 			return
 		}
 
+		// Faces are clustered across all pictures and users.
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		var f form.SearchFaces
 
 		err := c.MustBindWith(&f, binding.Form)
@@ -43,7 +49,7 @@ func SearchFaces(router *gin.RouterGroup) {
 		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		c.JSON(http.StatusOK, result)
 	})
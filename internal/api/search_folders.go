@@ -70,6 +70,14 @@ func SearchFolders(router *gin.RouterGroup, urlPath, rootName, rootPath string)
 		resp := FoldersResponse{Root: rootName, Recursive: recursive, Cached: !uncached}
 		path := clean.Path(c.Param("path"))
 
+		// Users with their own originals folder can only browse this folder.
+		if _, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if s.User.Confined() {
+			path = s.User.UserPath(path)
+		}
+
 		cacheKey := fmt.Sprintf("folder:%s:%t:%t", filepath.Join(rootName, path), recursive, listFiles)
 
 		if !uncached {
@@ -108,7 +116,7 @@ func SearchFolders(router *gin.RouterGroup, urlPath, rootName, rootPath string)
 		AddCountHeader(c, len(resp.Files)+len(resp.Folders))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		c.JSON(http.StatusOK, resp)
 	}
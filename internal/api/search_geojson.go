@@ -74,6 +74,15 @@ func SearchGeo(router *gin.RouterGroup) {
 			f.Public = conf.Settings().Features.Private
 		}
 
+		// Users with their own originals folder only see their pictures and shared albums.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if folder != "" {
+			f.Scope = folder
+			f.Shared = s.Shares
+		}
+
 		// Find matching pictures.
 		photos, err := search.PhotosGeo(f)
 
@@ -84,7 +93,7 @@ func SearchGeo(router *gin.RouterGroup) {
 		}
 
 		// Add response headers.
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		var resp []byte
 
@@ -92,7 +101,7 @@ func SearchGeo(router *gin.RouterGroup) {
 		switch clean.Token(c.Param("format")) {
 		case "view":
 			conf := service.Config()
-			resp, err = photos.ViewerJSON(conf.ContentUri(), conf.ApiUri(), conf.PreviewToken(), conf.DownloadToken())
+			resp, err = photos.ViewerJSON(conf.ContentUri(), conf.ApiUri(), PreviewToken(&s.User), DownloadToken(&s.User))
 		default:
 			resp, err = photos.GeoJSON()
 		}
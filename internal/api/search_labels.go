@@ -32,6 +32,15 @@ func SearchLabels(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users with their own originals folder only see the labels of their pictures.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if folder != "" {
+			f.Scope = folder
+			f.Shared = s.Shares
+		}
+
 		result, err := search.Labels(f)
 
 		if err != nil {
@@ -42,7 +51,7 @@ func SearchLabels(router *gin.RouterGroup) {
 		// TODO c.Header("X-Count", strconv.Itoa(count))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		c.JSON(http.StatusOK, result)
 	})
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
 // SearchPhotos searches the pictures index and returns the result as JSON.
@@ -22,19 +23,19 @@ import (
 // See form.SearchPhotos for supported search params and data types.
 func SearchPhotos(router *gin.RouterGroup) {
 	// searchPhotos checking authorization and parses the search request.
-	searchForm := func(c *gin.Context) (f form.SearchPhotos, err error) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+	searchForm := func(c *gin.Context) (f form.SearchPhotos, s session.Data, err error) {
+		s = Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
 
 		if s.Invalid() {
 			AbortUnauthorized(c)
-			return f, i18n.Error(i18n.ErrUnauthorized)
+			return f, s, i18n.Error(i18n.ErrUnauthorized)
 		}
 
 		err = c.MustBindWith(&f, binding.Form)
 
 		if err != nil {
 			AbortBadRequest(c)
-			return f, err
+			return f, s, err
 		}
 
 		// Limit results to a specific album?
@@ -42,7 +43,7 @@ func SearchPhotos(router *gin.RouterGroup) {
 			// Do nothing.
 		} else if a, err := entity.CachedAlbumByUID(f.Album); err != nil {
 			AbortAlbumNotFound(c)
-			return f, i18n.Error(i18n.ErrAlbumNotFound)
+			return f, s, i18n.Error(i18n.ErrAlbumNotFound)
 		} else {
 			f.Filter = a.AlbumFilter
 		}
@@ -51,16 +52,22 @@ func SearchPhotos(router *gin.RouterGroup) {
 		if err = f.ParseQueryString(); err != nil {
 			log.Debugf("search: %s", err)
 			AbortBadRequest(c)
-			return f, err
+			return f, s, err
 		}
 
 		conf := service.Config()
 
+		// Use semantic search for all queries? Falls back to keyword search if no model is configured.
+		if conf.Settings().Search.Semantic && search.SemanticEnabled() && f.Semantic == "" && f.Query != "" {
+			f.Semantic = f.Query
+			f.Query = ""
+		}
+
 		// Guests may only see public content in shared albums.
 		if s.Guest() {
 			if f.Album == "" || !s.HasShare(f.Album) {
 				AbortUnauthorized(c)
-				return f, i18n.Error(i18n.ErrUnauthorized)
+				return f, s, i18n.Error(i18n.ErrUnauthorized)
 			}
 
 			f.UID = ""
@@ -74,12 +81,21 @@ func SearchPhotos(router *gin.RouterGroup) {
 			f.Public = false
 		}
 
-		return f, nil
+		// Users with their own originals folder only see their pictures and shared albums.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return f, s, i18n.Error(i18n.ErrUnauthorized)
+		} else if folder != "" {
+			f.Scope = folder
+			f.Shared = s.Shares
+		}
+
+		return f, s, nil
 	}
 
 	// defaultHandler a standard JSON result with all fields.
 	defaultHandler := func(c *gin.Context) {
-		f, err := searchForm(c)
+		f, s, err := searchForm(c)
 
 		// Abort if authorization or form are invalid.
 		if err != nil {
@@ -98,7 +114,7 @@ func SearchPhotos(router *gin.RouterGroup) {
 		AddCountHeader(c, count)
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		// Render as JSON.
 		c.JSON(http.StatusOK, result)
@@ -106,7 +122,7 @@ func SearchPhotos(router *gin.RouterGroup) {
 
 	// viewHandler returns a photo viewer formatted result.
 	viewHandler := func(c *gin.Context) {
-		f, err := searchForm(c)
+		f, s, err := searchForm(c)
 
 		// Abort if authorization or form are invalid.
 		if err != nil {
@@ -115,7 +131,7 @@ func SearchPhotos(router *gin.RouterGroup) {
 
 		conf := service.Config()
 
-		result, count, err := search.PhotosViewerResults(f, conf.ContentUri(), conf.ApiUri(), conf.PreviewToken(), conf.DownloadToken())
+		result, count, err := search.PhotosViewerResults(f, conf.ContentUri(), conf.ApiUri(), PreviewToken(&s.User), DownloadToken(&s.User))
 
 		if err != nil {
 			log.Warnf("search: %s", err)
@@ -127,7 +143,7 @@ func SearchPhotos(router *gin.RouterGroup) {
 		AddCountHeader(c, count)
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		// Render as JSON.
 		c.JSON(http.StatusOK, result)
@@ -33,6 +33,15 @@ func SearchSubjects(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users with their own originals folder only see the people in their pictures.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if folder != "" {
+			f.Scope = folder
+			f.Shared = s.Shares
+		}
+
 		result, err := search.Subjects(f)
 
 		if err != nil {
@@ -43,7 +52,7 @@ func SearchSubjects(router *gin.RouterGroup) {
 		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+		AddTokenHeaders(c, s)
 
 		c.JSON(http.StatusOK, result)
 	})
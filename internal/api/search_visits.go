@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SearchVisits finds the places where pictures have been taken and returns them in chronological order as JSON.
+//
+// GET /api/v1/places/visits
+//
+// See form.SearchVisits for supported search params and data types.
+func SearchVisits(router *gin.RouterGroup) {
+	router.GET("/places/visits", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePlaces, acl.ActionSearch)
+
+		// Guests may only see the contents of shared albums.
+		if s.Invalid() || s.Guest() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SearchVisits
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		f.Public = service.Config().Settings().Features.Private
+
+		// Users with their own originals folder only see their pictures and shared albums.
+		if folder, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		} else if folder != "" {
+			f.Scope = folder
+			f.Shared = s.Shares
+		}
+
+		result, count, err := search.Visits(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, count)
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+		AddTokenHeaders(c, s)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
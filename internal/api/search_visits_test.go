@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestSearchVisits(t *testing.T) {
+	if err := photoprism.NewTimeline(service.Config()).Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchVisits(router)
+		r := PerformRequest(app, "GET", "/api/v1/places/visits")
+		assert.Equal(t, http.StatusOK, r.Code)
+		count := gjson.Get(r.Body.String(), "#")
+		assert.LessOrEqual(t, int64(1), count.Int())
+		assert.Equal(t, strconv.FormatInt(count.Int(), 10), r.Header().Get("X-Count"))
+		arrival := gjson.Get(r.Body.String(), "0.Arrival")
+		assert.NotEmpty(t, arrival.String())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "0.PhotoUIDs.#").Int())
+	})
+	t.Run("Count", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchVisits(router)
+		r := PerformRequest(app, "GET", "/api/v1/places/visits?count=1&reverse=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		count := gjson.Get(r.Body.String(), "#")
+		assert.Equal(t, int64(1), count.Int())
+
+		// The count header contains the total number of visits.
+		total, err := strconv.Atoi(r.Header().Get("X-Count"))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, 1, total)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchVisits(router)
+		r := PerformRequest(app, "GET", "/api/v1/places/visits?q=xxxNotExistingPlacexxx")
+		assert.Equal(t, http.StatusOK, r.Code)
+		count := gjson.Get(r.Body.String(), "#")
+		assert.Equal(t, int64(0), count.Int())
+		assert.Equal(t, "0", r.Header().Get("X-Count"))
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchVisits(router)
+		r := PerformRequest(app, "GET", "/api/v1/places/visits?count=abc")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Confined", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		SearchVisits(router)
+
+		adminId, err := service.Session().Create(session.Data{User: entity.Admin})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		all := AuthenticatedRequest(app, "GET", "/api/v1/places/visits", adminId)
+		assert.Equal(t, http.StatusOK, all.Code)
+
+		sessId := confinedSession(t, "xxxNotExistingFolderxxx")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/places/visits", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "0", r.Header().Get("X-Count"))
+		assert.NotEqual(t, "0", all.Header().Get("X-Count"))
+
+		sessId = confinedSession(t, "../etc")
+		r = AuthenticatedRequest(app, "GET", "/api/v1/places/visits", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		SearchVisits(router)
+
+		guest := entity.User{ID: 10000999, UserUID: "uqxetse3cy5eo9z8", Username: "guest", UserRole: acl.RoleGuest.String()}
+		sessId, err := service.Session().Create(session.Data{User: guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/places/visits", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -28,7 +31,7 @@ func CreateSession(router *gin.RouterGroup) {
 
 		id := SessionID(c)
 
-		if s := Session(id); s.Valid() {
+		if s := Session(id); s.Valid() && !s.Restricted() {
 			data = s
 		} else {
 			data = session.Data{}
@@ -55,35 +58,67 @@ func CreateSession(router *gin.RouterGroup) {
 			if data.User.IsAnonymous() {
 				data.User = entity.Guest
 			}
+
+			if data.AuthMethod == "" {
+				data.AuthMethod = entity.AuthMethodLink
+			}
 		} else if f.HasCredentials() {
 			user := entity.FindUserByLogin(f.Username)
 
 			if user == nil {
+				Audit(c, data, acl.ResourceSessions, entity.AuditLogin, fmt.Errorf("unknown user %s", clean.LogHash(f.Username)))
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
 			if user.InvalidPassword(f.Password) {
+				Audit(c, data, acl.ResourceSessions, entity.AuditLogin, errors.New("invalid password"), user.UserUID)
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
+			data.AuthMethod = entity.AuthMethodPassword
+
+			// Ask for the second factor if two-factor authentication is enabled.
+			if user.RequiresPasscode() {
+				data.AuthMethod = entity.AuthMethodPasscode
+
+				if !f.HasPasscode() {
+					c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrPasscodeRequired), "passcode": true})
+					return
+				} else if user.InvalidPasscode(f.Passcode) {
+					Audit(c, data, acl.ResourceSessions, entity.AuditLogin, errors.New("invalid passcode"), user.UserUID)
+					c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPasscode), "passcode": true})
+					return
+				}
+			}
+
 			data.User = *user
 		} else {
 			c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword)})
 			return
 		}
 
+		data.SetClient(c.ClientIP(), c.Request.UserAgent())
+
 		if err := service.Session().Update(id, data); err != nil {
-			id = service.Session().Create(data)
+			if id, err = service.Session().Create(data); err != nil {
+				log.Error(err)
+				AbortUnexpected(c)
+				return
+			}
 		}
 
 		AddSessionHeader(c, id)
 
+		if f.HasCredentials() {
+			Audit(c, service.Session().Get(id), acl.ResourceSessions, entity.AuditLogin, nil, data.User.UserUID)
+		}
+
 		if data.User.IsAnonymous() {
 			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.GuestConfig()})
 		} else {
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfig()})
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": UserConfig(&data.User)})
 		}
 	})
 }
@@ -93,15 +128,34 @@ func DeleteSession(router *gin.RouterGroup) {
 	router.DELETE("/session/:id", func(c *gin.Context) {
 		id := clean.Token(c.Param("id"))
 
+		if s := service.Session().Get(id); s.Valid() {
+			Audit(c, s, acl.ResourceSessions, entity.AuditLogout, nil, s.User.UserUID)
+		}
+
 		service.Session().Delete(id)
 
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id})
 	})
 }
 
-// SessionID returns the session id from the HTTP header.
+// SessionID returns the session id from the HTTP header, or a personal access token if provided as bearer token.
 func SessionID(c *gin.Context) string {
-	return c.GetHeader("X-Session-ID")
+	if id := c.GetHeader("X-Session-ID"); id != "" {
+		return id
+	}
+
+	return BearerToken(c)
+}
+
+// BearerToken returns the bearer token from the authorization header, if any.
+func BearerToken(c *gin.Context) string {
+	auth := c.GetHeader("Authorization")
+
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "Bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+
+	return ""
 }
 
 // Session returns the current session data.
@@ -111,15 +165,42 @@ func Session(id string) session.Data {
 		return session.Data{User: entity.Admin}
 	}
 
+	// Check if id is a personal access token.
+	if entity.IsAccessToken(id) {
+		return TokenSession(id)
+	}
+
 	// Check if session id is valid.
 	return service.Session().Get(id)
 }
 
+// TokenSession returns the session data for a personal access token.
+func TokenSession(secret string) session.Data {
+	token := entity.FindAccessToken(secret)
+
+	if token == nil {
+		return session.Data{}
+	}
+
+	user := token.User()
+
+	if user == nil || user.Deleted() {
+		return session.Data{}
+	}
+
+	return session.Data{User: *user, AccessToken: token}
+}
+
 // Auth returns the session if user is authorized for the current action.
 func Auth(id string, resource acl.Resource, action acl.Action) session.Data {
 	sess := Session(id)
 
-	if acl.Permissions.Deny(resource, sess.User.AclRole(), action) {
+	if acl.Permissions.Deny(resource, sess.User.AclRole(), action) || sess.Restricted() && !sess.AccessToken.Allow(resource, action) {
+		// Record denied requests of registered users, anonymous requests are not logged.
+		if sess.User.IsRegistered() {
+			auditLog(sess, sess.ClientIP, resource, string(action), entity.AuditDenied, nil)
+		}
+
 		return session.Data{}
 	}
 
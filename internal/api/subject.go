@@ -28,6 +28,11 @@ func GetSubject(router *gin.RouterGroup) {
 			return
 		}
 
+		if SubjectOutOfScope(s, clean.IdString(c.Param("uid"))) {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
 		if subj := entity.FindSubject(clean.IdString(c.Param("uid"))); subj == nil {
 			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
 			return
@@ -56,6 +61,12 @@ func UpdateSubject(router *gin.RouterGroup) {
 			return
 		}
 
+		// Confined users cannot change people, as they are shared by all users.
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		uid := clean.IdString(c.Param("uid"))
 		m := entity.FindSubject(uid)
 
@@ -110,6 +121,11 @@ func LikeSubject(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		uid := clean.IdString(c.Param("uid"))
 		subj := entity.FindSubject(uid)
 
@@ -145,6 +161,11 @@ func DislikeSubject(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.User.Confined() {
+			AbortForbidden(c)
+			return
+		}
+
 		uid := clean.IdString(c.Param("uid"))
 		subj := entity.FindSubject(uid)
 
@@ -39,6 +39,12 @@ func GetThumb(router *gin.RouterGroup) {
 		download := c.Query("download") != ""
 		fileHash, cropArea := crop.ParseThumb(clean.Token(c.Param("thumb")))
 
+		// Users with their own originals folder may only view pictures in scope.
+		if s, ok := PreviewSession(c); !ok || FileOutOfScope(s, fileHash) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		// Is cropped thumbnail?
 		if cropArea != "" {
 			cropName := crop.Name(clean.Token(c.Param("size")))
@@ -31,6 +31,12 @@ func Upload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users with an invalid originals folder must not upload files.
+		if _, ok := UserScope(s); !ok {
+			AbortUnauthorized(c)
+			return
+		}
+
 		start := time.Now()
 		subPath := clean.Path(c.Param("path"))
 
@@ -48,7 +54,7 @@ func Upload(router *gin.RouterGroup) {
 		uploaded := len(files)
 		var uploads []string
 
-		p := path.Join(conf.ImportPath(), "upload", subPath)
+		p := path.Join(conf.ImportPath(), UploadPath(s, subPath))
 
 		if err := os.MkdirAll(p, os.ModePerm); err != nil {
 			log.Errorf("upload: failed creating folder %s", clean.Log(subPath))
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// passcodeUser returns the current user if they are allowed to change their two-factor authentication settings.
+func passcodeUser(c *gin.Context) (session.Data, *entity.User, form.Passcode) {
+	var f form.Passcode
+
+	conf := service.Config()
+
+	if conf.Public() || conf.DisableSettings() {
+		Abort(c, http.StatusForbidden, i18n.ErrPublic)
+		return session.Data{}, nil, f
+	}
+
+	s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdateSelf)
+
+	if s.Invalid() || s.Restricted() || s.User.UserUID != clean.IdString(c.Param("uid")) {
+		AbortUnauthorized(c)
+		return s, nil, f
+	}
+
+	m := entity.FindUserByUID(s.User.UserUID)
+
+	if m == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		return s, nil, f
+	}
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return s, nil, f
+	}
+
+	return s, m, f
+}
+
+// POST /api/v1/users/:uid/passcode
+func CreateUserPasscode(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passcode", func(c *gin.Context) {
+		_, m, f := passcodeUser(c)
+
+		if m == nil {
+			return
+		}
+
+		if m.InvalidPassword(f.Password) {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
+			return
+		}
+
+		if p := entity.FindPasscode(m.UserUID); p != nil && p.Verified() {
+			AbortAlreadyExists(c, "passcode")
+			return
+		}
+
+		p := entity.NewPasscode(m.UserUID)
+
+		if err := p.Save(); err != nil {
+			log.Errorf("passcode: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"Secret": p.Secret,
+			"URI":    p.URI(service.Config().SiteTitle(), m.UserName()),
+		})
+	})
+}
+
+// POST /api/v1/users/:uid/passcode/confirm
+func ConfirmUserPasscode(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passcode/confirm", func(c *gin.Context) {
+		s, m, f := passcodeUser(c)
+
+		if m == nil {
+			return
+		}
+
+		p := entity.FindPasscode(m.UserUID)
+
+		if p == nil || p.Verified() {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		codes, err := p.Activate(f.Passcode)
+
+		Audit(c, s, acl.ResourceUsers, entity.AuditEnable2FA, err, m.UserUID)
+
+		if err != nil {
+			log.Warnf("passcode: %s for user %s", err, m.String())
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPasscode)
+			return
+		}
+
+		log.Infof("passcode: enabled two-factor authentication for user %s", m.String())
+
+		c.JSON(http.StatusOK, gin.H{"RecoveryCodes": codes})
+	})
+}
+
+// DELETE /api/v1/users/:uid/passcode
+func DeleteUserPasscode(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/passcode", func(c *gin.Context) {
+		s, m, f := passcodeUser(c)
+
+		if m == nil {
+			return
+		}
+
+		p := entity.FindPasscode(m.UserUID)
+
+		if p == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if m.InvalidPassword(f.Password) {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
+			return
+		} else if p.Verified() && m.InvalidPasscode(f.Passcode) {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPasscode)
+			return
+		}
+
+		err := p.Delete()
+
+		Audit(c, s, acl.ResourceUsers, entity.AuditDisable2FA, err, m.UserUID)
+
+		if err != nil {
+			log.Errorf("passcode: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("passcode: disabled two-factor authentication for user %s", m.String())
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+}
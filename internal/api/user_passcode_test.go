@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+func TestUserPasscode(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUserPasscode(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users/"+entity.Admin.UserUID+"/passcode", `{"password": "photoprism"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("EnableLoginDisable", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateUserPasscode(router)
+		ConfirmUserPasscode(router)
+		DeleteUserPasscode(router)
+		sessId := AuthenticateUser(app, router, "friend", "!Friend321")
+		uid := entity.UserFixtures.Get("friend").UserUID
+		uri := "/api/v1/users/" + uid + "/passcode"
+
+		defer func() {
+			if p := entity.FindPasscode(uid); p != nil {
+				_ = p.Delete()
+			}
+		}()
+
+		r := AuthenticatedRequestWithBody(app, "POST", uri, `{"password": "wrong"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", uri, `{"password": "!Friend321"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		secret := gjson.Get(r.Body.String(), "Secret").String()
+		assert.Contains(t, gjson.Get(r.Body.String(), "URI").String(), "otpauth://totp/")
+
+		r = AuthenticatedRequestWithBody(app, "POST", uri+"/confirm", `{"passcode": "abc"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		code, err := totp.Code(secret, totp.Counter(time.Now()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = AuthenticatedRequestWithBody(app, "POST", uri+"/confirm", `{"passcode": "`+code+`"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		recovery := gjson.Get(r.Body.String(), "RecoveryCodes").Array()
+		assert.Len(t, recovery, entity.RecoveryCodes)
+
+		// Password alone is no longer sufficient.
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "friend", "password": "!Friend321"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "passcode").Bool())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "friend", "password": "!Friend321", "passcode": "`+recovery[0].String()+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "DELETE", uri, `{"password": "!Friend321", "passcode": "`+recovery[1].String()+`"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindPasscode(uid))
+	})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -51,11 +52,16 @@ func ChangePassword(router *gin.RouterGroup) {
 		}
 
 		if m.InvalidPassword(f.OldPassword) {
+			Audit(c, s, acl.ResourcePasswords, entity.AuditUpdate, errors.New("invalid password"), m.UserUID)
 			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
 			return
 		}
 
-		if err := m.SetPassword(f.NewPassword); err != nil {
+		err := m.SetPassword(f.NewPassword)
+
+		Audit(c, s, acl.ResourcePasswords, entity.AuditUpdate, err, m.UserUID)
+
+		if err != nil {
 			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidPassword)
 			return
 		}
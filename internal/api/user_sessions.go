@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GET /api/v1/users/:uid/sessions
+func GetUserSessions(router *gin.RouterGroup) {
+	router.GET("/users/:uid/sessions", func(c *gin.Context) {
+		s, m := managedUser(c)
+
+		if m == nil {
+			return
+		}
+
+		sessions := entity.FindSessions(m.UserUID)
+		result := make([]gin.H, len(sessions))
+
+		for i, sess := range sessions {
+			result[i] = gin.H{
+				"UID":        sess.SessUID,
+				"AuthMethod": sess.AuthMethod,
+				"ClientIP":   sess.ClientIP,
+				"UserAgent":  sess.UserAgent,
+				"Current":    sess.SessUID == s.UID,
+				"LastActive": sess.LastActive,
+				"ExpiresAt":  sess.ExpiresAt,
+				"CreatedAt":  sess.CreatedAt,
+			}
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// DELETE /api/v1/users/:uid/sessions/:sid
+func DeleteUserSession(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions/:sid", func(c *gin.Context) {
+		s, m := managedUser(c)
+
+		if m == nil {
+			return
+		}
+
+		sess := entity.FindSessionByUID(clean.IdString(c.Param("sid")))
+
+		if sess == nil || sess.UserUID != m.UserUID {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		err := sess.Delete()
+
+		Audit(c, s, acl.ResourceSessions, entity.AuditDelete, err, sess.SessUID)
+
+		if err != nil {
+			log.Errorf("session: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("session: revoked %s of user %s", clean.Log(sess.SessUID), m.String())
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": sess.SessUID})
+	})
+}
+
+// DELETE /api/v1/users/:uid/sessions
+//
+// Logs the user out on all devices, e.g. after a phone has been lost. Browser sessions and access
+// tokens, including app passwords used for WebDAV, are revoked. Share links remain valid, as they
+// are not bound to the user, and so does the login at an OpenID Connect identity provider.
+func DeleteUserSessions(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions", func(c *gin.Context) {
+		s, m := managedUser(c)
+
+		if m == nil {
+			return
+		}
+
+		n, err := entity.DeleteUserSessions(m.UserUID)
+
+		Audit(c, s, acl.ResourceSessions, entity.AuditDelete, err, m.UserUID)
+
+		if err != nil {
+			log.Errorf("session: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		tokens, err := entity.DeleteUserTokens(m.UserUID)
+
+		Audit(c, s, acl.ResourceUsers, entity.AuditRevokeToken, err, m.UserUID)
+
+		if err != nil {
+			log.Errorf("token: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "count": n, "tokens": tokens})
+	})
+}
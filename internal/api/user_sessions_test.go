@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestUserSessions(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserSessions(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.UserUID+"/sessions")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("ListRevoke", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserSessions(router)
+		DeleteUserSession(router)
+		DeleteUserSessions(router)
+		SearchPhotos(router)
+		uri := "/api/v1/users/" + entity.UserFixtures.Get("bob").UserUID + "/sessions"
+		first := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		second := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "bob", "password": "Bobbob123!"}`).Header().Get("X-Session-ID")
+		assert.NotEmpty(t, second)
+
+		r := AuthenticatedRequest(app, "GET", uri, first)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		sessions := gjson.Parse(r.Body.String()).Array()
+		assert.GreaterOrEqual(t, len(sessions), 2)
+
+		var other string
+
+		for _, sess := range sessions {
+			assert.Equal(t, entity.AuthMethodPassword, sess.Get("AuthMethod").String())
+
+			if !sess.Get("Current").Bool() && other == "" {
+				other = sess.Get("UID").String()
+			}
+		}
+
+		r = AuthenticatedRequest(app, "DELETE", uri+"/"+other, first)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "DELETE", uri+"/"+other, first)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		token, secret := entity.NewAccessToken(entity.UserFixtures.Get("bob").UserUID, "Phone", acl.Scopes{acl.ScopeSearch}, 0)
+
+		if err := token.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		r = BearerRequest(app, "GET", "/api/v1/photos?count=1", secret)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// Log out everywhere, access tokens are revoked as well.
+		r = AuthenticatedRequest(app, "DELETE", uri, first)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "tokens").Int())
+
+		r = BearerRequest(app, "GET", "/api/v1/photos?count=1", secret)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", uri, first)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", uri, second)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		DeleteUserSessions(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.UserUID+"/sessions", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
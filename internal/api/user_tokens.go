@@ -0,0 +1,153 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// managedUser returns the user whose tokens or sessions are managed if the current session is allowed to do so.
+func managedUser(c *gin.Context) (session.Data, *entity.User) {
+	if service.Config().Public() {
+		Abort(c, http.StatusForbidden, i18n.ErrPublic)
+		return session.Data{}, nil
+	}
+
+	s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdateSelf)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return s, nil
+	}
+
+	uid := clean.IdString(c.Param("uid"))
+
+	if s.User.UserUID != uid && !s.User.IsAdmin() {
+		AbortUnauthorized(c)
+		return s, nil
+	}
+
+	m := entity.FindUserByUID(uid)
+
+	if m == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		return s, nil
+	}
+
+	return s, m
+}
+
+// GET /api/v1/users/:uid/tokens
+func GetUserTokens(router *gin.RouterGroup) {
+	router.GET("/users/:uid/tokens", func(c *gin.Context) {
+		_, m := managedUser(c)
+
+		if m == nil {
+			return
+		}
+
+		tokens := entity.FindUserTokens(m.UserUID)
+		result := make([]gin.H, len(tokens))
+
+		for i, t := range tokens {
+			result[i] = gin.H{
+				"UID":       t.TokenUID,
+				"Name":      t.TokenName,
+				"Scopes":    t.Scopes().String(),
+				"Expired":   t.Expired(),
+				"ExpiresAt": t.ExpiresAt,
+				"CreatedAt": t.CreatedAt,
+			}
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/users/:uid/tokens
+func CreateUserToken(router *gin.RouterGroup) {
+	router.POST("/users/:uid/tokens", func(c *gin.Context) {
+		s, m := managedUser(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.AccessToken
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		scopes := acl.ParseScopes(f.Scopes)
+
+		if len(scopes) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		token, secret := entity.NewAccessToken(m.UserUID, f.Name, scopes, time.Duration(f.Expires)*24*time.Hour)
+
+		err := token.Create()
+
+		Audit(c, s, acl.ResourceUsers, entity.AuditAddToken, err, m.UserUID)
+
+		if err != nil {
+			log.Errorf("token: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("token: created %s for user %s", clean.Log(token.TokenUID), m.String())
+
+		c.JSON(http.StatusOK, gin.H{
+			"UID":       token.TokenUID,
+			"Name":      token.TokenName,
+			"Scopes":    token.Scopes().String(),
+			"ExpiresAt": token.ExpiresAt,
+			"Secret":    secret,
+		})
+	})
+}
+
+// DELETE /api/v1/users/:uid/tokens/:tid
+func DeleteUserToken(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/tokens/:tid", func(c *gin.Context) {
+		s, m := managedUser(c)
+
+		if m == nil {
+			return
+		}
+
+		token := entity.FindToken(clean.IdString(c.Param("tid")))
+
+		if token == nil || token.UserUID != m.UserUID {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		err := token.Delete()
+
+		Audit(c, s, acl.ResourceUsers, entity.AuditRevokeToken, err, m.UserUID)
+
+		if err != nil {
+			log.Errorf("token: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("token: revoked %s", clean.Log(token.TokenUID))
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": token.TokenUID})
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestUserTokens(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserTokens(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.UserUID+"/tokens")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("CreateUseRevoke", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTokens(router)
+		CreateUserToken(router)
+		DeleteUserToken(router)
+		SearchPhotos(router)
+		sessId := AuthenticateAdmin(app, router)
+		uri := "/api/v1/users/" + entity.Admin.UserUID + "/tokens"
+
+		r := AuthenticatedRequestWithBody(app, "POST", uri, `{"name": "Sync", "scopes": "search"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		secret := gjson.Get(r.Body.String(), "Secret").String()
+
+		assert.True(t, entity.IsAccessToken(secret))
+		assert.Equal(t, "search", gjson.Get(r.Body.String(), "Scopes").String())
+
+		r = AuthenticatedRequest(app, "GET", uri, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), uid)
+		assert.NotContains(t, r.Body.String(), secret)
+
+		// Search is allowed with the token.
+		r = BearerRequest(app, "GET", "/api/v1/photos?count=1", secret)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// Tokens cannot be used to manage tokens.
+		r = BearerRequest(app, "GET", uri, secret)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = AuthenticatedRequest(app, "DELETE", uri+"/"+uid, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = BearerRequest(app, "GET", "/api/v1/photos?count=1", secret)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("NoScopes", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateUserToken(router)
+		sessId := AuthenticateAdmin(app, router)
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/"+entity.Admin.UserUID+"/tokens", `{"name": "Sync"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTokens(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/"+entity.Admin.UserUID+"/tokens", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
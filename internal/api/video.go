@@ -49,6 +49,12 @@ func GetVideo(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users with their own originals folder may only view videos in scope.
+		if s, ok := PreviewSession(c); !ok || PhotosOutOfScope(s, f.PhotoUID) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		if !f.FileVideo {
 			f, err = query.VideoByPhotoUID(f.PhotoUID)
 
@@ -69,7 +69,7 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 				if sess.User.IsGuest() {
 					clientConfig = conf.GuestConfig()
 				} else if sess.User.IsRegistered() {
-					clientConfig = conf.UserConfig()
+					clientConfig = UserConfig(&sess.User)
 				} else {
 					clientConfig = conf.PublicConfig()
 				}
@@ -152,6 +152,11 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 			wsAuth.mutex.RUnlock()
 
 			if user.IsRegistered() {
+				// Confined users must not receive the shared download token.
+				if _, ok := msg.Fields["config"].(config.ClientConfig); ok && user.Confined() {
+					msg.Fields = event.Data{"config": UserConfig(&user)}
+				}
+
 				writeMutex.Lock()
 
 				if err := ws.SetWriteDeadline(time.Now().Add(30 * time.Second)); err != nil {
@@ -56,6 +56,11 @@ func ZipCreate(router *gin.RouterGroup) {
 			return
 		}
 
+		if !ScopeSelection(s, &f) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		// Configure file selection based on user settings.
 		var selection query.FileSelection
 		if dl := conf.Settings().Download; dl.Disabled {
@@ -11,8 +11,10 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 var autoImport = time.Time{}
@@ -76,9 +78,33 @@ func Import() error {
 		opt = photoprism.ImportOptionsCopy(path)
 	}
 
-	imported := imp.Start(opt)
+	// Files in the import folders of confined users are imported into their originals folders.
+	imported := 0
 
-	if len(imported) == 0 {
+	for _, user := range query.RegisteredUsers() {
+		folder := user.UserFolder()
+
+		if folder == "" {
+			continue
+		}
+
+		opt.Exclude = append(opt.Exclude, folder)
+
+		if !fs.PathExists(filepath.Join(path, folder)) {
+			continue
+		}
+
+		userOpt := opt
+		userOpt.Path = filepath.Join(path, folder)
+		userOpt.DestFolder = folder
+		userOpt.Exclude = nil
+
+		imported += len(imp.Start(userOpt))
+	}
+
+	imported += len(imp.Start(opt))
+
+	if imported == 0 {
 		return nil
 	}
 
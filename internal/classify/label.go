@@ -29,7 +29,7 @@ func LocationLabel(name string, uncertainty int) Label {
 
 	var categories []string
 
-	if rule, ok := Rules.Find(name); ok {
+	if rule, ok := FindRule(name); ok {
 		priority = rule.Priority
 		categories = rule.Categories
 	}
@@ -0,0 +1,202 @@
+package classify
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// RulesCheckInterval is the minimum time between checks whether the user rules file has changed.
+var RulesCheckInterval = 10 * time.Second
+
+// ruleSet holds the active label rules and the state of the user rules file.
+type ruleSet struct {
+	sync.RWMutex
+	fileName string
+	modTime  time.Time
+	checked  time.Time
+	active   LabelRules
+}
+
+var rules = &ruleSet{active: Rules}
+
+// ruleYaml represents a label rule in a YAML file.
+type ruleYaml struct {
+	Label      string   `yaml:"label"`
+	See        string   `yaml:"see"`
+	Threshold  float32  `yaml:"threshold"`
+	Categories []string `yaml:"categories"`
+	Priority   int      `yaml:"priority"`
+}
+
+// SetRulesFile sets the name of an optional YAML file with user-defined rules that are merged
+// over the built-in rules, and loads it if it exists.
+func SetRulesFile(fileName string) {
+	rules.Lock()
+
+	if fileName == rules.fileName {
+		rules.Unlock()
+		return
+	}
+
+	rules.fileName = fileName
+	rules.modTime = time.Time{}
+	rules.checked = time.Time{}
+	rules.active = Rules
+	rules.Unlock()
+
+	if err := ReloadRules(); err != nil {
+		log.Warnf("classify: %s", err)
+	}
+}
+
+// ReloadRules loads the user rules file again, the built-in rules are used if it does not exist.
+func ReloadRules() error {
+	rules.Lock()
+	defer rules.Unlock()
+
+	return rules.reload(true)
+}
+
+// ActiveRules returns the built-in rules merged with the user-defined rules,
+// which are reloaded if the file has changed. The result must not be modified.
+func ActiveRules() LabelRules {
+	rules.RLock()
+	recheck := rules.fileName != "" && time.Since(rules.checked) >= RulesCheckInterval
+	result := rules.active
+	rules.RUnlock()
+
+	if !recheck {
+		return result
+	}
+
+	rules.Lock()
+	defer rules.Unlock()
+
+	if err := rules.reload(false); err != nil {
+		log.Warnf("classify: %s", err)
+	}
+
+	return rules.active
+}
+
+// FindRule returns the active rule for a label, see ActiveRules.
+func FindRule(label string) (LabelRule, bool) {
+	return ActiveRules().Find(label)
+}
+
+// ParseRules parses label rules in YAML format and merges them over the base rules,
+// "see" references may point to rules in both sets.
+func ParseRules(yamlData []byte, base LabelRules) (LabelRules, error) {
+	var data map[string]ruleYaml
+
+	if err := yaml.Unmarshal(yamlData, &data); err != nil {
+		return nil, err
+	}
+
+	parsed := make(map[string]ruleYaml, len(data))
+
+	for name, rule := range data {
+		parsed[normalizeRuleName(name)] = rule
+	}
+
+	result := make(LabelRules, len(base)+len(parsed))
+
+	for name, rule := range base {
+		result[name] = rule
+	}
+
+	// Add rules without references first.
+	for name, rule := range parsed {
+		if rule.See != "" {
+			continue
+		}
+
+		result[name] = LabelRule{
+			Label:      normalizeRuleName(rule.Label),
+			Threshold:  rule.Threshold,
+			Categories: rule.Categories,
+			Priority:   rule.Priority,
+		}
+	}
+
+	// Resolve references to other rules, which may refer to other rules as well.
+	for name, rule := range parsed {
+		see := normalizeRuleName(rule.See)
+
+		for i := 0; see != "" && i < len(parsed); i++ {
+			if next, ok := parsed[see]; ok && next.See != "" {
+				see = normalizeRuleName(next.See)
+			} else {
+				break
+			}
+		}
+
+		if see == "" {
+			continue
+		} else if target, ok := result[see]; !ok {
+			return nil, fmt.Errorf("rule %s refers to missing label %s", clean.Log(name), clean.Log(rule.See))
+		} else {
+			result[name] = target
+		}
+	}
+
+	return result, nil
+}
+
+// normalizeRuleName returns a label name as used in rules.
+func normalizeRuleName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// reload loads the user rules file if it has changed or force is true, the caller must hold the lock.
+func (r *ruleSet) reload(force bool) error {
+	r.checked = time.Now()
+
+	if r.fileName == "" {
+		r.active = Rules
+		return nil
+	}
+
+	info, err := os.Stat(r.fileName)
+
+	if os.IsNotExist(err) {
+		if !r.modTime.IsZero() {
+			log.Infof("classify: %s removed, using built-in label rules", clean.Log(r.fileName))
+		}
+
+		r.active = Rules
+		r.modTime = time.Time{}
+		return nil
+	} else if err != nil {
+		return err
+	} else if !force && info.ModTime().Equal(r.modTime) {
+		return nil
+	}
+
+	r.modTime = info.ModTime()
+
+	data, err := os.ReadFile(r.fileName)
+
+	if err != nil {
+		return err
+	}
+
+	result, err := ParseRules(data, Rules)
+
+	if err != nil {
+		return fmt.Errorf("%s in %s", err, clean.Log(r.fileName))
+	}
+
+	r.active = result
+
+	log.Infof("classify: loaded label rules from %s", clean.Log(r.fileName))
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package classify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Run("Merge", func(t *testing.T) {
+		yamlData := []byte(`
+cat:
+  label: pet
+  threshold: 0.4
+  priority: 3
+  categories:
+    - animal
+
+Tabby Cat:
+  see: cat
+
+dashboard:
+  label: car
+  threshold: 0.3
+`)
+
+		result, err := ParseRules(yamlData, Rules)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pet", result["cat"].Label)
+		assert.Equal(t, float32(0.4), result["cat"].Threshold)
+		assert.Equal(t, 3, result["cat"].Priority)
+		assert.Equal(t, []string{"animal"}, result["cat"].Categories)
+		assert.Equal(t, result["cat"], result["tabby cat"])
+		assert.Equal(t, "car", result["dashboard"].Label)
+		assert.Equal(t, Rules["persian cat"], result["persian cat"])
+		assert.Equal(t, "cat", Rules["cat"].Label)
+	})
+	t.Run("SeeBuiltIn", func(t *testing.T) {
+		result, err := ParseRules([]byte("kitty:\n  see: persian cat\n"), Rules)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Rules["persian cat"], result["kitty"])
+	})
+	t.Run("SeeChain", func(t *testing.T) {
+		result, err := ParseRules([]byte("a:\n  see: b\nb:\n  see: c\nc:\n  label: letter\n  threshold: 0.5\n"), LabelRules{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "letter", result["a"].Label)
+		assert.Equal(t, "letter", result["b"].Label)
+	})
+	t.Run("MissingLabel", func(t *testing.T) {
+		_, err := ParseRules([]byte("kitty:\n  see: unknown label\n"), Rules)
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidYaml", func(t *testing.T) {
+		_, err := ParseRules([]byte("- foo\n- bar\n"), Rules)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSetRulesFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rules.yml")
+	interval := RulesCheckInterval
+
+	RulesCheckInterval = 0
+
+	defer func() {
+		RulesCheckInterval = interval
+		SetRulesFile("")
+	}()
+
+	t.Run("NotExists", func(t *testing.T) {
+		SetRulesFile(fileName)
+
+		rule, ok := FindRule("cat")
+
+		assert.True(t, ok)
+		assert.Equal(t, Rules["cat"], rule)
+	})
+	t.Run("Created", func(t *testing.T) {
+		if err := os.WriteFile(fileName, []byte("cat:\n  label: pet\n  threshold: 0.5\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		rule, ok := FindRule("cat")
+
+		assert.True(t, ok)
+		assert.Equal(t, "pet", rule.Label)
+		assert.Equal(t, 0, LocationLabel("cat", 0).Priority)
+	})
+	t.Run("Changed", func(t *testing.T) {
+		if err := os.WriteFile(fileName, []byte("cat:\n  label: kitten\n  threshold: 0.5\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		modTime := time.Now().Add(time.Minute)
+
+		if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		rule, _ := FindRule("cat")
+
+		assert.Equal(t, "kitten", rule.Label)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		if err := os.WriteFile(fileName, []byte("cat:\n  see: unknown\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, ReloadRules())
+
+		rule, _ := FindRule("cat")
+
+		assert.Equal(t, "kitten", rule.Label)
+	})
+	t.Run("Removed", func(t *testing.T) {
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		rule, _ := FindRule("cat")
+
+		assert.Equal(t, Rules["cat"], rule)
+	})
+}
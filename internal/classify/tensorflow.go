@@ -14,13 +14,16 @@ import (
 	"strings"
 
 	"github.com/disintegration/imaging"
-	"github.com/photoprism/photoprism/pkg/clean"
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+
+	"github.com/photoprism/photoprism/internal/inference"
+	"github.com/photoprism/photoprism/pkg/clean"
 )
 
 // TensorFlow is a wrapper for tensorflow low-level API.
 type TensorFlow struct {
 	model      *tf.SavedModel
+	backend    inference.Model
 	modelsPath string
 	disabled   bool
 	modelName  string
@@ -33,9 +36,16 @@ func New(modelsPath string, disabled bool) *TensorFlow {
 	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, modelName: "nasnet", modelTags: []string{"photoprism"}}
 }
 
+// WithBackend uses another model for classification, e.g. on a local inference server,
+// instead of the built-in TensorFlow model. Label rules are applied to its output.
+func (t *TensorFlow) WithBackend(model inference.Model) *TensorFlow {
+	t.backend = model
+	return t
+}
+
 // Init initialises tensorflow models if not disabled
 func (t *TensorFlow) Init() (err error) {
-	if t.disabled {
+	if t.disabled || t.backend != nil {
 		return nil
 	}
 
@@ -59,25 +69,49 @@ func (t *TensorFlow) File(filename string) (result Labels, err error) {
 
 // Labels returns matching labels for a jpeg media string.
 func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
+	var output inference.Result
+
+	if t.backend != nil {
+		output, err = t.backend.Infer(img)
+	} else {
+		output, err = t.Infer(img)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	// Return best labels
+	result = t.bestLabels(output.Labels)
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
+
+// Infer runs the TensorFlow model and returns the probabilities of all labels for a jpeg media string.
+func (t *TensorFlow) Infer(img []byte) (result inference.Result, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("classify: %s (inference panic)\nstack: %s", r, debug.Stack())
 		}
 	}()
 
-	if t.disabled {
-		return result, nil
-	}
-
 	if err := t.loadModel(); err != nil {
-		return nil, err
+		return result, err
 	}
 
 	// Create tensor from image.
 	tensor, err := t.createTensor(img, "jpeg")
 
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 
 	// Run inference.
@@ -98,14 +132,23 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 		return result, fmt.Errorf("classify: inference failed, no output")
 	}
 
-	// Return best labels
-	result = t.bestLabels(output[0].Value().([][]float32)[0])
+	result.Labels = t.labelScores(output[0].Value().([][]float32)[0])
 
-	if len(result) > 0 {
-		log.Tracef("classify: image classified as %+v", result)
+	return result, nil
+}
+
+// labelScores returns the model labels with their probabilities.
+func (t *TensorFlow) labelScores(probabilities []float32) (result inference.Labels) {
+	for i, p := range probabilities {
+		if i >= len(t.labels) {
+			// break if probabilities and labels does not match
+			break
+		}
+
+		result = append(result, inference.Label{Name: t.labels[i], Score: p})
 	}
 
-	return result, nil
+	return result
 }
 
 func (t *TensorFlow) loadLabels(path string) error {
@@ -163,23 +206,20 @@ func (t *TensorFlow) loadModel() error {
 }
 
 // bestLabels returns the best 5 labels (if enough high probability labels) from the prediction of the model
-func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
+func (t *TensorFlow) bestLabels(labels inference.Labels) Labels {
 	var result Labels
 
-	for i, p := range probabilities {
-		if i >= len(t.labels) {
-			// break if probabilities and labels does not match
-			break
-		}
+	for _, l := range labels {
+		p := l.Score
 
 		// discard labels with low probabilities
 		if p < 0.1 {
 			continue
 		}
 
-		labelText := strings.ToLower(t.labels[i])
+		labelText := strings.ToLower(l.Name)
 
-		rule, _ := Rules.Find(labelText)
+		rule, _ := FindRule(labelText)
 
 		// discard labels that don't met the threshold
 		if p < rule.Threshold {
@@ -1,6 +1,8 @@
 package classify
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"testing"
@@ -9,6 +11,8 @@ import (
 	tensorflow "github.com/tensorflow/tensorflow/tensorflow/go"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/inference"
 )
 
 var assetsPath = fs.Abs("../../assets")
@@ -197,7 +201,7 @@ func TestTensorFlow_BestLabels(t *testing.T) {
 
 		p[666] = 0.5
 
-		result := tensorFlow.bestLabels(p)
+		result := tensorFlow.bestLabels(tensorFlow.labelScores(p))
 		assert.Empty(t, result)
 	})
 	t.Run("labels loaded", func(t *testing.T) {
@@ -212,7 +216,7 @@ func TestTensorFlow_BestLabels(t *testing.T) {
 		p[8] = 0.7
 		p[1] = 0.5
 
-		result := tensorFlow.bestLabels(p)
+		result := tensorFlow.bestLabels(tensorFlow.labelScores(p))
 		assert.Equal(t, "chicken", result[0].Name)
 		assert.Equal(t, "bird", result[0].Categories[0])
 		assert.Equal(t, "image", result[0].Source)
@@ -251,3 +255,39 @@ func Test_convertValue(t *testing.T) {
 	result := convertValue(uint32(98765432))
 	assert.Equal(t, float32(3024.898), result)
 }
+
+func TestTensorFlow_WithBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"labels": [{"name": "tabby cat", "score": 0.8}, {"name": "abacus", "score": 0.9}, {"name": "robot", "score": 0.05}]}`))
+	}))
+
+	defer server.Close()
+
+	t.Run("Labels", func(t *testing.T) {
+		tensorFlow := New(assetsPath, false).WithBackend(inference.NewHttp(server.URL))
+
+		assert.NoError(t, tensorFlow.Init())
+		assert.False(t, tensorFlow.ModelLoaded())
+
+		result, err := tensorFlow.Labels([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "cat", result[0].Name)
+			assert.Equal(t, 20, result[0].Uncertainty)
+			assert.Equal(t, SrcImage, result[0].Source)
+		}
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		tensorFlow := New(assetsPath, true).WithBackend(inference.NewHttp(server.URL))
+
+		result, err := tensorFlow.Labels([]byte("jpeg"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// AuditCommand registers the audit log cli command.
+var AuditCommand = cli.Command{
+	Name:      "audit",
+	Usage:     "Shows the audit log of logins and destructive actions",
+	ArgsUsage: "[search]",
+	Flags: append(report.CliFlags,
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "only show actions of the user with this `USERNAME`",
+		},
+		cli.StringFlag{
+			Name:  "action, a",
+			Usage: "only show entries with this `ACTION`, e.g. login or delete",
+		},
+		cli.StringFlag{
+			Name:  "uid",
+			Usage: "only show entries concerning the resource with this `UID`",
+		},
+		cli.IntFlag{
+			Name:  "count, n",
+			Usage: "maximum `NUMBER` of entries",
+			Value: 100,
+		},
+	),
+	Action: auditAction,
+}
+
+// auditAction shows the most recent audit log entries.
+func auditAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		f := form.NewAuditSearch(strings.TrimSpace(strings.Join(ctx.Args(), " ")))
+
+		f.User = strings.TrimSpace(ctx.String("user"))
+		f.Action = strings.TrimSpace(ctx.String("action"))
+		f.UID = strings.TrimSpace(ctx.String("uid"))
+		f.Count = ctx.Int("count")
+
+		entries, err := search.AuditLogs(f)
+
+		if err != nil {
+			return err
+		}
+
+		cols := []string{"Time", "User", "Action", "Resource", "UID", "Outcome", "Client IP", "Session", "Message"}
+		rows := make([][]string, len(entries))
+
+		log.Infof("found %s", english.Plural(len(entries), "entry", "entries"))
+
+		for i, m := range entries {
+			rows[i] = []string{
+				m.CreatedAt.Format("2006-01-02 15:04:05"),
+				m.ActorName,
+				m.Action,
+				m.Resource,
+				m.ResourceUID,
+				m.Outcome,
+				m.ClientIP,
+				m.SessionUID,
+				m.Message,
+			}
+		}
+
+		result, err := report.Render(rows, cols, report.CliFormat(ctx))
+
+		fmt.Println(result)
+
+		return err
+	})
+}
@@ -46,6 +46,7 @@ var PhotoPrism = []cli.Command{
 	ImportCommand,
 	CopyCommand,
 	FacesCommand,
+	LabelsCommand,
 	PlacesCommand,
 	PurgeCommand,
 	CleanUpCommand,
@@ -59,6 +60,9 @@ var PhotoPrism = []cli.Command{
 	ResetCommand,
 	PasswdCommand,
 	UsersCommand,
+	SessionsCommand,
+	AuditCommand,
+	DuplicatesCommand,
 	ShowCommand,
 	VersionCommand,
 	ShowConfigCommand,
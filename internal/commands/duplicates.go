@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// DuplicatesCommand registers the near-duplicate detection subcommands.
+var DuplicatesCommand = cli.Command{
+	Name:  "duplicates",
+	Usage: "Near-duplicate detection subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:  "ls",
+			Usage: "Lists groups of near-duplicate photos",
+			Flags: append(report.CliFlags,
+				cli.IntFlag{
+					Name:  "dist, d",
+					Usage: "maximum `NUMBER` of different perceptual hash bits",
+					Value: query.DuplicateDistance,
+				},
+			),
+			Action: duplicatesListAction,
+		},
+		{
+			Name:   "hash",
+			Usage:  "Computes missing perceptual hashes of indexed primary, RAW, and HEIC files",
+			Action: duplicatesHashAction,
+		},
+	},
+}
+
+// duplicatesListAction lists groups of near-duplicate photos.
+func duplicatesListAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		groups, _, err := query.NearDuplicates(ctx.Int("dist"), 0, 0, "", nil)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(groups), "group", "groups"))
+
+		cols := []string{"Group", "Keep", "Photo UID", "File Name", "Resolution", "Size", "Quality", "Distance"}
+		var rows [][]string
+
+		for i, g := range groups {
+			for _, f := range g.Files {
+				keep := "no"
+
+				if f.Keep {
+					keep = "yes"
+				}
+
+				rows = append(rows, []string{
+					fmt.Sprintf("%d", i+1),
+					keep,
+					f.PhotoUID,
+					f.FileName,
+					fmt.Sprintf("%dx%d", f.FileWidth, f.FileHeight),
+					humanize.Bytes(uint64(f.FileSize)),
+					fmt.Sprintf("%d", f.QualityScore),
+					fmt.Sprintf("%d", f.Distance),
+				})
+			}
+		}
+
+		result, err := report.Render(rows, cols, report.CliFormat(ctx))
+
+		fmt.Println(result)
+
+		return err
+	})
+}
+
+// duplicatesHashAction computes missing perceptual hashes, e.g. of files indexed with a previous version.
+func duplicatesHashAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+		updated := 0
+
+		var lastId uint
+
+		for {
+			files, err := query.FilesWithoutPerceptualHash(500, lastId)
+
+			if err != nil {
+				return err
+			} else if len(files) == 0 {
+				break
+			}
+
+			for _, file := range files {
+				lastId = file.ID
+				fileName := photoprism.FileName(file.FileRoot, file.FileName)
+
+				if !fs.FileExists(fileName) {
+					continue
+				}
+
+				m, err := photoprism.NewMediaFile(fileName)
+
+				if err != nil {
+					log.Warnf("duplicates: %s", err)
+					continue
+				}
+
+				h, err := m.PerceptualHash(conf.ThumbCachePath())
+
+				if err != nil {
+					log.Warnf("duplicates: %s in %s", err, clean.Log(file.FileName))
+					continue
+				}
+
+				if err = file.Update("FilePHash", h.Hex()); err != nil {
+					log.Errorf("duplicates: %s", err)
+					continue
+				}
+
+				updated++
+			}
+		}
+
+		log.Infof("updated %s in %s", english.Plural(updated, "file", "files"), time.Since(start))
+
+		return nil
+	})
+}
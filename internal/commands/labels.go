@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LabelsCommand registers the label subcommands.
+var LabelsCommand = cli.Command{
+	Name:  "labels",
+	Usage: "Label management subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "reclassify",
+			Usage:  "Classifies photos again to apply changed label rules",
+			Action: labelsReclassifyAction,
+		},
+	},
+}
+
+// labelsReclassifyAction replaces the labels from the image classifier using the current rules.
+func labelsReclassifyAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		if conf.DisableClassification() {
+			return errors.New("classification is disabled")
+		}
+
+		if err := classify.ReloadRules(); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		ind := service.Index()
+		updated := 0
+
+		var lastId uint
+
+		for {
+			photos, err := query.PhotosByLabelSource(classify.SrcImage, 500, lastId)
+
+			if err != nil {
+				return err
+			} else if len(photos) == 0 {
+				break
+			}
+
+			for i := range photos {
+				lastId = photos[i].ID
+
+				if _, err := ind.Reclassify(&photos[i]); err != nil {
+					log.Warnf("labels: %s in %s", err, clean.Log(photos[i].PhotoUID))
+					continue
+				}
+
+				updated++
+			}
+		}
+
+		log.Infof("reclassified %s in %s", english.Plural(updated, "photo", "photos"), time.Since(start))
+
+		return nil
+	})
+}
@@ -2,15 +2,26 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
+
 	"github.com/manifoldco/promptui"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+	"github.com/photoprism/photoprism/pkg/track"
 )
 
 // PlacesCommand registers the places subcommands.
@@ -30,6 +41,53 @@ var PlacesCommand = cli.Command{
 			},
 			Action: placesUpdateAction,
 		},
+		{
+			Name:      "geotag",
+			Usage:     "Assigns positions from GPX, KML, and GeoJSON track logs to pictures without location",
+			ArgsUsage: "[track files or folders]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "tracks, t",
+					Usage: "import track logs from the import folder",
+				},
+				cli.DurationFlag{
+					Name:  "offset, o",
+					Usage: "camera clock `OFFSET` relative to GPS time, e.g. 1h30s if the camera is ahead",
+				},
+				cli.StringFlag{
+					Name:  "timezone, tz",
+					Usage: "time `ZONE` of pictures without time zone information, e.g. Europe/Berlin",
+				},
+				cli.DurationFlag{
+					Name:  "max-gap, g",
+					Usage: "max time `DIFFERENCE` between a picture and the nearest track point",
+					Value: photoprism.GeotagMaxGapDefault,
+				},
+			},
+			Action: placesGeotagAction,
+		},
+		{
+			Name:      "visits",
+			Usage:     "Lists the places where pictures have been taken in chronological order",
+			ArgsUsage: "[search]",
+			Flags: append(report.CliFlags,
+				cli.Float64Flag{
+					Name:  "radius, r",
+					Usage: "max `DISTANCE` in km between neighboring pictures taken at the same place",
+					Value: photoprism.VisitsRadius,
+				},
+				cli.DurationFlag{
+					Name:  "max-gap, g",
+					Usage: "max time `DIFFERENCE` between two pictures taken during the same visit",
+					Value: photoprism.VisitsMaxGap,
+				},
+				cli.BoolFlag{
+					Name:  "reverse",
+					Usage: "show the most recent visits first",
+				},
+			),
+			Action: placesVisitsAction,
+		},
 	},
 }
 
@@ -106,3 +164,114 @@ func placesUpdateAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// placesGeotagAction imports track logs and assigns their positions to pictures without location.
+func placesGeotagAction(ctx *cli.Context) error {
+	opt := photoprism.GeotagOptions{
+		Offset: ctx.Duration("offset"),
+		MaxGap: ctx.Duration("max-gap"),
+	}
+
+	if tz := ctx.String("timezone"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+
+		if err != nil {
+			return fmt.Errorf("invalid time zone %s", clean.Log(tz))
+		}
+
+		opt.TimeZone = loc
+	}
+
+	// Load config.
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	start := time.Now()
+
+	paths := ctx.Args()
+
+	// Import track logs from the import folder?
+	if ctx.Bool("tracks") {
+		paths = append(paths, conf.ImportPath())
+	}
+
+	// Import track logs passed as arguments.
+	for _, arg := range paths {
+		err := filepath.Walk(arg, func(fileName string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			} else if info.IsDir() || !track.Supported(fileName) {
+				return nil
+			}
+
+			if t, err := photoprism.ImportTrack(fileName, filepath.Base(fileName)); err != nil {
+				log.Warnf("geotag: %s in %s", err, clean.Log(fileName))
+			} else {
+				log.Infof("geotag: added track %s with %s", clean.Log(t.TrackName), english.Plural(t.PointCount, "point", "points"))
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Run geotag worker.
+	if _, err := photoprism.NewGeotag(conf).Start(opt); err != nil {
+		return err
+	}
+
+	log.Infof("completed in %s", time.Since(start))
+
+	return nil
+}
+
+// placesVisitsAction lists the places where pictures have been taken in chronological order.
+func placesVisitsAction(ctx *cli.Context) error {
+	opt := photoprism.VisitsOptions{
+		Query:   strings.TrimSpace(strings.Join(ctx.Args(), " ")),
+		Radius:  ctx.Float64("radius"),
+		MaxGap:  ctx.Duration("max-gap"),
+		Reverse: ctx.Bool("reverse"),
+	}
+
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		visits, err := photoprism.FindVisits(opt)
+
+		if err != nil {
+			return err
+		}
+
+		cols := []string{"Place", "Location", "Country", "Arrival", "Departure", "Photos"}
+		rows := make([][]string, len(visits))
+
+		for i, v := range visits {
+			rows[i] = []string{
+				v.Title(),
+				v.Label,
+				v.CountryName,
+				v.Arrival.Format("2006-01-02 15:04:05"),
+				v.Departure.Format("2006-01-02 15:04:05"),
+				strconv.Itoa(v.PhotoCount),
+			}
+		}
+
+		result, err := report.Render(rows, cols, report.CliFormat(ctx))
+
+		fmt.Println(result)
+
+		return err
+	})
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// SessionsCommand registers the session management subcommands.
+var SessionsCommand = cli.Command{
+	Name:  "sessions",
+	Usage: "Session management subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "ls",
+			Aliases:   []string{"list"},
+			Usage:     "Shows active sessions, optionally of a single user",
+			ArgsUsage: "[username]",
+			Flags:     report.CliFlags,
+			Action:    sessionsListAction,
+		},
+		{
+			Name:      "rm",
+			Aliases:   []string{"delete", "revoke"},
+			Usage:     "Revokes a session, or all sessions and access tokens of a user to log them out everywhere",
+			ArgsUsage: "[session uid]",
+			Action:    sessionsDeleteAction,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "user, u",
+					Usage: "revokes all sessions and access tokens of the user with this `USERNAME`",
+				},
+			},
+		},
+	},
+}
+
+// sessionsListAction shows active sessions.
+func sessionsListAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		var userUID string
+
+		// Show the sessions of all users if no username was passed.
+		if login := strings.TrimSpace(ctx.Args().First()); login != "" {
+			user := entity.FindUserByLogin(login)
+
+			if user == nil {
+				return errors.New("please provide a valid username")
+			}
+
+			userUID = user.UserUID
+		}
+
+		cols := []string{"UID", "User", "Method", "Client IP", "User Agent", "Created At", "Last Active", "Expires At"}
+
+		sessions := entity.FindSessions(userUID)
+		rows := make([][]string, len(sessions))
+
+		log.Infof("found %s", english.Plural(len(sessions), "session", "sessions"))
+
+		for i, s := range sessions {
+			rows[i] = []string{
+				s.SessUID,
+				s.UserName,
+				s.AuthMethod,
+				s.ClientIP,
+				s.UserAgent,
+				s.CreatedAt.Format("2006-01-02 15:04:05"),
+				s.LastActive.Format("2006-01-02 15:04:05"),
+				s.ExpiresAt.Format("2006-01-02 15:04:05"),
+			}
+		}
+
+		result, err := report.Render(rows, cols, report.CliFormat(ctx))
+
+		fmt.Println(result)
+
+		return err
+	})
+}
+
+// sessionsDeleteAction revokes a single session, or all sessions and access tokens of a user.
+func sessionsDeleteAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		if login := strings.TrimSpace(ctx.String("user")); login != "" {
+			user := entity.FindUserByLogin(login)
+
+			if user == nil {
+				return errors.New("please provide a valid username")
+			}
+
+			n, err := entity.DeleteUserSessions(user.UserUID)
+
+			if err != nil {
+				return err
+			}
+
+			entity.AuditCLI(entity.AuditDelete, string(acl.ResourceSessions), user.UserUID)
+
+			tokens, err := entity.DeleteUserTokens(user.UserUID)
+
+			if err != nil {
+				return err
+			}
+
+			entity.AuditCLI(entity.AuditRevokeToken, string(acl.ResourceUsers), user.UserUID)
+
+			log.Infof("revoked %s and %s of %s", english.Plural(n, "session", "sessions"), english.Plural(tokens, "access token", "access tokens"), user.String())
+
+			return nil
+		}
+
+		uid := clean.IdString(ctx.Args().First())
+		sess := entity.FindSessionByUID(uid)
+
+		if sess == nil {
+			return errors.New("please provide a valid session uid")
+		} else if err := sess.Delete(); err != nil {
+			return err
+		}
+
+		entity.AuditCLI(entity.AuditDelete, string(acl.ResourceSessions), sess.SessUID)
+
+		log.Infof("session %s revoked", clean.Log(uid))
+
+		return nil
+	})
+}
@@ -14,7 +14,9 @@ import (
 
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/server"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/workers"
@@ -73,6 +75,11 @@ func startAction(ctx *cli.Context) error {
 	// initialize the database
 	conf.InitDb()
 
+	// set text encoder for semantic search
+	if url := conf.SemanticUrl(); url != "" {
+		search.SemanticModel = inference.NewHttp(url)
+	}
+
 	// check if daemon is running, if not initialize the daemon
 	dctx := new(daemon.Context)
 	dctx.LogFileName = conf.LogFilename()
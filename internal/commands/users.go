@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/manifoldco/promptui"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
@@ -21,6 +24,7 @@ import (
 const UsernameUsage = "unique login identifier"
 const EmailUsage = "unique email address"
 const PasswordUsage = "secure login password"
+const UserFolderUsage = "originals `FOLDER` the user is confined to, none if empty"
 
 // UsersCommand registers user management subcommands.
 var UsersCommand = cli.Command{
@@ -52,6 +56,10 @@ var UsersCommand = cli.Command{
 					Name:  "password, p",
 					Usage: PasswordUsage,
 				},
+				cli.StringFlag{
+					Name:  "path",
+					Usage: UserFolderUsage,
+				},
 			},
 		},
 		{
@@ -72,6 +80,10 @@ var UsersCommand = cli.Command{
 					Name:  "password, p",
 					Usage: PasswordUsage,
 				},
+				cli.StringFlag{
+					Name:  "path",
+					Usage: UserFolderUsage,
+				},
 			},
 		},
 		{
@@ -81,6 +93,8 @@ var UsersCommand = cli.Command{
 			Action:    usersDeleteAction,
 			ArgsUsage: "[username]",
 		},
+		UsersTokensCommand,
+		Users2FACommand,
 	},
 }
 
@@ -170,6 +184,20 @@ func usersAddAction(ctx *cli.Context) error {
 			return err
 		}
 
+		u := entity.FindUserByLogin(uc.Username)
+
+		if u == nil {
+			return errors.New("user not found")
+		}
+
+		entity.AuditCLI(entity.AuditCreate, string(acl.ResourceUsers), u.UserUID)
+
+		if folder := strings.TrimSpace(ctx.String("path")); folder == "" {
+			return nil
+		} else if err := setUserFolder(conf, u, folder); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -193,6 +221,7 @@ func usersDeleteAction(ctx *cli.Context) error {
 			} else if err := m.Delete(); err != nil {
 				return err
 			} else {
+				entity.AuditCLI(entity.AuditDelete, string(acl.ResourceUsers), m.UserUID)
 				log.Infof("%s deleted", clean.LogQuote(login))
 			}
 		} else {
@@ -205,7 +234,7 @@ func usersDeleteAction(ctx *cli.Context) error {
 
 func usersListAction(ctx *cli.Context) error {
 	return callWithDependencies(ctx, func(conf *config.Config) error {
-		cols := []string{"UID", "Role", "Username", "Email", "Display Name"}
+		cols := []string{"UID", "Role", "Username", "Email", "Display Name", "Folder"}
 
 		users := query.RegisteredUsers()
 		rows := make([][]string, len(users))
@@ -213,7 +242,7 @@ func usersListAction(ctx *cli.Context) error {
 		log.Infof("found %s", english.Plural(len(users), "user", "users"))
 
 		for i, user := range users {
-			rows[i] = []string{user.UserUID, user.AclRole().String(), user.UserName(), user.UserEmail(), user.RealName()}
+			rows[i] = []string{user.UserUID, user.AclRole().String(), user.UserName(), user.UserEmail(), user.RealName(), user.UserFolder()}
 		}
 
 		result, err := report.Render(rows, cols, report.CliFormat(ctx))
@@ -252,9 +281,16 @@ func usersUpdateAction(ctx *cli.Context) error {
 			if err != nil {
 				return err
 			}
+			entity.AuditCLI(entity.AuditUpdate, string(acl.ResourcePasswords), u.UserUID)
 			fmt.Printf("password successfully changed: %s\n", clean.Log(u.UserName()))
 		}
 
+		if ctx.IsSet("path") {
+			if err := setUserFolder(conf, u, ctx.String("path")); err != nil {
+				return err
+			}
+		}
+
 		if err := u.Validate(); err != nil {
 			return err
 		}
@@ -263,12 +299,32 @@ func usersUpdateAction(ctx *cli.Context) error {
 			return err
 		}
 
+		entity.AuditCLI(entity.AuditUpdate, string(acl.ResourceUsers), u.UserUID)
+
 		fmt.Printf("user account successfully updated: %s\n", clean.Log(u.UserName()))
 
 		return nil
 	})
 }
 
+// setUserFolder confines the user to an originals subfolder and creates it if needed.
+func setUserFolder(conf *config.Config, u *entity.User, folder string) error {
+	if err := u.SetUserFolder(folder); err != nil {
+		return err
+	} else if folder = u.UserFolder(); folder == "" {
+		log.Infof("user %s has access to all originals", clean.Log(u.UserName()))
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(conf.OriginalsPath(), folder), os.ModePerm); err != nil {
+		return err
+	}
+
+	log.Infof("user %s is confined to originals folder %s", clean.Log(u.UserName()), clean.Log(folder))
+
+	return nil
+}
+
 func callWithDependencies(ctx *cli.Context, f func(conf *config.Config) error) error {
 	conf := config.NewConfig(ctx)
 
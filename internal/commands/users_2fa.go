@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Users2FACommand registers the command to reset two-factor authentication, e.g. if a user has lost their device.
+var Users2FACommand = cli.Command{
+	Name:      "2fa",
+	Usage:     "Resets two-factor authentication so that the user can log in with their password",
+	ArgsUsage: "[username]",
+	Action:    users2FAResetAction,
+}
+
+// users2FAResetAction disables two-factor authentication for a user.
+func users2FAResetAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		user := entity.FindUserByLogin(strings.TrimSpace(ctx.Args().First()))
+
+		if user == nil {
+			return errors.New("please provide a valid username")
+		}
+
+		p := entity.FindPasscode(user.UserUID)
+
+		if p == nil {
+			log.Infof("two-factor authentication is not enabled for %s", user.String())
+			return nil
+		}
+
+		actionPrompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Reset two-factor authentication for %s?", user.String()),
+			IsConfirm: true,
+		}
+
+		if _, err := actionPrompt.Run(); err != nil {
+			log.Infof("keeping two-factor authentication")
+			return nil
+		}
+
+		if err := p.Delete(); err != nil {
+			return err
+		}
+
+		entity.AuditCLI(entity.AuditDisable2FA, string(acl.ResourceUsers), user.UserUID)
+
+		log.Infof("two-factor authentication has been reset for %s", user.String())
+
+		return nil
+	})
+}
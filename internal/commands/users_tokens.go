@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// UsersTokensCommand registers the personal access token subcommands.
+var UsersTokensCommand = cli.Command{
+	Name:  "tokens",
+	Usage: "Personal access token subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "ls",
+			Aliases:   []string{"list"},
+			Usage:     "Shows the access tokens of a user",
+			ArgsUsage: "[username]",
+			Flags:     report.CliFlags,
+			Action:    usersTokensListAction,
+		},
+		{
+			Name:      "add",
+			Aliases:   []string{"create"},
+			Usage:     "Creates a new access token, e.g. for scripts or sync apps",
+			ArgsUsage: "[username]",
+			Action:    usersTokensAddAction,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "name, n",
+					Usage: "token `NAME` that helps you identify it later",
+				},
+				cli.StringFlag{
+					Name:  "scopes, s",
+					Usage: fmt.Sprintf("comma separated list of `SCOPES` (%s)", acl.AllScopes.String()),
+					Value: "search,download",
+				},
+				cli.IntFlag{
+					Name:  "expires, e",
+					Usage: "number of `DAYS` until the token expires (0 for never)",
+				},
+			},
+		},
+		{
+			Name:      "rm",
+			Aliases:   []string{"delete", "revoke"},
+			Usage:     "Revokes an access token",
+			ArgsUsage: "[token uid]",
+			Action:    usersTokensDeleteAction,
+		},
+	},
+}
+
+// usersTokensListAction shows the access tokens of a user.
+func usersTokensListAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		user := entity.FindUserByLogin(strings.TrimSpace(ctx.Args().First()))
+
+		if user == nil {
+			return errors.New("please provide a valid username")
+		}
+
+		cols := []string{"UID", "Name", "Scopes", "Created At", "Expires At"}
+
+		tokens := entity.FindUserTokens(user.UserUID)
+		rows := make([][]string, len(tokens))
+
+		log.Infof("found %s", english.Plural(len(tokens), "token", "tokens"))
+
+		for i, t := range tokens {
+			expires := "never"
+
+			if t.ExpiresAt != nil {
+				expires = t.ExpiresAt.Format("2006-01-02 15:04:05")
+			}
+
+			rows[i] = []string{t.TokenUID, t.TokenName, t.Scopes().String(), t.CreatedAt.Format("2006-01-02 15:04:05"), expires}
+		}
+
+		result, err := report.Render(rows, cols, report.CliFormat(ctx))
+
+		fmt.Println(result)
+
+		return err
+	})
+}
+
+// usersTokensAddAction creates a new access token and displays the secret.
+func usersTokensAddAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		user := entity.FindUserByLogin(strings.TrimSpace(ctx.Args().First()))
+
+		if user == nil {
+			return errors.New("please provide a valid username")
+		}
+
+		scopes := acl.ParseScopes(ctx.String("scopes"))
+
+		if len(scopes) == 0 {
+			return fmt.Errorf("please provide at least one scope (%s)", acl.AllScopes.String())
+		}
+
+		token, secret := entity.NewAccessToken(user.UserUID, ctx.String("name"), scopes, time.Duration(ctx.Int("expires"))*24*time.Hour)
+
+		if err := token.Create(); err != nil {
+			return err
+		}
+
+		entity.AuditCLI(entity.AuditAddToken, string(acl.ResourceUsers), user.UserUID)
+
+		log.Infof("created token %s with scopes %s for %s", clean.Log(token.TokenUID), clean.Log(scopes.String()), user.String())
+
+		fmt.Printf("\n%s\n\n", secret)
+
+		log.Infof("please store the token in a safe place, it cannot be displayed again")
+
+		return nil
+	})
+}
+
+// usersTokensDeleteAction revokes an access token.
+func usersTokensDeleteAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		uid := clean.IdString(ctx.Args().First())
+
+		token := entity.FindToken(uid)
+
+		if token == nil {
+			return errors.New("please provide a valid token uid")
+		} else if err := token.Delete(); err != nil {
+			return err
+		}
+
+		entity.AuditCLI(entity.AuditRevokeToken, string(acl.ResourceUsers), token.UserUID)
+
+		log.Infof("token %s revoked", clean.Log(uid))
+
+		return nil
+	})
+}
@@ -56,6 +56,7 @@ type ClientConfig struct {
 	ReadOnly        bool                `json:"readonly"`
 	UploadNSFW      bool                `json:"uploadNSFW"`
 	Public          bool                `json:"public"`
+	OIDC            bool                `json:"oidc"`
 	Experimental    bool                `json:"experimental"`
 	AlbumCategories []string            `json:"albumCategories"`
 	Albums          entity.Albums       `json:"albums"`
@@ -90,6 +91,7 @@ type ClientDisable struct {
 	Settings       bool `json:"settings"`
 	Places         bool `json:"places"`
 	ExifTool       bool `json:"exiftool"`
+	OCR            bool `json:"ocr"`
 	FFmpeg         bool `json:"ffmpeg"`
 	Raw            bool `json:"raw"`
 	Darktable      bool `json:"darktable"`
@@ -207,6 +209,7 @@ func (c *Config) PublicConfig() ClientConfig {
 			Settings:       c.DisableSettings(),
 			Places:         c.DisablePlaces(),
 			ExifTool:       true,
+			OCR:            true,
 			FFmpeg:         true,
 			Raw:            true,
 			Darktable:      true,
@@ -249,6 +252,7 @@ func (c *Config) PublicConfig() ClientConfig {
 		Sponsor:         c.Sponsor(),
 		ReadOnly:        c.ReadOnly(),
 		Public:          c.Public(),
+		OIDC:            c.OIDCEnabled(),
 		Experimental:    c.Experimental(),
 		Status:          "",
 		MapKey:          "",
@@ -283,6 +287,7 @@ func (c *Config) GuestConfig() ClientConfig {
 			Settings:       c.DisableSettings(),
 			Places:         c.DisablePlaces(),
 			ExifTool:       true,
+			OCR:            true,
 			FFmpeg:         true,
 			Raw:            true,
 			Darktable:      true,
@@ -353,6 +358,7 @@ func (c *Config) UserConfig() ClientConfig {
 			Settings:       c.DisableSettings(),
 			Places:         c.DisablePlaces(),
 			ExifTool:       c.DisableExifTool(),
+			OCR:            c.DisableOCR(),
 			FFmpeg:         c.DisableFFmpeg(),
 			Raw:            c.DisableRaw(),
 			Darktable:      c.DisableDarktable(),
@@ -396,6 +402,7 @@ func (c *Config) UserConfig() ClientConfig {
 		ReadOnly:        c.ReadOnly(),
 		UploadNSFW:      c.UploadNSFW(),
 		Public:          c.Public(),
+		OIDC:            c.OIDCEnabled(),
 		Experimental:    c.Experimental(),
 		Colors:          colors.All.List(),
 		Thumbs:          Thumbs,
@@ -4,10 +4,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash/crc32"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,16 +24,21 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/hub"
 	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/internal/maps/gazetteer"
+	"github.com/photoprism/photoprism/internal/maps/geocoder"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
@@ -152,6 +159,9 @@ func (c *Config) Propagate() {
 
 	// Set geocoding parameters.
 	places.UserAgent = c.UserAgent()
+	gazetteer.Path = c.GazetteerPath()
+	geocoder.UserAgent = c.UserAgent()
+	maps.RegisterProvider(geocoder.New(c.GeoApi(), c.GeoApiUrl(), c.GeoApiRateLimit(), c.GeoApiLanguage()))
 	entity.GeoApi = c.GeoApi()
 
 	// Set face recognition parameters.
@@ -163,6 +173,9 @@ func (c *Config) Propagate() {
 	face.ClusterDist = c.FaceClusterDist()
 	face.MatchDist = c.FaceMatchDist()
 
+	// Set user-defined label rules.
+	classify.SetRulesFile(c.RulesYaml())
+
 	c.Settings().Propagate()
 	c.Hub().Propagate()
 }
@@ -642,13 +655,81 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
-// GeoApi returns the preferred geocoding api (none or places).
+// GeoApi returns the preferred geocoding api (places, gazetteer, nominatim, or photon), or an empty string if disabled.
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
 		return ""
 	}
 
-	return "places"
+	switch api := clean.TypeLower(c.options.GeoApi); api {
+	case "", places.ApiName:
+		return places.ApiName
+	case gazetteer.ApiName, "offline":
+		return gazetteer.ApiName
+	case geocoder.NominatimName:
+		return geocoder.NominatimName
+	case geocoder.PhotonName:
+		return geocoder.PhotonName
+	case "none", "off", "false":
+		return ""
+	default:
+		log.Errorf("config: unknown geocoding api %s, location details will not be retrieved", clean.Log(api))
+		return ""
+	}
+}
+
+// GeoApiUrl returns the Nominatim or Photon API endpoint URL, or an empty string for the default.
+func (c *Config) GeoApiUrl() string {
+	return strings.TrimSpace(c.options.GeoApiUrl)
+}
+
+// GeoApiRateLimit returns the max number of Nominatim or Photon API requests per second, 0 for the default.
+func (c *Config) GeoApiRateLimit() float64 {
+	if c.options.GeoApiRateLimit < 0 {
+		return -1
+	}
+
+	return c.options.GeoApiRateLimit
+}
+
+// GeoApiLanguage returns the preferred language of Nominatim or Photon place names.
+func (c *Config) GeoApiLanguage() string {
+	return clean.TypeLower(c.options.GeoApiLanguage)
+}
+
+// HomeLocation returns the home location for trip detection, or an empty position if not set.
+func (c *Config) HomeLocation() (pos geo.Position) {
+	s := strings.TrimSpace(c.options.HomeLocation)
+
+	if s == "" {
+		return pos
+	}
+
+	values := strings.Split(s, ",")
+
+	if len(values) != 2 {
+		log.Warnf("config: invalid home location %s", clean.Log(s))
+		return pos
+	}
+
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	lng, lngErr := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+	if latErr != nil || lngErr != nil || math.Abs(lat) > 90 || math.Abs(lng) > 180 {
+		log.Warnf("config: invalid home location %s", clean.Log(s))
+		return pos
+	}
+
+	return geo.Position{Name: "home", Lat: lat, Lng: lng}
+}
+
+// TripDistance returns the min distance from home in km to detect pictures taken on a trip.
+func (c *Config) TripDistance() float64 {
+	if c.options.TripDistance <= 0 {
+		return DefaultTripDistance
+	}
+
+	return c.options.TripDistance
 }
 
 // OriginalsLimit returns the maximum size of originals in MB.
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"regexp"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -82,7 +86,23 @@ func (c *Config) CheckPassword(p string) bool {
 
 // InvalidDownloadToken checks if the token is invalid.
 func (c *Config) InvalidDownloadToken(t string) bool {
-	return c.DownloadToken() != t
+	return c.DownloadToken() != t && c.DownloadTokenUser(t) == ""
+}
+
+// UserDownloadToken returns a download token that is only valid for the user with the specified UID,
+// so that downloads can be limited to the content the user has access to.
+func (c *Config) UserDownloadToken(uid string) string {
+	if uid == "" {
+		return c.DownloadToken()
+	}
+
+	return userToken(c.DownloadToken(), uid)
+}
+
+// DownloadTokenUser returns the UID of the user the download token was issued for,
+// or an empty string if it is not a valid user download token.
+func (c *Config) DownloadTokenUser(t string) string {
+	return tokenUser(c.DownloadToken(), t)
 }
 
 // DownloadToken returns the DOWNLOAD api token (you can optionally use a static value for permanent caching).
@@ -96,7 +116,60 @@ func (c *Config) DownloadToken() string {
 
 // InvalidPreviewToken checks if the preview token is invalid.
 func (c *Config) InvalidPreviewToken(t string) bool {
-	return c.PreviewToken() != t && c.DownloadToken() != t
+	return c.PreviewToken() != t && c.DownloadToken() != t && c.PreviewTokenUser(t) == ""
+}
+
+// UserPreviewToken returns a preview token that is only valid for the user with the specified UID,
+// so that thumbnails and videos can be limited to the content the user has access to.
+func (c *Config) UserPreviewToken(uid string) string {
+	if uid == "" {
+		return c.PreviewToken()
+	}
+
+	return userToken(c.PreviewToken(), uid)
+}
+
+// PreviewTokenUser returns the UID of the user the preview token was issued for,
+// or an empty string if it is not a valid user preview token.
+func (c *Config) PreviewTokenUser(t string) string {
+	return tokenUser(c.PreviewToken(), t)
+}
+
+// userToken returns a token derived from the secret that is only valid for the user with the specified UID.
+func userToken(secret, uid string) string {
+	h := sha256.Sum256([]byte(secret + ":" + uid))
+
+	return uid + ":" + hex.EncodeToString(h[:8])
+}
+
+// tokenUser returns the UID of the user the token was derived from, see userToken,
+// or an empty string if the token is invalid.
+func tokenUser(secret, t string) string {
+	i := strings.Index(t, ":")
+
+	if i < 1 {
+		return ""
+	}
+
+	uid := t[:i]
+
+	if subtle.ConstantTimeCompare([]byte(t), []byte(userToken(secret, uid))) != 1 {
+		return ""
+	}
+
+	return uid
+}
+
+// MetricsToken returns the bearer token for the metrics endpoint, or an empty string if it is disabled.
+func (c *Config) MetricsToken() string {
+	return strings.TrimSpace(c.options.MetricsToken)
+}
+
+// InvalidMetricsToken checks if the metrics token is invalid, which is always the case if metrics are disabled.
+func (c *Config) InvalidMetricsToken(t string) bool {
+	token := c.MetricsToken()
+
+	return token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(t)) != 1
 }
 
 // PreviewToken returns the preview image api token (based on the unique storage serial by default).
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -77,6 +78,58 @@ func TestConfig_InvalidDownloadToken(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
 	assert.True(t, c.InvalidDownloadToken("xxx"))
+	assert.False(t, c.InvalidDownloadToken(c.DownloadToken()))
+}
+
+func TestConfig_UserDownloadToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.DownloadToken(), c.UserDownloadToken(""))
+
+	token := c.UserDownloadToken("uqxetse3cy5eo9z2")
+
+	assert.True(t, strings.HasPrefix(token, "uqxetse3cy5eo9z2:"))
+	assert.NotEqual(t, c.DownloadToken(), token)
+	assert.Equal(t, token, c.UserDownloadToken("uqxetse3cy5eo9z2"))
+	assert.False(t, c.InvalidDownloadToken(token))
+	assert.Equal(t, "uqxetse3cy5eo9z2", c.DownloadTokenUser(token))
+	assert.Equal(t, "", c.DownloadTokenUser("uqxetse3cy5eo9z2:0000000000000000"))
+	assert.Equal(t, "", c.DownloadTokenUser("uqxc08w3d0ej2283"+token[16:]))
+	assert.Equal(t, "", c.DownloadTokenUser(c.DownloadToken()))
+	assert.True(t, c.InvalidDownloadToken("uqxetse3cy5eo9z2:0000000000000000"))
+}
+
+func TestConfig_UserPreviewToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.PreviewToken(), c.UserPreviewToken(""))
+
+	token := c.UserPreviewToken("uqxetse3cy5eo9z2")
+
+	assert.True(t, strings.HasPrefix(token, "uqxetse3cy5eo9z2:"))
+	assert.NotEqual(t, c.PreviewToken(), token)
+	assert.Equal(t, token, c.UserPreviewToken("uqxetse3cy5eo9z2"))
+	assert.False(t, c.InvalidPreviewToken(token))
+	assert.Equal(t, "uqxetse3cy5eo9z2", c.PreviewTokenUser(token))
+	assert.Equal(t, "", c.PreviewTokenUser("uqxetse3cy5eo9z2:0000000000000000"))
+	assert.Equal(t, "", c.PreviewTokenUser("uqxc08w3d0ej2283"+token[16:]))
+	assert.Equal(t, "", c.PreviewTokenUser(c.PreviewToken()))
+	assert.True(t, c.InvalidPreviewToken("uqxetse3cy5eo9z2:0000000000000000"))
+}
+
+func TestConfig_InvalidMetricsToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MetricsToken())
+	assert.True(t, c.InvalidMetricsToken(""))
+
+	c.options.MetricsToken = " secret "
+
+	assert.Equal(t, "secret", c.MetricsToken())
+	assert.True(t, c.InvalidMetricsToken("xxx"))
+	assert.False(t, c.InvalidMetricsToken("secret"))
+
+	c.options.MetricsToken = ""
 }
 
 func TestConfig_InvalidPreviewToken(t *testing.T) {
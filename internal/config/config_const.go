@@ -24,6 +24,9 @@ const MsgSponsorCommand = "Since running this command puts additional load on ou
 const DefaultAutoIndexDelay = int(5 * 60)  // 5 Minutes
 const DefaultAutoImportDelay = int(3 * 60) // 3 Minutes
 
+// DefaultTripDistance is the default min distance from home in km to detect trips.
+const DefaultTripDistance = 100.0
+
 // MinWakeupInterval and MaxWakeupInterval limit the interval duration
 // in which the background worker can be invoked.
 const MinWakeupInterval = time.Minute             // 1 Minute
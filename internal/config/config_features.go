@@ -52,7 +52,11 @@ func (c *Config) DisableTensorFlow() bool {
 
 // DisableFaces checks if face recognition is disabled.
 func (c *Config) DisableFaces() bool {
-	if c.DisableTensorFlow() || c.options.DisableFaces {
+	if c.options.DisableFaces {
+		return true
+	} else if c.FaceUrl() != "" {
+		return false
+	} else if c.DisableTensorFlow() {
 		return true
 	}
 
@@ -61,7 +65,11 @@ func (c *Config) DisableFaces() bool {
 
 // DisableClassification checks if image classification is disabled.
 func (c *Config) DisableClassification() bool {
-	if c.DisableTensorFlow() || c.options.DisableClassification {
+	if c.options.DisableClassification {
+		return true
+	} else if c.ClassifyUrl() != "" {
+		return false
+	} else if c.DisableTensorFlow() {
 		return true
 	}
 
@@ -79,6 +87,17 @@ func (c *Config) DisableFFmpeg() bool {
 	return c.options.DisableFFmpeg
 }
 
+// DisableOCR checks if text recognition with Tesseract OCR is disabled.
+func (c *Config) DisableOCR() bool {
+	if c.options.DisableOCR {
+		return true
+	} else if c.OCRBin() == "" {
+		c.options.DisableOCR = true
+	}
+
+	return c.options.DisableOCR
+}
+
 // DisableRaw checks if indexing and conversion of RAW files is disabled.
 func (c *Config) DisableRaw() bool {
 	if LowMem && !c.options.DisableRaw {
@@ -67,6 +67,9 @@ func TestConfig_DisableFaces(t *testing.T) {
 	c.options.DisableFaces = false
 	c.options.DisableTensorFlow = true
 	assert.True(t, c.DisableFaces())
+	c.options.FaceUrl = "http://inference:8000/facenet"
+	assert.False(t, c.DisableFaces())
+	c.options.FaceUrl = ""
 	c.options.DisableTensorFlow = false
 	assert.False(t, c.DisableFaces())
 }
@@ -79,6 +82,12 @@ func TestConfig_DisableClassification(t *testing.T) {
 	c.options.DisableClassification = false
 	c.options.DisableTensorFlow = true
 	assert.True(t, c.DisableClassification())
+	c.options.ClassifyUrl = "http://inference:8000/clip"
+	assert.False(t, c.DisableClassification())
+	c.options.DisableClassification = true
+	assert.True(t, c.DisableClassification())
+	c.options.DisableClassification = false
+	c.options.ClassifyUrl = ""
 	c.options.DisableTensorFlow = false
 	assert.False(t, c.DisableClassification())
 }
@@ -207,6 +207,11 @@ func (c *Config) SettingsYaml() string {
 	return filepath.Join(c.ConfigPath(), "settings.yml")
 }
 
+// RulesYaml returns the filename of user-defined label rules, which are merged over the built-in rules.
+func (c *Config) RulesYaml() string {
+	return filepath.Join(c.ConfigPath(), "rules.yml")
+}
+
 // PIDFilename returns the filename for storing the server process id (pid).
 func (c *Config) PIDFilename() string {
 	if c.options.PIDFilename == "" {
@@ -453,6 +458,15 @@ func (c *Config) CustomStaticUri() string {
 	}
 }
 
+// GazetteerPath returns the offline gazetteer path containing GeoNames files.
+func (c *Config) GazetteerPath() string {
+	if c.options.GazetteerPath != "" {
+		return fs.Abs(c.options.GazetteerPath)
+	}
+
+	return filepath.Join(c.AssetsPath(), "gazetteer")
+}
+
 // LocalesPath returns the translation locales path.
 func (c *Config) LocalesPath() string {
 	return filepath.Join(c.AssetsPath(), "locales")
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -375,3 +376,8 @@ func TestConfig_SqliteBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.SqliteBin(), "sqlite")
 }
+
+func TestConfig_RulesYaml(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, filepath.Join(c.ConfigPath(), "rules.yml"), c.RulesYaml())
+}
@@ -15,6 +15,15 @@ func (c *Config) ExifToolJson() bool {
 	return !c.DisableExifTool()
 }
 
+// WriteXmp checks if metadata changes should be written to XMP sidecar files.
+func (c *Config) WriteXmp() bool {
+	if !c.SidecarWritable() {
+		return false
+	}
+
+	return c.options.WriteXmp
+}
+
 // BackupYaml checks if creating YAML files is enabled.
 func (c *Config) BackupYaml() bool {
 	return !c.DisableBackups()
@@ -30,6 +30,16 @@ func TestConfig_ExifToolJson(t *testing.T) {
 	assert.Equal(t, c.DisableExifTool(), !c.ExifToolJson())
 }
 
+func TestConfig_WriteXmp(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, false, c.WriteXmp())
+
+	c.options.WriteXmp = true
+
+	assert.Equal(t, true, c.WriteXmp())
+}
+
 func TestConfig_SidecarYaml(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -0,0 +1,22 @@
+package config
+
+import "strings"
+
+// OCRBin returns the Tesseract OCR executable file name.
+func (c *Config) OCRBin() string {
+	return findExecutable(c.options.OCRBin, "tesseract")
+}
+
+// OCREnabled checks if text recognition with Tesseract OCR is enabled.
+func (c *Config) OCREnabled() bool {
+	return !c.DisableOCR()
+}
+
+// OCRLang returns the Tesseract OCR languages separated by +, e.g. eng+deu.
+func (c *Config) OCRLang() string {
+	if lang := strings.TrimSpace(c.options.OCRLang); lang != "" {
+		return lang
+	}
+
+	return "eng"
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OCRBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.OCRBin = "/bin/xxx-tesseract"
+	assert.Equal(t, "", c.OCRBin())
+	assert.True(t, c.DisableOCR())
+	assert.False(t, c.OCREnabled())
+}
+
+func TestConfig_OCRLang(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "eng", c.OCRLang())
+	c.options.OCRLang = " eng+deu "
+	assert.Equal(t, "eng+deu", c.OCRLang())
+	c.options.OCRLang = ""
+	assert.Equal(t, "eng", c.OCRLang())
+}
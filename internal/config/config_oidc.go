@@ -0,0 +1,106 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// OIDCUri returns the OpenID Connect issuer URL, or an empty string if single sign-on is disabled.
+func (c *Config) OIDCUri() string {
+	return strings.TrimRight(strings.TrimSpace(c.options.OIDCUri), "/")
+}
+
+// OIDCClient returns the OpenID Connect client ID.
+func (c *Config) OIDCClient() string {
+	return strings.TrimSpace(c.options.OIDCClient)
+}
+
+// OIDCSecret returns the OpenID Connect client secret.
+func (c *Config) OIDCSecret() string {
+	return strings.TrimSpace(c.options.OIDCSecret)
+}
+
+// OIDCEnabled checks if users can log in with OpenID Connect.
+func (c *Config) OIDCEnabled() bool {
+	return c.OIDCUri() != "" && c.OIDCClient() != "" && !c.Public()
+}
+
+// OIDCScopes returns the scopes requested from the identity provider.
+func (c *Config) OIDCScopes() []string {
+	scopes := strings.Fields(strings.ReplaceAll(c.options.OIDCScopes, ",", " "))
+
+	for _, s := range scopes {
+		if s == "openid" {
+			return scopes
+		}
+	}
+
+	return append([]string{"openid"}, scopes...)
+}
+
+// OIDCRegister checks if new user accounts should be created on first login.
+func (c *Config) OIDCRegister() bool {
+	return c.options.OIDCRegister
+}
+
+// OIDCLinkAdmin checks if existing admin accounts may be linked with an identity provider account
+// that has the same, verified email address.
+func (c *Config) OIDCLinkAdmin() bool {
+	return c.options.OIDCLinkAdmin
+}
+
+// OIDCRoleClaim returns the name of the ID token claim that contains the user's groups or roles.
+func (c *Config) OIDCRoleClaim() string {
+	if s := strings.TrimSpace(c.options.OIDCRoleClaim); s != "" {
+		return s
+	}
+
+	return "groups"
+}
+
+// OIDCRoles returns a map of claim values to user role names.
+func (c *Config) OIDCRoles() map[string]string {
+	result := make(map[string]string)
+
+	for _, s := range strings.Split(c.options.OIDCRoles, ",") {
+		kv := strings.SplitN(s, "=", 2)
+
+		if len(kv) != 2 {
+			continue
+		}
+
+		k := strings.TrimSpace(kv[0])
+		v := clean.Role(kv[1])
+
+		if k == "" || v == "" {
+			continue
+		}
+
+		result[k] = v
+	}
+
+	return result
+}
+
+// OIDCDefaultRole returns the user role name if no claim value matches, "none" prevents login.
+func (c *Config) OIDCDefaultRole() string {
+	if s := clean.Role(c.options.OIDCDefaultRole); s != "" {
+		return s
+	}
+
+	return acl.RoleViewer.String()
+}
+
+// OIDCRedirectURL returns the URL the identity provider should redirect to after login.
+func (c *Config) OIDCRedirectURL() string {
+	u, err := url.Parse(c.SiteUrl())
+
+	if err != nil || u.Host == "" {
+		return "http://localhost:2342" + c.ApiUri() + "/oidc/redirect"
+	}
+
+	return u.Scheme + "://" + u.Host + c.ApiUri() + "/oidc/redirect"
+}
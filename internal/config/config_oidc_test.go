@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OIDCEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCEnabled())
+	c.options.OIDCUri = "https://accounts.example.com/"
+	c.options.OIDCClient = "photoprism"
+	assert.Equal(t, "https://accounts.example.com", c.OIDCUri())
+	assert.True(t, c.OIDCEnabled())
+	c.options.Public = true
+	assert.False(t, c.OIDCEnabled())
+}
+
+func TestConfig_OIDCScopes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.OIDCScopes = "openid email profile"
+	assert.Equal(t, []string{"openid", "email", "profile"}, c.OIDCScopes())
+	c.options.OIDCScopes = "email,groups"
+	assert.Equal(t, []string{"openid", "email", "groups"}, c.OIDCScopes())
+}
+
+func TestConfig_OIDCRoles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, map[string]string{}, c.OIDCRoles())
+	c.options.OIDCRoles = "photo-admins=admin, family = viewer,invalid,=editor"
+	assert.Equal(t, map[string]string{"photo-admins": "admin", "family": "viewer"}, c.OIDCRoles())
+	assert.Equal(t, "groups", c.OIDCRoleClaim())
+	c.options.OIDCDefaultRole = ""
+	assert.Equal(t, "viewer", c.OIDCDefaultRole())
+	c.options.OIDCDefaultRole = "None"
+	assert.Equal(t, "none", c.OIDCDefaultRole())
+}
+
+func TestConfig_OIDCRedirectURL(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.SiteUrl = "https://photos.example.com/"
+	assert.Equal(t, "https://photos.example.com/api/v1/oidc/redirect", c.OIDCRedirectURL())
+	c.options.SiteUrl = "https://example.com/photos/"
+	assert.Equal(t, "https://example.com/photos/api/v1/oidc/redirect", c.OIDCRedirectURL())
+}
+
+func TestConfig_OIDCLinkAdmin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCLinkAdmin())
+	c.options.OIDCLinkAdmin = true
+	assert.True(t, c.OIDCLinkAdmin())
+}
@@ -17,6 +17,16 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"admin-user", c.AdminUser()},
 		{"admin-password", strings.Repeat("*", utf8.RuneCountInString(c.AdminPassword()))},
 		{"public", fmt.Sprintf("%t", c.Public())},
+		{"oidc-uri", c.OIDCUri()},
+		{"oidc-client", c.OIDCClient()},
+		{"oidc-secret", strings.Repeat("*", utf8.RuneCountInString(c.OIDCSecret()))},
+		{"oidc-scopes", strings.Join(c.OIDCScopes(), " ")},
+		{"oidc-register", fmt.Sprintf("%t", c.OIDCRegister())},
+		{"oidc-link-admin", fmt.Sprintf("%t", c.OIDCLinkAdmin())},
+		{"oidc-role-claim", c.OIDCRoleClaim()},
+		{"oidc-roles", c.options.OIDCRoles},
+		{"oidc-default-role", c.OIDCDefaultRole()},
+		{"oidc-redirect-url", c.OIDCRedirectURL()},
 
 		// Logging.
 		{"log-level", c.LogLevel().String()},
@@ -28,6 +38,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"options-yaml", c.OptionsYaml()},
 		{"defaults-yaml", c.DefaultsYaml()},
 		{"settings-yaml", c.SettingsYaml()},
+		{"rules-yaml", c.RulesYaml()},
 
 		// Originals.
 		{"originals-path", c.OriginalsPath()},
@@ -44,6 +55,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"thumb-cache-path", c.ThumbCachePath()},
 		{"import-path", c.ImportPath()},
 		{"assets-path", c.AssetsPath()},
+		{"gazetteer-path", c.GazetteerPath()},
 		{"static-path", c.StaticPath()},
 		{"build-path", c.BuildPath()},
 		{"img-path", c.ImgPath()},
@@ -62,12 +74,20 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"disable-webdav", fmt.Sprintf("%t", c.DisableWebDAV())},
 		{"disable-settings", fmt.Sprintf("%t", c.DisableSettings())},
 		{"disable-places", fmt.Sprintf("%t", c.DisablePlaces())},
+		{"geo-api", c.GeoApi()},
+		{"geo-api-url", c.GeoApiUrl()},
+		{"geo-api-rate-limit", fmt.Sprintf("%g", c.GeoApiRateLimit())},
+		{"geo-api-language", c.GeoApiLanguage()},
+		{"home-location", c.options.HomeLocation},
+		{"trip-distance", fmt.Sprintf("%g", c.TripDistance())},
 		{"disable-backups", fmt.Sprintf("%t", c.DisableBackups())},
+		{"write-xmp", fmt.Sprintf("%t", c.WriteXmp())},
 		{"disable-tensorflow", fmt.Sprintf("%t", c.DisableTensorFlow())},
 		{"disable-faces", fmt.Sprintf("%t", c.DisableFaces())},
 		{"disable-classification", fmt.Sprintf("%t", c.DisableClassification())},
 		{"disable-ffmpeg", fmt.Sprintf("%t", c.DisableFFmpeg())},
 		{"disable-exiftool", fmt.Sprintf("%t", c.DisableExifTool())},
+		{"disable-ocr", fmt.Sprintf("%t", c.DisableOCR())},
 		{"disable-heifconvert", fmt.Sprintf("%t", c.DisableHeifConvert())},
 		{"disable-darktable", fmt.Sprintf("%t", c.DisableDarktable())},
 		{"disable-rawtherapee", fmt.Sprintf("%t", c.DisableRawtherapee())},
@@ -81,6 +101,12 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// TensorFlow.
 		{"detect-nsfw", fmt.Sprintf("%t", c.DetectNSFW())},
 		{"upload-nsfw", fmt.Sprintf("%t", c.UploadNSFW())},
+		{"classify-url", c.ClassifyUrl()},
+		{"nsfw-url", c.NSFWUrl()},
+		{"face-url", c.FaceUrl()},
+		{"semantic-url", c.SemanticUrl()},
+		{"objects-url", c.ObjectsUrl()},
+		{"pets-url", c.PetsUrl()},
 		{"tensorflow-version", c.TensorFlowVersion()},
 		{"tensorflow-model-path", c.TensorFlowModelPath()},
 
@@ -116,6 +142,15 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"http-port", fmt.Sprintf("%d", c.HttpPort())},
 		{"http-mode", c.HttpMode()},
 		{"http-compression", c.HttpCompression()},
+		{"tls-cert", c.TLSCert()},
+		{"tls-key", c.TLSKey()},
+		{"tls-self-signed", fmt.Sprintf("%t", c.TLSSelfSigned())},
+		{"tls-redirect-port", fmt.Sprintf("%d", c.TLSRedirectPort())},
+		{"acme-domains", strings.Join(c.ACMEDomains(), ",")},
+		{"acme-email", c.ACMEEmail()},
+		{"acme-directory", c.ACMEDirectory()},
+		{"acme-ca-cert", c.ACMECACert()},
+		{"certificates-path", c.CertificatesPath()},
 
 		// Database.
 		{"database-driver", c.DatabaseDriver()},
@@ -141,10 +176,13 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"ffmpeg-encoder", c.FFmpegEncoder().String()},
 		{"ffmpeg-bitrate", fmt.Sprintf("%d", c.FFmpegBitrate())},
 		{"exiftool-bin", c.ExifToolBin()},
+		{"ocr-bin", c.OCRBin()},
+		{"ocr-lang", c.OCRLang()},
 
 		// Thumbnails.
 		{"download-token", c.DownloadToken()},
 		{"preview-token", c.PreviewToken()},
+		{"metrics-token", strings.Repeat("*", utf8.RuneCountInString(c.MetricsToken()))},
 		{"thumb-color", c.ThumbColor()},
 		{"thumb-filter", string(c.ThumbFilter())},
 		{"thumb-size", fmt.Sprintf("%d", c.ThumbSizePrecached())},
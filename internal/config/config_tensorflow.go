@@ -2,6 +2,7 @@ package config
 
 import (
 	"path/filepath"
+	"strings"
 
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
 )
@@ -25,3 +26,33 @@ func (c *Config) NSFWModelPath() string {
 func (c *Config) FaceNetModelPath() string {
 	return filepath.Join(c.AssetsPath(), "facenet")
 }
+
+// ClassifyUrl returns the URL of an image classification inference server, if any.
+func (c *Config) ClassifyUrl() string {
+	return strings.TrimSpace(c.options.ClassifyUrl)
+}
+
+// NSFWUrl returns the URL of an NSFW detection inference server, if any.
+func (c *Config) NSFWUrl() string {
+	return strings.TrimSpace(c.options.NSFWUrl)
+}
+
+// FaceUrl returns the URL of a face embeddings inference server, if any.
+func (c *Config) FaceUrl() string {
+	return strings.TrimSpace(c.options.FaceUrl)
+}
+
+// SemanticUrl returns the URL of an image and text embeddings inference server for semantic search, if any.
+func (c *Config) SemanticUrl() string {
+	return strings.TrimSpace(c.options.SemanticUrl)
+}
+
+// ObjectsUrl returns the URL of an object detection inference server, if any.
+func (c *Config) ObjectsUrl() string {
+	return strings.TrimSpace(c.options.ObjectsUrl)
+}
+
+// PetsUrl returns the URL of a pet face detection and embeddings inference server, if any.
+func (c *Config) PetsUrl() string {
+	return strings.TrimSpace(c.options.PetsUrl)
+}
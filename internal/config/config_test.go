@@ -2,11 +2,13 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
@@ -253,6 +255,31 @@ func TestConfig_TensorFlowModelPath(t *testing.T) {
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/nasnet", path)
 }
 
+func TestConfig_InferenceUrls(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ClassifyUrl())
+	assert.Equal(t, "", c.NSFWUrl())
+	assert.Equal(t, "", c.FaceUrl())
+	assert.Equal(t, "", c.SemanticUrl())
+	assert.Equal(t, "", c.ObjectsUrl())
+	assert.Equal(t, "", c.PetsUrl())
+
+	c.options.ClassifyUrl = " http://inference:8000/clip "
+	c.options.NSFWUrl = "http://inference:8000/nsfw"
+	c.options.FaceUrl = "http://inference:8000/facenet"
+	c.options.SemanticUrl = "http://inference:8000/clip"
+	c.options.ObjectsUrl = "http://inference:8000/yolo"
+	c.options.PetsUrl = "http://inference:8000/pets"
+
+	assert.Equal(t, "http://inference:8000/clip", c.ClassifyUrl())
+	assert.Equal(t, "http://inference:8000/nsfw", c.NSFWUrl())
+	assert.Equal(t, "http://inference:8000/facenet", c.FaceUrl())
+	assert.Equal(t, "http://inference:8000/clip", c.SemanticUrl())
+	assert.Equal(t, "http://inference:8000/yolo", c.ObjectsUrl())
+	assert.Equal(t, "http://inference:8000/pets", c.PetsUrl())
+}
+
 func TestConfig_TemplatesPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -376,10 +403,77 @@ func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
 	assert.Equal(t, "places", c.GeoApi())
+	c.options.GeoApi = "gazetteer"
+	assert.Equal(t, "gazetteer", c.GeoApi())
+	c.options.GeoApi = "Nominatim"
+	assert.Equal(t, "nominatim", c.GeoApi())
+	c.options.GeoApi = "photon"
+	assert.Equal(t, "photon", c.GeoApi())
+	c.options.GeoApi = "none"
+	assert.Equal(t, "", c.GeoApi())
+	c.options.GeoApi = "nominatin"
+	assert.Equal(t, "", c.GeoApi())
+	c.options.GeoApi = ""
+	assert.Equal(t, "places", c.GeoApi())
+	c.options.GeoApi = "places"
 	c.options.DisablePlaces = true
 	assert.Equal(t, "", c.GeoApi())
 }
 
+func TestConfig_GeoApiUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.GeoApiUrl())
+	c.options.GeoApiUrl = " http://nominatim:8080 "
+	assert.Equal(t, "http://nominatim:8080", c.GeoApiUrl())
+}
+
+func TestConfig_GeoApiRateLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, float64(0), c.GeoApiRateLimit())
+	c.options.GeoApiRateLimit = 2.5
+	assert.Equal(t, 2.5, c.GeoApiRateLimit())
+	c.options.GeoApiRateLimit = -5
+	assert.Equal(t, float64(-1), c.GeoApiRateLimit())
+}
+
+func TestConfig_GeoApiLanguage(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.GeoApiLanguage())
+	c.options.GeoApiLanguage = "DE"
+	assert.Equal(t, "de", c.GeoApiLanguage())
+}
+
+func TestConfig_HomeLocation(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, geo.Position{}, c.HomeLocation())
+	c.options.HomeLocation = "52.52, 13.405"
+	assert.Equal(t, geo.Position{Name: "home", Lat: 52.52, Lng: 13.405}, c.HomeLocation())
+	c.options.HomeLocation = "95.0,13.405"
+	assert.Equal(t, geo.Position{}, c.HomeLocation())
+	c.options.HomeLocation = "Berlin"
+	assert.Equal(t, geo.Position{}, c.HomeLocation())
+}
+
+func TestConfig_TripDistance(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultTripDistance, c.TripDistance())
+	c.options.TripDistance = 250
+	assert.Equal(t, float64(250), c.TripDistance())
+}
+
+func TestConfig_GazetteerPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.AssetsPath(), "gazetteer"), c.GazetteerPath())
+	c.options.GazetteerPath = "/srv/geonames"
+	assert.Equal(t, "/srv/geonames", c.GazetteerPath())
+}
+
 func TestConfig_OriginalsLimit(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
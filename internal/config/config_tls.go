@@ -0,0 +1,91 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// TLSCert returns the TLS certificate filename, if any.
+func (c *Config) TLSCert() string {
+	if c.options.TLSCert == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.TLSCert)
+}
+
+// TLSKey returns the TLS private key filename, if any.
+func (c *Config) TLSKey() string {
+	if c.options.TLSKey == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.TLSKey)
+}
+
+// TLSSelfSigned checks if a self-signed certificate should be used if no other certificate is configured.
+func (c *Config) TLSSelfSigned() bool {
+	return c.options.TLSSelfSigned
+}
+
+// TLSRedirectPort returns the plain HTTP port that redirects to HTTPS, or 0 if disabled.
+func (c *Config) TLSRedirectPort() int {
+	if c.options.TLSRedirectPort < 0 || !c.TLSEnabled() {
+		return 0
+	}
+
+	return c.options.TLSRedirectPort
+}
+
+// TLSEnabled checks if the built-in server should use HTTPS.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCert() != "" && c.TLSKey() != "" || c.TLSSelfSigned() || c.ACMEEnabled()
+}
+
+// ACMEDomains returns the domain names for which certificates are obtained via ACME.
+func (c *Config) ACMEDomains() (result []string) {
+	for _, s := range strings.Split(c.options.ACMEDomains, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// ACMEEnabled checks if certificates should be obtained automatically via ACME.
+func (c *Config) ACMEEnabled() bool {
+	return len(c.ACMEDomains()) > 0
+}
+
+// ACMEEmail returns the contact email address for the ACME account.
+func (c *Config) ACMEEmail() string {
+	return strings.TrimSpace(c.options.ACMEEmail)
+}
+
+// ACMEDirectory returns the ACME directory URL of the certificate authority.
+func (c *Config) ACMEDirectory() string {
+	if s := strings.TrimSpace(c.options.ACMEDirectory); s != "" {
+		return s
+	}
+
+	return acme.LetsEncryptURL
+}
+
+// ACMECACert returns the filename of an additional CA certificate to trust when connecting to the ACME directory.
+func (c *Config) ACMECACert() string {
+	if c.options.ACMECACert == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.ACMECACert)
+}
+
+// CertificatesPath returns the path to self-signed and ACME certificates.
+func (c *Config) CertificatesPath() string {
+	return filepath.Join(c.ConfigPath(), "certificates")
+}
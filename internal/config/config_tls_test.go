@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TLSEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.TLSEnabled())
+	assert.Equal(t, 0, c.TLSRedirectPort())
+
+	c.options.TLSCert = "/etc/ssl/photoprism.crt"
+	assert.False(t, c.TLSEnabled())
+
+	c.options.TLSKey = "/etc/ssl/photoprism.key"
+	c.options.TLSRedirectPort = 80
+	assert.True(t, c.TLSEnabled())
+	assert.Equal(t, "/etc/ssl/photoprism.crt", c.TLSCert())
+	assert.Equal(t, "/etc/ssl/photoprism.key", c.TLSKey())
+	assert.Equal(t, 80, c.TLSRedirectPort())
+
+	c.options.TLSCert = ""
+	c.options.TLSKey = ""
+	c.options.TLSSelfSigned = true
+	assert.True(t, c.TLSEnabled())
+}
+
+func TestConfig_ACMEDomains(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ACMEDomains())
+	assert.False(t, c.ACMEEnabled())
+	assert.Equal(t, "https://acme-v02.api.letsencrypt.org/directory", c.ACMEDirectory())
+
+	c.options.ACMEDomains = " Photos.example.com, ,www.example.com"
+	c.options.ACMEDirectory = "https://localhost:14000/dir"
+
+	assert.Equal(t, []string{"photos.example.com", "www.example.com"}, c.ACMEDomains())
+	assert.True(t, c.ACMEEnabled())
+	assert.True(t, c.TLSEnabled())
+	assert.Equal(t, "https://localhost:14000/dir", c.ACMEDirectory())
+}
+
+func TestConfig_CertificatesPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.CertificatesPath(), "/config/certificates")
+}
@@ -28,6 +28,15 @@ type Options struct {
 	Public                bool          `yaml:"Public" json:"-" flag:"public"`
 	AdminUser             string        `yaml:"AdminUser" json:"-" flag:"admin-user"`
 	AdminPassword         string        `yaml:"AdminPassword" json:"-" flag:"admin-password"`
+	OIDCUri               string        `yaml:"OIDCUri" json:"-" flag:"oidc-uri"`
+	OIDCClient            string        `yaml:"OIDCClient" json:"-" flag:"oidc-client"`
+	OIDCSecret            string        `yaml:"OIDCSecret" json:"-" flag:"oidc-secret"`
+	OIDCScopes            string        `yaml:"OIDCScopes" json:"-" flag:"oidc-scopes"`
+	OIDCRegister          bool          `yaml:"OIDCRegister" json:"-" flag:"oidc-register"`
+	OIDCLinkAdmin         bool          `yaml:"OIDCLinkAdmin" json:"-" flag:"oidc-link-admin"`
+	OIDCRoleClaim         string        `yaml:"OIDCRoleClaim" json:"-" flag:"oidc-role-claim"`
+	OIDCRoles             string        `yaml:"OIDCRoles" json:"-" flag:"oidc-roles"`
+	OIDCDefaultRole       string        `yaml:"OIDCDefaultRole" json:"-" flag:"oidc-default-role"`
 	LogLevel              string        `yaml:"LogLevel" json:"-" flag:"log-level"`
 	Prod                  bool          `yaml:"Prod" json:"Prod" flag:"prod"`
 	Debug                 bool          `yaml:"Debug" json:"Debug" flag:"debug"`
@@ -57,13 +66,22 @@ type Options struct {
 	AutoImport            int           `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
 	DisableWebDAV         bool          `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
 	DisableBackups        bool          `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
+	WriteXmp              bool          `yaml:"WriteXmp" json:"WriteXmp" flag:"write-xmp"`
 	DisableSettings       bool          `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
 	DisablePlaces         bool          `yaml:"DisablePlaces" json:"DisablePlaces" flag:"disable-places"`
+	GeoApi                string        `yaml:"GeoApi" json:"GeoApi" flag:"geo-api"`
+	GeoApiUrl             string        `yaml:"GeoApiUrl" json:"-" flag:"geo-api-url"`
+	GeoApiRateLimit       float64       `yaml:"GeoApiRateLimit" json:"-" flag:"geo-api-rate-limit"`
+	GeoApiLanguage        string        `yaml:"GeoApiLanguage" json:"GeoApiLanguage" flag:"geo-api-language"`
+	GazetteerPath         string        `yaml:"GazetteerPath" json:"-" flag:"gazetteer-path"`
+	HomeLocation          string        `yaml:"HomeLocation" json:"-" flag:"home-location"`
+	TripDistance          float64       `yaml:"TripDistance" json:"TripDistance" flag:"trip-distance"`
 	DisableTensorFlow     bool          `yaml:"DisableTensorFlow" json:"DisableTensorFlow" flag:"disable-tensorflow"`
 	DisableFaces          bool          `yaml:"DisableFaces" json:"DisableFaces" flag:"disable-faces"`
 	DisableClassification bool          `yaml:"DisableClassification" json:"DisableClassification" flag:"disable-classification"`
 	DisableFFmpeg         bool          `yaml:"DisableFFmpeg" json:"DisableFFmpeg" flag:"disable-ffmpeg"`
 	DisableExifTool       bool          `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
+	DisableOCR            bool          `yaml:"DisableOCR" json:"DisableOCR" flag:"disable-ocr"`
 	DisableHeifConvert    bool          `yaml:"DisableHeifConvert" json:"DisableHeifConvert" flag:"disable-heifconvert"`
 	DisableDarktable      bool          `yaml:"DisableDarktable" json:"DisableDarktable" flag:"disable-darktable"`
 	DisableRawtherapee    bool          `yaml:"DisableRawtherapee" json:"DisableRawtherapee" flag:"disable-rawtherapee"`
@@ -73,6 +91,12 @@ type Options struct {
 	ExifBruteForce        bool          `yaml:"ExifBruteForce" json:"ExifBruteForce" flag:"exif-bruteforce"`
 	DetectNSFW            bool          `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW            bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	ClassifyUrl           string        `yaml:"ClassifyUrl" json:"-" flag:"classify-url"`
+	NSFWUrl               string        `yaml:"NSFWUrl" json:"-" flag:"nsfw-url"`
+	FaceUrl               string        `yaml:"FaceUrl" json:"-" flag:"face-url"`
+	SemanticUrl           string        `yaml:"SemanticUrl" json:"-" flag:"semantic-url"`
+	ObjectsUrl            string        `yaml:"ObjectsUrl" json:"-" flag:"objects-url"`
+	PetsUrl               string        `yaml:"PetsUrl" json:"-" flag:"pets-url"`
 	DefaultTheme          string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
 	DefaultLocale         string        `yaml:"DefaultLocale" json:"DefaultLocale" flag:"default-locale"`
 	AppIcon               string        `yaml:"AppIcon" json:"AppIcon" flag:"app-icon"`
@@ -100,6 +124,14 @@ type Options struct {
 	HttpPort              int           `yaml:"HttpPort" json:"-" flag:"http-port"`
 	HttpMode              string        `yaml:"HttpMode" json:"-" flag:"http-mode"`
 	HttpCompression       string        `yaml:"HttpCompression" json:"-" flag:"http-compression"`
+	TLSCert               string        `yaml:"TLSCert" json:"-" flag:"tls-cert"`
+	TLSKey                string        `yaml:"TLSKey" json:"-" flag:"tls-key"`
+	TLSSelfSigned         bool          `yaml:"TLSSelfSigned" json:"-" flag:"tls-self-signed"`
+	TLSRedirectPort       int           `yaml:"TLSRedirectPort" json:"-" flag:"tls-redirect-port"`
+	ACMEDomains           string        `yaml:"ACMEDomains" json:"-" flag:"acme-domains"`
+	ACMEEmail             string        `yaml:"ACMEEmail" json:"-" flag:"acme-email"`
+	ACMEDirectory         string        `yaml:"ACMEDirectory" json:"-" flag:"acme-directory"`
+	ACMECACert            string        `yaml:"ACMECACert" json:"-" flag:"acme-ca-cert"`
 	DarktableBin          string        `yaml:"DarktableBin" json:"-" flag:"darktable-bin"`
 	DarktableCachePath    string        `yaml:"DarktableCachePath" json:"-" flag:"darktable-cache-path"`
 	DarktableConfigPath   string        `yaml:"DarktableConfigPath" json:"-" flag:"darktable-config-path"`
@@ -112,9 +144,12 @@ type Options struct {
 	FFmpegEncoder         string        `yaml:"FFmpegEncoder" json:"FFmpegEncoder" flag:"ffmpeg-encoder"`
 	FFmpegBitrate         int           `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
 	ExifToolBin           string        `yaml:"ExifToolBin" json:"-" flag:"exiftool-bin"`
+	OCRBin                string        `yaml:"OCRBin" json:"-" flag:"ocr-bin"`
+	OCRLang               string        `yaml:"OCRLang" json:"-" flag:"ocr-lang"`
 	DetachServer          bool          `yaml:"DetachServer" json:"-" flag:"detach-server"`
 	DownloadToken         string        `yaml:"DownloadToken" json:"-" flag:"download-token"`
 	PreviewToken          string        `yaml:"PreviewToken" json:"-" flag:"preview-token"`
+	MetricsToken          string        `yaml:"MetricsToken" json:"-" flag:"metrics-token"`
 	ThumbColor            string        `yaml:"ThumbColor" json:"ThumbColor" flag:"thumb-color"`
 	ThumbFilter           string        `yaml:"ThumbFilter" json:"ThumbFilter" flag:"thumb-filter"`
 	ThumbSize             int           `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
@@ -5,6 +5,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
@@ -31,6 +32,63 @@ var Flags = CliFlags{
 			Usage:  "initial admin `PASSWORD`, must have at least 8 characters",
 			EnvVar: "PHOTOPRISM_ADMIN_PASSWORD",
 		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-uri",
+			Usage:  "OpenID Connect issuer `URL` for single sign-on (leave empty to disable)",
+			EnvVar: "PHOTOPRISM_OIDC_URI",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-client",
+			Usage:  "OpenID Connect client `ID`",
+			EnvVar: "PHOTOPRISM_OIDC_CLIENT",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-secret",
+			Usage:  "OpenID Connect client `SECRET`",
+			EnvVar: "PHOTOPRISM_OIDC_SECRET",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-scopes",
+			Usage:  "OpenID Connect `SCOPES` requested from the identity provider",
+			Value:  "openid email profile",
+			EnvVar: "PHOTOPRISM_OIDC_SCOPES",
+		}},
+	CliFlag{
+		Flag: cli.BoolFlag{
+			Name:   "oidc-register",
+			Usage:  "create user accounts on first login with OpenID Connect",
+			EnvVar: "PHOTOPRISM_OIDC_REGISTER",
+		}},
+	CliFlag{
+		Flag: cli.BoolFlag{
+			Name:   "oidc-link-admin",
+			Usage:  "allow linking existing admin accounts by verified email address",
+			EnvVar: "PHOTOPRISM_OIDC_LINK_ADMIN",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-role-claim",
+			Usage:  "ID token `CLAIM` that contains the user's groups or roles",
+			Value:  "groups",
+			EnvVar: "PHOTOPRISM_OIDC_ROLE_CLAIM",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-roles",
+			Usage:  "maps claim values to user roles, e.g. \"photo-admins=admin,family=viewer\"",
+			EnvVar: "PHOTOPRISM_OIDC_ROLES",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "oidc-default-role",
+			Usage:  "user `ROLE` if no claim value matches (admin, editor, viewer, guest, or none)",
+			Value:  "viewer",
+			EnvVar: "PHOTOPRISM_OIDC_DEFAULT_ROLE",
+		}},
 	CliFlag{
 		Flag: cli.BoolFlag{
 			Name:   "public, p",
@@ -234,12 +292,62 @@ var Flags = CliFlags{
 			Usage:  "disable reverse geocoding and maps",
 			EnvVar: "PHOTOPRISM_DISABLE_PLACES",
 		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "geo-api",
+			Usage:  "reverse geocoding `API` (places, gazetteer, nominatim, photon, none)",
+			Value:  places.ApiName,
+			EnvVar: "PHOTOPRISM_GEO_API",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "geo-api-url",
+			Usage:  "Nominatim or Photon API endpoint `URL`, e.g. of a self-hosted instance *optional*",
+			EnvVar: "PHOTOPRISM_GEO_API_URL",
+		}},
+	CliFlag{
+		Flag: cli.Float64Flag{
+			Name:   "geo-api-rate-limit",
+			Usage:  "max number of Nominatim or Photon API `REQUESTS` per second, -1 to disable",
+			EnvVar: "PHOTOPRISM_GEO_API_RATE_LIMIT",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "geo-api-language",
+			Usage:  "preferred `LANGUAGE` of Nominatim or Photon place names, e.g. de or en",
+			EnvVar: "PHOTOPRISM_GEO_API_LANGUAGE",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "gazetteer-path",
+			Usage:  "offline gazetteer `PATH` containing GeoNames files like cities500.txt and admin1CodesASCII.txt *optional*",
+			EnvVar: "PHOTOPRISM_GAZETTEER_PATH",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "home-location",
+			Usage:  "home `LATITUDE,LONGITUDE` for trip detection, estimated from pictures if empty",
+			EnvVar: "PHOTOPRISM_HOME_LOCATION",
+		}},
+	CliFlag{
+		Flag: cli.Float64Flag{
+			Name:   "trip-distance",
+			Usage:  "min `KM` from home to detect pictures taken on a trip",
+			Value:  DefaultTripDistance,
+			EnvVar: "PHOTOPRISM_TRIP_DISTANCE",
+		}},
 	CliFlag{
 		Flag: cli.BoolFlag{
 			Name:   "disable-backups",
 			Usage:  "disable backing up albums and photo metadata to YAML files",
 			EnvVar: "PHOTOPRISM_DISABLE_BACKUPS",
 		}},
+	CliFlag{
+		Flag: cli.BoolFlag{
+			Name:   "write-xmp",
+			Usage:  "write metadata changes to XMP sidecar files for use with other applications",
+			EnvVar: "PHOTOPRISM_WRITE_XMP",
+		}},
 	CliFlag{
 		Flag: cli.BoolFlag{
 			Name:   "disable-tensorflow",
@@ -270,6 +378,12 @@ var Flags = CliFlags{
 			Usage:  "disable creating JSON metadata sidecar files with ExifTool",
 			EnvVar: "PHOTOPRISM_DISABLE_EXIFTOOL",
 		}},
+	CliFlag{
+		Flag: cli.BoolFlag{
+			Name:   "disable-ocr",
+			Usage:  "disable text recognition in scans, screenshots, and other pictures with Tesseract OCR",
+			EnvVar: "PHOTOPRISM_DISABLE_OCR",
+		}},
 	CliFlag{
 		Flag: cli.BoolFlag{
 			Name:   "disable-heifconvert",
@@ -324,6 +438,42 @@ var Flags = CliFlags{
 			Usage:  "allow uploads that MAY be offensive (no effect without TensorFlow)",
 			EnvVar: "PHOTOPRISM_UPLOAD_NSFW",
 		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "classify-url",
+			Usage:  "image classification inference server `URL` to use instead of the built-in TensorFlow model",
+			EnvVar: "PHOTOPRISM_CLASSIFY_URL",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "nsfw-url",
+			Usage:  "NSFW detection inference server `URL` to use instead of the built-in TensorFlow model",
+			EnvVar: "PHOTOPRISM_NSFW_URL",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "face-url",
+			Usage:  "face embeddings inference server `URL` to use instead of the built-in TensorFlow model",
+			EnvVar: "PHOTOPRISM_FACE_URL",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "semantic-url",
+			Usage:  "image and text embeddings inference server `URL` for semantic search, e.g. with a CLIP model",
+			EnvVar: "PHOTOPRISM_SEMANTIC_URL",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "objects-url",
+			Usage:  "object detection inference server `URL` for finding pets, cars, text, and other objects in pictures",
+			EnvVar: "PHOTOPRISM_OBJECTS_URL",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "pets-url",
+			Usage:  "pet face detection and embeddings inference server `URL` for recognizing individual pets like people",
+			EnvVar: "PHOTOPRISM_PETS_URL",
+		}},
 	CliFlag{
 		Flag: cli.StringFlag{
 			Name:   "default-locale, lang",
@@ -468,6 +618,55 @@ var Flags = CliFlags{
 			Usage:  "http server compression `METHOD` (none or gzip)",
 			EnvVar: "PHOTOPRISM_HTTP_COMPRESSION",
 		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "tls-cert",
+			Usage:  "TLS certificate `FILENAME` for HTTPS, reloaded automatically when changed",
+			EnvVar: "PHOTOPRISM_TLS_CERT",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "tls-key",
+			Usage:  "TLS private key `FILENAME` for HTTPS",
+			EnvVar: "PHOTOPRISM_TLS_KEY",
+		}},
+	CliFlag{
+		Flag: cli.BoolFlag{
+			Name:   "tls-self-signed",
+			Usage:  "enable HTTPS with a self-signed certificate if no other certificate is configured, e.g. on a local network",
+			EnvVar: "PHOTOPRISM_TLS_SELF_SIGNED",
+		}},
+	CliFlag{
+		Flag: cli.IntFlag{
+			Name:   "tls-redirect-port",
+			Usage:  "HTTP `PORT` that redirects to HTTPS and answers ACME HTTP-01 challenges (0 to disable)",
+			EnvVar: "PHOTOPRISM_TLS_REDIRECT_PORT",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "acme-domains",
+			Usage:  "comma-separated `DOMAINS` for which HTTPS certificates are obtained automatically via ACME",
+			EnvVar: "PHOTOPRISM_ACME_DOMAINS",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "acme-email",
+			Usage:  "contact `EMAIL` address for the ACME account",
+			EnvVar: "PHOTOPRISM_ACME_EMAIL",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "acme-directory",
+			Usage:  "ACME directory `URL` of the certificate authority",
+			Value:  "https://acme-v02.api.letsencrypt.org/directory",
+			EnvVar: "PHOTOPRISM_ACME_DIRECTORY",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "acme-ca-cert",
+			Usage:  "CA certificate `FILENAME` to trust when connecting to the ACME directory, e.g. of a local test server",
+			EnvVar: "PHOTOPRISM_ACME_CA_CERT",
+		}},
 	CliFlag{
 		Flag: cli.StringFlag{
 			Name:   "database-driver, db",
@@ -605,6 +804,20 @@ var Flags = CliFlags{
 			Value:  "exiftool",
 			EnvVar: "PHOTOPRISM_EXIFTOOL_BIN",
 		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "ocr-bin",
+			Usage:  "Tesseract OCR `COMMAND` for extracting text from pictures",
+			Value:  "tesseract",
+			EnvVar: "PHOTOPRISM_OCR_BIN",
+		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "ocr-lang",
+			Usage:  "Tesseract OCR `LANGUAGES` separated by +, e.g. eng+deu",
+			Value:  "eng",
+			EnvVar: "PHOTOPRISM_OCR_LANG",
+		}},
 	CliFlag{
 		Flag: cli.StringFlag{
 			Name:   "download-token",
@@ -617,6 +830,12 @@ var Flags = CliFlags{
 			Usage:  "`SECRET` thumbnail and video streaming URL token (default: random)",
 			EnvVar: "PHOTOPRISM_PREVIEW_TOKEN",
 		}},
+	CliFlag{
+		Flag: cli.StringFlag{
+			Name:   "metrics-token",
+			Usage:  "`SECRET` bearer token for the Prometheus metrics endpoint (leave blank to disable)",
+			EnvVar: "PHOTOPRISM_METRICS_TOKEN",
+		}},
 	CliFlag{
 		Flag: cli.StringFlag{
 			Name:   "thumb-color",
@@ -37,6 +37,7 @@ func NewSettings(c *Config) *Settings {
 		},
 		Search: SearchSettings{
 			BatchSize: 0,
+			Semantic:  false,
 		},
 		Maps: MapsSettings{
 			Animate: 0,
@@ -2,5 +2,6 @@ package config
 
 // SearchSettings represents search UI preferences.
 type SearchSettings struct {
-	BatchSize int `json:"batchSize" yaml:"BatchSize"`
+	BatchSize int  `json:"batchSize" yaml:"BatchSize"`
+	Semantic  bool `json:"semantic" yaml:"Semantic"`
 }
@@ -24,6 +24,7 @@ const (
 	AlbumMoment  = "moment"
 	AlbumMonth   = "month"
 	AlbumState   = "state"
+	AlbumTrip    = "trip"
 )
 
 type Albums []Album
@@ -54,6 +55,7 @@ type Album struct {
 	AlbumPrivate     bool        `json:"Private" yaml:"Private,omitempty"`
 	Thumb            string      `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
 	ThumbSrc         string      `gorm:"type:VARBINARY(8);default:'';" json:"ThumbSrc,omitempty" yaml:"ThumbSrc,omitempty"`
+	CreatedBy        string      `gorm:"type:VARBINARY(42);index;default:'';" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
 	CreatedAt        time.Time   `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time   `json:"UpdatedAt" yaml:"UpdatedAt,omitempty"`
 	DeletedAt        *time.Time  `sql:"index" json:"DeletedAt" yaml:"DeletedAt,omitempty"`
@@ -247,6 +249,33 @@ func NewMonthAlbum(albumTitle, albumSlug string, year, month int) *Album {
 	return result
 }
 
+// NewTripAlbum creates a new trip album starting at the specified time.
+func NewTripAlbum(albumTitle, albumSlug string, start time.Time) *Album {
+	albumTitle = strings.TrimSpace(albumTitle)
+	albumSlug = strings.TrimSpace(albumSlug)
+
+	if albumTitle == "" || albumSlug == "" || start.IsZero() {
+		return nil
+	}
+
+	now := TimeStamp()
+
+	result := &Album{
+		AlbumOrder: SortOrderOldest,
+		AlbumType:  AlbumTrip,
+		AlbumSlug:  txt.Clip(albumSlug, txt.ClipSlug),
+		AlbumYear:  start.Year(),
+		AlbumMonth: int(start.Month()),
+		AlbumDay:   start.Day(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	result.SetTitle(albumTitle)
+
+	return result
+}
+
 // FindMonthAlbum finds a matching month album or returns nil.
 func FindMonthAlbum(year, month int) *Album {
 	result := Album{}
@@ -328,6 +357,11 @@ func (m *Album) Find() (err error) {
 
 	stmt := UnscopedDb().Where("album_type = ?", m.AlbumType)
 
+	// Only find albums created by the same user?
+	if m.CreatedBy != "" {
+		stmt = stmt.Where("created_by = ?", m.CreatedBy)
+	}
+
 	if m.AlbumType != AlbumDefault && m.AlbumFilter != "" {
 		stmt = stmt.Where("album_slug = ? OR album_filter = ?", m.AlbumSlug, m.AlbumFilter)
 	} else {
@@ -381,6 +415,11 @@ func (m *Album) IsState() bool {
 	return m.AlbumType == AlbumState
 }
 
+// IsTrip tests if the album is a trip.
+func (m *Album) IsTrip() bool {
+	return m.AlbumType == AlbumTrip
+}
+
 // IsDefault tests if the album is a regular album.
 func (m *Album) IsDefault() bool {
 	return m.AlbumType == AlbumDefault
@@ -281,6 +281,24 @@ func TestNewMonthAlbum(t *testing.T) {
 	})
 }
 
+func TestNewTripAlbum(t *testing.T) {
+	t.Run("Italy", func(t *testing.T) {
+		album := NewTripAlbum("Italy / June 2021", "italy-june-2021-2021-06-03", time.Date(2021, 6, 3, 10, 0, 0, 0, time.UTC))
+		assert.Equal(t, "Italy / June 2021", album.AlbumTitle)
+		assert.Equal(t, "italy-june-2021-2021-06-03", album.AlbumSlug)
+		assert.Equal(t, AlbumTrip, album.AlbumType)
+		assert.True(t, album.IsTrip())
+		assert.Equal(t, SortOrderOldest, album.AlbumOrder)
+		assert.Equal(t, "", album.AlbumFilter)
+		assert.Equal(t, 2021, album.AlbumYear)
+		assert.Equal(t, 6, album.AlbumMonth)
+		assert.Equal(t, 3, album.AlbumDay)
+	})
+	t.Run("NoStart", func(t *testing.T) {
+		assert.Nil(t, NewTripAlbum("Italy / June 2021", "italy", time.Time{}))
+	})
+}
+
 func TestFindAlbumBySlug(t *testing.T) {
 	t.Run("1 result", func(t *testing.T) {
 		album, err := FindAlbumBySlug("holiday-2030", AlbumDefault)
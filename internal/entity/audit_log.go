@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Audit log actions.
+const (
+	AuditCreate       = "create"
+	AuditUpdate       = "update"
+	AuditDelete       = "delete"
+	AuditLogin        = "login"
+	AuditLogout       = "logout"
+	AuditArchive      = "archive"
+	AuditRestore      = "restore"
+	AuditAddPhotos    = "add-photos"
+	AuditRemovePhotos = "remove-photos"
+	AuditAddToken     = "add-token"
+	AuditRevokeToken  = "revoke-token"
+	AuditEnable2FA    = "enable-2fa"
+	AuditDisable2FA   = "disable-2fa"
+)
+
+// Audit log outcomes.
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+	AuditDenied  = "denied"
+)
+
+// AuditActorCLI is the actor name of changes made with the command-line interface.
+const AuditActorCLI = "cli"
+
+// AuditLogs represents a list of audit log entries.
+type AuditLogs []AuditLog
+
+// AuditLog represents a security-relevant or destructive action, e.g. a login or deleting photos.
+type AuditLog struct {
+	ID          uint      `gorm:"primary_key" json:"ID" yaml:"ID"`
+	ActorUID    string    `gorm:"type:VARBINARY(42);index;" json:"ActorUID" yaml:"ActorUID,omitempty"`
+	ActorName   string    `gorm:"size:64;" json:"ActorName" yaml:"ActorName,omitempty"`
+	SessionUID  string    `gorm:"type:VARBINARY(42);" json:"SessionUID" yaml:"SessionUID,omitempty"`
+	ClientIP    string    `gorm:"type:VARBINARY(64);" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	Action      string    `gorm:"type:VARBINARY(32);index;" json:"Action" yaml:"Action"`
+	Resource    string    `gorm:"type:VARBINARY(32);" json:"Resource" yaml:"Resource"`
+	ResourceUID string    `gorm:"type:VARBINARY(255);index;" json:"ResourceUID" yaml:"ResourceUID,omitempty"`
+	Outcome     string    `gorm:"type:VARBINARY(16);" json:"Outcome" yaml:"Outcome"`
+	Message     string    `gorm:"type:VARBINARY(512);" json:"Message" yaml:"Message,omitempty"`
+	CreatedAt   time.Time `sql:"index" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// NewAuditLog returns a new audit log entry.
+func NewAuditLog(action, resource, resourceUID, outcome string) *AuditLog {
+	return &AuditLog{
+		Action:      txt.Clip(action, 32),
+		Resource:    txt.Clip(resource, 32),
+		ResourceUID: txt.Clip(resourceUID, 255),
+		Outcome:     outcome,
+	}
+}
+
+// SetActor sets the user who performed the action.
+func (m *AuditLog) SetActor(user *User) *AuditLog {
+	if user != nil {
+		m.ActorUID = user.UserUID
+		m.ActorName = txt.Clip(user.UserName(), 64)
+	}
+
+	return m
+}
+
+// SetClient sets the session uid and client IP.
+func (m *AuditLog) SetClient(sessionUID, ip string) *AuditLog {
+	m.SessionUID = sessionUID
+	m.ClientIP = txt.Clip(ip, 64)
+
+	return m
+}
+
+// SetMessage sets an optional message, e.g. the reason for a failure.
+func (m *AuditLog) SetMessage(msg string) *AuditLog {
+	m.Message = txt.Clip(msg, 512)
+
+	return m
+}
+
+// Create inserts a new row to the database.
+func (m *AuditLog) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save stores the entry and logs an error if this fails, so that the action itself is not interrupted.
+func (m *AuditLog) Save() {
+	if err := m.Create(); err != nil {
+		log.Errorf("audit: %s (%s %s)", err, m.Action, m.Resource)
+	} else {
+		log.Debugf("audit: %s %s %s by %s (%s)", m.Action, m.Resource, m.ResourceUID, m.ActorName, m.Outcome)
+	}
+}
+
+// AuditCLI records an action performed with the command-line interface.
+func AuditCLI(action, resource, resourceUID string) {
+	m := NewAuditLog(action, resource, resourceUID, AuditSuccess)
+	m.ActorName = AuditActorCLI
+	m.Save()
+}
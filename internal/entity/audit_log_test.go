@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	m := NewAuditLog(AuditLogin, "sessions", "", AuditFailure)
+
+	m.SetActor(UserFixtures.Pointer("alice")).SetClient("c1234567890abcde", "10.0.0.1").SetMessage(strings.Repeat("x", 600))
+
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.ActorUID)
+	assert.Equal(t, "alice", m.ActorName)
+	assert.Equal(t, "10.0.0.1", m.ClientIP)
+	assert.LessOrEqual(t, len(m.Message), 512)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+	assert.False(t, m.CreatedAt.IsZero())
+}
+
+func TestAuditCLI(t *testing.T) {
+	AuditCLI(AuditDelete, "users", "uqxc08w3d0ej2283")
+
+	var result AuditLog
+
+	if err := Db().Where("actor_name = ? AND resource_uid = ?", AuditActorCLI, "uqxc08w3d0ej2283").First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, AuditSuccess, result.Outcome)
+	assert.Equal(t, AuditDelete, result.Action)
+}
@@ -0,0 +1,215 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Login methods that are stored with a session.
+const (
+	AuthMethodPassword = "password"
+	AuthMethodPasscode = "2fa"
+	AuthMethodLink     = "link"
+)
+
+// SessionActivityInterval is the minimum interval between two updates of the last activity time.
+var SessionActivityInterval = time.Minute
+
+// Sessions represents a list of sessions.
+type Sessions []Session
+
+// Session represents a user session, the session id itself is only stored as hash.
+type Session struct {
+	ID         string    `gorm:"type:VARBINARY(64);primary_key;" json:"-" yaml:"-"`
+	SessUID    string    `gorm:"type:VARBINARY(42);column:sess_uid;unique_index;" json:"UID" yaml:"UID"`
+	UserUID    string    `gorm:"type:VARBINARY(42);column:user_uid;index;" json:"UserUID" yaml:"UserUID"`
+	UserName   string    `gorm:"size:64;column:user_name;" json:"UserName" yaml:"UserName,omitempty"`
+	AuthMethod string    `gorm:"type:VARBINARY(64);column:auth_method;" json:"AuthMethod" yaml:"AuthMethod,omitempty"`
+	AuthTokens string    `gorm:"type:VARBINARY(2048);column:auth_tokens;" json:"-" yaml:"-"`
+	ClientIP   string    `gorm:"type:VARBINARY(64);column:client_ip;" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	UserAgent  string    `gorm:"size:512;column:user_agent;" json:"UserAgent" yaml:"UserAgent,omitempty"`
+	LastActive time.Time `json:"LastActive" yaml:"LastActive"`
+	ExpiresAt  time.Time `gorm:"index;" json:"ExpiresAt" yaml:"ExpiresAt"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Session) TableName() string {
+	return "auth_sessions"
+}
+
+// NewSession returns a new session entity for the session id.
+func NewSession(id string, expires time.Duration) *Session {
+	now := TimeStamp()
+
+	return &Session{
+		ID:         SessionHash(id),
+		SessUID:    rnd.GenerateUID('c'),
+		LastActive: now,
+		ExpiresAt:  now.Add(expires),
+	}
+}
+
+// SessionHash returns the hash of a session id that is stored in the database.
+func SessionHash(id string) string {
+	h := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(h[:])
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Session) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.ValidID(m.SessUID, 'c') {
+		return nil
+	}
+
+	m.SessUID = rnd.GenerateUID('c')
+
+	return scope.SetColumn("SessUID", m.SessUID)
+}
+
+// Create new entity in the database.
+func (m *Session) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save entity properties.
+func (m *Session) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete revokes the session.
+func (m *Session) Delete() error {
+	if m.ID == "" {
+		return nil
+	}
+
+	return Db().Delete(m).Error
+}
+
+// SetClient updates the client IP and user agent.
+func (m *Session) SetClient(ip, userAgent string) {
+	m.ClientIP = txt.Clip(ip, 64)
+	m.UserAgent = txt.Clip(userAgent, 512)
+}
+
+// SetUser changes the session owner.
+func (m *Session) SetUser(user *User) {
+	if user == nil {
+		m.UserUID = ""
+		m.UserName = ""
+		return
+	}
+
+	m.UserUID = user.UserUID
+	m.UserName = user.UserName()
+}
+
+// SetTokens changes the secret share tokens of the session.
+func (m *Session) SetTokens(tokens []string) {
+	m.AuthTokens = strings.Join(tokens, " ")
+}
+
+// Tokens returns the secret share tokens of the session.
+func (m *Session) Tokens() []string {
+	return strings.Fields(m.AuthTokens)
+}
+
+// Expired checks if the session has expired.
+func (m *Session) Expired() bool {
+	return m.ExpiresAt.Before(time.Now())
+}
+
+// UpdateActivity updates the last activity time, unless it has been updated recently.
+func (m *Session) UpdateActivity() {
+	if time.Since(m.LastActive) < SessionActivityInterval {
+		return
+	}
+
+	m.LastActive = TimeStamp()
+
+	if err := Db().Model(m).UpdateColumn("last_active", m.LastActive).Error; err != nil {
+		log.Debugf("session: %s (update activity)", err)
+	}
+}
+
+// FindSession returns the session for the session id, or nil if it does not exist or has expired.
+func FindSession(id string) *Session {
+	if id == "" {
+		return nil
+	}
+
+	result := Session{}
+
+	if err := Db().Where("id = ?", SessionHash(id)).First(&result).Error; err != nil {
+		return nil
+	} else if result.Expired() {
+		if err = result.Delete(); err != nil {
+			log.Debugf("session: %s (delete expired)", err)
+		}
+
+		return nil
+	}
+
+	return &result
+}
+
+// FindSessionByUID returns the session with the specified uid, or nil if not found.
+func FindSessionByUID(uid string) *Session {
+	if !rnd.ValidID(uid, 'c') {
+		return nil
+	}
+
+	result := Session{}
+
+	if err := Db().Where("sess_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindSessions returns the active sessions of a user, or all sessions if the user uid is empty.
+func FindSessions(userUID string) (result Sessions) {
+	stmt := Db().Where("expires_at > ?", TimeStamp())
+
+	if userUID != "" {
+		stmt = stmt.Where("user_uid = ?", userUID)
+	}
+
+	if err := stmt.Order("last_active DESC").Find(&result).Error; err != nil {
+		log.Errorf("session: %s", err)
+	}
+
+	return result
+}
+
+// DeleteUserSessions revokes all sessions of a user and returns the number of deleted sessions.
+func DeleteUserSessions(userUID string) (int, error) {
+	if userUID == "" {
+		return 0, nil
+	}
+
+	res := Db().Where("user_uid = ?", userUID).Delete(&Session{})
+
+	if res.Error == nil && res.RowsAffected > 0 {
+		log.Infof("session: revoked %d sessions of user %s", res.RowsAffected, clean.Log(userUID))
+	}
+
+	return int(res.RowsAffected), res.Error
+}
+
+// DeleteExpiredSessions removes expired sessions from the database.
+func DeleteExpiredSessions() (int, error) {
+	res := Db().Where("expires_at < ?", TimeStamp()).Delete(&Session{})
+
+	return int(res.RowsAffected), res.Error
+}
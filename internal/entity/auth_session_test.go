@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSession(t *testing.T) {
+	m := NewSession("abc", time.Hour)
+
+	assert.Equal(t, SessionHash("abc"), m.ID)
+	assert.Len(t, m.ID, 64)
+	assert.Equal(t, byte('c'), m.SessUID[0])
+	assert.False(t, m.Expired())
+}
+
+func TestSession_Tokens(t *testing.T) {
+	m := Session{}
+
+	assert.Empty(t, m.Tokens())
+
+	m.SetTokens([]string{"1jxf3jfn2k", "4jxf3jfn2k"})
+
+	assert.Equal(t, "1jxf3jfn2k 4jxf3jfn2k", m.AuthTokens)
+	assert.Equal(t, []string{"1jxf3jfn2k", "4jxf3jfn2k"}, m.Tokens())
+}
+
+func TestFindSession(t *testing.T) {
+	user := UserFixtures.Pointer("friend")
+
+	m := NewSession("friend-session", time.Hour)
+	m.SetUser(user)
+	m.SetClient("192.168.1.2", "Mozilla/5.0")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		found := FindSession("friend-session")
+
+		if found == nil {
+			t.Fatal("session not found")
+		}
+
+		assert.Equal(t, m.SessUID, found.SessUID)
+		assert.Equal(t, "friend", found.UserName)
+		assert.Equal(t, "192.168.1.2", found.ClientIP)
+		assert.NotNil(t, FindSessionByUID(m.SessUID))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindSession(""))
+		assert.Nil(t, FindSession("xxx"))
+		assert.Nil(t, FindSessionByUID("xxx"))
+	})
+	t.Run("FindSessions", func(t *testing.T) {
+		assert.Len(t, FindSessions(user.UserUID), 1)
+		assert.GreaterOrEqual(t, len(FindSessions("")), 1)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		expired := NewSession("expired-session", -time.Hour)
+		expired.SetUser(user)
+
+		if err := expired.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindSession("expired-session"))
+		assert.Nil(t, FindSessionByUID(expired.SessUID))
+	})
+	t.Run("DeleteUserSessions", func(t *testing.T) {
+		n, err := DeleteUserSessions(user.UserUID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Nil(t, FindSession("friend-session"))
+	})
+}
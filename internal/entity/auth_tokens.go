@@ -1,13 +1,25 @@
 package entity
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
 
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
+// TokenTypeAccess is the type of personal access tokens, also known as app passwords.
+const TokenTypeAccess = "access"
+
+// TokenSecretLength is the number of random characters in an access token secret.
+const TokenSecretLength = 32
+
 // Tokens represents a list of auth tokens.
 type Tokens []Token
 
@@ -21,7 +33,7 @@ type Token struct {
 	UserUID     string     `gorm:"type:VARBINARY(42);column:user_uid;index;" json:"UserUID" yaml:"UserUID"`
 	Version     string     `gorm:"type:VARCHAR(32);column:version;default:'oauth20';" json:"Version,omitempty" yaml:"Version,omitempty"`
 	Auth        string     `gorm:"type:VARBINARY(512);column:auth;" json:"Auth,omitempty" yaml:"Auth,omitempty"`
-	Access      string     `gorm:"type:VARBINARY(4096);column:access;" json:"Access,omitempty" yaml:"Access,omitempty"`
+	Access      string     `gorm:"type:VARBINARY(4096);column:access;" json:"-" yaml:"-"`
 	Refresh     string     `gorm:"type:VARBINARY(512);column:refresh;" json:"Refresh,omitempty" yaml:"Refresh,omitempty"`
 	FileRoot    string     `gorm:"type:VARBINARY(16);column:file_root;" json:"FileRoot" yaml:"FileRoot,omitempty"`
 	FilePath    string     `gorm:"type:VARBINARY(500);column:file_path;" json:"FilePath" yaml:"FilePath,omitempty"`
@@ -62,10 +74,192 @@ func (m *Token) Updates(values interface{}) error {
 }
 
 // BeforeCreate creates a random UID if needed before inserting a new row to the database.
-func (m *Token) BeforeCreate(tx *gorm.DB) error {
-	if rnd.ValidID(m.TokenUID, 'u') {
+func (m *Token) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.ValidID(m.TokenUID, 't') {
+		return nil
+	}
+
+	m.TokenUID = rnd.GenerateUID('t')
+
+	return scope.SetColumn("TokenUID", m.TokenUID)
+}
+
+// Delete revokes the token.
+func (m *Token) Delete() error {
+	if m.ID < 1 {
 		return nil
 	}
-	m.TokenUID = rnd.GenerateUID('u')
-	return nil
+
+	return Db().Delete(m).Error
+}
+
+// NewAccessToken returns a new personal access token for the user and the secret needed to use it.
+// The secret is not stored in the database and cannot be recovered later.
+func NewAccessToken(userUID, name string, scopes acl.Scopes, expires time.Duration) (m *Token, secret string) {
+	m = &Token{
+		TokenUID:  rnd.GenerateUID('t'),
+		TokenName: clean.Name(name),
+		TokenType: TokenTypeAccess,
+		UserUID:   userUID,
+	}
+
+	m.SetScopes(scopes)
+
+	if expires > 0 {
+		m.ExpiresAt = TimePointer()
+		*m.ExpiresAt = m.ExpiresAt.Add(expires)
+	}
+
+	secret = m.TokenUID + "-" + rnd.GenerateSecret(TokenSecretLength)
+	m.Access = tokenHash(secret)
+
+	return m, secret
+}
+
+// tokenHash returns the hash of the secret that is stored in the database.
+func tokenHash(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// IsAccessToken checks if the string has the format of an access token secret.
+func IsAccessToken(s string) bool {
+	uid, secret, ok := splitAccessToken(s)
+	return ok && rnd.ValidID(uid, 't') && len(secret) == TokenSecretLength
+}
+
+// splitAccessToken returns the token uid and random part of an access token secret.
+func splitAccessToken(s string) (uid, secret string, ok bool) {
+	parts := strings.SplitN(s, "-", 2)
+
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// FindAccessToken returns the valid access token matching the secret, or nil if not found.
+func FindAccessToken(secret string) *Token {
+	if !IsAccessToken(secret) {
+		return nil
+	}
+
+	uid, _, _ := splitAccessToken(secret)
+	result := Token{}
+
+	if err := Db().Where("token_uid = ? AND token_type = ?", uid, TokenTypeAccess).First(&result).Error; err != nil {
+		log.Debugf("token %s not found", clean.Log(uid))
+		return nil
+	} else if subtle.ConstantTimeCompare([]byte(result.Access), []byte(tokenHash(secret))) != 1 {
+		log.Warnf("token %s has an invalid secret", clean.Log(uid))
+		return nil
+	} else if result.Expired() {
+		log.Debugf("token %s has expired", clean.Log(uid))
+		return nil
+	}
+
+	return &result
+}
+
+// FindToken returns the token with the specified uid, or nil if not found.
+func FindToken(uid string) *Token {
+	if !rnd.ValidID(uid, 't') {
+		return nil
+	}
+
+	result := Token{}
+
+	if err := Db().Where("token_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserTokens returns the access tokens of a user.
+func FindUserTokens(userUID string) (result Tokens) {
+	if userUID == "" {
+		return Tokens{}
+	}
+
+	if err := Db().Where("user_uid = ? AND token_type = ?", userUID, TokenTypeAccess).Order("created_at").Find(&result).Error; err != nil {
+		log.Errorf("token: %s", err)
+	}
+
+	return result
+}
+
+// DeleteUserTokens revokes all access tokens of a user and returns the number of revoked tokens.
+func DeleteUserTokens(userUID string) (int, error) {
+	if userUID == "" {
+		return 0, nil
+	}
+
+	res := Db().Where("user_uid = ? AND token_type = ?", userUID, TokenTypeAccess).Delete(&Token{})
+
+	if res.Error == nil && res.RowsAffected > 0 {
+		log.Infof("token: revoked %d access tokens of user %s", res.RowsAffected, clean.Log(userUID))
+	}
+
+	return int(res.RowsAffected), res.Error
+}
+
+// User returns the token owner, or nil if not found.
+func (m *Token) User() *User {
+	return FindUserByUID(m.UserUID)
+}
+
+// Expired checks if the token has expired.
+func (m *Token) Expired() bool {
+	if m.ExpiresAt == nil {
+		return false
+	}
+
+	return m.ExpiresAt.Before(time.Now())
+}
+
+// SetScopes grants the specified scopes and revokes all others.
+func (m *Token) SetScopes(scopes acl.Scopes) {
+	m.CanIndex = scopes.Contains(acl.ScopeIndex)
+	m.CanImport = scopes.Contains(acl.ScopeImport)
+	m.CanUpload = scopes.Contains(acl.ScopeUpload)
+	m.CanDownload = scopes.Contains(acl.ScopeDownload)
+	m.CanSearch = scopes.Contains(acl.ScopeSearch)
+	m.CanShare = scopes.Contains(acl.ScopeShare)
+	m.CanEdit = scopes.Contains(acl.ScopeEdit)
+	m.CanComment = scopes.Contains(acl.ScopeComment)
+	m.CanDelete = scopes.Contains(acl.ScopeDelete)
+}
+
+// Scopes returns the scopes granted to the token.
+func (m *Token) Scopes() (result acl.Scopes) {
+	granted := map[acl.Scope]bool{
+		acl.ScopeIndex:    m.CanIndex,
+		acl.ScopeImport:   m.CanImport,
+		acl.ScopeUpload:   m.CanUpload,
+		acl.ScopeDownload: m.CanDownload,
+		acl.ScopeSearch:   m.CanSearch,
+		acl.ScopeShare:    m.CanShare,
+		acl.ScopeEdit:     m.CanEdit,
+		acl.ScopeComment:  m.CanComment,
+		acl.ScopeDelete:   m.CanDelete,
+	}
+
+	for _, scope := range acl.AllScopes {
+		if granted[scope] {
+			result = append(result, scope)
+		}
+	}
+
+	return result
+}
+
+// Allow checks if the token permits the action on the resource.
+func (m *Token) Allow(resource acl.Resource, action acl.Action) bool {
+	if m.Expired() {
+		return false
+	}
+
+	return m.Scopes().Allow(resource, action)
 }
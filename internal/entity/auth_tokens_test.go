@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+func TestNewAccessToken(t *testing.T) {
+	m, secret := NewAccessToken("uqxetse3cy5eo9z2", "Sync App", acl.Scopes{acl.ScopeSearch, acl.ScopeUpload}, 0)
+
+	assert.True(t, IsAccessToken(secret))
+	assert.Equal(t, TokenTypeAccess, m.TokenType)
+	assert.Equal(t, "Sync App", m.TokenName)
+	assert.NotContains(t, m.Access, secret)
+	assert.True(t, m.CanSearch)
+	assert.True(t, m.CanUpload)
+	assert.False(t, m.CanDelete)
+	assert.Nil(t, m.ExpiresAt)
+	assert.Equal(t, acl.Scopes{acl.ScopeUpload, acl.ScopeSearch}, m.Scopes())
+}
+
+func TestFindAccessToken(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m, secret := NewAccessToken(Admin.UserUID, "Script", acl.Scopes{acl.ScopeSearch}, time.Hour)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindAccessToken(secret)
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, m.TokenUID, result.TokenUID)
+		assert.Equal(t, Admin.UserUID, result.User().UserUID)
+		assert.True(t, result.Allow(acl.ResourcePhotos, acl.ActionSearch))
+		assert.False(t, result.Allow(acl.ResourcePhotos, acl.ActionDelete))
+		assert.NotEmpty(t, FindUserTokens(Admin.UserUID))
+
+		if err := result.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindAccessToken(secret))
+	})
+	t.Run("Expired", func(t *testing.T) {
+		m, secret := NewAccessToken(Admin.UserUID, "Expired", acl.Scopes{acl.ScopeSearch}, time.Hour)
+		*m.ExpiresAt = time.Now().Add(-time.Minute)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Expired())
+		assert.Nil(t, FindAccessToken(secret))
+	})
+	t.Run("InvalidSecret", func(t *testing.T) {
+		m, secret := NewAccessToken(Admin.UserUID, "Invalid", nil, 0)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		invalid := m.TokenUID + "-" + strings.Repeat("x", TokenSecretLength)
+
+		assert.NotEqual(t, secret, invalid)
+		assert.Nil(t, FindAccessToken(invalid))
+		assert.Nil(t, FindAccessToken("foo"))
+	})
+}
+
+func TestDeleteUserTokens(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		userUID := UserFixtures.Get("friend").UserUID
+
+		for _, name := range []string{"Phone", "Laptop"} {
+			m, _ := NewAccessToken(userUID, name, acl.Scopes{acl.ScopeSearch}, 0)
+
+			if err := m.Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		n, err := DeleteUserTokens(userUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Empty(t, FindUserTokens(userUID))
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		n, err := DeleteUserTokens("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
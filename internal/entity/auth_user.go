@@ -421,6 +421,11 @@ func (m *User) InvalidPassword(password string) bool {
 		return true
 	}
 
+	// Failed attempts are reset after the second factor has been verified.
+	if m.RequiresPasscode() {
+		return false
+	}
+
 	if err := Db().Model(m).Updates(map[string]interface{}{"login_attempts": 0, "login_at": TimeStamp()}).Error; err != nil {
 		log.Errorf("user: %s (update last login)", err)
 	}
@@ -61,6 +61,20 @@ var UserFixtures = UserMap{
 		CanLogin:    true,
 		CanInvite:   false,
 	},
+	"confined": {
+		ID:          9,
+		UserUID:     "uqxetse3cy5eo9z9",
+		UserSlug:    "confined",
+		Username:    "confined",
+		Email:       "confined@example.com",
+		UserRole:    acl.RoleAdmin.String(),
+		SuperAdmin:  false,
+		DisplayName: "Confined User",
+		CanLogin:    true,
+		CanInvite:   false,
+		FileRoot:    RootOriginals,
+		FilePath:    "2016",
+	},
 	"deleted": {
 		ID:          10000008,
 		UserUID:     "uqxqg7i1kperxvu8",
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// UserFolder returns the originals subfolder the user is confined to, or an empty string if the user
+// has access to all originals. An empty string is also returned if the stored folder is invalid,
+// so callers must check Confined first.
+func (m *User) UserFolder() string {
+	if m.FilePath == "" {
+		return ""
+	}
+
+	folder, err := cleanUserFolder(m.FilePath)
+
+	if err != nil {
+		return ""
+	}
+
+	return folder
+}
+
+// Confined checks if the user is confined to an originals subfolder. Users with an invalid folder
+// are confined as well, so that access is denied rather than granted to all originals.
+func (m *User) Confined() bool {
+	return m.FilePath != ""
+}
+
+// SetUserFolder changes the originals subfolder the user is confined to, an empty string removes the restriction.
+// An error is returned if the folder is invalid, e.g. because it is outside the originals folder.
+func (m *User) SetUserFolder(folder string) error {
+	if folder = strings.TrimSpace(folder); folder == "" {
+		m.FileRoot = ""
+		m.FilePath = ""
+	} else if p, err := cleanUserFolder(folder); err != nil {
+		return err
+	} else {
+		m.FileRoot = RootOriginals
+		m.FilePath = p
+	}
+
+	if m.ID < 1 {
+		return nil
+	}
+
+	return m.Updates(map[string]interface{}{"FileRoot": m.FileRoot, "FilePath": m.FilePath})
+}
+
+// UserPath returns the originals path name for a path name relative to the user folder.
+func (m *User) UserPath(rel string) string {
+	rel = strings.Trim(path.Clean("/"+rel), "/")
+
+	return path.Join(m.UserFolder(), rel)
+}
+
+// cleanUserFolder returns the normalized user folder, or an error if it is empty or outside the originals folder.
+func cleanUserFolder(folder string) (string, error) {
+	p := clean.Path(folder)
+
+	if p == "" {
+		return "", fmt.Errorf("invalid user folder %s", clean.Log(folder))
+	}
+
+	if p = strings.Trim(path.Clean("/"+p), "/"); p == "" || p == "." {
+		return "", fmt.Errorf("invalid user folder %s", clean.Log(folder))
+	}
+
+	return p, nil
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_UserFolder(t *testing.T) {
+	assert.Equal(t, "", (&User{}).UserFolder())
+	assert.Equal(t, "alice", (&User{FilePath: "/alice/"}).UserFolder())
+	assert.Equal(t, "family/bob", (&User{FilePath: "family/bob/"}).UserFolder())
+	assert.Equal(t, "", (&User{FilePath: "../etc"}).UserFolder())
+	assert.Equal(t, "", (&User{FilePath: "/"}).UserFolder())
+}
+
+func TestUser_Confined(t *testing.T) {
+	assert.False(t, (&User{}).Confined())
+	assert.True(t, (&User{FilePath: "alice"}).Confined())
+
+	// Invalid folders must not grant access to all originals.
+	assert.True(t, (&User{FilePath: "../etc"}).Confined())
+	assert.True(t, (&User{FilePath: "/"}).Confined())
+}
+
+func TestUser_UserPath(t *testing.T) {
+	assert.Equal(t, "2020/Holiday", (&User{}).UserPath("/2020/Holiday/"))
+	assert.Equal(t, "", (&User{}).UserPath(""))
+	assert.Equal(t, "alice", (&User{FilePath: "alice"}).UserPath("/"))
+	assert.Equal(t, "alice/2020", (&User{FilePath: "alice"}).UserPath("2020"))
+	assert.Equal(t, "alice/bob", (&User{FilePath: "alice"}).UserPath("../../bob"))
+}
+
+func TestUser_SetUserFolder(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := User{}
+
+		assert.NoError(t, m.SetUserFolder("/alice/"))
+		assert.Equal(t, RootOriginals, m.FileRoot)
+		assert.Equal(t, "alice", m.FilePath)
+		assert.NoError(t, m.SetUserFolder(""))
+		assert.Equal(t, "", m.FileRoot)
+		assert.Equal(t, "", m.FilePath)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		m := User{FileRoot: RootOriginals, FilePath: "alice"}
+
+		assert.Error(t, m.SetUserFolder("../x"))
+		assert.Error(t, m.SetUserFolder("/"))
+		assert.Error(t, m.SetUserFolder("alice/../../etc"))
+
+		// The existing restriction must remain unchanged.
+		assert.Equal(t, RootOriginals, m.FileRoot)
+		assert.Equal(t, "alice", m.FilePath)
+		assert.True(t, m.Confined())
+	})
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// AuthSrcOIDC is the authentication source of users who log in with OpenID Connect.
+const AuthSrcOIDC = "oidc"
+
+// FindUserByAuth returns the user linked to an external account, or nil if not found.
+func FindUserByAuth(src, uid string) *User {
+	if src == "" || uid == "" {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Where("auth_src = ? AND auth_uid = ?", src, uid).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Debugf("user with %s account %s not found", clean.Log(src), clean.LogQuote(uid))
+		return nil
+	}
+}
+
+// LinkAuth links the user to an external account.
+func (m *User) LinkAuth(src, uid string) error {
+	if m.ID <= 0 {
+		return fmt.Errorf("cannot link unknown user")
+	}
+
+	m.AuthSrc = src
+	m.AuthUID = uid
+
+	return m.Updates(map[string]interface{}{"AuthSrc": src, "AuthUID": uid})
+}
+
+// UniqueUsername returns a username based on the specified name that is not used by other users.
+func UniqueUsername(name string) string {
+	name = clean.Login(name)
+
+	if len(name) < UsernameLength {
+		name = "user"
+	}
+
+	result := name
+
+	for i := 2; i < 1000; i++ {
+		if FindUserByLogin(result) == nil {
+			return result
+		}
+
+		result = fmt.Sprintf("%s%d", name, i)
+	}
+
+	return ""
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUserByAuth(t *testing.T) {
+	t.Run("Linked", func(t *testing.T) {
+		m := FindUserByLogin("bob")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		if err := m.LinkAuth(AuthSrcOIDC, "https://accounts.example.com#bob"); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindUserByAuth(AuthSrcOIDC, "https://accounts.example.com#bob")
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, m.UserUID, result.UserUID)
+
+		if err := m.LinkAuth("", ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindUserByAuth(AuthSrcOIDC, "https://accounts.example.com#bob"))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FindUserByAuth("", ""))
+		assert.Nil(t, FindUserByAuth(AuthSrcOIDC, ""))
+	})
+	t.Run("UnknownUser", func(t *testing.T) {
+		m := User{}
+		assert.Error(t, m.LinkAuth(AuthSrcOIDC, "foo"))
+	})
+}
+
+func TestUniqueUsername(t *testing.T) {
+	assert.Equal(t, "jens.mander", UniqueUsername("Jens.Mander"))
+	assert.Equal(t, "alice2", UniqueUsername("alice"))
+	assert.Equal(t, "user", UniqueUsername("x"))
+}
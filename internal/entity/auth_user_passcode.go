@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+// RecoveryCodes is the number of recovery codes generated when two-factor authentication is activated.
+var RecoveryCodes = 10
+
+// Passcode represents the shared secret for time-based one-time passwords used as second login factor.
+type Passcode struct {
+	UID           string     `gorm:"type:VARBINARY(42);primary_key;" json:"UID"`
+	Secret        string     `gorm:"type:VARBINARY(255);" json:"-"`
+	RecoveryCodes string     `gorm:"type:VARBINARY(1024);" json:"-"`
+	LastCounter   int64      `json:"-"`
+	VerifiedAt    *time.Time `json:"VerifiedAt"`
+	CreatedAt     time.Time  `json:"CreatedAt"`
+	UpdatedAt     time.Time  `json:"UpdatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (Passcode) TableName() string {
+	return "passcodes"
+}
+
+// NewPasscode creates a new, not yet verified passcode with a random secret.
+func NewPasscode(uid string) *Passcode {
+	return &Passcode{UID: uid, Secret: totp.GenerateSecret()}
+}
+
+// FindPasscode returns the passcode of a user, or nil if none exists.
+func FindPasscode(uid string) *Passcode {
+	if uid == "" {
+		return nil
+	}
+
+	result := Passcode{}
+
+	if err := Db().Where("uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Save inserts a new row to the database or updates a row if the primary key already exists.
+func (m *Passcode) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the passcode, which disables two-factor authentication.
+func (m *Passcode) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// Verified checks if the passcode was confirmed and is required for login.
+func (m *Passcode) Verified() bool {
+	return m.VerifiedAt != nil
+}
+
+// URI returns the provisioning URI for authenticator apps.
+func (m *Passcode) URI(issuer, account string) string {
+	return totp.URI(issuer, account, m.Secret)
+}
+
+// Activate verifies the first code from the authenticator app and returns new recovery codes.
+func (m *Passcode) Activate(code string) ([]string, error) {
+	if !m.validCode(code) {
+		return nil, errors.New("invalid passcode")
+	}
+
+	m.VerifiedAt = TimePointer()
+	codes := m.newRecoveryCodes()
+
+	if err := m.Save(); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Valid checks a one-time password or recovery code, recovery codes can only be used once.
+func (m *Passcode) Valid(code string) bool {
+	if m.validCode(code) {
+		return Db().Model(m).UpdateColumn("last_counter", m.LastCounter).Error == nil
+	}
+
+	hash := recoveryHash(code)
+	codes := strings.Fields(m.RecoveryCodes)
+
+	for i, h := range codes {
+		if h != hash {
+			continue
+		}
+
+		m.RecoveryCodes = strings.Join(append(codes[:i], codes[i+1:]...), " ")
+
+		log.Infof("auth: recovery code used for %s, %d left", m.UID, len(codes)-1)
+
+		return Db().Model(m).UpdateColumn("recovery_codes", m.RecoveryCodes).Error == nil
+	}
+
+	return false
+}
+
+// validCode checks a one-time password and remembers it so that it cannot be used again.
+func (m *Passcode) validCode(code string) bool {
+	counter, ok := totp.Validate(m.Secret, code, time.Now(), m.LastCounter)
+
+	if ok {
+		m.LastCounter = counter
+	}
+
+	return ok
+}
+
+// newRecoveryCodes replaces the recovery codes and returns them in plain text.
+func (m *Passcode) newRecoveryCodes() []string {
+	codes := make([]string, RecoveryCodes)
+	hashes := make([]string, RecoveryCodes)
+
+	for i := range codes {
+		s := rnd.GenerateSecret(10)
+		codes[i] = s[:5] + "-" + s[5:]
+		hashes[i] = recoveryHash(codes[i])
+	}
+
+	m.RecoveryCodes = strings.Join(hashes, " ")
+
+	return codes
+}
+
+// recoveryHash returns the hash of a recovery code, ignoring case, spaces, and dashes.
+func recoveryHash(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+
+	if code == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// RequiresPasscode checks if the user has activated two-factor authentication.
+func (m *User) RequiresPasscode() bool {
+	if !m.IsRegistered() {
+		return false
+	}
+
+	p := FindPasscode(m.UserUID)
+
+	return p != nil && p.Verified()
+}
+
+// InvalidPasscode returns true if the one-time password or recovery code is not valid for the user.
+func (m *User) InvalidPasscode(code string) bool {
+	p := FindPasscode(m.UserUID)
+
+	if p == nil || !p.Verified() || !p.Valid(code) {
+		if err := Db().Model(m).UpdateColumn("login_attempts", gorm.Expr("login_attempts + ?", 1)).Error; err != nil {
+			log.Errorf("user: %s (update login attempts)", err)
+		}
+
+		return true
+	}
+
+	if err := Db().Model(m).Updates(map[string]interface{}{"login_attempts": 0, "login_at": TimeStamp()}).Error; err != nil {
+		log.Errorf("user: %s (update last login)", err)
+	}
+
+	return false
+}
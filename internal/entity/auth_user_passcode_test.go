@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+func TestPasscode_Activate(t *testing.T) {
+	m := NewPasscode("uqxqg7i1kperxvu9")
+
+	assert.False(t, m.Verified())
+	assert.Contains(t, m.URI("PhotoPrism", "friend"), "secret="+m.Secret)
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		codes, err := m.Activate("000000")
+		assert.Error(t, err)
+		assert.Nil(t, codes)
+		assert.False(t, m.Verified())
+	})
+	t.Run("Success", func(t *testing.T) {
+		code, err := totp.Code(m.Secret, totp.Counter(time.Now()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		codes, err := m.Activate(code)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, codes, RecoveryCodes)
+		assert.True(t, m.Verified())
+
+		found := FindPasscode(m.UID)
+
+		if found == nil {
+			t.Fatal("passcode not found")
+		}
+
+		assert.True(t, found.Verified())
+		assert.False(t, found.Valid(code))
+		assert.True(t, found.Valid(codes[0]))
+		assert.False(t, found.Valid(codes[0]))
+		assert.True(t, found.Valid(codes[1]))
+	})
+
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindPasscode(m.UID))
+}
+
+func TestUser_InvalidPasscode(t *testing.T) {
+	m := UserFixtures.Pointer("friend")
+
+	assert.False(t, m.RequiresPasscode())
+	assert.True(t, m.InvalidPasscode("123456"))
+
+	p := NewPasscode(m.UserUID)
+	code, _ := totp.Code(p.Secret, totp.Counter(time.Now()))
+	codes, err := p.Activate(code)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer p.Delete()
+
+	assert.True(t, m.RequiresPasscode())
+	assert.True(t, m.InvalidPasscode(""))
+	assert.True(t, m.InvalidPasscode(code))
+	assert.False(t, m.InvalidPasscode(codes[0]))
+	assert.False(t, (&User{}).RequiresPasscode())
+}
@@ -358,3 +358,32 @@ func TestDetails_SetSoftware(t *testing.T) {
 		assert.Equal(t, "new", description.Software)
 	})
 }
+
+func TestDetails_SetText(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: ""}
+		assert.False(t, details.HasText())
+
+		details.SetText(" \n ", SrcOCR)
+		assert.False(t, details.HasText())
+	})
+	t.Run("Whitespace", func(t *testing.T) {
+		details := &Details{PhotoID: 123}
+
+		details.SetText("Invoice No. 42\n\n  Total:\t12.50 EUR\n", SrcOCR)
+		assert.Equal(t, "Invoice No. 42 Total: 12.50 EUR", details.Text)
+		assert.Equal(t, SrcOCR, details.TextSrc)
+	})
+	t.Run("NoPriority", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: "old", TextSrc: SrcManual}
+
+		details.SetText("new", SrcOCR)
+		assert.Equal(t, "old", details.Text)
+	})
+	t.Run("NewValue", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: "old", TextSrc: SrcOCR}
+
+		details.SetText("new", SrcOCR)
+		assert.Equal(t, "new", details.Text)
+	})
+}
@@ -16,9 +16,12 @@ type Tables map[string]interface{}
 var Entities = Tables{
 	migrate.Migration{}.TableName(): &migrate.Migration{},
 	Error{}.TableName():             &Error{},
+	AuditLog{}.TableName():          &AuditLog{},
 	Password{}.TableName():          &Password{},
+	Passcode{}.TableName():          &Passcode{},
 	User{}.TableName():              &User{},
 	Token{}.TableName():             &Token{},
+	Session{}.TableName():           &Session{},
 	Account{}.TableName():           &Account{},
 	Folder{}.TableName():            &Folder{},
 	Duplicate{}.TableName():         &Duplicate{},
@@ -37,12 +40,18 @@ var Entities = Tables{
 	Label{}.TableName():             &Label{},
 	Category{}.TableName():          &Category{},
 	PhotoLabel{}.TableName():        &PhotoLabel{},
+	PhotoEmbedding{}.TableName():    &PhotoEmbedding{},
 	Keyword{}.TableName():           &Keyword{},
 	PhotoKeyword{}.TableName():      &PhotoKeyword{},
 	Link{}.TableName():              &Link{},
 	Subject{}.TableName():           &Subject{},
 	Face{}.TableName():              &Face{},
 	Marker{}.TableName():            &Marker{},
+	Track{}.TableName():             &Track{},
+	TrackPoint{}.TableName():        &TrackPoint{},
+	Trip{}.TableName():              &Trip{},
+	Visit{}.TableName():             &Visit{},
+	PhotoVisit{}.TableName():        &PhotoVisit{},
 }
 
 // WaitForMigration waits for the database migration to be successful.
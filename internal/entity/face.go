@@ -55,6 +55,44 @@ func NewFace(subjUID, faceSrc string, embeddings face.Embeddings) *Face {
 	return result
 }
 
+// NewPetFace returns a new pet face.
+func NewPetFace(subjUID, faceSrc string, embeddings face.Embeddings) *Face {
+	result := &Face{
+		SubjUID:  subjUID,
+		FaceSrc:  faceSrc,
+		FaceKind: int(face.PetFace),
+	}
+
+	if err := result.SetEmbeddings(embeddings); err != nil {
+		log.Errorf("face: failed setting embeddings (%s)", err)
+	}
+
+	return result
+}
+
+// IsPet tests if this is the face of a pet rather than a person.
+func (m *Face) IsPet() bool {
+	return m.FaceKind == int(face.PetFace)
+}
+
+// MarkerType returns the type of markers that can match this face.
+func (m *Face) MarkerType() string {
+	if m.IsPet() {
+		return MarkerPet
+	}
+
+	return MarkerFace
+}
+
+// MatchDist returns the distance offset for matching embeddings with this face.
+func (m *Face) MatchDist() float64 {
+	if m.IsPet() {
+		return face.PetMatchDist
+	}
+
+	return face.MatchDist
+}
+
 // MatchId returns a compound id for matching.
 func (m *Face) MatchId(f Face) string {
 	if m.ID == "" || f.ID == "" {
@@ -70,6 +108,10 @@ func (m *Face) MatchId(f Face) string {
 
 // SkipMatching checks whether the face should be skipped when matching.
 func (m *Face) SkipMatching() bool {
+	if m.IsPet() {
+		return false
+	}
+
 	return m.Embedding().SkipMatching()
 }
 
@@ -81,7 +123,8 @@ func (m *Face) SetEmbeddings(embeddings face.Embeddings) (err error) {
 
 	m.embedding, m.SampleRadius, m.Samples = face.EmbeddingsMidpoint(embeddings)
 
-	if len(m.embedding) != len(face.NullEmbedding) {
+	// Pet face embeddings may have a different number of values, depending on the model.
+	if len(m.embedding) == 0 || !m.IsPet() && len(m.embedding) != len(face.NullEmbedding) {
 		return fmt.Errorf("invalid number of values")
 	}
 
@@ -100,9 +143,12 @@ func (m *Face) SetEmbeddings(embeddings face.Embeddings) (err error) {
 
 	// Update Face ID, Kind, and reset match timestamp,
 	m.ID = base32.StdEncoding.EncodeToString(s[:])
-	m.FaceKind = int(m.embedding.Kind())
 	m.MatchedAt = nil
 
+	if !m.IsPet() {
+		m.FaceKind = int(m.embedding.Kind())
+	}
+
 	return nil
 }
 
@@ -153,7 +199,7 @@ func (m *Face) Match(embeddings face.Embeddings) (match bool, dist float64) {
 	case dist < 0:
 		// Should never happen.
 		return false, dist
-	case dist > (m.SampleRadius + face.MatchDist):
+	case dist > (m.SampleRadius + m.MatchDist()):
 		// Too far.
 		return false, dist
 	case m.CollisionRadius > 0.1 && dist > m.CollisionRadius:
@@ -219,7 +265,7 @@ func (m *Face) ReviseMatches() (revised Markers, err error) {
 
 	var matches Markers
 
-	if err := Db().Where("face_id = ?", m.ID).Where("marker_type = ?", MarkerFace).
+	if err := Db().Where("face_id = ?", m.ID).Where("marker_type = ?", m.MarkerType()).
 		Find(&matches).Error; err != nil {
 		log.Debugf("faces: found no matching markers for conflict resolution (%s)", err)
 		return revised, err
@@ -244,7 +290,7 @@ func (m *Face) MatchMarkers(faceIds []string) error {
 	var markers Markers
 
 	err := Db().
-		Where("marker_invalid = 0 AND marker_type = ? AND face_id IN (?)", MarkerFace, faceIds).
+		Where("marker_invalid = 0 AND marker_type = ? AND face_id IN (?)", m.MarkerType(), faceIds).
 		Find(&markers).Error
 
 	if err != nil {
@@ -141,6 +141,26 @@ func TestNewFace(t *testing.T) {
 	})
 }
 
+func TestNewPetFace(t *testing.T) {
+	embeddings := face.Embeddings{face.Embedding{0.1, 0.2, 0.3, 0.4}, face.Embedding{0.1, 0.2, 0.3, 0.5}}
+
+	m := NewPetFace("", SrcAuto, embeddings)
+
+	assert.True(t, m.IsPet())
+	assert.Equal(t, MarkerPet, m.MarkerType())
+	assert.Equal(t, face.PetMatchDist, m.MatchDist())
+	assert.False(t, m.SkipMatching())
+	assert.Equal(t, 2, m.Samples)
+	assert.Len(t, m.Embedding(), 4)
+	assert.NotEmpty(t, m.ID)
+
+	person := NewFace("", SrcAuto, face.RandomEmbeddings(2, face.RegularFace))
+
+	assert.False(t, person.IsPet())
+	assert.Equal(t, MarkerFace, person.MarkerType())
+	assert.Equal(t, face.MatchDist, person.MatchDist())
+}
+
 func TestFace_MatchId(t *testing.T) {
 	t.Run("A123-B456", func(t *testing.T) {
 		f1 := Face{ID: "A123"}
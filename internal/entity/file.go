@@ -15,6 +15,7 @@ import (
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/inference"
 
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/colors"
@@ -83,6 +84,7 @@ type File struct {
 	FileLuminance    string        `gorm:"type:VARBINARY(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff         int           `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma       int16         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePHash        string        `gorm:"column:file_phash;type:VARBINARY(16);index;" json:"PHash" yaml:"PHash,omitempty"`
 	FileSoftware     string        `gorm:"type:VARCHAR(64)" json:"Software" yaml:"Software,omitempty"`
 	FileError        string        `gorm:"type:VARBINARY(512)" json:"Error" yaml:"Error,omitempty"`
 	ModTime          int64         `json:"ModTime" yaml:"-"`
@@ -747,6 +749,45 @@ func (m *File) AddFace(f face.Face, subjUID string) {
 	}
 }
 
+// AddObjects adds object markers to the file.
+func (m *File) AddObjects(objects inference.Objects) {
+	for _, obj := range objects {
+		m.AddObject(obj)
+	}
+}
+
+// AddObject adds an object marker to the file.
+func (m *File) AddObject(obj inference.Object) {
+	marker := NewObjectMarker(obj, *m)
+
+	// Ignored or failed creating new marker?
+	if marker == nil {
+		return
+	}
+
+	// Append marker if it doesn't conflict with existing marker.
+	if markers := m.Markers(); !markers.Contains(*marker) {
+		markers.Append(*marker)
+	}
+}
+
+// AddPets adds pet face markers to the file.
+func (m *File) AddPets(pets inference.Objects) {
+	for _, pet := range pets {
+		marker := NewPetMarker(pet, *m)
+
+		// Ignored or failed creating new marker?
+		if marker == nil {
+			continue
+		}
+
+		// Append marker if it doesn't conflict with existing marker.
+		if markers := m.Markers(); !markers.Contains(*marker) {
+			markers.Append(*marker)
+		}
+	}
+}
+
 // ValidFaceCount returns the number of valid face markers.
 func (m *File) ValidFaceCount() (c int) {
 	return ValidFaceCount(m.FileUID)
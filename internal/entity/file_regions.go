@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// RegionMarkerType returns the marker type for a metadata region, or an empty string if it is not supported.
+func RegionMarkerType(regionType string) string {
+	switch regionType {
+	case meta.RegionFace, "":
+		return MarkerFace
+	case meta.RegionPet:
+		return MarkerPet
+	default:
+		return ""
+	}
+}
+
+// AddRegions adds named face and pet regions from metadata, e.g. as tagged in Lightroom or digiKam.
+// Detected markers at the same position are named instead, so that they can be used as known samples
+// for face recognition.
+func (m *File) AddRegions(regions meta.Regions, src string) (count int) {
+	markers := m.Markers()
+
+	for _, r := range regions {
+		markerType := RegionMarkerType(r.Type)
+		name := clean.Name(r.Name)
+
+		// Skip unsupported and unnamed regions.
+		if markerType == "" || name == "" {
+			continue
+		}
+
+		area := crop.NewArea(markerType, r.X, r.Y, r.W, r.H)
+		marker := NewMarker(*m, area, "", src, markerType, objectSize(*m, area), 100)
+
+		// Failed creating new marker?
+		if marker == nil {
+			continue
+		} else if err := marker.InvalidArea(); err != nil {
+			log.Debugf("markers: %s in %s (%s)", err, clean.Log(m.FileName), SrcString(src))
+			continue
+		}
+
+		marker.MarkerName = name
+		marker.SubjSrc = src
+
+		// Name existing marker at the same position, or add a new one.
+		if i := markers.Overlapping(*marker); i < 0 {
+			markers.Append(*marker)
+			count++
+		} else if (*markers)[i].SetMetaName(name, src) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// AddPeople names the only face found in the file if the metadata contains exactly one person,
+// as for example Google Photos exports the names of people without their face regions.
+func (m *File) AddPeople(names []string, src string) (count int) {
+	if len(names) != 1 {
+		return 0
+	}
+
+	markers := m.Markers()
+	found := -1
+
+	for i := range *markers {
+		if (*markers)[i].MarkerType != MarkerFace || (*markers)[i].MarkerInvalid {
+			continue
+		} else if found >= 0 {
+			// More than one face.
+			return 0
+		}
+
+		found = i
+	}
+
+	if found < 0 {
+		return 0
+	} else if (*markers)[found].SetMetaName(names[0], src) {
+		return 1
+	}
+
+	return 0
+}
+
+// SetMetaName sets a subject name from metadata unless the marker was named from a source with a higher
+// priority. Subjects and faces are created by the face recognition worker.
+func (m *Marker) SetMetaName(name, src string) (changed bool) {
+	if src == SrcAuto || SrcPriority[src] < SrcPriority[m.SubjSrc] {
+		return false
+	}
+
+	name = clean.Name(name)
+
+	if name == "" || (m.MarkerName == name && m.SubjSrc == src) {
+		return false
+	}
+
+	m.MarkerName = name
+	m.SubjSrc = src
+	m.SubjUID = ""
+	m.MarkerReview = false
+
+	// Unsaved markers are created with the file.
+	if m.Unsaved() {
+		return true
+	}
+
+	if err := m.Updates(Values{"MarkerName": m.MarkerName, "SubjSrc": m.SubjSrc, "SubjUID": m.SubjUID, "MarkerReview": m.MarkerReview}); err != nil {
+		log.Errorf("markers: %s (set name)", err)
+		return false
+	}
+
+	return true
+}
+
+// Overlapping returns the index of a marker of the same type at the same position, or -1 if there is none.
+func (m Markers) Overlapping(other Marker) int {
+	for i := range m {
+		if m[i].MarkerType != other.MarkerType || m[i].MarkerInvalid {
+			continue
+		} else if m[i].OverlapPercent(other) > face.OverlapThreshold {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+func TestRegionMarkerType(t *testing.T) {
+	assert.Equal(t, MarkerFace, RegionMarkerType(meta.RegionFace))
+	assert.Equal(t, MarkerFace, RegionMarkerType(""))
+	assert.Equal(t, MarkerPet, RegionMarkerType(meta.RegionPet))
+	assert.Equal(t, "", RegionMarkerType("Focus"))
+}
+
+func TestFile_AddRegions(t *testing.T) {
+	t.Run("NewMarkers", func(t *testing.T) {
+		file := &File{FileHash: "243cdbe99b865607f98a951e748d528bc22f3143", FileWidth: 1000, FileHeight: 800}
+
+		regions := meta.Regions{
+			{Name: "Jane Doe", Type: meta.RegionFace, X: 0.1, Y: 0.1, W: 0.2, H: 0.2},
+			{Name: "Bello", Type: meta.RegionPet, X: 0.5, Y: 0.5, W: 0.2, H: 0.2},
+			{Name: "", Type: meta.RegionFace, X: 0.7, Y: 0.1, W: 0.2, H: 0.2},
+			{Name: "Focus", Type: "Focus", X: 0.7, Y: 0.1, W: 0.2, H: 0.2},
+			{Name: "Invalid", Type: meta.RegionFace, X: 0.9, Y: 0.9, W: 0, H: 0},
+		}
+
+		// Unnamed, unsupported, and invalid regions are skipped.
+		assert.Equal(t, 2, file.AddRegions(regions, SrcXmp))
+
+		markers := *file.Markers()
+
+		if len(markers) != 2 {
+			t.Fatalf("expected 2 markers, found %d", len(markers))
+		}
+
+		assert.Equal(t, "Jane Doe", markers[0].MarkerName)
+		assert.Equal(t, MarkerFace, markers[0].MarkerType)
+		assert.Equal(t, SrcXmp, markers[0].MarkerSrc)
+		assert.Equal(t, SrcXmp, markers[0].SubjSrc)
+		assert.Equal(t, 200, markers[0].Size)
+		assert.Equal(t, MarkerPet, markers[1].MarkerType)
+		assert.Equal(t, "Bello", markers[1].MarkerName)
+	})
+	t.Run("NameDetectedFace", func(t *testing.T) {
+		file := &File{FileHash: "243cdbe99b865607f98a951e748d528bc22f3143", FileWidth: 1000, FileHeight: 800}
+
+		detected := *NewMarker(*file, cropArea1, "", SrcImage, MarkerFace, 100, 65)
+		detected.SetEmbeddings(face.RandomEmbeddings(1, face.RegularFace))
+
+		file.Markers().Append(detected)
+
+		regions := meta.Regions{{Name: "Jane Doe", Type: meta.RegionFace, X: detected.X, Y: detected.Y, W: detected.W, H: detected.H}}
+
+		assert.Equal(t, 1, file.AddRegions(regions, SrcMeta))
+
+		markers := *file.Markers()
+
+		assert.Len(t, markers, 1)
+		assert.Equal(t, "Jane Doe", markers[0].MarkerName)
+		assert.Equal(t, SrcImage, markers[0].MarkerSrc)
+		assert.Equal(t, SrcMeta, markers[0].SubjSrc)
+		assert.True(t, markers[0].Embeddings().One())
+
+		// Names from sources with a higher priority are kept.
+		assert.Equal(t, 0, file.AddRegions(meta.Regions{{Name: "John Doe", X: detected.X, Y: detected.Y, W: detected.W, H: detected.H}}, SrcAuto))
+		assert.Equal(t, "Jane Doe", (*file.Markers())[0].MarkerName)
+	})
+}
+
+func TestFile_AddPeople(t *testing.T) {
+	t.Run("OneFace", func(t *testing.T) {
+		file := &File{FileHash: "243cdbe99b865607f98a951e748d528bc22f3143", FileWidth: 1000, FileHeight: 800}
+
+		file.Markers().Append(*NewMarker(*file, cropArea1, "", SrcImage, MarkerFace, 100, 65))
+
+		assert.Equal(t, 0, file.AddPeople([]string{"Jane Doe", "John Doe"}, SrcMeta))
+		assert.Equal(t, 1, file.AddPeople([]string{"Jane Doe"}, SrcMeta))
+		assert.Equal(t, 0, file.AddPeople([]string{"Jane Doe"}, SrcMeta))
+		assert.Equal(t, "Jane Doe", (*file.Markers())[0].MarkerName)
+	})
+	t.Run("MultipleFaces", func(t *testing.T) {
+		file := &File{FileHash: "243cdbe99b865607f98a951e748d528bc22f3143", FileWidth: 1000, FileHeight: 800}
+
+		file.Markers().Append(*NewMarker(*file, cropArea1, "", SrcImage, MarkerFace, 100, 65))
+		file.Markers().Append(*NewMarker(*file, cropArea3, "", SrcImage, MarkerFace, 100, 65))
+
+		assert.Equal(t, 0, file.AddPeople([]string{"Jane Doe"}, SrcMeta))
+	})
+	t.Run("NoFaces", func(t *testing.T) {
+		file := &File{FileHash: "243cdbe99b865607f98a951e748d528bc22f3143", FileWidth: 1000, FileHeight: 800}
+
+		assert.Equal(t, 0, file.AddPeople([]string{"Jane Doe"}, SrcMeta))
+	})
+}
@@ -30,6 +30,7 @@ type Label struct {
 	LabelFavorite    bool       `json:"Favorite" yaml:"Favorite,omitempty"`
 	LabelDescription string     `gorm:"type:VARCHAR(2048);" json:"Description" yaml:"Description,omitempty"`
 	LabelNotes       string     `gorm:"type:VARCHAR(1024);" json:"Notes" yaml:"Notes,omitempty"`
+	ParentID         uint       `gorm:"index;default:0" json:"ParentID" yaml:"-"`
 	LabelCategories  []*Label   `gorm:"many2many:categories;association_jointable_foreignkey:category_id" json:"-" yaml:"-"`
 	PhotoCount       int        `gorm:"default:1" json:"PhotoCount" yaml:"-"`
 	Thumb            string     `gorm:"type:VARBINARY(128);index;default:''" json:"Thumb" yaml:"Thumb,omitempty"`
@@ -92,9 +93,10 @@ func (m *Label) Create() error {
 	return Db().Create(m).Error
 }
 
-// Delete removes the label from the database.
+// Delete removes the label from the database, nested labels are moved to its parent.
 func (m *Label) Delete() error {
 	Db().Where("label_id = ? OR category_id = ?", m.ID, m.ID).Delete(&Category{})
+	UnscopedDb().Model(&Label{}).Where("parent_id = ?", m.ID).UpdateColumn("parent_id", m.ParentID)
 	Db().Where("label_id = ?", m.ID).Delete(&PhotoLabel{})
 	return Db().Delete(m).Error
 }
@@ -0,0 +1,244 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LabelPathSeparator separates the names of nested labels, e.g. "Places|Europe|Germany|Berlin".
+const LabelPathSeparator = "|"
+
+// HasParent tests if the label is nested below another label.
+func (m *Label) HasParent() bool {
+	return m.ParentID > 0
+}
+
+// Parent returns the parent label or nil if it has none.
+func (m *Label) Parent() *Label {
+	if !m.HasParent() {
+		return nil
+	}
+
+	result := Label{}
+
+	if err := Db().Where("id = ?", m.ParentID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Children returns the labels directly nested below this label.
+func (m *Label) Children() (result Labels) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("parent_id = ?", m.ID).Order("label_name").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find children of %s)", err, clean.Log(m.LabelName))
+	}
+
+	return result
+}
+
+// DescendantIDs returns the ids of all labels nested below this label.
+func (m *Label) DescendantIDs() (result []uint) {
+	if m.ID == 0 {
+		return result
+	}
+
+	found := map[uint]bool{m.ID: true}
+	parents := []uint{m.ID}
+
+	for len(parents) > 0 {
+		var ids []uint
+
+		if err := Db().Model(&Label{}).Where("parent_id IN (?)", parents).Pluck("id", &ids).Error; err != nil {
+			log.Errorf("label: %s (find descendants of %s)", err, clean.Log(m.LabelName))
+			break
+		}
+
+		parents = parents[:0]
+
+		for _, id := range ids {
+			if found[id] {
+				continue
+			}
+
+			found[id] = true
+			parents = append(parents, id)
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// Ancestors returns the parent labels, starting with the root label.
+func (m *Label) Ancestors() (result Labels) {
+	found := map[uint]bool{m.ID: true}
+	l := m
+
+	for l.HasParent() && !found[l.ParentID] {
+		found[l.ParentID] = true
+
+		if l = l.Parent(); l == nil {
+			break
+		}
+
+		result = append(Labels{*l}, result...)
+	}
+
+	return result
+}
+
+// Path returns the names of all parent labels and the label name, separated by LabelPathSeparator.
+func (m *Label) Path() string {
+	var names []string
+
+	for _, l := range m.Ancestors() {
+		names = append(names, l.LabelName)
+	}
+
+	return strings.Join(append(names, m.LabelName), LabelPathSeparator)
+}
+
+// SetParent nests the label below the parent label, or makes it a root label if parent is nil.
+func (m *Label) SetParent(parent *Label) error {
+	var parentID uint
+
+	if parent != nil {
+		if parent.ID == 0 {
+			return fmt.Errorf("parent label must be saved first")
+		} else if parent.ID == m.ID {
+			return fmt.Errorf("label cannot be its own parent")
+		}
+
+		for _, id := range m.DescendantIDs() {
+			if id == parent.ID {
+				return fmt.Errorf("label %s is nested below %s", clean.Log(parent.LabelName), clean.Log(m.LabelName))
+			}
+		}
+
+		parentID = parent.ID
+	}
+
+	if m.ParentID == parentID {
+		return nil
+	}
+
+	m.ParentID = parentID
+
+	if m.ID == 0 {
+		return nil
+	}
+
+	return m.Update("ParentID", parentID)
+}
+
+// FirstOrCreateLabelPath returns the last label of a path such as "Places|Europe|Germany|Berlin",
+// existing labels are nested below their parent if they are not nested yet. Since label names are
+// unique, paths that would nest an existing label below a different parent are rejected.
+func FirstOrCreateLabelPath(path string, priority int) *Label {
+	var names []string
+
+	for _, name := range strings.Split(path, LabelPathSeparator) {
+		if name = clean.Name(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	var parent *Label
+
+	for i, name := range names {
+		l := FirstOrCreateLabel(NewLabel(name, priority))
+
+		if l == nil {
+			return nil
+		}
+
+		// Only the last label is assigned to photos, so new parent labels start without photos.
+		if l.New && i < len(names)-1 {
+			if err := l.Update("PhotoCount", 0); err != nil {
+				log.Errorf("label: %s (reset photo count of %s)", err, clean.Log(l.LabelName))
+			} else {
+				l.PhotoCount = 0
+			}
+		}
+
+		if parent == nil || l.ParentID == parent.ID {
+			parent = l
+			continue
+		} else if l.HasParent() {
+			log.Errorf("label: %s is already nested below another label, path %s rejected", clean.Log(l.LabelName), clean.Log(path))
+			return nil
+		} else if err := l.SetParent(parent); err != nil {
+			log.Errorf("label: %s, path %s rejected", err, clean.Log(path))
+			return nil
+		}
+
+		parent = l
+	}
+
+	return parent
+}
+
+// LabelNode represents a label with its nested labels.
+type LabelNode struct {
+	UID        string    `json:"UID"`
+	Slug       string    `json:"Slug"`
+	Name       string    `json:"Name"`
+	PhotoCount int       `json:"PhotoCount"`
+	Children   LabelTree `json:"Children,omitempty"`
+}
+
+// LabelTree represents nested labels.
+type LabelTree []LabelNode
+
+// NewLabelTree nests the labels below their parents, labels without a parent in the list become root nodes.
+func NewLabelTree(labels Labels) LabelTree {
+	ids := make(map[uint]bool, len(labels))
+	children := make(map[uint]Labels, len(labels))
+
+	for _, l := range labels {
+		ids[l.ID] = true
+	}
+
+	var roots Labels
+
+	for _, l := range labels {
+		if l.HasParent() && ids[l.ParentID] && l.ParentID != l.ID {
+			children[l.ParentID] = append(children[l.ParentID], l)
+		} else {
+			roots = append(roots, l)
+		}
+	}
+
+	visited := make(map[uint]bool, len(labels))
+
+	var nodes func(labels Labels) LabelTree
+
+	nodes = func(labels Labels) (result LabelTree) {
+		for _, l := range labels {
+			if visited[l.ID] {
+				continue
+			}
+
+			visited[l.ID] = true
+
+			result = append(result, LabelNode{
+				UID:        l.LabelUID,
+				Slug:       l.CustomSlug,
+				Name:       l.LabelName,
+				PhotoCount: l.PhotoCount,
+				Children:   nodes(children[l.ID]),
+			})
+		}
+
+		return result
+	}
+
+	return nodes(roots)
+}
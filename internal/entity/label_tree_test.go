@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstOrCreateLabelPath(t *testing.T) {
+	t.Run("Nested", func(t *testing.T) {
+		berlin := FirstOrCreateLabelPath("Places|Europe|Germany|Berlin", 0)
+
+		if berlin == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.Equal(t, "Berlin", berlin.LabelName)
+		assert.True(t, berlin.HasParent())
+		assert.Equal(t, "Places|Europe|Germany|Berlin", berlin.Path())
+
+		germany := berlin.Parent()
+
+		if germany == nil {
+			t.Fatal("parent should not be nil")
+		}
+
+		assert.Equal(t, "Germany", germany.LabelName)
+
+		children := germany.Children()
+
+		if assert.Len(t, children, 1) {
+			assert.Equal(t, berlin.ID, children[0].ID)
+		}
+
+		places := FindLabel("Places")
+
+		if places == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.False(t, places.HasParent())
+		assert.Len(t, places.DescendantIDs(), 3)
+		assert.Contains(t, places.DescendantIDs(), berlin.ID)
+		assert.Len(t, berlin.Ancestors(), 3)
+	})
+	t.Run("Existing", func(t *testing.T) {
+		hamburg := FirstOrCreateLabelPath("Places|Europe|Germany|Hamburg", 0)
+		berlin := FirstOrCreateLabelPath("Germany|Berlin", 0)
+
+		if hamburg == nil || berlin == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.Equal(t, hamburg.ParentID, berlin.ParentID)
+		assert.Equal(t, "Places|Europe|Germany|Berlin", berlin.Path())
+	})
+	t.Run("Collision", func(t *testing.T) {
+		paris := FirstOrCreateLabelPath("Places|Europe|Paris", 0)
+
+		if paris == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.Nil(t, FirstOrCreateLabelPath("Places|USA|Texas|Paris", 0))
+		assert.Nil(t, FirstOrCreateLabelPath("Cities|Berlin", 0))
+		assert.Equal(t, "Places|Europe|Paris", FindLabel("Paris").Path())
+		assert.Equal(t, "Places|Europe|Germany|Berlin", FindLabel("Berlin").Path())
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		child := FirstOrCreateLabelPath("CycleParent|CycleChild", 0)
+
+		if child == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.Nil(t, FirstOrCreateLabelPath("CycleChild|CycleParent", 0))
+		assert.False(t, FindLabel("CycleParent").HasParent())
+	})
+	t.Run("PhotoCount", func(t *testing.T) {
+		leaf := FirstOrCreateLabelPath("PhotoCountRoot|PhotoCountLeaf", 0)
+
+		if leaf == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.Equal(t, 1, leaf.PhotoCount)
+		assert.Equal(t, 0, FindLabel("PhotoCountRoot").PhotoCount)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FirstOrCreateLabelPath(" | ", 0))
+	})
+}
+
+func TestLabel_SetParent(t *testing.T) {
+	t.Run("Cycle", func(t *testing.T) {
+		child := FirstOrCreateLabelPath("SetParentRoot|SetParentChild", 0)
+
+		if child == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		root := child.Parent()
+
+		if root == nil {
+			t.Fatal("parent should not be nil")
+		}
+
+		assert.Error(t, root.SetParent(child))
+		assert.Error(t, root.SetParent(root))
+		assert.False(t, root.HasParent())
+	})
+	t.Run("Root", func(t *testing.T) {
+		child := FirstOrCreateLabelPath("SetParentRoot|SetParentRootChild", 0)
+
+		if child == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.NoError(t, child.SetParent(nil))
+		assert.False(t, child.HasParent())
+		assert.Equal(t, "SetParentRootChild", FindLabel("SetParentRootChild").Path())
+	})
+	t.Run("Delete", func(t *testing.T) {
+		leaf := FirstOrCreateLabelPath("DeleteParentRoot|DeleteParentMiddle|DeleteParentLeaf", 0)
+
+		if leaf == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		middle := leaf.Parent()
+
+		if err := middle.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "DeleteParentRoot|DeleteParentLeaf", FindLabel("DeleteParentLeaf").Path())
+	})
+}
+
+func TestNewLabelTree(t *testing.T) {
+	labels := Labels{
+		{ID: 1, LabelUID: "lt9k3pw1wowuy001", LabelName: "Places", CustomSlug: "places"},
+		{ID: 2, LabelUID: "lt9k3pw1wowuy002", LabelName: "Europe", CustomSlug: "europe", ParentID: 1},
+		{ID: 3, LabelUID: "lt9k3pw1wowuy003", LabelName: "Germany", CustomSlug: "germany", ParentID: 2},
+		{ID: 4, LabelUID: "lt9k3pw1wowuy004", LabelName: "France", CustomSlug: "france", ParentID: 2},
+		{ID: 5, LabelUID: "lt9k3pw1wowuy005", LabelName: "Cat", CustomSlug: "cat", ParentID: 99},
+	}
+
+	result := NewLabelTree(labels)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "Places", result[0].Name)
+		assert.Equal(t, "Cat", result[1].Name)
+		assert.Len(t, result[1].Children, 0)
+
+		if assert.Len(t, result[0].Children, 1) {
+			assert.Equal(t, "europe", result[0].Children[0].Slug)
+			assert.Len(t, result[0].Children[0].Children, 2)
+		}
+	}
+}
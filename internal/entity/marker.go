@@ -10,18 +10,23 @@ import (
 	"github.com/dustin/go-humanize/english"
 	"github.com/jinzhu/gorm"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/inference"
 
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 const (
 	MarkerUnknown = ""
-	MarkerFace    = "face"  // MarkerType for faces (implemented).
-	MarkerLabel   = "label" // MarkerType for labels (todo).
+	MarkerFace    = "face"   // MarkerType for faces (implemented).
+	MarkerLabel   = "label"  // MarkerType for labels (todo).
+	MarkerObject  = "object" // MarkerType for detected objects such as pets, cars, and text.
+	MarkerPet     = "pet"    // MarkerType for pet faces that can be recognized like people.
 )
 
 // Marker represents an image marker point.
@@ -113,6 +118,84 @@ func NewFaceMarker(f face.Face, file File, subjUID string) *Marker {
 	return m
 }
 
+// NewObjectMarker creates a new entity from a detected object, or returns nil if it should be ignored.
+func NewObjectMarker(obj inference.Object, file File) *Marker {
+	name := strings.ToLower(strings.TrimSpace(obj.Name))
+	score := int(obj.Score * 100)
+
+	if name == "" || score < 1 {
+		return nil
+	}
+
+	// Apply label rules, so that objects have the same names as labels.
+	if rule, ok := classify.FindRule(name); !ok {
+		// Keep name.
+	} else if obj.Score < rule.Threshold {
+		return nil
+	} else if rule.Label != "" {
+		name = rule.Label
+	}
+
+	area := crop.NewArea(MarkerObject, obj.X, obj.Y, obj.W, obj.H)
+
+	m := NewMarker(file, area, "", SrcImage, MarkerObject, objectSize(file, area), score)
+
+	// Failed creating new marker?
+	if m == nil {
+		return nil
+	}
+
+	m.MarkerName = txt.Title(txt.Clip(name, txt.ClipDefault))
+
+	return m
+}
+
+// NewPetMarker creates a new entity from a detected pet face, or returns nil if it should be ignored.
+func NewPetMarker(obj inference.Object, file File) *Marker {
+	score := int(obj.Score * 100)
+
+	// Pet faces without embeddings cannot be recognized.
+	if score < 1 || len(obj.Embedding) == 0 {
+		return nil
+	}
+
+	area := crop.NewArea(MarkerPet, obj.X, obj.Y, obj.W, obj.H)
+
+	m := NewMarker(file, area, "", SrcImage, MarkerPet, objectSize(file, area), score)
+
+	// Failed creating new marker?
+	if m == nil {
+		return nil
+	}
+
+	m.SetEmbeddings(face.Embeddings{face.NewEmbedding(obj.Embedding)})
+
+	return m
+}
+
+// objectSize returns the larger side of a marker area in pixels.
+func objectSize(file File, area crop.Area) (size int) {
+	size = int(float32(file.FileWidth) * area.W)
+
+	if h := int(float32(file.FileHeight) * area.H); h > size {
+		size = h
+	}
+
+	return size
+}
+
+// SubjType returns the type of subject that can be recognized with this marker, if any.
+func (m *Marker) SubjType() string {
+	switch m.MarkerType {
+	case MarkerFace:
+		return SubjPerson
+	case MarkerPet:
+		return SubjPet
+	default:
+		return ""
+	}
+}
+
 // SetEmbeddings assigns new face emebddings to the marker.
 func (m *Marker) SetEmbeddings(e face.Embeddings) {
 	m.embeddings = e
@@ -222,7 +305,7 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 		return false, fmt.Errorf("face is nil")
 	}
 
-	if m.MarkerType != MarkerFace {
+	if m.SubjType() == "" {
 		return false, fmt.Errorf("not a face marker")
 	}
 
@@ -309,8 +392,8 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 
 // SyncSubject maintains the marker subject relationship.
 func (m *Marker) SyncSubject(updateRelated bool) (err error) {
-	// Face marker? If not, return.
-	if m.MarkerType != MarkerFace {
+	// Face or pet marker? If not, return.
+	if m.SubjType() == "" {
 		return nil
 	}
 
@@ -363,7 +446,7 @@ func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 
 // InvalidArea tests if the marker area is invalid or out of range.
 func (m *Marker) InvalidArea() error {
-	if m.MarkerType != MarkerFace {
+	if m.MarkerType != MarkerFace && m.MarkerType != MarkerObject && m.MarkerType != MarkerPet {
 		return nil
 	}
 
@@ -427,7 +510,7 @@ func (m *Marker) Subject() (subj *Subject) {
 
 	// Create subject?
 	if m.SubjSrc != SrcAuto && m.MarkerName != "" && m.SubjUID == "" {
-		if subj = NewSubject(m.MarkerName, SubjPerson, m.SubjSrc); subj == nil {
+		if subj = NewSubject(m.MarkerName, m.SubjType(), m.SubjSrc); subj == nil {
 			log.Errorf("faces: marker %s has invalid subject %s", clean.Log(m.MarkerUID), clean.Log(m.MarkerName))
 			return nil
 		} else if subj = FirstOrCreateSubject(subj); subj == nil {
@@ -497,7 +580,7 @@ func (m *Marker) Face() (f *Face) {
 
 	// Add face if size
 	if m.SubjSrc != SrcAuto && m.FaceID == "" {
-		if m.Size < face.ClusterSizeThreshold || m.Score < face.ClusterScoreThreshold {
+		if minSize, minScore := m.clusterThresholds(); m.Size < minSize || m.Score < minScore {
 			log.Debugf("faces: marker %s skipped adding face due to low-quality (size %d, score %d)", clean.Log(m.MarkerUID), m.Size, m.Score)
 			return nil
 		}
@@ -505,7 +588,7 @@ func (m *Marker) Face() (f *Face) {
 		if emb := m.Embeddings(); emb.Empty() {
 			log.Warnf("faces: marker %s has no face embeddings", clean.Log(m.MarkerUID))
 			return nil
-		} else if f = NewFace(m.SubjUID, m.SubjSrc, emb); f == nil {
+		} else if f = m.newFace(emb); f == nil {
 			log.Warnf("faces: failed assigning face to marker %s", clean.Log(m.MarkerUID))
 			return nil
 		} else if f.SkipMatching() {
@@ -527,6 +610,24 @@ func (m *Marker) Face() (f *Face) {
 	return m.face
 }
 
+// clusterThresholds returns the min size and score of markers that can be added as face.
+func (m *Marker) clusterThresholds() (size, score int) {
+	if m.MarkerType == MarkerPet {
+		return face.PetClusterSizeThreshold, face.PetClusterScoreThreshold
+	}
+
+	return face.ClusterSizeThreshold, face.ClusterScoreThreshold
+}
+
+// newFace returns a new face of the same kind as the marker.
+func (m *Marker) newFace(embeddings face.Embeddings) *Face {
+	if m.MarkerType == MarkerPet {
+		return NewPetFace(m.SubjUID, m.SubjSrc, embeddings)
+	}
+
+	return NewFace(m.SubjUID, m.SubjSrc, embeddings)
+}
+
 // ClearFace removes an existing face association.
 func (m *Marker) ClearFace() (updated bool, err error) {
 	if m.FaceID == "" {
@@ -649,6 +750,16 @@ func (m *Marker) ValidFace() bool {
 	return m.MarkerType == MarkerFace && !m.MarkerInvalid
 }
 
+// DetectedObject tests if the marker is an automatically detected object.
+func (m *Marker) DetectedObject() bool {
+	return m.MarkerType == MarkerObject && m.MarkerSrc == SrcImage
+}
+
+// DetectedPet tests if the marker is an automatically detected pet face.
+func (m *Marker) DetectedPet() bool {
+	return m.MarkerType == MarkerPet && m.MarkerSrc == SrcImage
+}
+
 // DetectedFace tests if the marker is an automatically detected face.
 func (m *Marker) DetectedFace() bool {
 	return m.MarkerType == MarkerFace && m.MarkerSrc == SrcImage
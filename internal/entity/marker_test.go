@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,6 +59,76 @@ func TestNewMarker(t *testing.T) {
 	assert.Equal(t, MarkerLabel, m.MarkerType)
 }
 
+func TestNewObjectMarker(t *testing.T) {
+	file := FileFixtures.Get("exampleFileName.jpg")
+
+	t.Run("Dog", func(t *testing.T) {
+		m := NewObjectMarker(inference.Object{Name: " Dog ", Score: 0.87, X: 0.1, Y: 0.2, W: 0.3, H: 0.4}, file)
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		}
+
+		assert.Equal(t, "Dog", m.MarkerName)
+		assert.Equal(t, MarkerObject, m.MarkerType)
+		assert.Equal(t, SrcImage, m.MarkerSrc)
+		assert.Equal(t, 87, m.Score)
+		assert.Equal(t, "", m.SubjUID)
+		assert.False(t, m.MarkerReview)
+		assert.True(t, m.DetectedObject())
+		assert.False(t, m.DetectedFace())
+		assert.Equal(t, float32(0.3), m.W)
+		assert.NoError(t, m.InvalidArea())
+	})
+	t.Run("Rule", func(t *testing.T) {
+		m := NewObjectMarker(inference.Object{Name: "tabby cat", Score: 0.5, W: 0.5, H: 0.5}, file)
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		}
+
+		assert.Equal(t, "Cat", m.MarkerName)
+	})
+	t.Run("LowScore", func(t *testing.T) {
+		m := NewObjectMarker(inference.Object{Name: "dog", Score: 0.25, W: 0.5, H: 0.5}, file)
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		}
+
+		assert.True(t, m.MarkerReview)
+		assert.Nil(t, NewObjectMarker(inference.Object{Name: "dog", Score: 0.001, W: 0.5, H: 0.5}, file))
+	})
+	t.Run("NoName", func(t *testing.T) {
+		assert.Nil(t, NewObjectMarker(inference.Object{Name: " ", Score: 0.9, W: 0.5, H: 0.5}, file))
+	})
+}
+
+func TestNewPetMarker(t *testing.T) {
+	file := FileFixtures.Get("exampleFileName.jpg")
+
+	t.Run("Dog", func(t *testing.T) {
+		m := NewPetMarker(inference.Object{Name: "dog", Score: 0.9, X: 0.1, Y: 0.2, W: 0.3, H: 0.4, Embedding: []float32{0.1, 0.2, 0.3, 0.4}}, file)
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		}
+
+		assert.Equal(t, MarkerPet, m.MarkerType)
+		assert.Equal(t, SubjPet, m.SubjType())
+		assert.Equal(t, "", m.MarkerName)
+		assert.True(t, m.DetectedPet())
+		assert.False(t, m.DetectedFace())
+		assert.False(t, m.DetectedObject())
+		assert.Len(t, m.Embeddings(), 1)
+		assert.Len(t, m.Embeddings()[0], 4)
+		assert.NoError(t, m.InvalidArea())
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		assert.Nil(t, NewPetMarker(inference.Object{Name: "dog", Score: 0.9, W: 0.5, H: 0.5}, file))
+	})
+}
+
 func TestMarker_SetName(t *testing.T) {
 	t.Run("InvalidName", func(t *testing.T) {
 		m := MarkerFixtures.Get("actress-a-1")
@@ -84,6 +155,35 @@ func TestMarker_SetName(t *testing.T) {
 }
 
 func TestMarker_SaveForm(t *testing.T) {
+	t.Run("Object", func(t *testing.T) {
+		m := NewObjectMarker(inference.Object{Name: "dog", Score: 0.9, X: 0.1, Y: 0.2, W: 0.3, H: 0.4}, FileFixtures.Get("exampleFileName.jpg"))
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		} else if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer UnscopedDb().Delete(m)
+
+		changed, err := m.SaveForm(form.Marker{SubjSrc: SrcManual, MarkerName: "Rex", MarkerInvalid: false})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, changed)
+		assert.Equal(t, "Rex", m.MarkerName)
+		assert.Equal(t, SrcManual, m.SubjSrc)
+		assert.Empty(t, m.SubjUID)
+
+		if found := FindMarker(m.MarkerUID); found == nil {
+			t.Fatal("marker not found")
+		} else {
+			assert.Equal(t, "Rex", found.MarkerName)
+			assert.Equal(t, MarkerObject, found.MarkerType)
+		}
+	})
 	t.Run("fa-ge add new name to marker then rename marker", func(t *testing.T) {
 		m := MarkerFixtures.Get("fa-gr-1")
 		m2 := MarkerFixtures.Get("fa-gr-2")
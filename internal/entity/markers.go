@@ -43,10 +43,15 @@ func (m Markers) Unsaved() bool {
 	return false
 }
 
-// Contains returns true if a marker at the same position already exists.
+// Contains returns true if a marker of the same type at the same position already exists.
 func (m Markers) Contains(other Marker) bool {
 	for i := range m {
-		if m[i].OverlapPercent(other) > face.OverlapThreshold {
+		if m[i].MarkerType != other.MarkerType {
+			continue
+		} else if m[i].MarkerType == MarkerObject && m[i].MarkerName != other.MarkerName {
+			// Different objects may overlap, e.g. a dog on a sofa.
+			continue
+		} else if m[i].OverlapPercent(other) > face.OverlapThreshold {
 			return true
 		}
 	}
@@ -65,6 +70,28 @@ func (m Markers) DetectedFaceCount() (count int) {
 	return count
 }
 
+// DetectedObjectCount returns the number of automatically detected object markers.
+func (m Markers) DetectedObjectCount() (count int) {
+	for i := range m {
+		if m[i].DetectedObject() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// DetectedPetCount returns the number of automatically detected pet face markers.
+func (m Markers) DetectedPetCount() (count int) {
+	for i := range m {
+		if m[i].DetectedPet() {
+			count++
+		}
+	}
+
+	return count
+}
+
 // ValidFaceCount returns the number of valid face markers.
 func (m Markers) ValidFaceCount() (count int) {
 	for i := range m {
@@ -117,9 +144,9 @@ func (m Markers) Labels() (result classify.Labels) {
 	var rule classify.LabelRule
 
 	if faceCount == 1 {
-		rule = classify.Rules["portrait"]
+		rule, _ = classify.FindRule("portrait")
 	} else {
-		rule = classify.Rules["people"]
+		rule, _ = classify.FindRule("people")
 	}
 
 	return classify.Labels{classify.Label{
@@ -7,6 +7,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/inference"
 )
 
 var cropArea1 = crop.Area{Name: "face", X: 0.308333, Y: 0.206944, W: 0.355556, H: 0.355556}
@@ -68,6 +69,35 @@ func TestMarkers_Contains(t *testing.T) {
 
 		assert.False(t, markers.Contains(conflicting))
 	})
+	t.Run("Objects", func(t *testing.T) {
+		file := File{FileUID: "fqzuh672i9btq6gu", FileHash: "243cdbe99b865607f98a951e748d528bc22f3143"}
+
+		dog := *NewObjectMarker(inference.Object{Name: "dog", Score: 0.9, X: 0.2, Y: 0.2, W: 0.4, H: 0.4}, file)
+		sofa := *NewObjectMarker(inference.Object{Name: "sofa", Score: 0.9, X: 0.2, Y: 0.2, W: 0.4, H: 0.4}, file)
+		faceMarker := *NewMarker(file, crop.Area{Name: "face", X: 0.2, Y: 0.2, W: 0.4, H: 0.4}, "", SrcImage, MarkerFace, 100, 65)
+
+		markers := Markers{dog}
+
+		assert.True(t, markers.Contains(dog))
+		assert.False(t, markers.Contains(sofa))
+		assert.False(t, markers.Contains(faceMarker))
+		assert.Equal(t, 1, markers.DetectedObjectCount())
+		assert.Equal(t, 0, markers.DetectedFaceCount())
+	})
+	t.Run("Pets", func(t *testing.T) {
+		file := File{FileUID: "fqzuh672i9btq6gu", FileHash: "243cdbe99b865607f98a951e748d528bc22f3143"}
+
+		pet := *NewPetMarker(inference.Object{Name: "dog", Score: 0.9, X: 0.2, Y: 0.2, W: 0.4, H: 0.4, Embedding: []float32{0.1, 0.2}}, file)
+		dog := *NewObjectMarker(inference.Object{Name: "dog", Score: 0.9, X: 0.2, Y: 0.2, W: 0.4, H: 0.4}, file)
+
+		markers := Markers{pet}
+
+		assert.True(t, markers.Contains(pet))
+		assert.False(t, markers.Contains(dog))
+		assert.Equal(t, 1, markers.DetectedPetCount())
+		assert.Equal(t, 0, markers.DetectedObjectCount())
+		assert.Equal(t, 0, markers.DetectedFaceCount())
+	})
 }
 
 func TestMarkers_DetectedFaceCount(t *testing.T) {
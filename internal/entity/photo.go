@@ -64,6 +64,10 @@ type Photo struct {
 	PhotoStack       int8         `json:"Stack" yaml:"Stack,omitempty"`
 	PhotoFavorite    bool         `json:"Favorite" yaml:"Favorite,omitempty"`
 	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
+	PhotoRating      int          `gorm:"type:SMALLINT;index;" json:"Rating" yaml:"Rating,omitempty"`
+	RatingSrc        string       `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
+	PhotoColorLabel  string       `gorm:"type:VARBINARY(8);index;" json:"ColorLabel" yaml:"ColorLabel,omitempty"`
+	ColorLabelSrc    string       `gorm:"type:VARBINARY(8);" json:"ColorLabelSrc" yaml:"ColorLabelSrc,omitempty"`
 	PhotoScan        bool         `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool         `json:"Panorama" yaml:"Panorama,omitempty"`
 	TimeZone         string       `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
@@ -140,11 +144,24 @@ func NewPhoto(stackable bool) Photo {
 // SavePhotoForm saves a model in the database using form data.
 func SavePhotoForm(model Photo, form form.Photo) error {
 	locChanged := model.PhotoLat != form.PhotoLat || model.PhotoLng != form.PhotoLng || model.PhotoCountry != form.PhotoCountry
+	rating, ratingSrc := model.PhotoRating, model.RatingSrc
+	colorLabel, colorLabelSrc := model.PhotoColorLabel, model.ColorLabelSrc
 
 	if err := deepcopier.Copy(&model).From(form); err != nil {
 		return err
 	}
 
+	// Ratings and color labels changed in the edit form take precedence over metadata.
+	if model.PhotoRating != rating {
+		model.RatingSrc = ratingSrc
+		model.SetRating(model.PhotoRating, SrcManual)
+	}
+
+	if model.PhotoColorLabel != colorLabel {
+		model.ColorLabelSrc = colorLabelSrc
+		model.SetColorLabel(model.PhotoColorLabel, SrcManual)
+	}
+
 	if !model.HasID() {
 		return errors.New("cannot save form when photo id is missing")
 	}
@@ -595,6 +612,51 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 	Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels)
 }
 
+// AddLabelPaths adds nested labels from hierarchical keywords such as "Places|Europe|Germany|Berlin",
+// the photo is only assigned to the last label of each path.
+func (m *Photo) AddLabelPaths(paths []string, source string) {
+	if len(paths) == 0 || !m.HasID() {
+		return
+	}
+
+	for _, path := range paths {
+		labelEntity := FirstOrCreateLabelPath(path, 0)
+
+		if labelEntity == nil {
+			log.Errorf("index: failed adding label path %s (%s)", clean.Log(path), m)
+			continue
+		}
+
+		if labelEntity.Deleted() {
+			log.Debugf("index: skipping deleted label %s (%s)", clean.Log(labelEntity.LabelName), m)
+			continue
+		}
+
+		if photoLabel := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, labelEntity.ID, 0, source)); photoLabel == nil {
+			log.Errorf("index: photo-label %d should not be nil - possible bug (%s)", labelEntity.ID, m)
+		} else if photoLabel.Uncertainty > 0 && photoLabel.Uncertainty < 100 {
+			if err := photoLabel.Updates(map[string]interface{}{
+				"Uncertainty": 0,
+				"LabelSrc":    source,
+			}); err != nil {
+				log.Errorf("index: %s", err)
+			}
+		}
+	}
+
+	Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels)
+}
+
+// RemoveLabelsBySource removes labels from the specified source, labels removed by a user are kept.
+func (m *Photo) RemoveLabelsBySource(source string) error {
+	if err := UnscopedDb().Where("photo_id = ? AND label_src = ? AND uncertainty < 100", m.ID, source).
+		Delete(PhotoLabel{}).Error; err != nil {
+		return err
+	}
+
+	return Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels).Error
+}
+
 // SetDescription changes the photo description if not empty and from the same source.
 func (m *Photo) SetDescription(desc, source string) {
 	newDesc := txt.Clip(desc, txt.ClipLongText)
@@ -770,6 +832,10 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("index: %s (remove albums)", logErr)
 	}
 
+	if logErr := UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", m.ID).Error; logErr != nil {
+		log.Errorf("index: %s (remove embedding)", logErr)
+	}
+
 	return files, UnscopedDb().Delete(m).Error
 }
 
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+// PhotoEmbedding represents the image embedding of a photo for semantic search.
+// Vectors are stored in binary format, as JSON would be several times larger.
+type PhotoEmbedding struct {
+	PhotoID       uint      `gorm:"primary_key;auto_increment:false"`
+	EmbeddingDim  int       `gorm:"column:embedding_dim;"`
+	EmbeddingData []byte    `gorm:"column:embedding_data;type:MEDIUMBLOB;"`
+	UpdatedAt     time.Time `gorm:"index;"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// NewPhotoEmbedding returns a new photo embedding entity.
+func NewPhotoEmbedding(photoID uint, embedding []float32) *PhotoEmbedding {
+	return &PhotoEmbedding{
+		PhotoID:       photoID,
+		EmbeddingDim:  len(embedding),
+		EmbeddingData: vector.Encode(embedding),
+	}
+}
+
+// Embedding returns the embedding vector.
+func (m *PhotoEmbedding) Embedding() ([]float32, error) {
+	return vector.Decode(m.EmbeddingData)
+}
+
+// Save inserts or updates the embedding in the database.
+func (m *PhotoEmbedding) Save() error {
+	return UnscopedDb().Save(m).Error
+}
+
+// FindPhotoEmbedding returns the embedding of a photo, or nil if it does not exist.
+func FindPhotoEmbedding(photoID uint) *PhotoEmbedding {
+	result := PhotoEmbedding{}
+
+	if err := UnscopedDb().Where("photo_id = ?", photoID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoEmbedding_Save(t *testing.T) {
+	m := NewPhotoEmbedding(1000001, []float32{0.5, -0.25, 1})
+
+	assert.Equal(t, "photos_embeddings", m.TableName())
+	assert.Equal(t, 3, m.EmbeddingDim)
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace existing embedding.
+	if err := NewPhotoEmbedding(1000001, []float32{0.1, 0.2}).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindPhotoEmbedding(1000001)
+
+	if found == nil {
+		t.Fatal("embedding not found")
+	}
+
+	v, err := found.Embedding()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0.1, 0.2}, v)
+	assert.Equal(t, 2, found.EmbeddingDim)
+	assert.Nil(t, FindPhotoEmbedding(123456789))
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// SetRating updates the star rating if the source has the same or a higher priority,
+// -1 means rejected and 0 means unrated. Only manual changes may reset the rating.
+func (m *Photo) SetRating(rating int, source string) {
+	if rating == meta.RatingMin && source != SrcManual {
+		return
+	} else if rating < meta.RatingRejected {
+		rating = meta.RatingRejected
+	} else if rating > meta.RatingMax {
+		rating = meta.RatingMax
+	}
+
+	if SrcPriority[source] < SrcPriority[m.RatingSrc] {
+		return
+	}
+
+	m.PhotoRating = rating
+	m.RatingSrc = source
+}
+
+// SetColorLabel updates the color label if the source has the same or a higher priority.
+// Only manual changes may remove the label.
+func (m *Photo) SetColorLabel(label, source string) {
+	label = meta.NormalizeColorLabel(label)
+
+	if label == "" && source != SrcManual {
+		return
+	}
+
+	if SrcPriority[source] < SrcPriority[m.ColorLabelSrc] {
+		return
+	}
+
+	m.PhotoColorLabel = label
+	m.ColorLabelSrc = source
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("Meta", func(t *testing.T) {
+		m := Photo{}
+		m.SetRating(0, SrcMeta)
+		assert.Equal(t, "", m.RatingSrc)
+		m.SetRating(3, SrcMeta)
+		assert.Equal(t, 3, m.PhotoRating)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+	})
+	t.Run("Range", func(t *testing.T) {
+		m := Photo{}
+		m.SetRating(9, SrcXmp)
+		assert.Equal(t, 5, m.PhotoRating)
+		m.SetRating(-5, SrcXmp)
+		assert.Equal(t, -1, m.PhotoRating)
+	})
+	t.Run("Priority", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, RatingSrc: SrcManual}
+		m.SetRating(5, SrcXmp)
+		assert.Equal(t, 2, m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+		m.SetRating(0, SrcManual)
+		assert.Equal(t, 0, m.PhotoRating)
+	})
+}
+
+func TestPhoto_SetColorLabel(t *testing.T) {
+	t.Run("Xmp", func(t *testing.T) {
+		m := Photo{}
+		m.SetColorLabel("Rot", SrcXmp)
+		assert.Equal(t, "", m.PhotoColorLabel)
+		assert.Equal(t, "", m.ColorLabelSrc)
+		m.SetColorLabel("Green", SrcXmp)
+		assert.Equal(t, "green", m.PhotoColorLabel)
+		assert.Equal(t, SrcXmp, m.ColorLabelSrc)
+	})
+	t.Run("Priority", func(t *testing.T) {
+		m := Photo{PhotoColorLabel: "blue", ColorLabelSrc: SrcManual}
+		m.SetColorLabel("red", SrcMeta)
+		assert.Equal(t, "blue", m.PhotoColorLabel)
+		m.SetColorLabel("", SrcManual)
+		assert.Equal(t, "", m.PhotoColorLabel)
+	})
+}
@@ -20,6 +20,8 @@ func TestSavePhotoForm(t *testing.T) {
 			TitleSrc:         SrcManual,
 			PhotoFavorite:    true,
 			PhotoPrivate:     true,
+			PhotoRating:      4,
+			PhotoColorLabel:  "Red",
 			PhotoType:        "image",
 			PhotoLat:         7.9999,
 			PhotoLng:         8.8888,
@@ -60,6 +62,10 @@ func TestSavePhotoForm(t *testing.T) {
 		assert.Equal(t, "manual", m.TitleSrc)
 		assert.Equal(t, true, m.PhotoFavorite)
 		assert.Equal(t, true, m.PhotoPrivate)
+		assert.Equal(t, 4, m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+		assert.Equal(t, "red", m.PhotoColorLabel)
+		assert.Equal(t, SrcManual, m.ColorLabelSrc)
 		assert.Equal(t, "image", m.PhotoType)
 		assert.Equal(t, float32(7.9999), m.PhotoLat)
 		assert.NotNil(t, m.EditedAt)
@@ -68,6 +74,41 @@ func TestSavePhotoForm(t *testing.T) {
 	})
 }
 
+func TestPhoto_AddLabelPaths(t *testing.T) {
+	t.Run("Xmp", func(t *testing.T) {
+		m := NewPhoto(false)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			if _, err := m.DeletePermanently(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		m.AddLabelPaths([]string{"Animals|Mammals|Cats"}, SrcXmp)
+
+		label := FindLabel("Cats")
+
+		if label == nil {
+			t.Fatal("label should not be nil")
+		}
+
+		assert.Equal(t, "Animals|Mammals|Cats", label.Path())
+
+		photoLabel := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, label.ID, 50, SrcImage))
+
+		if photoLabel == nil {
+			t.Fatal("photo label should not be nil")
+		}
+
+		assert.Equal(t, 0, photoLabel.Uncertainty)
+		assert.Equal(t, SrcXmp, photoLabel.LabelSrc)
+	})
+}
+
 func TestPhoto_SaveLabels(t *testing.T) {
 	t.Run("new photo", func(t *testing.T) {
 		photo := Photo{
@@ -252,6 +293,29 @@ func TestPhoto_AddLabels(t *testing.T) {
 	})
 }
 
+func TestPhoto_RemoveLabelsBySource(t *testing.T) {
+	m := NewPhoto(false)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.AddLabels(classify.Labels{
+		{Name: "reclassified", Uncertainty: 20, Source: SrcImage},
+		{Name: "kept", Uncertainty: 10, Source: SrcManual},
+	})
+
+	assert.Len(t, m.Labels, 2)
+
+	if err := m.RemoveLabelsBySource(SrcImage); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, m.Labels, 1) {
+		assert.Equal(t, SrcManual, m.Labels[0].LabelSrc)
+	}
+}
+
 func TestPhoto_SetDescription(t *testing.T) {
 	t.Run("empty description", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo15")
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var photoXmpMutex = sync.Mutex{}
+
+// XmpSidecar returns the photo metadata to be written to an XMP sidecar file.
+func (m *Photo) XmpSidecar() meta.XmpSidecar {
+	// Load details if not done yet.
+	details := m.GetDetails()
+
+	result := meta.XmpSidecar{
+		DocumentID:   m.UUID,
+		Title:        m.PhotoTitle,
+		Description:  m.PhotoDescription,
+		Artist:       details.Artist,
+		Copyright:    details.Copyright,
+		License:      details.License,
+		Keywords:     txt.Words(details.Keywords),
+		TakenAt:      m.TakenAt,
+		TakenAtLocal: m.TakenAtLocal,
+		TimeZone:     m.TimeZone,
+		Lat:          m.PhotoLat,
+		Lng:          m.PhotoLng,
+		Altitude:     m.PhotoAltitude,
+		Favorite:     m.PhotoFavorite,
+		Rating:       m.PhotoRating,
+		ColorLabel:   m.PhotoColorLabel,
+	}
+
+	// Don't export placeholder titles.
+	if result.Title == UnknownTitle {
+		result.Title = ""
+	}
+
+	// Add label names to keywords.
+	for _, l := range m.Labels {
+		if l.Uncertainty < 100 && l.Label != nil && l.Label.LabelName != "" {
+			result.Keywords = append(result.Keywords, l.Label.LabelName)
+		}
+	}
+
+	// Add people and face regions from the primary file.
+	if file := m.xmpFile(); file != nil && file.FileUID != "" {
+		result.Width = file.FileWidth
+		result.Height = file.FileHeight
+
+		if markers, err := FindMarkers(file.FileUID); err != nil {
+			log.Warnf("photo: %s while finding markers of %s (xmp)", err, m.PhotoUID)
+		} else {
+			result.People = markers.SubjectNames()
+
+			for i := range markers {
+				if markers[i].MarkerInvalid || markers[i].MarkerType != MarkerFace {
+					continue
+				} else if name := markers[i].SubjectName(); name != "" {
+					result.Regions = append(result.Regions, meta.Region{
+						Name: name,
+						Type: meta.RegionFace,
+						X:    markers[i].X,
+						Y:    markers[i].Y,
+						W:    markers[i].W,
+						H:    markers[i].H,
+					})
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// xmpFile returns the primary file, if any.
+func (m *Photo) xmpFile() *File {
+	for i := range m.Files {
+		if m.Files[i].FilePrimary {
+			return &m.Files[i]
+		}
+	}
+
+	if !m.HasID() {
+		return nil
+	} else if file, err := m.PrimaryFile(); err != nil {
+		return nil
+	} else {
+		return file
+	}
+}
+
+// Xmp returns photo metadata as XMP document.
+func (m *Photo) Xmp() ([]byte, error) {
+	s := m.XmpSidecar()
+
+	return s.Bytes()
+}
+
+// SaveAsXmp saves photo metadata as XMP sidecar file, existing files created by other applications
+// are updated so that their metadata is preserved.
+func (m *Photo) SaveAsXmp(fileName string) error {
+	s := m.XmpSidecar()
+	data, err := s.Bytes()
+
+	if err != nil {
+		return err
+	}
+
+	// Make sure directory exists.
+	if err = os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	photoXmpMutex.Lock()
+	defer photoXmpMutex.Unlock()
+
+	// Merge with XMP files created by other applications instead of replacing them.
+	if !meta.XmpWritable(fileName) {
+		existing, err := os.ReadFile(fileName)
+
+		if err != nil {
+			return err
+		} else if data, err = s.Merge(existing); err != nil {
+			return fmt.Errorf("%s in %s", err, clean.Log(filepath.Base(fileName)))
+		}
+	}
+
+	// Write XMP data to file.
+	if err = os.WriteFile(fileName, data, os.ModePerm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// XmpFileName returns the XMP sidecar file name.
+func (m *Photo) XmpFileName(originalsPath, sidecarPath string) string {
+	return fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.ExtXMP)
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestPhoto_Xmp(t *testing.T) {
+	t.Run("Photo01", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.PreloadFiles()
+		result, err := m.Xmp()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(result), `x:xmptk="PhotoPrism"`)
+
+		t.Logf("XMP: %s", result)
+	})
+}
+
+func TestPhoto_SaveAsXmp(t *testing.T) {
+	t.Run("Photo01", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.PreloadFiles()
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_test.xmp")
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PhotoDescription, data.Description)
+		assert.Equal(t, m.PhotoLat, data.Lat)
+		assert.Equal(t, m.PhotoLng, data.Lng)
+
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Rating", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.PhotoFavorite = false
+		m.PhotoRating = 3
+		m.PhotoColorLabel = meta.ColorLabelBlue
+
+		fileName := filepath.Join(t.TempDir(), "rating.xmp")
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.Sidecar(fileName, fs.XmpFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, meta.ColorLabelBlue, data.ColorLabel)
+	})
+	t.Run("Favorite", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.PhotoFavorite = true
+		m.PhotoRating = 0
+
+		fileName := filepath.Join(t.TempDir(), "favorite.xmp")
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.Sidecar(fileName, fs.XmpFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 5, data.Rating)
+	})
+	t.Run("ForeignFile", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		fileName := filepath.Join(t.TempDir(), "foreign.xmp")
+
+		if err := os.WriteFile(fileName, []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 5.5.0"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description rdf:about="" xmlns:darktable="http://darktable.sf.net/" darktable:xmp_version="4"></rdf:Description></rdf:RDF></x:xmpmeta>`), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), `x:xmptk="XMP Core 5.5.0"`)
+		assert.Contains(t, string(b), `darktable:xmp_version="4"`)
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PhotoTitle, data.Title)
+	})
+	t.Run("ForeignFileInvalid", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		fileName := filepath.Join(t.TempDir(), "foreign.xmp")
+
+		if err := os.WriteFile(fileName, []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 5.5.0"></x:xmpmeta>`), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, m.SaveAsXmp(fileName))
+	})
+}
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	t.Run("Photo01", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		assert.Equal(t, "xxx/2790/02/yyy/Photo01.xmp", m.XmpFileName("xxx", "yyy"))
+
+		if err := os.RemoveAll("xxx"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
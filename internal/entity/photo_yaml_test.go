@@ -20,6 +20,19 @@ func TestPhoto_Yaml(t *testing.T) {
 
 		t.Logf("YAML: %s", result)
 	})
+	t.Run("rating", func(t *testing.T) {
+		m := Photo{PhotoUID: "pt9jtdre2lvl0yh8"}
+		m.SetRating(4, SrcXmp)
+		m.SetColorLabel("red", SrcManual)
+
+		result, err := m.Yaml()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(result), "Rating: 4\nRatingSrc: xmp\nColorLabel: red\nColorLabelSrc: manual\n")
+	})
 }
 
 func TestPhoto_SaveAsYaml(t *testing.T) {
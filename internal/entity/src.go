@@ -10,10 +10,13 @@ const (
 	SrcDefault  = "default"            // Prio 1
 	SrcEstimate = "estimate"           // Prio 2
 	SrcName     = "name"               // Prio 4
+	SrcAae      = "aae"                // Prio 4
 	SrcYaml     = "yaml"               // Prio 8
 	SrcLocation = classify.SrcLocation // Prio 8
 	SrcMarker   = "marker"             // Prio 8
 	SrcImage    = classify.SrcImage    // Prio 8
+	SrcOCR      = "ocr"                // Prio 8
+	SrcTrack    = "track"              // Prio 16
 	SrcKeyword  = classify.SrcKeyword  // Prio 16
 	SrcMeta     = "meta"               // Prio 16
 	SrcXmp      = "xmp"                // Prio 32
@@ -35,10 +38,13 @@ var SrcPriority = Priorities{
 	SrcDefault:  1,
 	SrcEstimate: 2,
 	SrcName:     4,
+	SrcAae:      4,
 	SrcYaml:     8,
 	SrcLocation: 8,
 	SrcMarker:   8,
 	SrcImage:    8,
+	SrcOCR:      8,
+	SrcTrack:    16,
 	SrcKeyword:  16,
 	SrcMeta:     16,
 	SrcXmp:      32,
@@ -194,8 +194,9 @@ func FirstOrCreateSubject(m *Subject) *Subject {
 		return nil
 	}
 
+	// Names are unique, so a person and a pet cannot have the same name.
 	if found := FindSubjectByName(m.SubjName); found != nil {
-		return found
+		return sameSubjType(found, m)
 	} else if err := m.Create(); err == nil {
 		log.Infof("subject: added %s %s", TypeString(m.SubjType), clean.Log(m.SubjName))
 
@@ -210,7 +211,7 @@ func FirstOrCreateSubject(m *Subject) *Subject {
 
 		return m
 	} else if found = FindSubjectByName(m.SubjName); found != nil {
-		return found
+		return sameSubjType(found, m)
 	} else {
 		log.Errorf("subject: failed adding %s (%s)", clean.Log(m.SubjName), err)
 	}
@@ -218,6 +219,17 @@ func FirstOrCreateSubject(m *Subject) *Subject {
 	return nil
 }
 
+// sameSubjType returns the subject found if it has the same type as the subject to be created, or nil otherwise.
+func sameSubjType(found, m *Subject) *Subject {
+	if m.SubjType == "" || found.SubjType == m.SubjType {
+		return found
+	}
+
+	log.Errorf("subject: %s already exists as %s", clean.Log(m.SubjName), TypeString(found.SubjType))
+
+	return nil
+}
+
 // FindSubject returns an existing entity if exists.
 func FindSubject(uid string) *Subject {
 	if uid == "" {
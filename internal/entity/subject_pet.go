@@ -0,0 +1,10 @@
+package entity
+
+const (
+	SubjPet = "pet" // SubjType for pets.
+)
+
+// IsPet tests if the subject is a pet.
+func (m *Subject) IsPet() bool {
+	return m.SubjType == SubjPet
+}
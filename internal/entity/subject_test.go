@@ -88,6 +88,11 @@ func TestFirstOrCreatePerson(t *testing.T) {
 		assert.Equal(t, "john-doe", m.SubjSlug)
 		assert.Equal(t, "Short Note", m.SubjNotes)
 	})
+	t.Run("existing person as pet", func(t *testing.T) {
+		m := NewSubject(SubjectFixtures.Get("john-doe").SubjName, SubjPet, SrcManual)
+
+		assert.Nil(t, FirstOrCreateSubject(m))
+	})
 }
 
 func TestSubject_Save(t *testing.T) {
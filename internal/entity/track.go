@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/track"
+)
+
+var trackMutex = sync.Mutex{}
+
+// trackPointsBatch limits the number of points inserted with a single statement.
+const trackPointsBatch = 150
+
+// Track represents a GPS track log, e.g. from a GPX, KML or GeoJSON file.
+type Track struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	TrackName  string    `gorm:"type:VARBINARY(755);index;" json:"Name" yaml:"Name"`
+	TrackHash  string    `gorm:"type:VARBINARY(128);unique_index;" json:"Hash" yaml:"Hash"`
+	PointCount int       `json:"PointCount" yaml:"PointCount,omitempty"`
+	StartedAt  time.Time `gorm:"type:DATETIME;index;" json:"StartedAt" yaml:"StartedAt"`
+	EndedAt    time.Time `gorm:"type:DATETIME;index;" json:"EndedAt" yaml:"EndedAt"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Track) TableName() string {
+	return "tracks"
+}
+
+// TrackPoint represents a timestamped position of a track.
+type TrackPoint struct {
+	ID            uint      `gorm:"primary_key"`
+	TrackID       uint      `gorm:"index;"`
+	PointTime     time.Time `gorm:"type:DATETIME;index;"`
+	PointLat      float64   `gorm:"type:DOUBLE;"`
+	PointLng      float64   `gorm:"type:DOUBLE;"`
+	PointAltitude int
+}
+
+// TableName returns the entity database table name.
+func (TrackPoint) TableName() string {
+	return "tracks_points"
+}
+
+// Position returns the track point as geo position.
+func (m TrackPoint) Position() geo.Position {
+	return geo.Position{Time: m.PointTime.UTC(), Lat: m.PointLat, Lng: m.PointLng, Altitude: float64(m.PointAltitude)}
+}
+
+// FindTrack returns the track with the specified content hash, or nil if it does not exist.
+func FindTrack(hash string) *Track {
+	result := Track{}
+
+	if hash == "" {
+		return nil
+	}
+
+	if err := UnscopedDb().Where("track_hash = ?", hash).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// SaveTrack adds a track unless a track with the same content hash already exists, so that the same
+// file is only added once, no matter where it is stored. The name is only used for display.
+func SaveTrack(name, hash string, points track.Points) (*Track, error) {
+	if name == "" {
+		return nil, fmt.Errorf("track name must not be empty")
+	} else if hash == "" {
+		return nil, fmt.Errorf("track %s has no hash", name)
+	} else if len(points) == 0 {
+		return nil, fmt.Errorf("track %s has no points", name)
+	}
+
+	trackMutex.Lock()
+	defer trackMutex.Unlock()
+
+	if m := FindTrack(hash); m != nil {
+		return m, nil
+	}
+
+	m := &Track{
+		TrackName:  name,
+		TrackHash:  hash,
+		PointCount: len(points),
+		StartedAt:  points.Start(),
+		EndedAt:    points.End(),
+	}
+
+	// Points are added in a single transaction, so that a failed insert does not leave a track without points.
+	err := UnscopedDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+
+		for i := 0; i < len(points); i += trackPointsBatch {
+			batch := points[i:]
+
+			if len(batch) > trackPointsBatch {
+				batch = batch[:trackPointsBatch]
+			}
+
+			rows := make([]string, len(batch))
+			values := make([]interface{}, 0, len(batch)*5)
+
+			for j, p := range batch {
+				rows[j] = "(?, ?, ?, ?, ?)"
+				values = append(values, m.ID, p.Time.UTC(), p.Lat, p.Lng, p.AltitudeInt())
+			}
+
+			if err := tx.Exec("INSERT INTO tracks_points (track_id, point_time, point_lat, point_lng, point_altitude) VALUES "+
+				strings.Join(rows, ", "), values...).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Delete removes the track and its points from the database.
+func (m *Track) Delete() error {
+	if err := UnscopedDb().Where("track_id = ?", m.ID).Delete(&TrackPoint{}).Error; err != nil {
+		return err
+	}
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// Points returns the track points sorted by time.
+func (m *Track) Points() (result track.Points, err error) {
+	var points []TrackPoint
+
+	if err = UnscopedDb().Where("track_id = ?", m.ID).Order("point_time").Find(&points).Error; err != nil {
+		return result, err
+	}
+
+	result = make(track.Points, len(points))
+
+	for i, p := range points {
+		result[i] = p.Position()
+	}
+
+	return result, nil
+}
+
+// TrackPosition returns the position at the specified time, interpolated between the nearest
+// points of all tracks. The result is not ok if there is no point within maxGap.
+func TrackPosition(t time.Time, maxGap time.Duration) (pos geo.Position, ok bool) {
+	if t.IsZero() {
+		return pos, false
+	}
+
+	t = t.UTC()
+
+	var before, after TrackPoint
+	var points track.Points
+
+	if err := UnscopedDb().Where("point_time <= ? AND point_time >= ?", t, t.Add(-1*maxGap)).
+		Order("point_time DESC").First(&before).Error; err == nil {
+		points = append(points, before.Position())
+	}
+
+	if err := UnscopedDb().Where("point_time > ? AND point_time <= ?", t, t.Add(maxGap)).
+		Order("point_time ASC").First(&after).Error; err == nil {
+		points = append(points, after.Position())
+	}
+
+	return points.Position(t, maxGap)
+}
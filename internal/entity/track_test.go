@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/track"
+)
+
+func TestSaveTrack(t *testing.T) {
+	start := time.Date(2003, 3, 3, 10, 0, 0, 0, time.UTC)
+
+	points := track.Points{
+		geo.Position{Time: start, Lat: 48.1370, Lng: 11.5750, Altitude: 520},
+		geo.Position{Time: start.Add(10 * time.Minute), Lat: 48.1390, Lng: 11.5800, Altitude: 522},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		m, err := SaveTrack("2003/munich.gpx", "5a2b3c", points)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, m.PointCount)
+		assert.Equal(t, start, m.StartedAt.UTC())
+		assert.Equal(t, start.Add(10*time.Minute), m.EndedAt.UTC())
+
+		if found := FindTrack("5a2b3c"); found == nil {
+			t.Fatal("track should exist")
+		} else {
+			assert.Equal(t, m.ID, found.ID)
+		}
+
+		result, err := m.Points()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.InDelta(t, 48.1370, result[0].Lat, 0.00001)
+
+		pos, ok := TrackPosition(start.Add(5*time.Minute), 5*time.Minute)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 48.1380, pos.Lat, 0.00001)
+		assert.InDelta(t, 11.5775, pos.Lng, 0.00001)
+
+		_, ok = TrackPosition(start.Add(time.Hour), 5*time.Minute)
+
+		assert.False(t, ok)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindTrack("5a2b3c"))
+	})
+	t.Run("SameHash", func(t *testing.T) {
+		m, err := SaveTrack("2003/same.gpx", "1a", points)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Delete()
+
+		other, err := SaveTrack("backup/same.gpx", "1a", points)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.ID, other.ID)
+		assert.Equal(t, "2003/same.gpx", other.TrackName)
+	})
+	t.Run("SameName", func(t *testing.T) {
+		m, err := SaveTrack("track.gpx", "2b", points)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Delete()
+
+		other, err := SaveTrack("track.gpx", "3c", points[:1])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer other.Delete()
+
+		assert.NotEqual(t, m.ID, other.ID)
+
+		result, err := m.Points()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, 1, other.PointCount)
+	})
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := SaveTrack("", "", points)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoHash", func(t *testing.T) {
+		_, err := SaveTrack("nohash.gpx", "", points)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoPoints", func(t *testing.T) {
+		_, err := SaveTrack("empty.gpx", "4d", track.Points{})
+
+		assert.Error(t, err)
+	})
+}
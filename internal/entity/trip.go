@@ -0,0 +1,229 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// TripRouteMax is the max number of route positions stored per trip.
+const TripRouteMax = 50
+
+// Trip represents a journey away from home, shown as album of type AlbumTrip.
+type Trip struct {
+	ID            uint         `gorm:"primary_key" json:"-" yaml:"-"`
+	AlbumUID      string       `gorm:"type:VARBINARY(42);unique_index;" json:"AlbumUID" yaml:"AlbumUID"`
+	TripCountries string       `gorm:"type:VARBINARY(255);" json:"-" yaml:"Countries,omitempty"`
+	TripRoute     string       `gorm:"type:VARBINARY(2048);" json:"-" yaml:"Route,omitempty"`
+	TripKm        int          `json:"Km" yaml:"Km,omitempty"`
+	PhotoCount    int          `json:"PhotoCount" yaml:"-"`
+	StartedAt     time.Time    `gorm:"type:DATETIME;index;" json:"StartedAt" yaml:"StartedAt"`
+	EndedAt       time.Time    `gorm:"type:DATETIME;index;" json:"EndedAt" yaml:"EndedAt"`
+	CreatedAt     time.Time    `json:"CreatedAt" yaml:"-"`
+	UpdatedAt     time.Time    `json:"UpdatedAt" yaml:"-"`
+	Countries     []string     `gorm:"-" json:"Countries" yaml:"-"`
+	Route         [][2]float64 `gorm:"-" json:"Route" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Trip) TableName() string {
+	return "trips"
+}
+
+// NewTrip returns a new trip for the specified album.
+func NewTrip(albumUID string) *Trip {
+	return &Trip{AlbumUID: albumUID, Countries: []string{}, Route: [][2]float64{}}
+}
+
+// FindTrip returns the trip of the specified album, or nil if it does not exist.
+func FindTrip(albumUID string) *Trip {
+	if albumUID == "" {
+		return nil
+	}
+
+	result := Trip{}
+
+	if err := UnscopedDb().Where("album_uid = ?", albumUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindTripByTime returns the first trip that overlaps with the specified time range, or nil if none exists.
+func FindTripByTime(start, end time.Time) *Trip {
+	result := Trip{}
+
+	if err := UnscopedDb().Where("started_at <= ? AND ended_at >= ?", end.UTC(), start.UTC()).
+		Order("started_at").First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindTripsByTime returns all trips that overlap with the specified time range, sorted by start time.
+func FindTripsByTime(start, end time.Time) (result []Trip) {
+	if err := UnscopedDb().Where("started_at <= ? AND ended_at >= ?", end.UTC(), start.UTC()).
+		Order("started_at, id").Find(&result).Error; err != nil {
+		log.Errorf("trip: %s (find)", err)
+		return nil
+	}
+
+	return result
+}
+
+// FindTripsAfter returns all trips that ended at or after the specified time, sorted by start time.
+func FindTripsAfter(start time.Time) (result []Trip) {
+	if err := UnscopedDb().Where("ended_at >= ?", start.UTC()).
+		Order("started_at, id").Find(&result).Error; err != nil {
+		log.Errorf("trip: %s (find)", err)
+		return nil
+	}
+
+	return result
+}
+
+// AfterFind decodes the countries and route after querying.
+func (m *Trip) AfterFind() (err error) {
+	m.Countries = []string{}
+	m.Route = [][2]float64{}
+
+	if m.TripCountries != "" {
+		m.Countries = strings.Split(m.TripCountries, ",")
+	}
+
+	if m.TripRoute != "" {
+		err = json.Unmarshal([]byte(m.TripRoute), &m.Route)
+	}
+
+	return err
+}
+
+// AddCountries adds country codes in the order they were visited, ignoring unknown countries.
+func (m *Trip) AddCountries(codes ...string) {
+	for _, code := range codes {
+		if code == "" || code == UnknownID || list.Contains(m.Countries, code) {
+			continue
+		}
+
+		m.Countries = append(m.Countries, code)
+	}
+
+	m.TripCountries = strings.Join(m.Countries, ",")
+}
+
+// SetRoute sets the route based on the positions visited.
+func (m *Trip) SetRoute(positions []geo.Position) {
+	m.Route = make([][2]float64, 0, len(positions))
+
+	for _, p := range positions {
+		// Round to about one meter.
+		m.Route = append(m.Route, [2]float64{math.Round(p.Lat*1e5) / 1e5, math.Round(p.Lng*1e5) / 1e5})
+	}
+
+	if data, err := json.Marshal(m.Route); err != nil {
+		log.Errorf("trip: %s (encode route)", err)
+	} else {
+		m.TripRoute = string(data)
+	}
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *Trip) Save() error {
+	return UnscopedDb().Save(m).Error
+}
+
+// UpdatePhotoCount counts the pictures in the trip album, excluding pictures that were removed or deleted.
+func (m *Trip) UpdatePhotoCount() error {
+	var count int
+
+	if err := UnscopedDb().Table("photos_albums").
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid AND photos.deleted_at IS NULL").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0", m.AlbumUID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+
+	m.PhotoCount = count
+
+	return nil
+}
+
+// RemovePhotosExcept removes all pictures from the trip album except those with the specified UIDs,
+// pictures removed by the user stay hidden.
+func (m *Trip) RemovePhotosExcept(uids []string) error {
+	stmt := UnscopedDb().Where("album_uid = ? AND hidden = 0", m.AlbumUID)
+
+	if len(uids) > 0 {
+		stmt = stmt.Where("photo_uid NOT IN (?)", uids)
+	}
+
+	return stmt.Delete(&PhotoAlbum{}).Error
+}
+
+// Merge permanently deletes the other trip and its album, pictures removed by the user from the
+// other album are hidden in this trip album as well.
+func (m *Trip) Merge(other *Trip) error {
+	if other == nil || other.AlbumUID == m.AlbumUID {
+		return nil
+	}
+
+	var hidden []string
+
+	if err := UnscopedDb().Model(&PhotoAlbum{}).
+		Where("album_uid = ? AND hidden = 1", other.AlbumUID).
+		Pluck("photo_uid", &hidden).Error; err != nil {
+		return err
+	}
+
+	for _, uid := range hidden {
+		entry := NewPhotoAlbum(uid, m.AlbumUID)
+		entry.Hidden = true
+
+		if pa := FirstOrCreatePhotoAlbum(entry); pa != nil && !pa.Hidden {
+			pa.Hidden = true
+
+			if err := pa.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return other.DeletePermanently()
+}
+
+// DeletePermanently permanently deletes the trip and its album.
+func (m *Trip) DeletePermanently() error {
+	if err := UnscopedDb().Where("album_uid = ?", m.AlbumUID).Delete(&PhotoAlbum{}).Error; err != nil {
+		return err
+	}
+
+	if a := m.Album(); a != nil {
+		if err := a.DeletePermanently(); err != nil {
+			return err
+		}
+	}
+
+	return m.Delete()
+}
+
+// Delete removes the trip from the database.
+func (m *Trip) Delete() error {
+	return UnscopedDb().Delete(m).Error
+}
+
+// Album returns the trip album including deleted albums, or nil if it does not exist.
+func (m *Trip) Album() *Album {
+	result := Album{}
+
+	if err := UnscopedDb().Where("album_uid = ?", m.AlbumUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
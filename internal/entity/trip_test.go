@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestTrip_Save(t *testing.T) {
+	album := NewTripAlbum("France & Italy / May 2004", "france-italy-may-2004-2004-05-01", time.Date(2004, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewTrip(album.AlbumUID)
+	m.StartedAt = time.Date(2004, 5, 1, 10, 0, 0, 0, time.UTC)
+	m.EndedAt = time.Date(2004, 5, 9, 18, 0, 0, 0, time.UTC)
+	m.AddCountries("fr", "zz", "it", "fr", "")
+	m.SetRoute([]geo.Position{{Lat: 48.856614, Lng: 2.3522219}, {Lat: 41.902784, Lng: 12.496366}})
+
+	assert.Equal(t, []string{"fr", "it"}, m.Countries)
+	assert.Equal(t, "fr,it", m.TripCountries)
+	assert.Equal(t, "[[48.85661,2.35222],[41.90278,12.49637]]", m.TripRoute)
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("FindTrip", func(t *testing.T) {
+		found := FindTrip(album.AlbumUID)
+
+		if found == nil {
+			t.Fatal("trip should not be nil")
+		}
+
+		assert.Equal(t, []string{"fr", "it"}, found.Countries)
+		assert.Equal(t, [][2]float64{{48.85661, 2.35222}, {41.90278, 12.49637}}, found.Route)
+
+		if a := found.Album(); a == nil {
+			t.Fatal("album should not be nil")
+		} else {
+			assert.Equal(t, album.AlbumUID, a.AlbumUID)
+		}
+
+		assert.Nil(t, FindTrip(""))
+		assert.Nil(t, FindTrip("at9lxuqxpoaaaaaa"))
+	})
+	t.Run("FindTripByTime", func(t *testing.T) {
+		if found := FindTripByTime(time.Date(2004, 5, 9, 12, 0, 0, 0, time.UTC), time.Date(2004, 5, 12, 0, 0, 0, 0, time.UTC)); found == nil {
+			t.Fatal("trip should not be nil")
+		} else {
+			assert.Equal(t, m.ID, found.ID)
+		}
+
+		assert.Nil(t, FindTripByTime(time.Date(2004, 5, 10, 0, 0, 0, 0, time.UTC), time.Date(2004, 5, 12, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindTrip(album.AlbumUID))
+	})
+}
+
+func TestTrip_Merge(t *testing.T) {
+	newTrip := func(title, slug string, start, end time.Time) *Trip {
+		album := NewTripAlbum(title, slug, start)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		m := NewTrip(album.AlbumUID)
+		m.StartedAt = start
+		m.EndedAt = end
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		return m
+	}
+
+	first := newTrip("Spain / June 2003", "spain-june-2003-2003-06-01", time.Date(2003, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2003, 6, 5, 0, 0, 0, 0, time.UTC))
+	second := newTrip("Spain / June 2003", "spain-june-2003-2003-06-10", time.Date(2003, 6, 10, 0, 0, 0, 0, time.UTC), time.Date(2003, 6, 12, 0, 0, 0, 0, time.UTC))
+
+	defer first.Delete()
+
+	photo01 := PhotoFixtures.Get("Photo01").PhotoUID
+	photo03 := PhotoFixtures.Get("Photo03").PhotoUID
+	photo04 := PhotoFixtures.Get("Photo04").PhotoUID
+
+	FirstOrCreatePhotoAlbum(NewPhotoAlbum(photo01, first.AlbumUID))
+	FirstOrCreatePhotoAlbum(NewPhotoAlbum(photo03, first.AlbumUID))
+	removed := NewPhotoAlbum(photo04, second.AlbumUID)
+	removed.Hidden = true
+	FirstOrCreatePhotoAlbum(removed)
+
+	t.Run("FindTripsByTime", func(t *testing.T) {
+		found := FindTripsByTime(time.Date(2003, 6, 4, 0, 0, 0, 0, time.UTC), time.Date(2003, 6, 11, 0, 0, 0, 0, time.UTC))
+
+		if assert.Len(t, found, 2) {
+			assert.Equal(t, first.ID, found[0].ID)
+			assert.Equal(t, second.ID, found[1].ID)
+		}
+
+		assert.Empty(t, FindTripsByTime(time.Date(2003, 6, 6, 0, 0, 0, 0, time.UTC), time.Date(2003, 6, 9, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("Merge", func(t *testing.T) {
+		if err := first.Merge(second); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindTrip(second.AlbumUID))
+		assert.Nil(t, second.Album())
+
+		if pa := FirstOrCreatePhotoAlbum(NewPhotoAlbum(photo04, first.AlbumUID)); pa == nil {
+			t.Fatal("photo album should not be nil")
+		} else {
+			assert.True(t, pa.Hidden)
+		}
+	})
+	t.Run("RemovePhotosExcept", func(t *testing.T) {
+		if err := first.RemovePhotosExcept([]string{photo01}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := first.UpdatePhotoCount(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, first.PhotoCount)
+
+		var hidden int
+
+		if err := UnscopedDb().Model(&PhotoAlbum{}).Where("album_uid = ? AND hidden = 1", first.AlbumUID).Count(&hidden).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, hidden)
+	})
+}
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+var visitMutex = sync.Mutex{}
+
+// visitPhotosBatch limits the number of photo references inserted with a single statement.
+const visitPhotosBatch = 500
+
+// Visits represents a list of visits.
+type Visits []Visit
+
+// Visit represents pictures taken at the same place without a longer break, see photoprism.Timeline.
+type Visit struct {
+	ID            uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	VisitName     string    `gorm:"type:VARCHAR(200);" json:"Name" yaml:"Name,omitempty"`
+	VisitCategory string    `gorm:"type:VARCHAR(100);" json:"Category" yaml:"Category,omitempty"`
+	PlaceID       string    `gorm:"type:VARBINARY(42);index;" json:"PlaceID" yaml:"PlaceID,omitempty"`
+	PlaceLabel    string    `gorm:"type:VARCHAR(400);" json:"Label" yaml:"Label,omitempty"`
+	PlaceCity     string    `gorm:"type:VARCHAR(100);" json:"City" yaml:"City,omitempty"`
+	PlaceState    string    `gorm:"type:VARCHAR(100);" json:"State" yaml:"State,omitempty"`
+	VisitCountry  string    `gorm:"type:VARBINARY(2);index;" json:"Country" yaml:"Country,omitempty"`
+	VisitLat      float64   `gorm:"type:DOUBLE;" json:"Lat" yaml:"Lat,omitempty"`
+	VisitLng      float64   `gorm:"type:DOUBLE;" json:"Lng" yaml:"Lng,omitempty"`
+	ArrivedAt     time.Time `gorm:"type:DATETIME;index;" json:"Arrival" yaml:"Arrival"`
+	DepartedAt    time.Time `gorm:"type:DATETIME;" json:"Departure" yaml:"Departure"`
+	PhotoIDs      []uint    `gorm:"-" json:"-" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Visit) TableName() string {
+	return "visits"
+}
+
+// PhotoVisit represents the many-to-many relation between Photo and Visit.
+type PhotoVisit struct {
+	VisitID uint `gorm:"primary_key;auto_increment:false"`
+	PhotoID uint `gorm:"primary_key;auto_increment:false;index"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoVisit) TableName() string {
+	return "photos_visits"
+}
+
+// Key returns a string that identifies the visit and its properties, so that unchanged visits can be found.
+func (m *Visit) Key() string {
+	ids := make([]uint, len(m.PhotoIDs))
+	copy(ids, m.PhotoIDs)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%.6f|%.6f|%d|%d|%v",
+		m.VisitName, m.VisitCategory, m.PlaceID, m.PlaceLabel, m.PlaceCity, m.PlaceState, m.VisitCountry,
+		m.VisitLat, m.VisitLng, m.ArrivedAt.Unix(), m.DepartedAt.Unix(), ids)
+}
+
+// UpdateVisits updates the stored visits and their photo references in a single transaction, so that they
+// match the specified visits. Unchanged visits are kept, so only added and removed visits are written.
+func UpdateVisits(visits Visits) (added, removed int, err error) {
+	visitMutex.Lock()
+	defer visitMutex.Unlock()
+
+	var existing Visits
+	var refs []PhotoVisit
+
+	if err = UnscopedDb().Find(&existing).Error; err != nil {
+		return 0, 0, err
+	} else if err = UnscopedDb().Order("visit_id, photo_id").Find(&refs).Error; err != nil {
+		return 0, 0, err
+	}
+
+	photoIDs := make(map[uint][]uint, len(existing))
+
+	for _, r := range refs {
+		photoIDs[r.VisitID] = append(photoIDs[r.VisitID], r.PhotoID)
+	}
+
+	keep := make(map[string]uint, len(existing))
+	var remove []uint
+
+	for i := range existing {
+		m := &existing[i]
+		m.PhotoIDs = photoIDs[m.ID]
+
+		if k := m.Key(); keep[k] == 0 {
+			keep[k] = m.ID
+		} else {
+			remove = append(remove, m.ID)
+		}
+	}
+
+	var add []*Visit
+
+	for i := range visits {
+		m := &visits[i]
+		k := m.Key()
+
+		if id := keep[k]; id > 0 {
+			m.ID = id
+			delete(keep, k)
+		} else {
+			m.ID = 0
+			add = append(add, m)
+		}
+	}
+
+	for _, id := range keep {
+		remove = append(remove, id)
+	}
+
+	if len(add) == 0 && len(remove) == 0 {
+		return 0, 0, nil
+	}
+
+	err = UnscopedDb().Transaction(func(tx *gorm.DB) error {
+		for i := 0; i < len(remove); i += visitPhotosBatch {
+			batch := remove[i:]
+
+			if len(batch) > visitPhotosBatch {
+				batch = batch[:visitPhotosBatch]
+			}
+
+			if err := tx.Exec("DELETE FROM photos_visits WHERE visit_id IN (?)", batch).Error; err != nil {
+				return err
+			} else if err = tx.Exec("DELETE FROM visits WHERE id IN (?)", batch).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, m := range add {
+			if err := createVisit(tx, m); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(add), len(remove), nil
+}
+
+// createVisit adds a visit and its photo references.
+func createVisit(tx *gorm.DB, m *Visit) error {
+	if err := tx.Create(m).Error; err != nil {
+		return err
+	}
+
+	for j := 0; j < len(m.PhotoIDs); j += visitPhotosBatch {
+		batch := m.PhotoIDs[j:]
+
+		if len(batch) > visitPhotosBatch {
+			batch = batch[:visitPhotosBatch]
+		}
+
+		rows := make([]string, len(batch))
+		values := make([]interface{}, 0, len(batch)*2)
+
+		for k, photoID := range batch {
+			rows[k] = "(?, ?)"
+			values = append(values, m.ID, photoID)
+		}
+
+		if err := tx.Exec("INSERT INTO photos_visits (visit_id, photo_id) VALUES "+
+			strings.Join(rows, ", "), values...).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
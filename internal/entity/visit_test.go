@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateVisits(t *testing.T) {
+	arrival := time.Date(2021, 6, 12, 10, 0, 0, 0, time.UTC)
+
+	visits := Visits{
+		{
+			VisitName:    "Praça do Comércio",
+			PlaceLabel:   "Lisbon, Portugal",
+			VisitCountry: "pt",
+			ArrivedAt:    arrival,
+			DepartedAt:   arrival.Add(2 * time.Hour),
+			PhotoIDs:     []uint{PhotoFixtures.Get("Photo01").ID, PhotoFixtures.Get("Photo03").ID},
+		},
+		{
+			VisitName:    "Porto",
+			VisitCountry: "pt",
+			ArrivedAt:    arrival.Add(26 * time.Hour),
+			DepartedAt:   arrival.Add(26 * time.Hour),
+			PhotoIDs:     []uint{PhotoFixtures.Get("Photo04").ID},
+		},
+	}
+
+	count := func(table string) (n int) {
+		if err := UnscopedDb().Table(table).Count(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return n
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		added, removed, err := UpdateVisits(visits)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, added)
+		assert.Equal(t, 0, removed)
+		assert.NotEqual(t, uint(0), visits[0].ID)
+		assert.Equal(t, 2, count("visits"))
+		assert.Equal(t, 3, count("photos_visits"))
+
+		firstID := visits[0].ID
+
+		// Unchanged visits are kept.
+		if added, removed, err = UpdateVisits(visits[:1]); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, added)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, firstID, visits[0].ID)
+		assert.Equal(t, 1, count("visits"))
+		assert.Equal(t, 2, count("photos_visits"))
+
+		// Changed visits are replaced.
+		changed := Visits{visits[0]}
+		changed[0].PhotoIDs = changed[0].PhotoIDs[:1]
+
+		if added, removed, err = UpdateVisits(changed); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, added)
+		assert.Equal(t, 1, removed)
+		assert.NotEqual(t, firstID, changed[0].ID)
+		assert.Equal(t, 1, count("visits"))
+		assert.Equal(t, 1, count("photos_visits"))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		if _, _, err := UpdateVisits(nil); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count("visits"))
+		assert.Equal(t, 0, count("photos_visits"))
+	})
+}
@@ -1,22 +1,27 @@
 package face
 
 import (
+	"bytes"
 	"fmt"
 	"image"
+	"image/jpeg"
 	"path"
 	"path/filepath"
 	"runtime/debug"
 	"sync"
 
+	"github.com/disintegration/imaging"
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
 // Net is a wrapper for the TensorFlow Facenet model.
 type Net struct {
 	model     *tf.SavedModel
+	backend   inference.Model
 	modelPath string
 	cachePath string
 	disabled  bool
@@ -30,6 +35,13 @@ func NewNet(modelPath, cachePath string, disabled bool) *Net {
 	return &Net{modelPath: modelPath, cachePath: cachePath, disabled: disabled, modelTags: []string{"serve"}}
 }
 
+// WithBackend uses another model to compute face embeddings, e.g. on a local inference server,
+// instead of the built-in TensorFlow model. Faces are still detected locally.
+func (t *Net) WithBackend(model inference.Model) *Net {
+	t.backend = model
+	return t
+}
+
 // Detect runs the detection and facenet algorithms over the provided source image.
 func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int) (faces Faces, err error) {
 	faces, err = Detect(fileName, false, minSize)
@@ -45,10 +57,10 @@ func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int)
 		return faces, nil
 	}
 
-	err = t.loadModel()
-
-	if err != nil {
-		return faces, err
+	if t.backend == nil {
+		if err = t.loadModel(); err != nil {
+			return faces, err
+		}
 	}
 
 	for i, f := range faces {
@@ -96,18 +108,79 @@ func (t *Net) loadModel() error {
 	return nil
 }
 
+// Infer runs the TensorFlow model and returns the embeddings of a jpeg face crop.
+func (t *Net) Infer(img []byte) (result inference.Result, err error) {
+	if err = t.loadModel(); err != nil {
+		return result, err
+	}
+
+	decoded, err := imaging.Decode(bytes.NewReader(img))
+
+	if err != nil {
+		return result, err
+	}
+
+	if b := decoded.Bounds(); b.Dx() != CropSize.Width || b.Dy() != CropSize.Height {
+		decoded = imaging.Fill(decoded, CropSize.Width, CropSize.Height, imaging.Center, imaging.Lanczos)
+	}
+
+	result.Embeddings, err = t.modelEmbeddings(decoded)
+
+	return result, err
+}
+
 // getEmbeddings returns the face embeddings for an image.
 func (t *Net) getEmbeddings(img image.Image) Embeddings {
+	var output [][]float32
+	var err error
+
+	if t.backend != nil {
+		output, err = t.backendEmbeddings(img)
+	} else {
+		output, err = t.modelEmbeddings(img)
+	}
+
+	if err != nil {
+		log.Errorf("faces: %s", err)
+		return nil
+	}
+
+	return NewEmbeddings(output)
+}
+
+// backendEmbeddings returns the face embeddings for an image computed by the backend model.
+func (t *Net) backendEmbeddings(img image.Image) ([][]float32, error) {
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		return nil, err
+	}
+
+	result, err := t.backend.Infer(buf.Bytes())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Embeddings, nil
+}
+
+// modelEmbeddings returns the face embeddings for an image computed by the TensorFlow model.
+func (t *Net) modelEmbeddings(img image.Image) ([][]float32, error) {
 	tensor, err := imageToTensor(img, CropSize.Width, CropSize.Height)
 
 	if err != nil {
-		log.Errorf("faces: failed to convert image to tensor: %s", err)
+		return nil, fmt.Errorf("failed to convert image to tensor: %s", err)
 	}
 
 	// TODO: pre-whiten image as in facenet
 
 	trainPhaseBoolTensor, err := tf.NewTensor(false)
 
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := t.model.Session.Run(
 		map[tf.Output]*tf.Tensor{
 			t.model.Graph.Operation("input").Output(0):       tensor,
@@ -119,16 +192,14 @@ func (t *Net) getEmbeddings(img image.Image) Embeddings {
 		nil)
 
 	if err != nil {
-		log.Errorf("faces: %s", err)
+		return nil, err
 	}
 
 	if len(output) < 1 {
-		log.Errorf("faces: inference failed, no output")
-	} else {
-		return NewEmbeddings(output[0].Value().([][]float32))
+		return nil, fmt.Errorf("inference failed, no output")
 	}
 
-	return nil
+	return output[0].Value().([][]float32), nil
 }
 
 func imageToTensor(img image.Image, imageHeight, imageWidth int) (tfTensor *tf.Tensor, err error) {
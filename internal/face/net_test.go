@@ -1,12 +1,17 @@
 package face
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/pkg/fastwalk"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/inference"
 )
 
 var modelPath, _ = filepath.Abs("../../assets/facenet")
@@ -133,3 +138,39 @@ func TestNet(t *testing.T) {
 	// 3 out of 55 with the 1.21 threshold
 	assert.Equal(t, 52, correct)
 }
+
+func TestNet_WithBackend(t *testing.T) {
+	embedding := RandomEmbedding()
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		values := make([]float32, len(embedding))
+
+		for i := range embedding {
+			values[i] = float32(embedding[i])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(inference.Result{Embeddings: [][]float32{values}})
+	}))
+
+	defer server.Close()
+
+	faceNet := NewNet(modelPath, "testdata/cache", false).WithBackend(inference.NewHttp(server.URL))
+
+	faces, err := faceNet.Detect("testdata/1.jpg", 20, false, -1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, faceNet.ModelLoaded())
+
+	if assert.Len(t, faces, 1) {
+		assert.Equal(t, 1, requests)
+		assert.Len(t, faces[0].Embeddings, 1)
+		assert.InDelta(t, embedding[0], faces[0].Embeddings[0][0], 0.0001)
+	}
+}
@@ -15,6 +15,11 @@ var ClusterDist = 0.64                           // Similarity distance threshol
 var MatchDist = 0.46                             // Dist offset threshold for matching new faces with clusters.
 var ClusterCore = 4                              // Min number of faces forming a cluster core.
 var SampleThreshold = 2 * ClusterCore            // Threshold for automatic clustering to start.
+var PetClusterScoreThreshold = 50                // Min detection score in percent for pet faces forming a cluster.
+var PetClusterSizeThreshold = 60                 // Min size for pet faces forming a cluster in pixels.
+var PetClusterDist = 0.5                         // Similarity distance threshold of pet faces forming a cluster core.
+var PetMatchDist = 0.35                          // Dist offset threshold for matching new pet faces with clusters.
+var PetClusterCore = 3                           // Min number of pet faces forming a cluster core.
 
 // QualityThreshold returns the scale adjusted quality score threshold.
 func QualityThreshold(scale int) (score float32) {
@@ -0,0 +1,8 @@
+package form
+
+// AccessToken represents a personal access token create form.
+type AccessToken struct {
+	Name    string `json:"name"`
+	Scopes  string `json:"scopes"`
+	Expires int    `json:"expires"` // Days until the token expires, 0 for no expiration.
+}
@@ -1,8 +1,9 @@
 package form
 
-// Label represents a label edit form.
+// Label represents a label edit form, the parent remains unchanged if ParentUID is omitted.
 type Label struct {
-	LabelName     string `json:"Name"`
-	Uncertainty   int    `json:"Uncertainty"`
-	LabelPriority int    `json:"Priority"`
+	LabelName     string  `json:"Name"`
+	Uncertainty   int     `json:"Uncertainty"`
+	LabelPriority int     `json:"Priority"`
+	ParentUID     *string `json:"ParentUID"`
 }
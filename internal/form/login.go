@@ -5,6 +5,7 @@ type Login struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 	Token    string `json:"token"`
+	Passcode string `json:"passcode"`
 }
 
 func (f Login) HasToken() bool {
@@ -22,3 +23,7 @@ func (f Login) HasPassword() bool {
 func (f Login) HasCredentials() bool {
 	return f.HasUsername() && f.HasPassword()
 }
+
+func (f Login) HasPasscode() bool {
+	return f.Passcode != "" && len(f.Passcode) <= 255
+}
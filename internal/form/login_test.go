@@ -49,3 +49,14 @@ func TestLogin_HasCredentials(t *testing.T) {
 		assert.Equal(t, true, form.HasCredentials())
 	})
 }
+
+func TestLogin_HasPasscode(t *testing.T) {
+	t.Run("false", func(t *testing.T) {
+		form := &Login{Username: "John", Password: "passwd"}
+		assert.Equal(t, false, form.HasPasscode())
+	})
+	t.Run("true", func(t *testing.T) {
+		form := &Login{Username: "John", Password: "passwd", Passcode: "123456"}
+		assert.Equal(t, true, form.HasPasscode())
+	})
+}
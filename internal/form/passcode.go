@@ -0,0 +1,7 @@
+package form
+
+// Passcode represents a two-factor authentication setup form.
+type Passcode struct {
+	Password string `json:"password"`
+	Passcode string `json:"passcode"`
+}
@@ -42,6 +42,10 @@ type Photo struct {
 	PhotoStack       int8      `json:"Stack"`
 	PhotoFavorite    bool      `json:"Favorite"`
 	PhotoPrivate     bool      `json:"Private"`
+	PhotoRating      int       `json:"Rating"`
+	RatingSrc        string    `json:"RatingSrc"`
+	PhotoColorLabel  string    `json:"ColorLabel"`
+	ColorLabelSrc    string    `json:"ColorLabelSrc"`
 	PhotoScan        bool      `json:"Scan"`
 	PhotoPanorama    bool      `json:"Panorama"`
 	PhotoAltitude    int       `json:"Altitude"`
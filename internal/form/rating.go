@@ -0,0 +1,14 @@
+package form
+
+// Rating represents a batch edit form for star ratings and color labels,
+// fields that are omitted remain unchanged.
+type Rating struct {
+	Selection
+	Rating     *int    `json:"rating"`
+	ColorLabel *string `json:"colorLabel"`
+}
+
+// Empty tests if no change was requested.
+func (f Rating) Empty() bool {
+	return f.Rating == nil && f.ColorLabel == nil
+}
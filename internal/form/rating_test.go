@@ -0,0 +1,32 @@
+package form
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRating_Empty(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		var f Rating
+
+		if err := json.Unmarshal([]byte(`{"photos": ["pt9jtdre2lvl0yh8"]}`), &f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, f.Empty())
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh8"}, f.Photos)
+	})
+	t.Run("Rating", func(t *testing.T) {
+		var f Rating
+
+		if err := json.Unmarshal([]byte(`{"photos": ["pt9jtdre2lvl0yh8"], "rating": 0}`), &f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, f.Empty())
+		assert.Equal(t, 0, *f.Rating)
+		assert.Nil(t, f.ColorLabel)
+	})
+}
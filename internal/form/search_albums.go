@@ -2,23 +2,26 @@ package form
 
 // SearchAlbums represents search form fields for "/api/v1/albums".
 type SearchAlbums struct {
-	Query    string `form:"q"`
-	UID      string `form:"uid"`
-	Type     string `form:"type"`
-	Location string `form:"location"`
-	Category string `form:"category"`
-	Slug     string `form:"slug"`
-	Title    string `form:"title"`
-	Country  string `json:"country"`
-	Year     int    `json:"year"`
-	Month    int    `json:"month"`
-	Day      int    `json:"day"`
-	Favorite bool   `form:"favorite"`
-	Public   bool   `form:"public"`
-	Private  bool   `form:"private"`
-	Count    int    `form:"count" binding:"required" serialize:"-"`
-	Offset   int    `form:"offset" serialize:"-"`
-	Order    string `form:"order" serialize:"-"`
+	Query    string   `form:"q"`
+	UID      string   `form:"uid"`
+	Type     string   `form:"type"`
+	Location string   `form:"location"`
+	Category string   `form:"category"`
+	Slug     string   `form:"slug"`
+	Title    string   `form:"title"`
+	Country  string   `json:"country"`
+	Year     int      `json:"year"`
+	Month    int      `json:"month"`
+	Day      int      `json:"day"`
+	Favorite bool     `form:"favorite"`
+	Public   bool     `form:"public"`
+	Private  bool     `form:"private"`
+	Count    int      `form:"count" binding:"required" serialize:"-"`
+	Offset   int      `form:"offset" serialize:"-"`
+	Order    string   `form:"order" serialize:"-"`
+	Scope    string   `form:"-" serialize:"-"` // Limits results to an originals subfolder, set by the server only
+	Shared   []string `form:"-" serialize:"-"` // Album UIDs shared with the user, found regardless of scope
+	User     string   `form:"-" serialize:"-"` // UID of the user, albums created by the user are found regardless of scope
 }
 
 func (f *SearchAlbums) GetQuery() string {
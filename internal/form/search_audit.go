@@ -0,0 +1,29 @@
+package form
+
+// SearchAudit represents search form fields for "/api/v1/audit".
+type SearchAudit struct {
+	Query    string `form:"q"`
+	UID      string `form:"uid"`
+	User     string `form:"user"`
+	Action   string `form:"action"`
+	Resource string `form:"resource"`
+	Outcome  string `form:"outcome"`
+	Count    int    `form:"count" binding:"required" serialize:"-"`
+	Offset   int    `form:"offset" serialize:"-"`
+}
+
+func (f *SearchAudit) GetQuery() string {
+	return f.Query
+}
+
+func (f *SearchAudit) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *SearchAudit) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewAuditSearch(query string) SearchAudit {
+	return SearchAudit{Query: query}
+}
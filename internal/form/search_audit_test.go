@@ -0,0 +1,26 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAudit_ParseQueryString(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f := NewAuditSearch("user:alice action:delete outcome:success")
+
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", f.Query)
+		assert.Equal(t, "alice", f.User)
+		assert.Equal(t, "delete", f.Action)
+		assert.Equal(t, "success", f.Outcome)
+	})
+	t.Run("InvalidFilter", func(t *testing.T) {
+		f := NewAuditSearch("xxx:false")
+		assert.Error(t, f.ParseQueryString())
+	})
+}
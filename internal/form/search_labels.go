@@ -2,15 +2,17 @@ package form
 
 // SearchLabels represents search form fields for "/api/v1/labels".
 type SearchLabels struct {
-	Query    string `form:"q"`
-	UID      string `form:"uid"`
-	Slug     string `form:"slug"`
-	Name     string `form:"name"`
-	All      bool   `form:"all"`
-	Favorite bool   `form:"favorite"`
-	Count    int    `form:"count" binding:"required" serialize:"-"`
-	Offset   int    `form:"offset" serialize:"-"`
-	Order    string `form:"order" serialize:"-"`
+	Query    string   `form:"q"`
+	UID      string   `form:"uid"`
+	Slug     string   `form:"slug"`
+	Name     string   `form:"name"`
+	All      bool     `form:"all"`
+	Favorite bool     `form:"favorite"`
+	Count    int      `form:"count" binding:"required" serialize:"-"`
+	Offset   int      `form:"offset" serialize:"-"`
+	Order    string   `form:"order" serialize:"-"`
+	Scope    string   `form:"-" serialize:"-"` // Limits results to an originals subfolder, set by the server only
+	Shared   []string `form:"-" serialize:"-"` // Album UIDs shared with the user, found regardless of scope
 }
 
 func (f *SearchLabels) GetQuery() string {
@@ -51,6 +51,18 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Foo Bar", form.Keywords)
 	})
+	t.Run("rating", func(t *testing.T) {
+		form := &SearchPhotos{Query: "rating:>=3 colorlabel:red|blue"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">=3", form.Rating)
+		assert.Equal(t, "red|blue", form.ColorLabel)
+	})
 	t.Run("and query", func(t *testing.T) {
 		form := &SearchPhotos{Query: "\"Jens & Mander\" title:\"Tübingen\""}
 
@@ -662,6 +674,32 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Could not find format for \"cat\"", err.Error())
 	})
+	t.Run("semantic", func(t *testing.T) {
+		form := &SearchPhotos{Query: "Semantic:\"dog playing in the snow\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dog playing in the snow", form.Semantic)
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("semantic with filters", func(t *testing.T) {
+		form := &SearchPhotos{Query: "semantic: dog playing in the snow year:2020 favorite:true"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dog playing in the snow", form.Semantic)
+		assert.Equal(t, "", form.Query)
+		assert.Equal(t, "2020", form.Year)
+		assert.True(t, form.Favorite)
+	})
 }
 
 func TestNewPhotoSearch(t *testing.T) {
@@ -2,20 +2,22 @@ package form
 
 // SearchSubjects represents search form fields for "/api/v1/subjects".
 type SearchSubjects struct {
-	Query    string `form:"q"`
-	UID      string `form:"uid"`
-	Type     string `form:"type"`
-	Name     string `form:"name"`
-	All      bool   `form:"all"`
-	Hidden   string `form:"hidden"`
-	Favorite string `form:"favorite"`
-	Private  string `form:"private"`
-	Excluded string `form:"excluded"`
-	Files    int    `form:"files"`
-	Photos   int    `form:"photos"`
-	Count    int    `form:"count" binding:"required" serialize:"-"`
-	Offset   int    `form:"offset" serialize:"-"`
-	Order    string `form:"order" serialize:"-"`
+	Query    string   `form:"q"`
+	UID      string   `form:"uid"`
+	Type     string   `form:"type"`
+	Name     string   `form:"name"`
+	All      bool     `form:"all"`
+	Hidden   string   `form:"hidden"`
+	Favorite string   `form:"favorite"`
+	Private  string   `form:"private"`
+	Excluded string   `form:"excluded"`
+	Files    int      `form:"files"`
+	Photos   int      `form:"photos"`
+	Count    int      `form:"count" binding:"required" serialize:"-"`
+	Offset   int      `form:"offset" serialize:"-"`
+	Order    string   `form:"order" serialize:"-"`
+	Scope    string   `form:"-" serialize:"-"` // Limits results to an originals subfolder, set by the server only
+	Shared   []string `form:"-" serialize:"-"` // Album UIDs shared with the user, found regardless of scope
 }
 
 func (f *SearchSubjects) GetQuery() string {
@@ -0,0 +1,16 @@
+package form
+
+// SearchVisits represents search form fields for "/api/v1/places/visits".
+type SearchVisits struct {
+	Query   string   `form:"q"`
+	Reverse bool     `form:"reverse"`
+	Count   int      `form:"count" serialize:"-"`
+	Offset  int      `form:"offset" serialize:"-"`
+	Public  bool     `form:"-" serialize:"-"` // Excludes private pictures, set by the server only
+	Scope   string   `form:"-" serialize:"-"` // Limits results to an originals subfolder, set by the server only
+	Shared  []string `form:"-" serialize:"-"` // Album UIDs shared with the user, found regardless of scope
+}
+
+func NewVisitSearch(query string) SearchVisits {
+	return SearchVisits{Query: query}
+}
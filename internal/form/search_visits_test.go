@@ -0,0 +1,14 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVisitSearch(t *testing.T) {
+	r := NewVisitSearch("lisbon")
+	assert.IsType(t, SearchVisits{}, r)
+	assert.Equal(t, "lisbon", r.Query)
+	assert.False(t, r.Reverse)
+}
@@ -9,6 +9,8 @@ type Selection struct {
 	Labels   []string `json:"labels"`
 	Places   []string `json:"places"`
 	Subjects []string `json:"subjects"`
+	Scope    string   `json:"-"` // Limits the selection to an originals subfolder, set by the server only
+	Shared   []string `json:"-"` // Album UIDs shared with the user, selected regardless of scope
 }
 
 func (f Selection) Empty() bool {
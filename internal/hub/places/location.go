@@ -23,6 +23,7 @@ type Location struct {
 	LocPostcode string  `json:"postcode"`
 	LocCategory string  `json:"category"`
 	Place       Place   `json:"place"`
+	LocSource   string  `json:"-"`
 	Cached      bool    `json:"-"`
 }
 
@@ -224,5 +225,9 @@ func (l Location) Keywords() (result []string) {
 
 // Source returns the backend API name.
 func (l Location) Source() string {
-	return "places"
+	if l.LocSource != "" {
+		return l.LocSource
+	}
+
+	return ApiName
 }
@@ -1,5 +1,13 @@
 package places
 
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
 // Place represents a region identified by city, state, and country.
 type Place struct {
 	PlaceID     string `json:"id"`
@@ -10,3 +18,38 @@ type Place struct {
 	LocCountry  string `json:"country"`
 	LocKeywords string `json:"keywords"`
 }
+
+// NewPlace returns a place with a generated ID and label, e.g. for results from other geocoding APIs.
+func NewPlace(district, city, state, countryCode, countryName string) Place {
+	countryCode = strings.ToLower(strings.TrimSpace(countryCode))
+
+	var parts []string
+
+	for _, s := range []string{district, city, state, countryName} {
+		if s = strings.TrimSpace(s); s == "" || list.Contains(parts, s) {
+			continue
+		}
+
+		parts = append(parts, s)
+	}
+
+	return Place{
+		PlaceID:     NewPlaceID(countryCode, state, city),
+		LocLabel:    strings.Join(parts, ", "),
+		LocDistrict: district,
+		LocCity:     city,
+		LocState:    state,
+		LocCountry:  countryCode,
+	}
+}
+
+// NewPlaceID returns a place identifier derived from the country code, state, and city name.
+func NewPlaceID(countryCode, state, city string) string {
+	if countryCode == "" {
+		countryCode = "zz"
+	}
+
+	hash := sha1.Sum([]byte(strings.Join([]string{countryCode, state, city}, "|")))
+
+	return fmt.Sprintf("%s:%x", countryCode, hash[:6])
+}
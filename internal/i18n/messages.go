@@ -38,6 +38,8 @@ const (
 	ErrBusy
 	ErrWakeupInterval
 	ErrAccountConnect
+	ErrPasscodeRequired
+	ErrInvalidPasscode
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -121,6 +123,8 @@ var Messages = MessageMap{
 	ErrBusy:               gettext("Busy, please try again later"),
 	ErrWakeupInterval:     gettext("The wakeup interval is %s, but must be 1h or less"),
 	ErrAccountConnect:     gettext("Your account could not be connected"),
+	ErrPasscodeRequired:   gettext("Please enter the code from your authenticator app"),
+	ErrInvalidPasscode:    gettext("Invalid code, please try again"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -0,0 +1,98 @@
+package inference
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// HttpTimeout is the maximum duration of an inference request.
+var HttpTimeout = 60 * time.Second
+
+// HttpMaxResponse is the maximum size of an inference server response in bytes.
+var HttpMaxResponse int64 = 32 * 1024 * 1024
+
+// Http runs inference with a model on a local HTTP server, e.g. in another container.
+//
+// The JPEG image is sent as request body with a POST request to the server URL,
+// and the server responds with a JSON encoded Result:
+//
+//	{"labels": [{"name": "cat", "score": 0.93}], "embeddings": [[0.01, -0.2, ...]]}
+//
+// Object detection models return bounding boxes relative to the image size instead:
+//
+//	{"objects": [{"name": "dog", "score": 0.87, "x": 0.1, "y": 0.2, "w": 0.3, "h": 0.4}]}
+//
+// Pet face models additionally return an embedding for each face, e.g. "embedding": [0.01, -0.2, ...].
+//
+// Models with a text encoder, such as CLIP, receive text as "text/plain" request body instead.
+type Http struct {
+	url    string
+	client *http.Client
+}
+
+// NewHttp returns a new HTTP inference client for the specified server URL.
+func NewHttp(url string) *Http {
+	return &Http{url: url, client: &http.Client{Timeout: HttpTimeout}}
+}
+
+// Url returns the server URL.
+func (m *Http) Url() string {
+	return m.url
+}
+
+// Infer sends a JPEG image to the inference server and returns the result.
+func (m *Http) Infer(jpeg []byte) (result Result, err error) {
+	return m.post("image/jpeg", jpeg)
+}
+
+// InferText sends text to the inference server and returns the result.
+func (m *Http) InferText(text string) (result Result, err error) {
+	return m.post("text/plain; charset=utf-8", []byte(text))
+}
+
+// post sends data to the inference server and returns the result.
+func (m *Http) post(contentType string, data []byte) (result Result, err error) {
+	req, err := http.NewRequest(http.MethodPost, m.url, bytes.NewReader(data))
+
+	if err != nil {
+		return result, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.client.Do(req)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("inference server %s returned status %d", clean.Log(m.url), resp.StatusCode)
+	}
+
+	// Read one more byte than allowed to detect responses that exceed the limit.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, HttpMaxResponse+1))
+
+	if err != nil {
+		return result, err
+	} else if int64(len(body)) > HttpMaxResponse {
+		return result, fmt.Errorf("response from inference server %s exceeds %d bytes", clean.Log(m.url), HttpMaxResponse)
+	}
+
+	if err = json.Unmarshal(body, &result); err != nil {
+		return result, fmt.Errorf("invalid response from inference server %s (%s)", clean.Log(m.url), err)
+	}
+
+	log.Tracef("inference: %s returned %d labels, %d embeddings, and %d objects", clean.Log(m.url), len(result.Labels), len(result.Embeddings), len(result.Objects))
+
+	return result, nil
+}
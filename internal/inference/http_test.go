@@ -0,0 +1,151 @@
+package inference
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubServer returns a test server that stands in for a model and checks the request.
+func stubServer(t *testing.T, result Result) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+
+		if string(body) != "jpeg" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+}
+
+func TestHttp_Infer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := stubServer(t, Result{
+			Labels:     Labels{{Name: "cat", Score: 0.9}, {Name: "dog", Score: 0.05}},
+			Embeddings: [][]float32{{0.1, 0.2, 0.3}},
+		})
+
+		defer server.Close()
+
+		m := NewHttp(server.URL)
+
+		assert.Equal(t, server.URL, m.Url())
+
+		result, err := m.Infer([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result.Labels, 2)
+		assert.Equal(t, float32(0.9), result.Labels.Score("cat"))
+		assert.Equal(t, []float32{0.9, 0, 0.05}, result.Scores([]string{"cat", "bird", "dog"}))
+		assert.Equal(t, [][]float32{{0.1, 0.2, 0.3}}, result.Embeddings)
+	})
+	t.Run("Objects", func(t *testing.T) {
+		server := stubServer(t, Result{
+			Objects: Objects{{Name: "dog", Score: 0.87, X: 0.1, Y: 0.2, W: 0.3, H: 0.4}},
+		})
+
+		defer server.Close()
+
+		result, err := NewHttp(server.URL).Infer([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Labels)
+		assert.Equal(t, Objects{{Name: "dog", Score: 0.87, X: 0.1, Y: 0.2, W: 0.3, H: 0.4}}, result.Objects)
+	})
+	t.Run("Pets", func(t *testing.T) {
+		server := stubServer(t, Result{
+			Objects: Objects{{Name: "cat", Score: 0.9, X: 0.1, Y: 0.2, W: 0.3, H: 0.4, Embedding: []float32{0.1, -0.2, 0.3}}},
+		})
+
+		defer server.Close()
+
+		result, err := NewHttp(server.URL).Infer([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result.Objects, 1)
+		assert.Equal(t, []float32{0.1, -0.2, 0.3}, result.Objects[0].Embedding)
+	})
+	t.Run("BadStatus", func(t *testing.T) {
+		server := stubServer(t, Result{})
+
+		defer server.Close()
+
+		_, err := NewHttp(server.URL).Infer([]byte("png"))
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidResponse", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("<html>"))
+		}))
+
+		defer server.Close()
+
+		_, err := NewHttp(server.URL).Infer([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		server := stubServer(t, Result{Embeddings: [][]float32{make([]float32, 512)}})
+
+		defer server.Close()
+
+		limit := HttpMaxResponse
+		HttpMaxResponse = 64
+
+		defer func() {
+			HttpMaxResponse = limit
+		}()
+
+		_, err := NewHttp(server.URL).Infer([]byte("jpeg"))
+
+		assert.ErrorContains(t, err, "exceeds 64 bytes")
+	})
+	t.Run("Unreachable", func(t *testing.T) {
+		_, err := NewHttp("http://127.0.0.1:1/").Infer([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHttp_InferText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "text/plain; charset=utf-8", r.Header.Get("Content-Type"))
+		assert.Equal(t, "a dog on the beach", string(body))
+
+		_ = json.NewEncoder(w).Encode(Result{Embeddings: [][]float32{{0.5, 0.5}}})
+	}))
+
+	defer server.Close()
+
+	var m TextModel = NewHttp(server.URL)
+
+	result, err := m.InferText("a dog on the beach")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, [][]float32{{0.5, 0.5}}, result.Embeddings)
+}
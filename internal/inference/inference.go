@@ -0,0 +1,95 @@
+/*
+Package inference provides a common interface for image classification and embedding models,
+including a client for models that run on a local HTTP inference server.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+The AGPL is supplemented by our Trademark and Brand Guidelines,
+which describe how our Brand Assets may be used:
+<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package inference
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Model runs inference on JPEG images, e.g. to classify them or to compute embeddings.
+type Model interface {
+	Infer(jpeg []byte) (Result, error)
+}
+
+// TextModel runs inference on text, e.g. to compute the embedding of a search query.
+type TextModel interface {
+	InferText(text string) (Result, error)
+}
+
+// Label represents a class name and its score, usually a probability between 0 and 1.
+type Label struct {
+	Name  string  `json:"name"`
+	Score float32 `json:"score"`
+}
+
+// Labels represents a list of class names and scores.
+type Labels []Label
+
+// Score returns the score of the label with the specified name, or 0 if it was not found.
+func (l Labels) Score(name string) float32 {
+	for _, label := range l {
+		if label.Name == name {
+			return label.Score
+		}
+	}
+
+	return 0
+}
+
+// Object represents a detected object with its bounding box, relative to the image size,
+// and an optional embedding for recognizing individuals, e.g. pets.
+type Object struct {
+	Name      string    `json:"name"`
+	Score     float32   `json:"score"`
+	X         float32   `json:"x"`
+	Y         float32   `json:"y"`
+	W         float32   `json:"w"`
+	H         float32   `json:"h"`
+	Embedding []float32 `json:"embedding,omitempty"`
+}
+
+// Objects represents a list of detected objects.
+type Objects []Object
+
+// Result represents the inference output for an image.
+type Result struct {
+	Labels     Labels      `json:"labels,omitempty"`
+	Embeddings [][]float32 `json:"embeddings,omitempty"`
+	Objects    Objects     `json:"objects,omitempty"`
+}
+
+// Scores returns the scores of the labels in the specified order, which must match the number of names.
+func (r Result) Scores(names []string) []float32 {
+	result := make([]float32, len(names))
+
+	for i, name := range names {
+		result[i] = r.Labels.Score(name)
+	}
+
+	return result
+}
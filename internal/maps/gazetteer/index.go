@@ -0,0 +1,232 @@
+package gazetteer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// Admin1FileName is the name of the GeoNames file with first-level administrative division names.
+const Admin1FileName = "admin1CodesASCII.txt"
+
+// Entry represents a populated place in the gazetteer.
+type Entry struct {
+	Name       string
+	Lat        float64
+	Lng        float64
+	Country    string
+	State      string
+	District   bool
+	Population int
+}
+
+// Position returns the entry position.
+func (e Entry) Position() geo.Position {
+	return geo.Position{Lat: e.Lat, Lng: e.Lng}
+}
+
+// cellKey identifies a grid cell of one degree latitude and longitude.
+type cellKey struct {
+	lat int
+	lng int
+}
+
+// newCellKey returns the grid cell key for the specified position.
+func newCellKey(lat, lng float64) cellKey {
+	return cellKey{lat: int(math.Floor(lat)), lng: int(math.Floor(lng))}
+}
+
+// Index represents a spatial index of populated places.
+type Index struct {
+	cells map[cellKey][]Entry
+	count int
+}
+
+// NewIndex returns a new, empty index.
+func NewIndex() *Index {
+	return &Index{cells: make(map[cellKey][]Entry)}
+}
+
+// Add adds an entry to the index.
+func (idx *Index) Add(e Entry) {
+	k := newCellKey(e.Lat, e.Lng)
+	idx.cells[k] = append(idx.cells[k], e)
+	idx.count++
+}
+
+// Len returns the number of indexed entries.
+func (idx *Index) Len() int {
+	if idx == nil {
+		return 0
+	}
+
+	return idx.count
+}
+
+// Nearest returns the closest city, or district if requested, within maxKm.
+func (idx *Index) Nearest(lat, lng, maxKm float64, district bool) (result Entry, km float64, ok bool) {
+	if idx.Len() == 0 {
+		return result, 0, false
+	}
+
+	pos := geo.Position{Lat: lat, Lng: lng}
+
+	// Number of grid cells to search in each direction.
+	latSpan := int(math.Ceil(maxKm / 111))
+	lngSpan := 180
+
+	if c := math.Cos(geo.DegToRad(lat)); c > 0.01 {
+		lngSpan = int(math.Min(180, math.Ceil(maxKm/(111*c))))
+	}
+
+	center := newCellKey(lat, lng)
+	km = maxKm
+
+	for i := center.lat - latSpan; i <= center.lat+latSpan; i++ {
+		for j := center.lng - lngSpan; j <= center.lng+lngSpan; j++ {
+			// Wrap around the antimeridian.
+			k := cellKey{lat: i, lng: (j+540)%360 - 180}
+
+			for _, e := range idx.cells[k] {
+				if e.District != district {
+					continue
+				}
+
+				if d := geo.Km(pos, e.Position()); d <= km {
+					result, km, ok = e, d, true
+				}
+			}
+		}
+	}
+
+	return result, km, ok
+}
+
+// LoadIndex creates an index from the GeoNames files in the specified directory.
+func LoadIndex(dir string) (*Index, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("gazetteer path not set")
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	idx := NewIndex()
+	admin1 := make(map[string]string)
+
+	// Read state names first.
+	if f, err := os.Open(filepath.Join(dir, Admin1FileName)); err == nil {
+		err = ReadAdmin1(f, admin1)
+		f.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fileName := range files {
+		if filepath.Base(fileName) == Admin1FileName {
+			continue
+		}
+
+		f, err := os.Open(fileName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = ReadGeoNames(f, admin1, idx)
+		f.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("%s in %s", err, clean.Log(filepath.Base(fileName)))
+		}
+	}
+
+	if idx.Len() == 0 {
+		return nil, fmt.Errorf("no places found in %s", clean.Log(dir))
+	}
+
+	return idx, nil
+}
+
+// ReadAdmin1 reads first-level administrative division names, e.g. "DE.16	Berlin	Berlin	2950157".
+func ReadAdmin1(r io.Reader, result map[string]string) error {
+	s := bufio.NewScanner(r)
+
+	for s.Scan() {
+		cols := strings.Split(s.Text(), "\t")
+
+		if len(cols) < 3 || cols[0] == "" {
+			continue
+		}
+
+		// Prefer ASCII name if the name is empty.
+		if cols[1] != "" {
+			result[cols[0]] = cols[1]
+		} else {
+			result[cols[0]] = cols[2]
+		}
+	}
+
+	return s.Err()
+}
+
+// ReadGeoNames reads populated places in GeoNames dump format, e.g. from cities500.txt or DE.txt.
+func ReadGeoNames(r io.Reader, admin1 map[string]string, idx *Index) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for s.Scan() {
+		cols := strings.Split(s.Text(), "\t")
+
+		// Skip invalid lines and features other than populated places.
+		if len(cols) < 15 || cols[6] != "P" {
+			continue
+		}
+
+		switch cols[7] {
+		case "PPLH", "PPLQ", "PPLW", "PPLCH":
+			// Skip historical, abandoned, and destroyed places.
+			continue
+		}
+
+		lat, err := strconv.ParseFloat(cols[4], 64)
+
+		if err != nil {
+			continue
+		}
+
+		lng, err := strconv.ParseFloat(cols[5], 64)
+
+		if err != nil {
+			continue
+		}
+
+		country := strings.ToUpper(cols[8])
+		population, _ := strconv.Atoi(cols[14])
+
+		idx.Add(Entry{
+			Name:       cols[1],
+			Lat:        lat,
+			Lng:        lng,
+			Country:    strings.ToLower(country),
+			State:      admin1[country+"."+cols[10]],
+			District:   cols[7] == "PPLX",
+			Population: population,
+		})
+	}
+
+	return s.Err()
+}
@@ -0,0 +1,74 @@
+package gazetteer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadIndex(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		idx, err := LoadIndex("testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, idx.Len())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := LoadIndex("testdata/notfound")
+
+		assert.Error(t, err)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := LoadIndex("")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReadAdmin1(t *testing.T) {
+	result := make(map[string]string)
+
+	if err := ReadAdmin1(strings.NewReader("DE.16\tBerlin\tBerlin\t2950157\nFR.11\t\tIle-de-France\t3012874\ninvalid\n"), result); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]string{"DE.16": "Berlin", "FR.11": "Ile-de-France"}, result)
+}
+
+func TestIndex_Nearest(t *testing.T) {
+	idx, err := LoadIndex("testdata")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("City", func(t *testing.T) {
+		e, km, ok := idx.Nearest(52.5163, 13.3777, 50, false)
+
+		assert.True(t, ok)
+		assert.Equal(t, "Berlin", e.Name)
+		assert.Equal(t, "Berlin", e.State)
+		assert.Equal(t, "de", e.Country)
+		assert.Less(t, km, 3.0)
+	})
+	t.Run("District", func(t *testing.T) {
+		e, _, ok := idx.Nearest(52.5163, 13.3777, 3, true)
+
+		assert.True(t, ok)
+		assert.Equal(t, "Mitte", e.Name)
+	})
+	t.Run("TooFar", func(t *testing.T) {
+		_, _, ok := idx.Nearest(40.7128, -74.0060, 50, false)
+
+		assert.False(t, ok)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, _, ok := NewIndex().Nearest(52.5163, 13.3777, 50, false)
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,204 @@
+package gazetteer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// Location represents a specific geolocation identified by its S2 ID.
+type Location struct {
+	ID          string
+	LocLat      float64
+	LocLng      float64
+	LocDistrict string
+	LocCity     string
+	LocState    string
+	LocCountry  string
+	Cached      bool
+}
+
+// ApiName is the backend API name.
+const ApiName = "gazetteer"
+
+// Path is the directory containing the GeoNames dataset.
+var Path = ""
+
+// MaxCityDistance is the max distance in km between a location and the nearest city.
+var MaxCityDistance = 50.0
+
+// MaxDistrictDistance is the max distance in km between a location and the nearest district.
+var MaxDistrictDistance = 3.0
+
+// LoadRetry is the time to wait before loading the index again after it failed to load.
+var LoadRetry = 5 * time.Minute
+
+var index *Index
+var indexPath string
+var indexErr error
+var indexFailed time.Time
+var indexMutex = sync.Mutex{}
+
+// GetIndex returns the gazetteer index, which is loaded from Path on first use. If loading fails,
+// the error is returned without reading the files again until LoadRetry has passed.
+func GetIndex() (*Index, error) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	if indexPath != Path {
+		index, indexErr = nil, nil
+	} else if index != nil {
+		return index, nil
+	} else if indexErr != nil && time.Since(indexFailed) < LoadRetry {
+		return nil, indexErr
+	}
+
+	start := time.Now()
+
+	idx, err := LoadIndex(Path)
+
+	if err != nil {
+		indexPath, indexErr, indexFailed = Path, err, start
+		log.Errorf("gazetteer: %s, retrying in %s", err, LoadRetry)
+		return nil, err
+	}
+
+	index, indexPath, indexErr = idx, Path, nil
+	cache.Flush()
+
+	log.Infof("gazetteer: indexed %d places [%s]", idx.Len(), time.Since(start))
+
+	return index, nil
+}
+
+// FindLocation retrieves location details from the local gazetteer.
+func FindLocation(id string) (result Location, err error) {
+	// Normalize S2 Cell ID.
+	id = s2.NormalizeToken(id)
+
+	// Valid?
+	if len(id) == 0 {
+		return result, fmt.Errorf("empty cell id")
+	} else if n := len(id); n < 4 || n > 16 {
+		return result, fmt.Errorf("invalid cell id %s", clean.Log(id))
+	}
+
+	// Convert S2 Cell ID to latitude and longitude.
+	lat, lng := s2.LatLng(id)
+
+	// Return if latitude and longitude are null.
+	if lat == 0.0 || lng == 0.0 {
+		return result, fmt.Errorf("skipping lat %f, lng %f", lat, lng)
+	}
+
+	idx, err := GetIndex()
+
+	if err != nil {
+		return result, err
+	}
+
+	// Location details cached?
+	if hit, ok := cache.Get(id); ok {
+		log.Tracef("gazetteer: cache hit for lat %f, lng %f", lat, lng)
+		cached := hit.(Location)
+		cached.Cached = true
+		return cached, nil
+	}
+
+	city, _, ok := idx.Nearest(lat, lng, MaxCityDistance, false)
+
+	if !ok {
+		return result, fmt.Errorf("no place found near lat %f, lng %f", lat, lng)
+	}
+
+	result = Location{
+		ID:         id,
+		LocLat:     lat,
+		LocLng:     lng,
+		LocCity:    city.Name,
+		LocState:   city.State,
+		LocCountry: city.Country,
+	}
+
+	if district, _, ok := idx.Nearest(lat, lng, MaxDistrictDistance, true); ok && district.Name != city.Name {
+		result.LocDistrict = district.Name
+	}
+
+	cache.SetDefault(id, result)
+
+	return result, nil
+}
+
+// CellID returns the S2 cell identifier string.
+func (l Location) CellID() string {
+	return l.ID
+}
+
+// PlaceID returns a place identifier string derived from the country, state, and city.
+func (l Location) PlaceID() string {
+	return places.NewPlaceID(l.LocCountry, l.LocState, l.LocCity)
+}
+
+// Name returns the location name if any.
+func (l Location) Name() (result string) {
+	return ""
+}
+
+// Street returns the location street if any.
+func (l Location) Street() (result string) {
+	return ""
+}
+
+// Postcode returns the location postcode if any.
+func (l Location) Postcode() (result string) {
+	return ""
+}
+
+// Category returns the location category if any.
+func (l Location) Category() (result string) {
+	return ""
+}
+
+// City returns the location address city name.
+func (l Location) City() (result string) {
+	return l.LocCity
+}
+
+// District returns the location address district name.
+func (l Location) District() (result string) {
+	return l.LocDistrict
+}
+
+// CountryCode returns the location address country code.
+func (l Location) CountryCode() (result string) {
+	return l.LocCountry
+}
+
+// State returns the location address state name.
+func (l Location) State() (result string) {
+	return clean.State(l.LocState, l.CountryCode())
+}
+
+// Latitude returns the location position latitude.
+func (l Location) Latitude() (result float64) {
+	return l.LocLat
+}
+
+// Longitude returns the location position longitude.
+func (l Location) Longitude() (result float64) {
+	return l.LocLng
+}
+
+// Keywords returns location keywords if any.
+func (l Location) Keywords() (result []string) {
+	return []string{}
+}
+
+// Source returns the backend API name.
+func (l Location) Source() string {
+	return ApiName
+}
@@ -0,0 +1,98 @@
+package gazetteer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+func TestFindLocation(t *testing.T) {
+	Path = "testdata"
+
+	t.Run("Berlin", func(t *testing.T) {
+		l, err := FindLocation(s2.Token(52.5163, 13.3777))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "Berlin", l.State())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, ApiName, l.Source())
+		assert.Len(t, l.PlaceID(), 15)
+		assert.Equal(t, "de:", l.PlaceID()[:3])
+	})
+	t.Run("Potsdam", func(t *testing.T) {
+		l, err := FindLocation(s2.Token(52.4009, 13.0591))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, l.Cached)
+		assert.Equal(t, "", l.District())
+		assert.Equal(t, "Potsdam", l.City())
+		assert.Equal(t, "Brandenburg", l.State())
+
+		l, err = FindLocation(s2.Token(52.4009, 13.0591))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, l.Cached)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := FindLocation(s2.Token(40.7128, -74.0060))
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := FindLocation("ab")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetIndex(t *testing.T) {
+	dir := t.TempDir()
+	Path = dir
+
+	defer func() {
+		Path = "testdata"
+	}()
+
+	_, err := GetIndex()
+
+	assert.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join("testdata", "cities500.txt"))
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(filepath.Join(dir, "cities500.txt"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The files are not read again until the retry time has passed.
+	_, err = GetIndex()
+
+	assert.Error(t, err)
+
+	indexFailed = time.Now().Add(-LoadRetry)
+
+	idx, err := GetIndex()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, idx.Len(), 0)
+}
@@ -0,0 +1,169 @@
+package geocoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// UserAgent is the HTTP user agent sent with each request.
+var UserAgent = ""
+
+// Client represents a rate-limited HTTP client for a geocoding API.
+type Client struct {
+	Url       string
+	RateLimit float64
+	Language  string
+	client    *http.Client
+	mutex     sync.Mutex
+	last      time.Time
+}
+
+// NewClient returns a new client for the specified endpoint, rate limit in requests per second, and language.
+func NewClient(endpoint string, rateLimit float64, language string) *Client {
+	return &Client{
+		Url:       strings.TrimRight(endpoint, "/"),
+		RateLimit: rateLimit,
+		Language:  strings.TrimSpace(language),
+		client:    &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// wait blocks until the next request is allowed by the rate limit.
+func (c *Client) wait() {
+	if c.RateLimit <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	interval := time.Duration(float64(time.Second) / c.RateLimit)
+
+	if d := interval - time.Since(c.last); d > 0 {
+		time.Sleep(d)
+	}
+
+	c.last = time.Now()
+}
+
+// Get sends a GET request and decodes the JSON response into result.
+func (c *Client) Get(path string, query url.Values, result interface{}) error {
+	reqUrl := c.Url + path + "?" + query.Encode()
+
+	// Create GET request instance.
+	req, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+
+	if err != nil {
+		return err
+	}
+
+	// Set user agent.
+	if UserAgent != "" {
+		req.Header.Set("User-Agent", UserAgent)
+	} else {
+		req.Header.Set("User-Agent", "PhotoPrism/Test")
+	}
+
+	// Set preferred language.
+	if c.Language != "" {
+		req.Header.Set("Accept-Language", c.Language)
+	}
+
+	c.wait()
+
+	log.Tracef("geocoder: sending request to %s", reqUrl)
+
+	r, err := c.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("%s (http request failed)", err)
+	}
+
+	defer r.Body.Close()
+
+	if r.StatusCode >= 400 {
+		return fmt.Errorf("request failed with code %d", r.StatusCode)
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(result); err != nil {
+		return fmt.Errorf("%s (decode json failed)", err)
+	}
+
+	return nil
+}
+
+// cellLatLng validates the S2 cell id and returns its normalized token and position.
+func cellLatLng(id string) (token string, lat, lng float64, err error) {
+	// Normalize S2 Cell ID.
+	token = s2.NormalizeToken(id)
+
+	// Valid?
+	if len(token) == 0 {
+		return token, 0, 0, fmt.Errorf("empty cell id")
+	} else if n := len(token); n < 4 || n > 16 {
+		return token, 0, 0, fmt.Errorf("invalid cell id %s", clean.Log(token))
+	}
+
+	// Convert S2 Cell ID to latitude and longitude.
+	lat, lng = s2.LatLng(token)
+
+	// Return if latitude and longitude are null.
+	if lat == 0.0 || lng == 0.0 {
+		return token, lat, lng, fmt.Errorf("skipping lat %f, lng %f", lat, lng)
+	}
+
+	return token, lat, lng, nil
+}
+
+// findCached returns a cached location, or calls find and caches its result.
+func findCached(api, lang, id string, find func(token string, lat, lng float64) (places.Location, error)) (result places.Location, err error) {
+	token, lat, lng, err := cellLatLng(id)
+
+	if err != nil {
+		return result, err
+	}
+
+	key := api + ":" + lang + ":" + token
+
+	// Location details cached?
+	if hit, ok := cache.Get(key); ok {
+		log.Tracef("geocoder: cache hit for lat %f, lng %f", lat, lng)
+		cached := hit.(places.Location)
+		cached.Cached = true
+		return cached, nil
+	}
+
+	start := time.Now()
+
+	if result, err = find(token, lat, lng); err != nil {
+		return result, err
+	}
+
+	result.ID = token
+	result.LocSource = api
+
+	cache.SetDefault(key, result)
+	log.Tracef("geocoder: cached cell %s [%s]", clean.Log(token), time.Since(start))
+
+	return result, nil
+}
+
+// firstNonEmpty returns the first string that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,44 @@
+/*
+Package geocoder provides reverse geocoding with self-hostable APIs like Nominatim and Photon.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package geocoder
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/maps"
+)
+
+var log = event.Log
+
+// New returns a new provider for the specified API name, or nil if it is not supported.
+func New(api, url string, rateLimit float64, language string) maps.Provider {
+	switch api {
+	case NominatimName:
+		return NewNominatim(url, rateLimit, language)
+	case PhotonName:
+		return NewPhoton(url, rateLimit, language)
+	default:
+		return nil
+	}
+}
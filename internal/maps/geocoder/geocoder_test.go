@@ -0,0 +1,47 @@
+package geocoder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer returns a test server that responds with the specified file and records the last request.
+func testServer(t *testing.T, fileName string, last **http.Request) *httptest.Server {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, NominatimName, New(NominatimName, "", 0, "").Name())
+	assert.Equal(t, PhotonName, New(PhotonName, "", 0, "").Name())
+	assert.Nil(t, New("places", "", 0, ""))
+}
+
+func TestClient_Wait(t *testing.T) {
+	c := NewClient("http://localhost/", 0, "")
+
+	assert.Equal(t, "http://localhost", c.Url)
+
+	c.wait()
+	c.wait()
+
+	assert.True(t, c.last.IsZero())
+}
+
+func TestFirstNonEmpty(t *testing.T) {
+	assert.Equal(t, "Berlin", firstNonEmpty("", " ", "Berlin", "Potsdam"))
+	assert.Equal(t, "", firstNonEmpty())
+}
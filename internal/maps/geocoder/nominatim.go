@@ -0,0 +1,125 @@
+package geocoder
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+)
+
+// NominatimName is the Nominatim API name.
+const NominatimName = "nominatim"
+
+// NominatimUrl is the default Nominatim API endpoint.
+var NominatimUrl = "https://nominatim.openstreetmap.org"
+
+// NominatimRateLimit is the default max number of requests per second, as required by the public usage policy.
+var NominatimRateLimit = 1.0
+
+// NominatimResult represents a reverse geocoding response in jsonv2 format.
+type NominatimResult struct {
+	Error       string           `json:"error"`
+	PlaceID     int64            `json:"place_id"`
+	Lat         string           `json:"lat"`
+	Lon         string           `json:"lon"`
+	Category    string           `json:"category"`
+	Type        string           `json:"type"`
+	Name        string           `json:"name"`
+	DisplayName string           `json:"display_name"`
+	Address     NominatimAddress `json:"address"`
+}
+
+// NominatimAddress represents the address details of a Nominatim result.
+type NominatimAddress struct {
+	HouseNumber   string `json:"house_number"`
+	Road          string `json:"road"`
+	Pedestrian    string `json:"pedestrian"`
+	Neighbourhood string `json:"neighbourhood"`
+	Quarter       string `json:"quarter"`
+	Suburb        string `json:"suburb"`
+	Borough       string `json:"borough"`
+	CityDistrict  string `json:"city_district"`
+	Hamlet        string `json:"hamlet"`
+	Village       string `json:"village"`
+	Town          string `json:"town"`
+	City          string `json:"city"`
+	Municipality  string `json:"municipality"`
+	County        string `json:"county"`
+	State         string `json:"state"`
+	Postcode      string `json:"postcode"`
+	Country       string `json:"country"`
+	CountryCode   string `json:"country_code"`
+}
+
+// Location maps the Nominatim result onto a places location.
+func (r NominatimResult) Location() places.Location {
+	a := r.Address
+
+	city := firstNonEmpty(a.City, a.Town, a.Village, a.Municipality, a.Hamlet)
+	district := firstNonEmpty(a.Suburb, a.CityDistrict, a.Borough, a.Quarter, a.Neighbourhood)
+
+	result := places.Location{
+		LocName:     r.Name,
+		LocStreet:   firstNonEmpty(a.Road, a.Pedestrian),
+		LocPostcode: a.Postcode,
+		LocCategory: r.Category,
+		Place:       places.NewPlace(district, city, firstNonEmpty(a.State, a.County), a.CountryCode, a.Country),
+	}
+
+	result.LocLat, _ = strconv.ParseFloat(r.Lat, 64)
+	result.LocLng, _ = strconv.ParseFloat(r.Lon, 64)
+
+	return result
+}
+
+// Nominatim represents a Nominatim API client, see https://nominatim.org/release-docs/latest/api/Reverse/.
+type Nominatim struct {
+	*Client
+}
+
+// NewNominatim returns a new Nominatim API client.
+func NewNominatim(endpoint string, rateLimit float64, language string) *Nominatim {
+	if endpoint == "" {
+		endpoint = NominatimUrl
+	}
+
+	if rateLimit == 0 {
+		rateLimit = NominatimRateLimit
+	}
+
+	return &Nominatim{Client: NewClient(endpoint, rateLimit, language)}
+}
+
+// Name returns the API name.
+func (g *Nominatim) Name() string {
+	return NominatimName
+}
+
+// FindLocation retrieves location details for the specified S2 cell id.
+func (g *Nominatim) FindLocation(id string) (places.Location, error) {
+	return findCached(NominatimName, g.Language, id, func(token string, lat, lng float64) (result places.Location, err error) {
+		query := url.Values{}
+		query.Set("format", "jsonv2")
+		query.Set("addressdetails", "1")
+		query.Set("zoom", "18")
+		query.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+		query.Set("lon", strconv.FormatFloat(lng, 'f', -1, 64))
+
+		if g.Language != "" {
+			query.Set("accept-language", g.Language)
+		}
+
+		var r NominatimResult
+
+		if err = g.Get("/reverse", query, &r); err != nil {
+			return result, err
+		} else if r.Error != "" {
+			return result, fmt.Errorf("%s", r.Error)
+		} else if r.Address.CountryCode == "" {
+			return result, fmt.Errorf("no result for %s", token)
+		}
+
+		return r.Location(), nil
+	})
+}
@@ -0,0 +1,65 @@
+package geocoder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+func TestNominatim_FindLocation(t *testing.T) {
+	var req *http.Request
+
+	srv := testServer(t, "testdata/nominatim.json", &req)
+	defer srv.Close()
+
+	g := NewNominatim(srv.URL, 100, "de")
+
+	t.Run("Success", func(t *testing.T) {
+		l, err := g.FindLocation(s2.Token(52.5208, 13.40953))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/reverse", req.URL.Path)
+		assert.Equal(t, "jsonv2", req.URL.Query().Get("format"))
+		assert.Equal(t, "de", req.URL.Query().Get("accept-language"))
+		assert.Equal(t, "de", req.Header.Get("Accept-Language"))
+
+		assert.False(t, l.Cached)
+		assert.Equal(t, NominatimName, l.Source())
+		assert.Equal(t, "Berliner Fernsehturm", l.Name())
+		assert.Equal(t, "Panoramastraße", l.Street())
+		assert.Equal(t, "10178", l.Postcode())
+		assert.Equal(t, "man_made", l.Category())
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, "Mitte, Berlin, Deutschland", l.Label())
+		assert.Equal(t, "de:", l.PlaceID()[:3])
+	})
+	t.Run("Cached", func(t *testing.T) {
+		l, err := g.FindLocation(s2.Token(52.5208, 13.40953))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, l.Cached)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := g.FindLocation("ab")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewNominatim(t *testing.T) {
+	g := NewNominatim("", 0, "")
+
+	assert.Equal(t, NominatimUrl, g.Url)
+	assert.Equal(t, NominatimRateLimit, g.RateLimit)
+}
@@ -0,0 +1,132 @@
+package geocoder
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+)
+
+// PhotonName is the Photon API name.
+const PhotonName = "photon"
+
+// PhotonUrl is the default Photon API endpoint.
+var PhotonUrl = "https://photon.komoot.io"
+
+// PhotonRateLimit is the default max number of requests per second.
+var PhotonRateLimit = 1.0
+
+// PhotonResult represents a reverse geocoding response in GeoJSON format.
+type PhotonResult struct {
+	Features []PhotonFeature `json:"features"`
+}
+
+// PhotonFeature represents a GeoJSON feature returned by Photon.
+type PhotonFeature struct {
+	Geometry struct {
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties PhotonProperties `json:"properties"`
+}
+
+// PhotonProperties represents the properties of a Photon feature.
+type PhotonProperties struct {
+	Name        string `json:"name"`
+	Street      string `json:"street"`
+	HouseNumber string `json:"housenumber"`
+	Postcode    string `json:"postcode"`
+	Locality    string `json:"locality"`
+	District    string `json:"district"`
+	City        string `json:"city"`
+	County      string `json:"county"`
+	State       string `json:"state"`
+	Country     string `json:"country"`
+	CountryCode string `json:"countrycode"`
+	OsmKey      string `json:"osm_key"`
+	OsmValue    string `json:"osm_value"`
+	Type        string `json:"type"`
+}
+
+// Location maps the Photon feature onto a places location.
+func (f PhotonFeature) Location() places.Location {
+	p := f.Properties
+
+	// The feature itself may be the city or district.
+	city := p.City
+	district := firstNonEmpty(p.District, p.Locality)
+
+	switch p.Type {
+	case "city":
+		city = firstNonEmpty(p.City, p.Name)
+	case "district", "locality":
+		district = firstNonEmpty(district, p.Name)
+	}
+
+	result := places.Location{
+		LocName:     p.Name,
+		LocStreet:   p.Street,
+		LocPostcode: p.Postcode,
+		LocCategory: p.OsmKey,
+		Place:       places.NewPlace(district, city, firstNonEmpty(p.State, p.County), p.CountryCode, p.Country),
+	}
+
+	if p.Type == "city" || p.Type == "district" || p.Type == "locality" {
+		result.LocName = ""
+	}
+
+	if c := f.Geometry.Coordinates; len(c) >= 2 {
+		result.LocLng, result.LocLat = c[0], c[1]
+	}
+
+	return result
+}
+
+// Photon represents a Photon API client, see https://github.com/komoot/photon.
+type Photon struct {
+	*Client
+}
+
+// NewPhoton returns a new Photon API client.
+func NewPhoton(endpoint string, rateLimit float64, language string) *Photon {
+	if endpoint == "" {
+		endpoint = PhotonUrl
+	}
+
+	if rateLimit == 0 {
+		rateLimit = PhotonRateLimit
+	}
+
+	return &Photon{Client: NewClient(endpoint, rateLimit, language)}
+}
+
+// Name returns the API name.
+func (g *Photon) Name() string {
+	return PhotonName
+}
+
+// FindLocation retrieves location details for the specified S2 cell id.
+func (g *Photon) FindLocation(id string) (places.Location, error) {
+	return findCached(PhotonName, g.Language, id, func(token string, lat, lng float64) (result places.Location, err error) {
+		query := url.Values{}
+		query.Set("limit", "1")
+		query.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+		query.Set("lon", strconv.FormatFloat(lng, 'f', -1, 64))
+
+		// Photon only supports a single, two-letter language code.
+		if lang := strings.ToLower(g.Language); len(lang) >= 2 {
+			query.Set("lang", lang[:2])
+		}
+
+		var r PhotonResult
+
+		if err = g.Get("/reverse", query, &r); err != nil {
+			return result, err
+		} else if len(r.Features) == 0 || r.Features[0].Properties.CountryCode == "" {
+			return result, fmt.Errorf("no result for %s", token)
+		}
+
+		return r.Features[0].Location(), nil
+	})
+}
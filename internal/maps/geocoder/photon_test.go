@@ -0,0 +1,52 @@
+package geocoder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+func TestPhoton_FindLocation(t *testing.T) {
+	var req *http.Request
+
+	srv := testServer(t, "testdata/photon.json", &req)
+	defer srv.Close()
+
+	g := NewPhoton(srv.URL, 100, "de-DE")
+
+	t.Run("Success", func(t *testing.T) {
+		l, err := g.FindLocation(s2.Token(52.5208, 13.40953))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/reverse", req.URL.Path)
+		assert.Equal(t, "de", req.URL.Query().Get("lang"))
+
+		assert.Equal(t, PhotonName, l.Source())
+		assert.Equal(t, "Berliner Fernsehturm", l.Name())
+		assert.Equal(t, "Panoramastraße", l.Street())
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "Berlin", l.State())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, "Mitte, Berlin, Deutschland", l.Label())
+		assert.InDelta(t, 52.5208, l.Latitude(), 0.0001)
+		assert.InDelta(t, 13.40953, l.Longitude(), 0.0001)
+	})
+}
+
+func TestPhotonFeature_Location(t *testing.T) {
+	t.Run("City", func(t *testing.T) {
+		f := PhotonFeature{Properties: PhotonProperties{Name: "Potsdam", Type: "city", State: "Brandenburg", Country: "Germany", CountryCode: "DE"}}
+		l := f.Location()
+
+		assert.Equal(t, "", l.Name())
+		assert.Equal(t, "Potsdam", l.City())
+		assert.Equal(t, "Potsdam, Brandenburg, Germany", l.Label())
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/s2"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -43,16 +42,16 @@ type LocationSource interface {
 }
 
 func (l *Location) QueryApi(api string) error {
-	switch api {
-	case places.ApiName:
-		return l.QueryPlaces()
+	if p := FindProvider(api); p != nil {
+		return l.QueryProvider(p)
 	}
 
 	return errors.New("maps: location lookup disabled")
 }
 
-func (l *Location) QueryPlaces() error {
-	s, err := places.FindLocation(l.ID)
+// QueryProvider retrieves location details from the specified reverse geocoding provider.
+func (l *Location) QueryProvider(p Provider) error {
+	s, err := p.FindLocation(l.ID)
 
 	if err != nil {
 		return err
@@ -74,6 +73,15 @@ func (l *Location) QueryPlaces() error {
 	return nil
 }
 
+func (l *Location) QueryPlaces() error {
+	return l.QueryProvider(placesProvider{})
+}
+
+// QueryGazetteer retrieves location details from the local gazetteer without network access.
+func (l *Location) QueryGazetteer() error {
+	return l.QueryProvider(gazetteerProvider{})
+}
+
 func (l *Location) Unknown() bool {
 	return l.ID == ""
 }
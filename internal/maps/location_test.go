@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/maps/gazetteer"
 	"github.com/photoprism/photoprism/pkg/s2"
 )
 
@@ -192,3 +193,36 @@ func TestLocation_QueryApi(t *testing.T) {
 	})
 
 }
+
+func TestLocation_QueryGazetteer(t *testing.T) {
+	gazetteer.Path = "gazetteer/testdata"
+
+	t.Run("Berlin", func(t *testing.T) {
+		l := Location{ID: s2.Token(52.5163, 13.3777)}
+
+		if err := l.QueryGazetteer(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Mitte, Berlin, Germany", l.Label())
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, gazetteer.ApiName, l.Source())
+		assert.Equal(t, "de:", l.PlaceID()[:3])
+	})
+	t.Run("Api", func(t *testing.T) {
+		l := Location{ID: s2.Token(52.4009, 13.0591)}
+
+		if err := l.QueryApi(gazetteer.ApiName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Potsdam, Brandenburg, Germany", l.Label())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		l := Location{ID: s2.Token(40.7128, -74.0060)}
+
+		assert.Error(t, l.QueryGazetteer())
+	})
+}
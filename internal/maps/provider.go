@@ -0,0 +1,80 @@
+package maps
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+	"github.com/photoprism/photoprism/internal/maps/gazetteer"
+)
+
+// Provider represents a reverse geocoding API whose results are mapped onto places locations.
+type Provider interface {
+	Name() string
+	FindLocation(id string) (places.Location, error)
+}
+
+var providers = map[string]Provider{
+	places.ApiName:    placesProvider{},
+	gazetteer.ApiName: gazetteerProvider{},
+}
+
+var providerMutex = sync.RWMutex{}
+
+// RegisterProvider adds a reverse geocoding provider, replacing any existing provider with the same name.
+func RegisterProvider(p Provider) {
+	if p == nil {
+		return
+	}
+
+	providerMutex.Lock()
+	defer providerMutex.Unlock()
+
+	providers[p.Name()] = p
+}
+
+// FindProvider returns the provider with the specified name, or nil if it does not exist.
+func FindProvider(name string) Provider {
+	providerMutex.RLock()
+	defer providerMutex.RUnlock()
+
+	return providers[name]
+}
+
+// placesProvider retrieves location details from the PhotoPrism places API.
+type placesProvider struct{}
+
+// Name returns the API name.
+func (placesProvider) Name() string {
+	return places.ApiName
+}
+
+// FindLocation retrieves location details for the specified S2 cell id.
+func (placesProvider) FindLocation(id string) (places.Location, error) {
+	return places.FindLocation(id)
+}
+
+// gazetteerProvider retrieves location details from the local gazetteer.
+type gazetteerProvider struct{}
+
+// Name returns the API name.
+func (gazetteerProvider) Name() string {
+	return gazetteer.ApiName
+}
+
+// FindLocation retrieves location details for the specified S2 cell id.
+func (gazetteerProvider) FindLocation(id string) (result places.Location, err error) {
+	l, err := gazetteer.FindLocation(id)
+
+	if err != nil {
+		return result, err
+	}
+
+	return places.Location{
+		ID:        l.CellID(),
+		LocLat:    l.Latitude(),
+		LocLng:    l.Longitude(),
+		Place:     places.NewPlace(l.District(), l.City(), l.State(), l.CountryCode(), CountryNames[l.CountryCode()]),
+		LocSource: l.Source(),
+		Cached:    l.Cached,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+	"github.com/photoprism/photoprism/internal/maps/gazetteer"
+)
+
+type testProvider struct{}
+
+func (testProvider) Name() string {
+	return "test"
+}
+
+func (testProvider) FindLocation(id string) (places.Location, error) {
+	return places.Location{ID: id, LocName: "Test", Place: places.NewPlace("", "Berlin", "Berlin", "de", "Germany"), LocSource: "test"}, nil
+}
+
+func TestRegisterProvider(t *testing.T) {
+	assert.NotNil(t, FindProvider(places.ApiName))
+	assert.NotNil(t, FindProvider(gazetteer.ApiName))
+	assert.Nil(t, FindProvider("test"))
+
+	RegisterProvider(nil)
+	RegisterProvider(testProvider{})
+
+	if p := FindProvider("test"); p == nil {
+		t.Fatal("provider should not be nil")
+	}
+
+	l := Location{ID: "1e95998417cc"}
+
+	if err := l.QueryApi("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Test", l.Name())
+	assert.Equal(t, "Berlin, Germany", l.Label())
+	assert.Equal(t, "test", l.Source())
+
+	assert.Error(t, l.QueryApi("none"))
+}
@@ -0,0 +1,100 @@
+package meta
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// AaeEditors maps Apple editor bundle IDs to application names.
+var AaeEditors = map[string]string{
+	"com.apple.mobileslideshow": "Apple Photos",
+	"com.apple.photos":          "Apple Photos",
+	"com.apple.camera":          "Apple Camera",
+	"com.apple.iphoto":          "Apple iPhoto",
+}
+
+// AaeDocument represents an Apple image edits sidecar file, which is a property list.
+type AaeDocument struct {
+	Dict struct {
+		Items []struct {
+			XMLName xml.Name
+			Text    string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"dict"`
+}
+
+// Value returns the value of the specified key, or an empty string if it is missing.
+func (doc *AaeDocument) Value(key string) string {
+	items := doc.Dict.Items
+
+	for i := 0; i < len(items)-1; i++ {
+		if items[i].XMLName.Local == "key" && items[i].Text == key {
+			return strings.TrimSpace(items[i+1].Text)
+		}
+	}
+
+	return ""
+}
+
+// Editor returns the name of the application used to edit the image.
+func (doc *AaeDocument) Editor() string {
+	id := SanitizeString(doc.Value("adjustmentEditorBundleID"))
+
+	if name, ok := AaeEditors[strings.ToLower(id)]; ok {
+		return name
+	}
+
+	return id
+}
+
+// Format returns the adjustment format identifier and version, e.g. "com.apple.photo 1.4".
+func (doc *AaeDocument) Format() string {
+	return strings.TrimSpace(SanitizeString(doc.Value("adjustmentFormatIdentifier")) + " " + SanitizeString(doc.Value("adjustmentFormatVersion")))
+}
+
+// EditedAt returns the time when the adjustments were made.
+func (doc *AaeDocument) EditedAt() time.Time {
+	if t, err := time.Parse(time.RFC3339, doc.Value("adjustmentTimestamp")); err == nil {
+		return t.UTC()
+	}
+
+	return time.Time{}
+}
+
+// AAE parses an Apple image edits sidecar file and returns a Data struct.
+func AAE(fileName string) (data Data, err error) {
+	err = data.AAE(fileName)
+
+	return data, err
+}
+
+// AAE parses an Apple image edits sidecar file and adds the editor application to the metadata.
+func (data *Data) AAE(fileName string) error {
+	b, err := readSidecar(fileName, "aae")
+
+	if err != nil {
+		return err
+	}
+
+	return data.ParseAAE(b)
+}
+
+// ParseAAE parses the contents of an Apple image edits sidecar file and adds the editor application to the metadata.
+func (data *Data) ParseAAE(b []byte) error {
+	doc := AaeDocument{}
+
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	if editor := doc.Editor(); editor != "" {
+		data.Software = editor
+	}
+
+	if editedAt := doc.EditedAt(); !editedAt.IsZero() {
+		data.EditedAt = editedAt
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package meta
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAAE(t *testing.T) {
+	t.Run("apple.aae", func(t *testing.T) {
+		data, err := AAE("testdata/apple.aae")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Apple Photos", data.Software)
+		assert.Equal(t, time.Date(2021, 7, 22, 19, 37, 2, 0, time.UTC), data.EditedAt)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := AAE("testdata/not-found.aae")
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidXML", func(t *testing.T) {
+		_, err := AAE("testdata/gphotos-1.json")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAaeDocument(t *testing.T) {
+	doc := AaeDocument{}
+
+	if err := xml.Unmarshal(testFile(t, "testdata/apple.aae"), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "0", doc.Value("adjustmentBaseVersion"))
+	assert.Equal(t, "", doc.Value("notFound"))
+	assert.Equal(t, "Apple Photos", doc.Editor())
+	assert.Equal(t, "com.apple.photo 1.4", doc.Format())
+}
@@ -21,6 +21,7 @@ type Data struct {
 	TakenAt       time.Time     `meta:"SubSecDateTimeOriginal,SubSecDateTimeCreated,DateTimeOriginal,CreationDate,DateTimeCreated,DateTime,DateTimeDigitized" xmp:"DateCreated"`
 	TakenAtLocal  time.Time     `meta:"SubSecDateTimeOriginal,SubSecDateTimeCreated,DateTimeOriginal,CreationDate,DateTimeCreated,DateTime,DateTimeDigitized"`
 	TakenGps      time.Time     `meta:"GPSDateTime,GPSDateStamp"`
+	EditedAt      time.Time     `meta:"-"`
 	TakenNs       int           `meta:"-"`
 	TimeZone      string        `meta:"-"`
 	Duration      time.Duration `meta:"Duration,MediaDuration,TrackDuration"`
@@ -30,6 +31,7 @@ type Data struct {
 	Title         string        `meta:"Headline,Title" xmp:"dc:title" dc:"title,title.Alt"`
 	Subject       string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets" xmp:"Subject"`
 	Keywords      Keywords      `meta:"Keywords"`
+	Hierarchy     []string      `meta:"-"`
 	Notes         string        `meta:"Comment"`
 	Artist        string        `meta:"Artist,Creator,By-line,OwnerName,Owner" xmp:"Creator"`
 	Description   string        `meta:"Description,Caption-Abstract" xmp:"Description,Description.Alt"`
@@ -62,6 +64,10 @@ type Data struct {
 	Height        int           `meta:"ImageHeight,ImageLength,PixelYDimension,ExifImageHeight,SourceImageHeight"`
 	Orientation   int           `meta:"-"`
 	Rotation      int           `meta:"Rotation"`
+	Rating        int           `meta:"Rating" xmp:"Rating"`
+	ColorLabel    string        `meta:"-"`
+	Regions       Regions       `meta:"-"`
+	People        []string      `meta:"-"`
 	Views         int           `meta:"-"`
 	Albums        []string      `meta:"-"`
 	Error         error         `meta:"-"`
@@ -6,4 +6,6 @@ var Docs = [][]string{
 	{"Adobe XMP", "https://docs.photoprism.app/developer-guide/metadata/xmp/#specification"},
 	{"Dublin Core (DCMI)", "https://www.dublincore.org/specifications/dublin-core/dcmi-terms/"},
 	{"IPTC Photo Metadata", "https://iptc.org/standards/photo-metadata/iptc-standard/"},
+	{"MWG Regions", "https://exiftool.org/TagNames/MWG.html#Regions"},
+	{"Microsoft Photo Regions", "https://exiftool.org/TagNames/Microsoft.html#MP"},
 }
@@ -162,6 +162,10 @@ func (data *Data) Exif(fileName string, fileFormat fs.Type, bruteForce bool) (er
 		data.Software = SanitizeString(value)
 	}
 
+	if value, ok := data.exif["Rating"]; ok {
+		data.Rating = NormalizeRating(value)
+	}
+
 	if value, ok := data.exif["ExposureTime"]; ok {
 		if n := strings.Split(value, "/"); len(n) == 2 {
 			if n[0] != "1" && len(n[0]) < len(n[1]) {
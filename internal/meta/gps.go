@@ -70,6 +70,11 @@ func GpsToDecimal(s string) float32 {
 	co := GpsCoordsRegexp.FindAllString(s, -1)
 	re := GpsRefRegexp.FindAllString(s, -1)
 
+	// Degrees and decimal minutes, e.g. "52,27.5814N" as used in XMP?
+	if len(co) == 2 && len(re) == 1 {
+		co = append(co, "0")
+	}
+
 	if len(co) != 3 || len(re) != 1 {
 		return 0
 	}
@@ -366,5 +366,17 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 	data.Subject = SanitizeMeta(data.Subject)
 	data.Artist = SanitizeMeta(data.Artist)
 
+	// Add named face regions and people, e.g. as tagged in Lightroom or digiKam.
+	data.exiftoolRegions(jsonValues)
+
+	// Add hierarchical keywords, e.g. "Places|Europe|Germany|Berlin" as tagged in Lightroom or digiKam.
+	for _, r := range exiftoolList(jsonValues["HierarchicalSubject"]) {
+		data.AddHierarchy(r.String(), HierarchySeparator)
+	}
+
+	for _, r := range exiftoolList(jsonValues["TagsList"]) {
+		data.AddHierarchy(r.String(), "/")
+	}
+
 	return nil
 }
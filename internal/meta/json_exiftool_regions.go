@@ -0,0 +1,87 @@
+package meta
+
+import (
+	"github.com/tidwall/gjson"
+)
+
+// exiftoolList returns the values of a list tag, which Exiftool outputs as a single value if it has only one item.
+func exiftoolList(r gjson.Result) []gjson.Result {
+	if r.IsArray() {
+		return r.Array()
+	} else if r.Exists() {
+		return []gjson.Result{r}
+	}
+
+	return nil
+}
+
+// exiftoolRegions adds MWG and Microsoft Photo regions as well as people from Exiftool JSON data.
+func (data *Data) exiftoolRegions(values map[string]gjson.Result) {
+	// MWG regions, structured as with "exiftool -struct".
+	if info := values["RegionInfo"]; info.IsObject() {
+		width, height := info.Get("AppliedToDimensions.W").Float(), info.Get("AppliedToDimensions.H").Float()
+
+		for _, r := range info.Get("RegionList").Array() {
+			if region, ok := mwgRegion(r.Get("Name").String(), r.Get("Type").String(),
+				r.Get("Area.X").Float(), r.Get("Area.Y").Float(), r.Get("Area.W").Float(), r.Get("Area.H").Float(),
+				r.Get("Area.Unit").String(), width, height); ok {
+				data.AddRegion(region)
+			}
+		}
+	} else if x := exiftoolList(values["RegionAreaX"]); len(x) > 0 {
+		// MWG regions, flattened by default.
+		y := exiftoolList(values["RegionAreaY"])
+		w := exiftoolList(values["RegionAreaW"])
+		h := exiftoolList(values["RegionAreaH"])
+		unit := exiftoolList(values["RegionAreaUnit"])
+		names := exiftoolList(values["RegionName"])
+		types := exiftoolList(values["RegionType"])
+		width, height := values["RegionAppliedToDimensionsW"].Float(), values["RegionAppliedToDimensionsH"].Float()
+
+		for i := range x {
+			if i >= len(y) || i >= len(w) || i >= len(h) {
+				break
+			}
+
+			var name, regionType, regionUnit string
+
+			// Names and types are optional.
+			if i < len(names) && len(names) == len(x) {
+				name = names[i].String()
+			}
+
+			if i < len(types) && len(types) == len(x) {
+				regionType = types[i].String()
+			}
+
+			if i < len(unit) {
+				regionUnit = unit[i].String()
+			}
+
+			if region, ok := mwgRegion(name, regionType, x[i].Float(), y[i].Float(), w[i].Float(), h[i].Float(), regionUnit, width, height); ok {
+				data.AddRegion(region)
+			}
+		}
+	}
+
+	// Microsoft Photo regions.
+	rects := exiftoolList(values["RegionRectangle"])
+	names := exiftoolList(values["RegionPersonDisplayName"])
+
+	for i := range rects {
+		var name string
+
+		if i < len(names) && len(names) == len(rects) {
+			name = names[i].String()
+		}
+
+		if region, ok := mpRegion(name, rects[i].String()); ok {
+			data.AddRegion(region)
+		}
+	}
+
+	// Names of people shown in the image.
+	for _, name := range exiftoolList(values["PersonInImage"]) {
+		data.AddPerson(name.String())
+	}
+}
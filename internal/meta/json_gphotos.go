@@ -10,13 +10,14 @@ import (
 )
 
 type GPhoto struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Views       int    `json:"imageViews,string"`
-	Geo         GGeo   `json:"geoData"`
-	TakenAt     GTime  `json:"photoTakenTime"`
-	CreatedAt   GTime  `json:"creationTime"`
-	UpdatedAt   GTime  `json:"modificationTime"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Views       int       `json:"imageViews,string"`
+	Geo         GGeo      `json:"geoData"`
+	TakenAt     GTime     `json:"photoTakenTime"`
+	CreatedAt   GTime     `json:"creationTime"`
+	UpdatedAt   GTime     `json:"modificationTime"`
+	People      []GPerson `json:"people"`
 }
 
 func (m GPhoto) SanitizedTitle() string {
@@ -27,6 +28,10 @@ func (m GPhoto) SanitizedDescription() string {
 	return SanitizeDescription(m.Description)
 }
 
+type GPerson struct {
+	Name string `json:"name"`
+}
+
 type GMeta struct {
 	Album GAlbum `json:"albumData"`
 }
@@ -114,6 +119,11 @@ func (data *Data) GPhoto(jsonData []byte) (err error) {
 		data.Views = p.Views
 	}
 
+	// Google Photos only exports the names of people, not their face regions.
+	for _, person := range p.People {
+		data.AddPerson(person.Name)
+	}
+
 	if p.TakenAt.Exists() {
 		if data.TakenAt.IsZero() {
 			data.TakenAt = p.TakenAt.Time()
@@ -420,6 +420,19 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, 1, data.Orientation)
 	})
 
+	t.Run("gphotos-people.json", func(t *testing.T) {
+		data, err := JSON("testdata/gphotos-people.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", data.Title)
+		assert.Equal(t, 3, data.Views)
+		assert.Equal(t, []string{"Jane Doe", "John Doe"}, data.People)
+		assert.Empty(t, data.Regions)
+	})
+
 	t.Run("gphotos-1.json", func(t *testing.T) {
 		data, err := JSON("testdata/gphotos-1.json", "")
 
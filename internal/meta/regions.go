@@ -0,0 +1,95 @@
+package meta
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mwgRegion returns a region as specified by the Metadata Working Group, whose area is
+// defined by its center point, with relative top left coordinates.
+func mwgRegion(name, regionType string, x, y, w, h float64, unit string, width, height float64) (result Region, ok bool) {
+	// Convert pixel values to relative coordinates.
+	if strings.EqualFold(unit, "pixel") {
+		if width <= 0 || height <= 0 {
+			return result, false
+		}
+
+		x, y, w, h = x/width, y/height, w/width, h/height
+	}
+
+	if w <= 0 || h <= 0 || w > 1 || h > 1 {
+		return result, false
+	}
+
+	return Region{
+		Name: SanitizeString(name),
+		Type: SanitizeString(regionType),
+		X:    float32(x - w/2),
+		Y:    float32(y - h/2),
+		W:    float32(w),
+		H:    float32(h),
+	}, true
+}
+
+// mpRegion returns a Microsoft Photo region, whose rectangle is specified as "x, y, w, h".
+func mpRegion(name, rect string) (result Region, ok bool) {
+	v := strings.Split(rect, ",")
+
+	if len(v) != 4 {
+		return result, false
+	}
+
+	x, y, w, h := parseFloat(v[0]), parseFloat(v[1]), parseFloat(v[2]), parseFloat(v[3])
+
+	if w <= 0 || h <= 0 || w > 1 || h > 1 {
+		return result, false
+	}
+
+	return Region{
+		Name: SanitizeString(name),
+		Type: RegionFace,
+		X:    float32(x),
+		Y:    float32(y),
+		W:    float32(w),
+		H:    float32(h),
+	}, true
+}
+
+// AddRegion adds an image region unless a region with the same name already exists.
+func (data *Data) AddRegion(r Region) {
+	if r.Name != "" {
+		for _, existing := range data.Regions {
+			if strings.EqualFold(existing.Name, r.Name) {
+				return
+			}
+		}
+	}
+
+	data.Regions = append(data.Regions, r)
+}
+
+// AddPerson adds the name of a person shown in the image, if it does not exist yet.
+func (data *Data) AddPerson(name string) {
+	if name = SanitizeString(name); name == "" {
+		return
+	}
+
+	for _, existing := range data.People {
+		if strings.EqualFold(existing, name) {
+			return
+		}
+	}
+
+	data.People = append(data.People, name)
+}
+
+// parseFloat parses a float value and returns 0 if it is invalid.
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	if err != nil {
+		return 0
+	}
+
+	return f
+}
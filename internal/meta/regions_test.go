@@ -0,0 +1,121 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_AddRegion(t *testing.T) {
+	data := Data{}
+
+	data.AddRegion(Region{Name: "Jane", Type: RegionFace, W: 0.1, H: 0.1})
+	data.AddRegion(Region{Name: "jane", Type: RegionFace, W: 0.2, H: 0.2})
+	data.AddRegion(Region{Type: RegionFace, W: 0.1, H: 0.1})
+	data.AddRegion(Region{Type: RegionFace, W: 0.1, H: 0.1})
+
+	assert.Len(t, data.Regions, 3)
+}
+
+func TestData_AddPerson(t *testing.T) {
+	data := Data{}
+
+	data.AddPerson("Jane Doe")
+	data.AddPerson(" jane doe ")
+	data.AddPerson("")
+	data.AddPerson("John Doe")
+
+	assert.Equal(t, []string{"Jane Doe", "John Doe"}, data.People)
+}
+
+func TestMwgRegion(t *testing.T) {
+	t.Run("Normalized", func(t *testing.T) {
+		r, ok := mwgRegion(" Jane ", "Face", 0.5, 0.5, 0.2, 0.4, "normalized", 0, 0)
+
+		assert.True(t, ok)
+		assert.Equal(t, "Jane", r.Name)
+		assert.Equal(t, RegionFace, r.Type)
+		assert.InDelta(t, 0.4, r.X, 0.0001)
+		assert.InDelta(t, 0.3, r.Y, 0.0001)
+	})
+	t.Run("Pixel", func(t *testing.T) {
+		r, ok := mwgRegion("", "Face", 500, 500, 100, 200, "pixel", 1000, 1000)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 0.45, r.X, 0.0001)
+		assert.InDelta(t, 0.4, r.Y, 0.0001)
+		assert.InDelta(t, 0.1, r.W, 0.0001)
+		assert.InDelta(t, 0.2, r.H, 0.0001)
+	})
+	t.Run("PixelWithoutDimensions", func(t *testing.T) {
+		_, ok := mwgRegion("", "Face", 500, 500, 100, 200, "pixel", 0, 0)
+
+		assert.False(t, ok)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := mwgRegion("Jane", "Face", 0.5, 0.5, 0, 0, "normalized", 0, 0)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestMpRegion(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		r, ok := mpRegion("Jens Mander", "0.412, 0.205, 0.110, 0.147")
+
+		assert.True(t, ok)
+		assert.Equal(t, "Jens Mander", r.Name)
+		assert.Equal(t, RegionFace, r.Type)
+		assert.InDelta(t, 0.412, r.X, 0.0001)
+		assert.InDelta(t, 0.147, r.H, 0.0001)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := mpRegion("Jens Mander", "0.412, 0.205")
+
+		assert.False(t, ok)
+	})
+}
+
+func TestData_ExiftoolRegions(t *testing.T) {
+	t.Run("Flattened", func(t *testing.T) {
+		data, err := JSON("testdata/regions-exiftool.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data.Regions) != 3 {
+			t.Fatalf("expected 3 regions, found %d", len(data.Regions))
+		}
+
+		assert.Equal(t, "Jane Doe", data.Regions[0].Name)
+		assert.InDelta(t, 0.4, data.Regions[0].X, 0.0001)
+		assert.InDelta(t, 0.25, data.Regions[0].Y, 0.0001)
+		assert.Equal(t, "John Doe", data.Regions[1].Name)
+		assert.InDelta(t, 0.45, data.Regions[1].X, 0.0001)
+		assert.InDelta(t, 0.4, data.Regions[1].Y, 0.0001)
+		assert.InDelta(t, 0.1, data.Regions[1].W, 0.0001)
+		assert.InDelta(t, 0.2, data.Regions[1].H, 0.0001)
+		assert.Equal(t, "Jens Mander", data.Regions[2].Name)
+		assert.Equal(t, []string{"Jane Doe", "Max Mustermann"}, data.People)
+	})
+	t.Run("Struct", func(t *testing.T) {
+		data, err := JSON("testdata/regions-struct.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data.Regions) != 2 {
+			t.Fatalf("expected 2 regions, found %d", len(data.Regions))
+		}
+
+		assert.Equal(t, "Bello", data.Regions[0].Name)
+		assert.Equal(t, RegionPet, data.Regions[0].Type)
+		assert.InDelta(t, 0.2, data.Regions[0].X, 0.0001)
+		assert.Equal(t, "Max Mustermann", data.Regions[1].Name)
+		assert.InDelta(t, 0.45, data.Regions[1].X, 0.0001)
+		assert.InDelta(t, 0.35, data.Regions[1].Y, 0.0001)
+		assert.Empty(t, data.People)
+	})
+}
@@ -0,0 +1,125 @@
+package meta
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// SidecarParser reads metadata from the contents of a sidecar file and adds it to the Data struct.
+type SidecarParser func(data *Data, b []byte) error
+
+// sidecarParser represents a named parser for a sidecar file type.
+type sidecarParser struct {
+	name  string
+	parse SidecarParser
+}
+
+var sidecarParsers = make(map[fs.Type][]sidecarParser)
+var sidecarMutex = sync.RWMutex{}
+
+func init() {
+	RegisterSidecarParser(fs.XmpFile, "xmp", (*Data).ParseXMP)
+	RegisterSidecarParser(fs.XmpFile, "hierarchy", (*Data).XmpHierarchy)
+	RegisterSidecarParser(fs.XmpFile, "rating", (*Data).XmpRating)
+	RegisterSidecarParser(fs.XmpFile, "regions", (*Data).XmpRegions)
+	RegisterSidecarParser(fs.AaeFile, "aae", (*Data).ParseAAE)
+}
+
+// RegisterSidecarParser adds a parser for the specified sidecar file type. Parsers
+// run in the order in which they were registered, and a parser with the same
+// name replaces the existing one.
+func RegisterSidecarParser(fileType fs.Type, name string, parse SidecarParser) {
+	if fileType == "" || name == "" || parse == nil {
+		return
+	}
+
+	sidecarMutex.Lock()
+	defer sidecarMutex.Unlock()
+
+	for i, p := range sidecarParsers[fileType] {
+		if p.name == name {
+			sidecarParsers[fileType][i].parse = parse
+			return
+		}
+	}
+
+	sidecarParsers[fileType] = append(sidecarParsers[fileType], sidecarParser{name: name, parse: parse})
+}
+
+// SidecarParsers returns the names of the parsers registered for the sidecar file type.
+func SidecarParsers(fileType fs.Type) (names []string) {
+	sidecarMutex.RLock()
+	defer sidecarMutex.RUnlock()
+
+	for _, p := range sidecarParsers[fileType] {
+		names = append(names, p.name)
+	}
+
+	return names
+}
+
+// SidecarSupported checks if metadata can be read from sidecar files of the specified type.
+func SidecarSupported(fileType fs.Type) bool {
+	sidecarMutex.RLock()
+	defer sidecarMutex.RUnlock()
+
+	return len(sidecarParsers[fileType]) > 0
+}
+
+// Sidecar parses a sidecar file and returns a Data struct.
+func Sidecar(fileName string, fileType fs.Type) (data Data, err error) {
+	err = data.Sidecar(fileName, fileType)
+
+	return data, err
+}
+
+// Sidecar parses a sidecar file with all parsers registered for its type, the file is read only once.
+func (data *Data) Sidecar(fileName string, fileType fs.Type) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (sidecar panic)\nstack: %s", e, clean.Log(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	sidecarMutex.RLock()
+	parsers := sidecarParsers[fileType]
+	sidecarMutex.RUnlock()
+
+	if len(parsers) == 0 {
+		return fmt.Errorf("metadata: %s sidecar files are not supported", clean.Log(fileType.String()))
+	}
+
+	b, err := readSidecar(fileName, fileType.String())
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parsers {
+		if err = p.parse(data, b); err != nil {
+			return fmt.Errorf("metadata: %s in %s (%s)", err, clean.Log(filepath.Base(fileName)), p.name)
+		}
+	}
+
+	return nil
+}
+
+// readSidecar returns the contents of a sidecar file.
+func readSidecar(fileName string, format string) (b []byte, err error) {
+	// Resolve file name e.g. in case it's a symlink.
+	if fileName, err = fs.Resolve(fileName); err != nil {
+		return b, fmt.Errorf("metadata: %s %s (%s)", err, clean.Log(filepath.Base(fileName)), format)
+	}
+
+	if b, err = os.ReadFile(fileName); err != nil {
+		return b, fmt.Errorf("metadata: cannot read %s (%s)", clean.Log(filepath.Base(fileName)), format)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,101 @@
+package meta
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSidecarSupported(t *testing.T) {
+	assert.True(t, SidecarSupported(fs.XmpFile))
+	assert.True(t, SidecarSupported(fs.AaeFile))
+	assert.False(t, SidecarSupported(fs.JsonFile))
+	assert.False(t, SidecarSupported(fs.ImageJPEG))
+}
+
+func TestSidecarParsers(t *testing.T) {
+	assert.Equal(t, []string{"xmp", "hierarchy", "rating", "regions"}, SidecarParsers(fs.XmpFile))
+	assert.Equal(t, []string{"aae"}, SidecarParsers(fs.AaeFile))
+	assert.Empty(t, SidecarParsers(fs.JsonFile))
+}
+
+func TestRegisterSidecarParser(t *testing.T) {
+	const fileType = fs.Type("test")
+
+	var calls []string
+
+	RegisterSidecarParser(fileType, "first", func(data *Data, b []byte) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	RegisterSidecarParser(fileType, "second", func(data *Data, b []byte) error {
+		calls = append(calls, "second")
+		data.Title = string(b)
+		return nil
+	})
+	RegisterSidecarParser(fileType, "first", func(data *Data, b []byte) error {
+		calls = append(calls, "replaced")
+		return nil
+	})
+	RegisterSidecarParser(fileType, "", nil)
+
+	data, err := Sidecar("testdata/example.test", fileType)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"replaced", "second"}, calls)
+	assert.Equal(t, "Example\n", data.Title)
+	assert.Equal(t, []string{"first", "second"}, SidecarParsers(fileType))
+}
+
+func TestSidecar(t *testing.T) {
+	t.Run("Lightroom", func(t *testing.T) {
+		data, err := Sidecar("testdata/lightroom.xmp", fs.XmpFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Brandenburg Gate", data.Title)
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, ColorLabelRed, data.ColorLabel)
+		assert.Equal(t, []string{"Places|Europe|Germany|Berlin", "People|Jane Doe"}, data.Hierarchy)
+		assert.Len(t, data.Regions, 2)
+	})
+	t.Run("AAE", func(t *testing.T) {
+		data, err := Sidecar("testdata/apple.aae", fs.AaeFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Apple Photos", data.Software)
+		assert.False(t, data.EditedAt.IsZero())
+	})
+	t.Run("NotSupported", func(t *testing.T) {
+		_, err := Sidecar("testdata/gphotos-1.json", fs.JsonFile)
+
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Sidecar("testdata/not-found.xmp", fs.XmpFile)
+
+		assert.Error(t, err)
+	})
+}
+
+// testFile returns the contents of a test file.
+func testFile(t *testing.T, fileName string) []byte {
+	b, err := os.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
@@ -1,7 +1,9 @@
 package meta
 
 import (
+	"encoding/xml"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime/debug"
 
@@ -30,12 +32,23 @@ func (data *Data) XMP(fileName string) (err error) {
 		return fmt.Errorf("metadata: %s %s (xmp)", err, clean.Log(filepath.Base(fileName)))
 	}
 
-	doc := XmpDocument{}
+	b, err := os.ReadFile(fileName)
 
-	if err = doc.Load(fileName); err != nil {
+	if err != nil {
 		return fmt.Errorf("metadata: cannot read %s (xmp)", clean.Log(filepath.Base(fileName)))
 	}
 
+	return data.ParseXMP(b)
+}
+
+// ParseXMP parses the contents of an XMP file and adds the metadata to the Data struct.
+func (data *Data) ParseXMP(b []byte) error {
+	doc := XmpDocument{}
+
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
 	if doc.Title() != "" {
 		data.Title = doc.Title()
 	}
@@ -68,9 +81,18 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.TakenAt = takenAt
 	}
 
+	if lat, lng := doc.LatLng(); lat != 0 || lng != 0 {
+		data.Lat, data.Lng = lat, lng
+		data.Altitude = doc.Altitude()
+	}
+
 	if len(doc.Keywords()) != 0 {
 		data.AddKeywords(doc.Keywords())
 	}
 
+	for _, name := range doc.People() {
+		data.AddPerson(name)
+	}
+
 	return nil
 }
@@ -2,7 +2,9 @@ package meta
 
 import (
 	"encoding/xml"
+	"math"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -190,8 +192,8 @@ type XmpDocument struct {
 			PersonInImage struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Bag  struct {
-					Text string `xml:",chardata" json:"text,omitempty"`
-					Li   string `xml:"li"` // Gopher
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
@@ -209,6 +211,11 @@ func (doc *XmpDocument) Load(filename string) error {
 	return xml.Unmarshal(data, doc)
 }
 
+// Toolkit returns the name of the toolkit that created the XMP document.
+func (doc *XmpDocument) Toolkit() string {
+	return SanitizeString(doc.Xmptk)
+}
+
 // Title returns the XMP document title.
 func (doc *XmpDocument) Title() string {
 	t := doc.RDF.Description.Title.Alt.Li.Text
@@ -285,5 +292,59 @@ func (doc *XmpDocument) TakenAt() time.Time {
 func (doc *XmpDocument) Keywords() string {
 	s := doc.RDF.Description.Subject.Seq.Li
 
+	if len(s) == 0 {
+		s = doc.RDF.Description.Subject.Bag.Li
+	}
+
 	return strings.Join(s, ", ")
 }
+
+// People returns the names of the people shown in the image.
+func (doc *XmpDocument) People() (names []string) {
+	for _, n := range doc.RDF.Description.PersonInImage.Bag.Li {
+		if n = SanitizeString(n); n != "" {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
+
+// LatLng returns the XMP document GPS position.
+func (doc *XmpDocument) LatLng() (lat, lng float32) {
+	return GpsToDecimal(doc.RDF.Description.GPSLatitude), GpsToDecimal(doc.RDF.Description.GPSLongitude)
+}
+
+// Altitude returns the XMP document GPS altitude in meters.
+func (doc *XmpDocument) Altitude() int {
+	s := SanitizeString(doc.RDF.Description.GPSAltitude)
+
+	if s == "" {
+		return 0
+	}
+
+	var alt float64
+
+	if v := strings.SplitN(s, "/", 2); len(v) == 2 {
+		if n, err := strconv.ParseFloat(v[0], 64); err != nil {
+			return 0
+		} else if d, err := strconv.ParseFloat(v[1], 64); err != nil || d == 0 {
+			return 0
+		} else {
+			alt = n / d
+		}
+	} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+		alt = f
+	}
+
+	if doc.RDF.Description.GPSAltitudeRef == "1" {
+		alt = -alt
+	}
+
+	return int(math.Round(alt))
+}
+
+// Favorite checks if the image has the highest possible rating.
+func (doc *XmpDocument) Favorite() bool {
+	return doc.RDF.Description.Rating == "5"
+}
@@ -0,0 +1,83 @@
+package meta
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// HierarchySeparator separates the levels of hierarchical keywords, e.g. "Places|Europe|Germany|Berlin".
+const HierarchySeparator = "|"
+
+// xmpHierarchyDocument represents the hierarchical keywords in an XMP sidecar file,
+// as written by Lightroom, darktable ("lr:hierarchicalSubject"), and digiKam ("digiKam:TagsList").
+type xmpHierarchyDocument struct {
+	RDF struct {
+		Description []struct {
+			HierarchicalSubject struct {
+				Bag struct {
+					Li []string `xml:"li"`
+				} `xml:"Bag"`
+				Seq struct {
+					Li []string `xml:"li"`
+				} `xml:"Seq"`
+			} `xml:"hierarchicalSubject"`
+			TagsList struct {
+				Seq struct {
+					Li []string `xml:"li"`
+				} `xml:"Seq"`
+				Bag struct {
+					Li []string `xml:"li"`
+				} `xml:"Bag"`
+			} `xml:"TagsList"`
+		} `xml:"Description"`
+	} `xml:"RDF"`
+}
+
+// XmpHierarchy reads hierarchical keywords from the contents of an XMP sidecar file and adds the
+// most specific level of each keyword to the keywords.
+func (data *Data) XmpHierarchy(b []byte) error {
+	doc := xmpHierarchyDocument{}
+
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	for _, d := range doc.RDF.Description {
+		for _, s := range append(d.HierarchicalSubject.Bag.Li, d.HierarchicalSubject.Seq.Li...) {
+			data.AddHierarchy(s, HierarchySeparator)
+		}
+
+		// digiKam separates levels with a slash.
+		for _, s := range append(d.TagsList.Seq.Li, d.TagsList.Bag.Li...) {
+			data.AddHierarchy(s, "/")
+		}
+	}
+
+	return nil
+}
+
+// AddHierarchy adds a hierarchical keyword, with levels separated by sep.
+func (data *Data) AddHierarchy(s, sep string) {
+	var levels []string
+
+	for _, l := range strings.Split(s, sep) {
+		if l = SanitizeString(l); l != "" {
+			levels = append(levels, l)
+		}
+	}
+
+	if len(levels) == 0 {
+		return
+	}
+
+	path := strings.Join(levels, HierarchySeparator)
+
+	for _, h := range data.Hierarchy {
+		if strings.EqualFold(h, path) {
+			return
+		}
+	}
+
+	data.Hierarchy = append(data.Hierarchy, path)
+	data.AddKeywords(levels[len(levels)-1])
+}
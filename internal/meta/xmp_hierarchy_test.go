@@ -0,0 +1,66 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_XmpHierarchy(t *testing.T) {
+	t.Run("Lightroom", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpHierarchy(testFile(t, "testdata/lightroom.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"Places|Europe|Germany|Berlin", "People|Jane Doe"}, data.Hierarchy)
+		assert.Equal(t, Keywords{"berlin", "doe", "jane"}, data.Keywords)
+	})
+	t.Run("darktable", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpHierarchy(testFile(t, "testdata/darktable.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"Animals|Birds|Seagull", "darktable|format|nef"}, data.Hierarchy)
+	})
+	t.Run("digiKam", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpHierarchy(testFile(t, "testdata/digikam.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"People|Max Mustermann", "Events|Summer Party"}, data.Hierarchy)
+	})
+	t.Run("InvalidXML", func(t *testing.T) {
+		data := Data{}
+
+		assert.Error(t, data.XmpHierarchy([]byte("{}")))
+	})
+}
+
+func TestData_AddHierarchy(t *testing.T) {
+	data := Data{}
+
+	data.AddHierarchy("Places|Europe|Germany", "|")
+	data.AddHierarchy("places|europe|germany", "|")
+	data.AddHierarchy(" | ", "|")
+	data.AddHierarchy("Places/Asia", "/")
+
+	assert.Equal(t, []string{"Places|Europe|Germany", "Places|Asia"}, data.Hierarchy)
+	assert.Equal(t, Keywords{"asia", "germany"}, data.Keywords)
+}
+
+func TestJSON_Hierarchy(t *testing.T) {
+	data, err := JSON("testdata/hierarchy.json", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"Places|Europe|Germany|Berlin", "Animals|Cat"}, data.Hierarchy)
+	assert.Equal(t, "berlin, cat", data.Keywords.String())
+}
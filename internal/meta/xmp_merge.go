@@ -0,0 +1,320 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// xmpName represents a property name with its namespace URI.
+type xmpName struct {
+	Space string
+	Local string
+}
+
+var (
+	xmpRDFName         = xmpName{XmpNsRdf, "RDF"}
+	xmpDescriptionName = xmpName{XmpNsRdf, "Description"}
+)
+
+// xmpMerged contains the properties that may be replaced when metadata is merged into an XMP sidecar
+// file created by another application. Existing properties are only replaced if PhotoPrism has a value
+// for them, all others are preserved.
+var xmpMerged = map[xmpName]bool{
+	{XmpNsXmp, "MetadataDate"}:      true,
+	{XmpNsXmp, "Rating"}:            true,
+	{XmpNsXmp, "Label"}:             true,
+	{XmpNsDc, "title"}:              true,
+	{XmpNsDc, "description"}:        true,
+	{XmpNsDc, "creator"}:            true,
+	{XmpNsDc, "rights"}:             true,
+	{XmpNsDc, "subject"}:            true,
+	{XmpNsXmpRights, "UsageTerms"}:  true,
+	{XmpNsPhotoshop, "DateCreated"}: true,
+	{XmpNsExif, "DateTimeOriginal"}: true,
+	{XmpNsExif, "GPSLatitude"}:      true,
+	{XmpNsExif, "GPSLongitude"}:     true,
+	{XmpNsExif, "GPSAltitude"}:      true,
+	{XmpNsExif, "GPSAltitudeRef"}:   true,
+	{XmpNsIptcExt, "PersonInImage"}: true,
+	{XmpNsMwgRs, "Regions"}:         true,
+}
+
+// xmpEdit replaces the bytes from start to end with text.
+type xmpEdit struct {
+	start int64
+	end   int64
+	text  []byte
+}
+
+// Merge updates the metadata in the content of an existing XMP sidecar file, so that properties
+// written by other applications, e.g. darktable or digiKam, are preserved.
+func (s *XmpSidecar) Merge(existing []byte) ([]byte, error) {
+	generated, err := s.Bytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	props, replaced, namespaces, err := xmpProperties(generated)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []xmpElement
+	var edits []xmpEdit
+
+	merged := false
+	skip := -1
+
+	var skipStart int64
+
+	d := xml.NewDecoder(bytes.NewReader(existing))
+
+	for {
+		start := d.InputOffset()
+		token, err := d.RawToken()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		end := d.InputOffset()
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := xmpElement{}
+
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+
+			elem := newXmpElement(t, parent.scope)
+			stack = append(stack, elem)
+
+			if skip >= 0 {
+				continue
+			} else if elem.name == xmpDescriptionName && parent.name == xmpRDFName {
+				var add map[string]string
+
+				if !merged {
+					add = namespaces
+				}
+
+				tag, err := xmpStartTag(t, elem.scope, add, replaced)
+
+				if err != nil {
+					return nil, err
+				}
+
+				// Properties are added before the end tag, self-closing tags must be expanded for this.
+				if bytes.HasSuffix(existing[start:end], []byte("/>")) {
+					if merged {
+						tag = append(tag[:len(tag)-1], "/>"...)
+					} else {
+						tag = append(tag, props...)
+						tag = append(tag, fmt.Sprintf("\n</%s>", xmpRawName(t.Name))...)
+						merged = true
+					}
+				}
+
+				edits = append(edits, xmpEdit{start: start, end: end, text: tag})
+			} else if parent.name == xmpDescriptionName && replaced[elem.name] {
+				skip = len(stack)
+				skipStart = start
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, errors.New("invalid xmp document")
+			}
+
+			depth := len(stack)
+			elem := stack[depth-1]
+			stack = stack[:depth-1]
+
+			if skip == depth {
+				edits = append(edits, xmpEdit{start: xmpTrimStart(existing, skipStart), end: end})
+				skip = -1
+			} else if skip < 0 && !merged && start < end && elem.name == xmpDescriptionName {
+				pos := xmpTrimStart(existing, start)
+				edits = append(edits, xmpEdit{start: pos, end: pos, text: props})
+				merged = true
+			}
+		}
+	}
+
+	if !merged {
+		return nil, errors.New("no rdf:Description found in xmp document")
+	}
+
+	var b bytes.Buffer
+	var pos int64
+
+	for _, e := range edits {
+		if e.start < pos {
+			return nil, errors.New("invalid xmp document")
+		}
+
+		b.Write(existing[pos:e.start])
+		b.Write(e.text)
+		pos = e.end
+	}
+
+	b.Write(existing[pos:])
+
+	return b.Bytes(), nil
+}
+
+// xmpElement represents an XML element with the namespace prefixes in its scope.
+type xmpElement struct {
+	name  xmpName
+	scope map[string]string
+}
+
+// newXmpElement returns the element of a start tag, with namespace prefixes resolved.
+func newXmpElement(t xml.StartElement, parent map[string]string) xmpElement {
+	scope := make(map[string]string, len(parent))
+
+	for prefix, uri := range parent {
+		scope[prefix] = uri
+	}
+
+	for _, a := range t.Attr {
+		if a.Name.Space == "xmlns" {
+			scope[a.Name.Local] = a.Value
+		}
+	}
+
+	return xmpElement{name: xmpName{Space: scope[t.Name.Space], Local: t.Name.Local}, scope: scope}
+}
+
+// xmpProperties returns the properties, their names, and the namespaces of an XMP document created by
+// PhotoPrism, the creator tool and document id of existing files are not changed.
+func xmpProperties(data []byte) (props []byte, names map[xmpName]bool, namespaces map[string]string, err error) {
+	var stack []xmpElement
+
+	names = make(map[xmpName]bool)
+	var propStart int64
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		start := d.InputOffset()
+		token, err := d.RawToken()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := xmpElement{}
+
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+
+			elem := newXmpElement(t, parent.scope)
+			stack = append(stack, elem)
+
+			if elem.name == xmpDescriptionName {
+				namespaces = make(map[string]string)
+
+				for _, a := range t.Attr {
+					if a.Name.Space == "xmlns" {
+						namespaces[a.Name.Local] = a.Value
+					}
+				}
+			} else if parent.name == xmpDescriptionName {
+				propStart = start
+			}
+		case xml.EndElement:
+			elem := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if len(stack) > 0 && stack[len(stack)-1].name == xmpDescriptionName && xmpMerged[elem.name] {
+				props = append(props, data[xmpTrimStart(data, propStart):d.InputOffset()]...)
+				names[elem.name] = true
+			}
+		}
+	}
+
+	return props, names, namespaces, nil
+}
+
+// xmpStartTag returns the start tag without replaced properties, and with the namespaces to be added.
+func xmpStartTag(t xml.StartElement, scope, add map[string]string, replaced map[xmpName]bool) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString("<" + xmpRawName(t.Name))
+
+	for _, a := range t.Attr {
+		if a.Name.Space != "xmlns" && a.Name.Space != "" && replaced[xmpName{scope[a.Name.Space], a.Name.Local}] {
+			continue
+		}
+
+		b.WriteString(" " + xmpRawName(a.Name) + `="`)
+
+		if err := xml.EscapeText(&b, []byte(a.Value)); err != nil {
+			return nil, err
+		}
+
+		b.WriteString(`"`)
+	}
+
+	prefixes := make([]string, 0, len(add))
+
+	for prefix := range add {
+		prefixes = append(prefixes, prefix)
+	}
+
+	sort.Strings(prefixes)
+
+	for _, prefix := range prefixes {
+		if uri, ok := scope[prefix]; ok && uri == add[prefix] {
+			continue
+		} else if ok {
+			return nil, fmt.Errorf("xmp namespace prefix %s is already used for %s", prefix, uri)
+		}
+
+		b.WriteString(fmt.Sprintf(` xmlns:%s="%s"`, prefix, add[prefix]))
+	}
+
+	b.WriteString(">")
+
+	return b.Bytes(), nil
+}
+
+// xmpRawName returns the element or attribute name with namespace prefix.
+func xmpRawName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+
+	return n.Space + ":" + n.Local
+}
+
+// xmpTrimStart returns the position of the line break before the indentation at pos, if any.
+func xmpTrimStart(data []byte, pos int64) int64 {
+	for pos > 0 && (data[pos-1] == ' ' || data[pos-1] == '\t') {
+		pos--
+	}
+
+	if pos > 0 && data[pos-1] == '\n' {
+		pos--
+	}
+
+	if pos > 0 && data[pos-1] == '\r' {
+		pos--
+	}
+
+	return pos
+}
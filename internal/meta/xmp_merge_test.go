@@ -0,0 +1,166 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestXmpSidecar_Merge(t *testing.T) {
+	s := XmpSidecar{
+		Title:    "Seagull",
+		Keywords: Keywords{"bird", "beach"},
+		People:   []string{"Jens Mander"},
+		TakenAt:  time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC),
+		TimeZone: "UTC",
+		Lat:      52.459690,
+		Lng:      13.321832,
+		Rating:   3,
+	}
+
+	t.Run("Darktable", func(t *testing.T) {
+		existing, err := os.ReadFile("testdata/darktable.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := s.Merge(existing)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.Contains(t, out, `x:xmptk="XMP Core 4.4.0-Exiv2"`)
+		assert.Contains(t, out, `darktable:xmp_version="4"`)
+		assert.Contains(t, out, "<rdf:li>Animals|Birds|Seagull</rdf:li>")
+		assert.Contains(t, out, "<rdf:li>2</rdf:li>")
+		assert.NotContains(t, out, `xmp:Rating="-1"`)
+		assert.NotContains(t, out, XmpToolkit)
+
+		fileName := filepath.Join(t.TempDir(), "darktable.xmp")
+
+		if err = os.WriteFile(fileName, b, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := Sidecar(fileName, fs.XmpFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Seagull", data.Title)
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, s.TakenAt, data.TakenAt.UTC())
+		assert.InEpsilon(t, 52.459690, data.Lat, 0.00001)
+		assert.InEpsilon(t, 13.321832, data.Lng, 0.00001)
+		assert.False(t, XmpWritable(fileName))
+
+		doc := XmpDocument{}
+
+		if err = doc.Load(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"Jens Mander"}, doc.People())
+	})
+	t.Run("Photoshop", func(t *testing.T) {
+		existing, err := os.ReadFile("testdata/photoshop.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := s.Merge(existing)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.Equal(t, 1, strings.Count(out, "<dc:title>"))
+		assert.Equal(t, 1, strings.Count(out, "<xmp:Rating>"))
+		assert.Contains(t, out, "<xmp:Rating>3</xmp:Rating>")
+		assert.Contains(t, out, "<xmp:CreatorTool>ELE-L29 10.0.0.168(C431E22R2P5)</xmp:CreatorTool>")
+		assert.Contains(t, out, "<xmpMM:DocumentID>2C678C1811D7095FD79CC822B9296F2B</xmpMM:DocumentID>")
+		assert.Contains(t, out, "<aux:Lens>HUAWEI P30 Rear Main Camera</aux:Lens>")
+		assert.NotContains(t, out, "Night Shift / Berlin / 2020")
+	})
+	t.Run("SelfClosing", func(t *testing.T) {
+		existing := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 5.5.0"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="1" xmp:CreatorTool="digiKam"/></rdf:RDF></x:xmpmeta>`)
+
+		b, err := s.Merge(existing)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.Contains(t, out, `xmp:CreatorTool="digiKam"`)
+		assert.Contains(t, out, "<xmp:Rating>3</xmp:Rating>")
+		assert.Contains(t, out, "</rdf:Description></rdf:RDF>")
+		assert.NotContains(t, out, `xmp:Rating="1"`)
+	})
+	t.Run("Unrated", func(t *testing.T) {
+		unrated := XmpSidecar{Title: "Seagull", TakenAt: s.TakenAt, TimeZone: "UTC"}
+
+		existing, err := os.ReadFile("testdata/darktable.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := unrated.Merge(existing)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.Contains(t, out, `xmp:Rating="-1"`)
+		assert.NotContains(t, out, "<xmp:Rating>")
+
+		existing = []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="2"><dc:title><rdf:Alt><rdf:li xml:lang="x-default">Gull</rdf:li></rdf:Alt></dc:title><dc:creator><rdf:Seq><rdf:li>Jane Doe</rdf:li></rdf:Seq></dc:creator><dc:rights><rdf:Alt><rdf:li xml:lang="x-default">All rights reserved</rdf:li></rdf:Alt></dc:rights></rdf:Description></rdf:RDF></x:xmpmeta>`)
+
+		if b, err = unrated.Merge(existing); err != nil {
+			t.Fatal(err)
+		}
+
+		out = string(b)
+
+		assert.Contains(t, out, `xmp:Rating="2"`)
+		assert.Contains(t, out, "<rdf:li>Jane Doe</rdf:li>")
+		assert.Contains(t, out, "All rights reserved")
+		assert.Contains(t, out, "Seagull")
+		assert.NotContains(t, out, ">Gull<")
+	})
+	t.Run("PrefixConflict", func(t *testing.T) {
+		existing := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:dc="urn:example"></rdf:Description></rdf:RDF></x:xmpmeta>`)
+
+		_, err := s.Merge(existing)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoDescription", func(t *testing.T) {
+		_, err := s.Merge([]byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 5.5.0"></x:xmpmeta>`))
+
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := s.Merge([]byte(`<x:xmpmeta><rdf:RDF>`))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+package meta
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// Color labels as used in Lightroom, Bridge, darktable, and digiKam.
+const (
+	ColorLabelRed    = "red"
+	ColorLabelYellow = "yellow"
+	ColorLabelGreen  = "green"
+	ColorLabelBlue   = "blue"
+	ColorLabelPurple = "purple"
+)
+
+// Star ratings, where -1 means rejected and 0 means not rated.
+const (
+	RatingRejected = -1
+	RatingMin      = 0
+	RatingMax      = 5
+)
+
+// ColorLabels maps label names, including the default Bridge label set, to color labels.
+var ColorLabels = map[string]string{
+	"red":      ColorLabelRed,
+	"yellow":   ColorLabelYellow,
+	"green":    ColorLabelGreen,
+	"blue":     ColorLabelBlue,
+	"purple":   ColorLabelPurple,
+	"magenta":  ColorLabelPurple,
+	"select":   ColorLabelRed,
+	"second":   ColorLabelYellow,
+	"approved": ColorLabelGreen,
+	"review":   ColorLabelBlue,
+	"to do":    ColorLabelPurple,
+}
+
+// darktableColorLabels maps the darktable label numbers to color labels.
+var darktableColorLabels = []string{ColorLabelRed, ColorLabelYellow, ColorLabelGreen, ColorLabelBlue, ColorLabelPurple}
+
+// xmpRatingDocument represents the rating and color label in an XMP sidecar file.
+// The namespace is required, as e.g. Microsoft Photo ratings are percentages.
+type xmpRatingDocument struct {
+	RDF struct {
+		Description []struct {
+			RatingAttr      string `xml:"http://ns.adobe.com/xap/1.0/ Rating,attr"`
+			Rating          string `xml:"http://ns.adobe.com/xap/1.0/ Rating"`
+			LabelAttr       string `xml:"http://ns.adobe.com/xap/1.0/ Label,attr"`
+			Label           string `xml:"http://ns.adobe.com/xap/1.0/ Label"`
+			ColorLabelsAttr string `xml:"colorlabels,attr"`
+			ColorLabels     struct {
+				Seq struct {
+					Li []string `xml:"li"`
+				} `xml:"Seq"`
+			} `xml:"colorlabels"`
+		} `xml:"Description"`
+	} `xml:"RDF"`
+}
+
+// NormalizeRating returns a valid star rating, or 0 if the value cannot be parsed.
+func NormalizeRating(s string) int {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 32)
+
+	switch {
+	case err != nil:
+		return RatingMin
+	case f < 0:
+		return RatingRejected
+	case f > RatingMax:
+		return RatingMax
+	default:
+		return int(f)
+	}
+}
+
+// NormalizeColorLabel returns a supported color label, or an empty string if the label is unknown.
+func NormalizeColorLabel(s string) string {
+	return ColorLabels[strings.ToLower(SanitizeString(s))]
+}
+
+// XmpRating reads the star rating and color label from the contents of an XMP sidecar file.
+func (data *Data) XmpRating(b []byte) error {
+	doc := xmpRatingDocument{}
+
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	for _, d := range doc.RDF.Description {
+		if s := firstString(d.Rating, d.RatingAttr); s != "" {
+			data.Rating = NormalizeRating(s)
+		}
+
+		if l := NormalizeColorLabel(firstString(d.Label, d.LabelAttr)); l != "" {
+			data.ColorLabel = l
+			continue
+		}
+
+		// darktable stores color labels as numbers from 0 to 4.
+		for _, s := range append(d.ColorLabels.Seq.Li, d.ColorLabelsAttr) {
+			if i, err := strconv.Atoi(strings.TrimSpace(s)); err == nil && i >= 0 && i < len(darktableColorLabels) {
+				data.ColorLabel = darktableColorLabels[i]
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// firstString returns the first string that is not empty.
+func firstString(values ...string) string {
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,67 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_XmpRating(t *testing.T) {
+	t.Run("Lightroom", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRating(testFile(t, "testdata/lightroom.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, ColorLabelRed, data.ColorLabel)
+	})
+	t.Run("darktable", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRating(testFile(t, "testdata/darktable.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RatingRejected, data.Rating)
+		assert.Equal(t, ColorLabelGreen, data.ColorLabel)
+	})
+	t.Run("digiKam", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRating(testFile(t, "testdata/digikam.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, ColorLabelGreen, data.ColorLabel)
+	})
+	t.Run("Microsoft", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRating(testFile(t, "testdata/microsoft.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, data.Rating)
+		assert.Equal(t, "", data.ColorLabel)
+	})
+}
+
+func TestNormalizeRating(t *testing.T) {
+	assert.Equal(t, 0, NormalizeRating(""))
+	assert.Equal(t, 0, NormalizeRating("foo"))
+	assert.Equal(t, 3, NormalizeRating(" 3 "))
+	assert.Equal(t, 2, NormalizeRating("2.0"))
+	assert.Equal(t, RatingRejected, NormalizeRating("-1"))
+	assert.Equal(t, RatingMax, NormalizeRating("99"))
+}
+
+func TestNormalizeColorLabel(t *testing.T) {
+	assert.Equal(t, ColorLabelRed, NormalizeColorLabel("Red"))
+	assert.Equal(t, ColorLabelPurple, NormalizeColorLabel("To Do"))
+	assert.Equal(t, ColorLabelPurple, NormalizeColorLabel("magenta"))
+	assert.Equal(t, "", NormalizeColorLabel("Orange"))
+	assert.Equal(t, "", NormalizeColorLabel(""))
+}
@@ -0,0 +1,141 @@
+package meta
+
+import "encoding/xml"
+
+// xmpAreaValues represents an MWG region area, which can be specified with attributes or elements.
+type xmpAreaValues struct {
+	XAttr    string `xml:"x,attr"`
+	YAttr    string `xml:"y,attr"`
+	WAttr    string `xml:"w,attr"`
+	HAttr    string `xml:"h,attr"`
+	UnitAttr string `xml:"unit,attr"`
+	X        string `xml:"x"`
+	Y        string `xml:"y"`
+	W        string `xml:"w"`
+	H        string `xml:"h"`
+	Unit     string `xml:"unit"`
+}
+
+// xmpMwgRegion represents a region as specified by the Metadata Working Group.
+type xmpMwgRegion struct {
+	NameAttr string        `xml:"Name,attr"`
+	TypeAttr string        `xml:"Type,attr"`
+	Name     string        `xml:"Name"`
+	Type     string        `xml:"Type"`
+	Area     xmpAreaValues `xml:"Area"`
+}
+
+// xmpMwgRegionItem represents a region list item, with or without a nested rdf:Description.
+type xmpMwgRegionItem struct {
+	xmpMwgRegion
+	Description *xmpMwgRegion `xml:"Description"`
+}
+
+// xmpMpRegion represents a Microsoft Photo region with a person name.
+type xmpMpRegion struct {
+	RectangleAttr string `xml:"Rectangle,attr"`
+	NameAttr      string `xml:"PersonDisplayName,attr"`
+	Rectangle     string `xml:"Rectangle"`
+	Name          string `xml:"PersonDisplayName"`
+}
+
+// xmpMpRegionItem represents a Microsoft Photo region list item, with or without a nested rdf:Description.
+type xmpMpRegionItem struct {
+	xmpMpRegion
+	Description *xmpMpRegion `xml:"Description"`
+}
+
+// xmpRegionsDocument represents the MWG and Microsoft Photo regions in an XMP sidecar file.
+type xmpRegionsDocument struct {
+	RDF struct {
+		Description []struct {
+			Regions struct {
+				AppliedToDimensions struct {
+					WAttr string `xml:"w,attr"`
+					HAttr string `xml:"h,attr"`
+					W     string `xml:"w"`
+					H     string `xml:"h"`
+				} `xml:"AppliedToDimensions"`
+				RegionList struct {
+					Bag struct {
+						Li []xmpMwgRegionItem `xml:"li"`
+					} `xml:"Bag"`
+				} `xml:"RegionList"`
+			} `xml:"Regions"`
+			RegionInfo struct {
+				Regions struct {
+					Bag struct {
+						Li []xmpMpRegionItem `xml:"li"`
+					} `xml:"Bag"`
+				} `xml:"Regions"`
+				Description struct {
+					Regions struct {
+						Bag struct {
+							Li []xmpMpRegionItem `xml:"li"`
+						} `xml:"Bag"`
+					} `xml:"Regions"`
+				} `xml:"Description"`
+			} `xml:"RegionInfo"`
+		} `xml:"Description"`
+	} `xml:"RDF"`
+}
+
+// XmpRegions reads MWG and Microsoft Photo regions, e.g. named faces, from the contents of an XMP sidecar file.
+func (data *Data) XmpRegions(b []byte) error {
+	doc := xmpRegionsDocument{}
+
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	for _, d := range doc.RDF.Description {
+		// MWG regions as written by Lightroom, digiKam, Picasa, and many others.
+		width := parseFloat(firstString(d.Regions.AppliedToDimensions.W, d.Regions.AppliedToDimensions.WAttr))
+		height := parseFloat(firstString(d.Regions.AppliedToDimensions.H, d.Regions.AppliedToDimensions.HAttr))
+
+		for _, li := range d.Regions.RegionList.Bag.Li {
+			r := li.xmpMwgRegion
+
+			if li.Description != nil {
+				r = *li.Description
+			}
+
+			if region, ok := r.region(width, height); ok {
+				data.AddRegion(region)
+			}
+		}
+
+		// Microsoft Photo regions as written by Windows Live Photo Gallery.
+		for _, li := range append(d.RegionInfo.Regions.Bag.Li, d.RegionInfo.Description.Regions.Bag.Li...) {
+			r := li.xmpMpRegion
+
+			if li.Description != nil {
+				r = *li.Description
+			}
+
+			if region, ok := r.region(); ok {
+				data.AddRegion(region)
+			}
+		}
+	}
+
+	return nil
+}
+
+// region returns the MWG region with relative top left coordinates.
+func (r xmpMwgRegion) region(width, height float64) (Region, bool) {
+	return mwgRegion(
+		firstString(r.Name, r.NameAttr),
+		firstString(r.Type, r.TypeAttr),
+		parseFloat(firstString(r.Area.X, r.Area.XAttr)),
+		parseFloat(firstString(r.Area.Y, r.Area.YAttr)),
+		parseFloat(firstString(r.Area.W, r.Area.WAttr)),
+		parseFloat(firstString(r.Area.H, r.Area.HAttr)),
+		firstString(r.Area.Unit, r.Area.UnitAttr),
+		width, height)
+}
+
+// region returns the Microsoft Photo region.
+func (r xmpMpRegion) region() (Region, bool) {
+	return mpRegion(firstString(r.Name, r.NameAttr), firstString(r.Rectangle, r.RectangleAttr))
+}
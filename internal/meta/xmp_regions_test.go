@@ -0,0 +1,79 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_XmpRegions(t *testing.T) {
+	t.Run("Lightroom", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRegions(testFile(t, "testdata/lightroom.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data.Regions) != 2 {
+			t.Fatalf("expected 2 regions, found %d", len(data.Regions))
+		}
+
+		assert.Equal(t, "Jane Doe", data.Regions[0].Name)
+		assert.Equal(t, RegionFace, data.Regions[0].Type)
+		assert.InDelta(t, 0.4, data.Regions[0].X, 0.0001)
+		assert.InDelta(t, 0.25, data.Regions[0].Y, 0.0001)
+		assert.InDelta(t, 0.2, data.Regions[0].W, 0.0001)
+		assert.InDelta(t, 0.3, data.Regions[0].H, 0.0001)
+		assert.Equal(t, "", data.Regions[1].Name)
+		assert.InDelta(t, 0.15, data.Regions[1].X, 0.0001)
+	})
+	t.Run("digiKam", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRegions(testFile(t, "testdata/digikam.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data.Regions) != 2 {
+			t.Fatalf("expected 2 regions, found %d", len(data.Regions))
+		}
+
+		assert.Equal(t, "Max Mustermann", data.Regions[0].Name)
+		assert.InDelta(t, 0.45, data.Regions[0].X, 0.0001)
+		assert.InDelta(t, 0.35, data.Regions[0].Y, 0.0001)
+		assert.InDelta(t, 0.1, data.Regions[0].W, 0.0001)
+		assert.InDelta(t, 0.3, data.Regions[0].H, 0.0001)
+		assert.Equal(t, "Bello", data.Regions[1].Name)
+		assert.Equal(t, RegionPet, data.Regions[1].Type)
+		assert.InDelta(t, 0.2, data.Regions[1].X, 0.0001)
+		assert.InDelta(t, 0.65, data.Regions[1].Y, 0.0001)
+	})
+	t.Run("Microsoft", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRegions(testFile(t, "testdata/microsoft.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data.Regions) != 2 {
+			t.Fatalf("expected 2 regions, found %d", len(data.Regions))
+		}
+
+		assert.Equal(t, "Jens Mander", data.Regions[0].Name)
+		assert.Equal(t, RegionFace, data.Regions[0].Type)
+		assert.InDelta(t, 0.412, data.Regions[0].X, 0.0001)
+		assert.InDelta(t, 0.205, data.Regions[0].Y, 0.0001)
+		assert.InDelta(t, 0.11, data.Regions[0].W, 0.0001)
+		assert.InDelta(t, 0.147, data.Regions[0].H, 0.0001)
+		assert.Equal(t, "Erika Mustermann", data.Regions[1].Name)
+	})
+	t.Run("NoRegions", func(t *testing.T) {
+		data := Data{}
+
+		if err := data.XmpRegions(testFile(t, "testdata/photoshop.xmp")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, data.Regions)
+	})
+}
@@ -0,0 +1,377 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// XmpToolkit is the toolkit name used to identify XMP sidecar files created by PhotoPrism.
+const XmpToolkit = "PhotoPrism"
+
+// XMP namespace URIs.
+const (
+	XmpNsMeta      = "adobe:ns:meta/"
+	XmpNsRdf       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XmpNsDc        = "http://purl.org/dc/elements/1.1/"
+	XmpNsXmp       = "http://ns.adobe.com/xap/1.0/"
+	XmpNsXmpRights = "http://ns.adobe.com/xap/1.0/rights/"
+	XmpNsXmpMM     = "http://ns.adobe.com/xap/1.0/mm/"
+	XmpNsPhotoshop = "http://ns.adobe.com/photoshop/1.0/"
+	XmpNsExif      = "http://ns.adobe.com/exif/1.0/"
+	XmpNsIptcExt   = "http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+	XmpNsMwgRs     = "http://www.metadataworkinggroup.com/schemas/regions/"
+	XmpNsStDim     = "http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+	XmpNsStArea    = "http://ns.adobe.com/xmp/sType/Area#"
+)
+
+const (
+	xmpPacketBegin = "<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n"
+	xmpPacketEnd   = "\n<?xpacket end=\"w\"?>\n"
+)
+
+// Region represents a named rectangular image area, e.g. a face.
+// Coordinates are relative to the image size, with X and Y being the top left corner.
+type Region struct {
+	Name string
+	Type string
+	X    float32
+	Y    float32
+	W    float32
+	H    float32
+}
+
+// Regions represents a list of image regions.
+type Regions []Region
+
+// Region types as defined by the Metadata Working Group.
+const (
+	RegionFace = "Face"
+	RegionPet  = "Pet"
+)
+
+// XmpSidecar represents metadata that can be written to an XMP sidecar file.
+type XmpSidecar struct {
+	DocumentID   string
+	Title        string
+	Description  string
+	Artist       string
+	Copyright    string
+	License      string
+	Keywords     Keywords
+	People       []string
+	TakenAt      time.Time
+	TakenAtLocal time.Time
+	TimeZone     string
+	Lat          float32
+	Lng          float32
+	Altitude     int
+	Favorite     bool
+	Rating       int
+	ColorLabel   string
+	Width        int
+	Height       int
+	Regions      Regions
+}
+
+type xmpLangItem struct {
+	Lang string `xml:"xml:lang,attr"`
+	Text string `xml:",chardata"`
+}
+
+type xmpLangAlt struct {
+	Li xmpLangItem `xml:"rdf:li"`
+}
+
+type xmpLang struct {
+	Alt xmpLangAlt `xml:"rdf:Alt"`
+}
+
+type xmpList struct {
+	Li []string `xml:"rdf:li"`
+}
+
+type xmpSeq struct {
+	Seq xmpList `xml:"rdf:Seq"`
+}
+
+type xmpBag struct {
+	Bag xmpList `xml:"rdf:Bag"`
+}
+
+type xmpDimensions struct {
+	W    int    `xml:"stDim:w,attr"`
+	H    int    `xml:"stDim:h,attr"`
+	Unit string `xml:"stDim:unit,attr"`
+}
+
+type xmpArea struct {
+	X    string `xml:"stArea:x,attr"`
+	Y    string `xml:"stArea:y,attr"`
+	W    string `xml:"stArea:w,attr"`
+	H    string `xml:"stArea:h,attr"`
+	Unit string `xml:"stArea:unit,attr"`
+}
+
+type xmpRegion struct {
+	Name string  `xml:"mwg-rs:Name,attr,omitempty"`
+	Type string  `xml:"mwg-rs:Type,attr"`
+	Area xmpArea `xml:"mwg-rs:Area"`
+}
+
+type xmpRegionItem struct {
+	Description xmpRegion `xml:"rdf:Description"`
+}
+
+type xmpRegionList struct {
+	Bag struct {
+		Li []xmpRegionItem `xml:"rdf:li"`
+	} `xml:"rdf:Bag"`
+}
+
+type xmpRegions struct {
+	ParseType           string        `xml:"rdf:parseType,attr"`
+	AppliedToDimensions xmpDimensions `xml:"mwg-rs:AppliedToDimensions"`
+	RegionList          xmpRegionList `xml:"mwg-rs:RegionList"`
+}
+
+type xmpDescription struct {
+	About            string      `xml:"rdf:about,attr"`
+	NsDc             string      `xml:"xmlns:dc,attr"`
+	NsXmp            string      `xml:"xmlns:xmp,attr"`
+	NsXmpRights      string      `xml:"xmlns:xmpRights,attr"`
+	NsXmpMM          string      `xml:"xmlns:xmpMM,attr"`
+	NsPhotoshop      string      `xml:"xmlns:photoshop,attr"`
+	NsExif           string      `xml:"xmlns:exif,attr"`
+	NsIptcExt        string      `xml:"xmlns:Iptc4xmpExt,attr"`
+	NsMwgRs          string      `xml:"xmlns:mwg-rs,attr"`
+	NsStDim          string      `xml:"xmlns:stDim,attr"`
+	NsStArea         string      `xml:"xmlns:stArea,attr"`
+	CreatorTool      string      `xml:"xmp:CreatorTool"`
+	MetadataDate     string      `xml:"xmp:MetadataDate"`
+	Rating           string      `xml:"xmp:Rating,omitempty"`
+	Label            string      `xml:"xmp:Label,omitempty"`
+	DocumentID       string      `xml:"xmpMM:DocumentID,omitempty"`
+	Title            *xmpLang    `xml:"dc:title,omitempty"`
+	Description      *xmpLang    `xml:"dc:description,omitempty"`
+	Creator          *xmpSeq     `xml:"dc:creator,omitempty"`
+	Rights           *xmpLang    `xml:"dc:rights,omitempty"`
+	Subject          *xmpBag     `xml:"dc:subject,omitempty"`
+	UsageTerms       *xmpLang    `xml:"xmpRights:UsageTerms,omitempty"`
+	DateCreated      string      `xml:"photoshop:DateCreated,omitempty"`
+	DateTimeOriginal string      `xml:"exif:DateTimeOriginal,omitempty"`
+	GPSLatitude      string      `xml:"exif:GPSLatitude,omitempty"`
+	GPSLongitude     string      `xml:"exif:GPSLongitude,omitempty"`
+	GPSAltitude      string      `xml:"exif:GPSAltitude,omitempty"`
+	GPSAltitudeRef   string      `xml:"exif:GPSAltitudeRef,omitempty"`
+	PersonInImage    *xmpBag     `xml:"Iptc4xmpExt:PersonInImage,omitempty"`
+	Regions          *xmpRegions `xml:"mwg-rs:Regions,omitempty"`
+}
+
+type xmpRDF struct {
+	NsRdf       string         `xml:"xmlns:rdf,attr"`
+	Description xmpDescription `xml:"rdf:Description"`
+}
+
+type xmpMeta struct {
+	XMLName xml.Name `xml:"x:xmpmeta"`
+	NsX     string   `xml:"xmlns:x,attr"`
+	Toolkit string   `xml:"x:xmptk,attr"`
+	RDF     xmpRDF   `xml:"rdf:RDF"`
+}
+
+// newXmpLang returns a language alternative with a default value, or nil if the value is empty.
+func newXmpLang(s string) *xmpLang {
+	if s == "" {
+		return nil
+	}
+
+	return &xmpLang{Alt: xmpLangAlt{Li: xmpLangItem{Lang: "x-default", Text: s}}}
+}
+
+// newXmpBag returns an unordered list, or nil if it is empty.
+func newXmpBag(s []string) *xmpBag {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return &xmpBag{Bag: xmpList{Li: s}}
+}
+
+// xmpGps formats a GPS coordinate as "DDD,MM.mmmmk" as specified in the XMP Exif schema.
+func xmpGps(f float32, pos, neg string) string {
+	ref := pos
+
+	if f < 0 {
+		ref = neg
+		f = -f
+	}
+
+	deg := math.Floor(float64(f))
+	min := (float64(f) - deg) * 60
+
+	return fmt.Sprintf("%d,%.6f%s", int(deg), min, ref)
+}
+
+// xmpFloat formats a relative region coordinate.
+func xmpFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', 6, 32)
+}
+
+// DateCreated returns the time the photo was taken, formatted as XMP date string.
+func (s *XmpSidecar) DateCreated() string {
+	if s.TakenAt.IsZero() {
+		return ""
+	}
+
+	if s.TimeZone != "" && s.TimeZone != time.UTC.String() {
+		if loc, err := time.LoadLocation(s.TimeZone); err == nil {
+			return s.TakenAt.In(loc).Format(time.RFC3339)
+		}
+	}
+
+	if !s.TakenAtLocal.IsZero() && s.TimeZone == "" {
+		return s.TakenAtLocal.Format("2006-01-02T15:04:05")
+	}
+
+	return s.TakenAt.UTC().Format(time.RFC3339)
+}
+
+// document returns the XMP document structure.
+func (s *XmpSidecar) document() xmpMeta {
+	d := xmpDescription{
+		About:        "",
+		NsDc:         XmpNsDc,
+		NsXmp:        XmpNsXmp,
+		NsXmpRights:  XmpNsXmpRights,
+		NsXmpMM:      XmpNsXmpMM,
+		NsPhotoshop:  XmpNsPhotoshop,
+		NsExif:       XmpNsExif,
+		NsIptcExt:    XmpNsIptcExt,
+		NsMwgRs:      XmpNsMwgRs,
+		NsStDim:      XmpNsStDim,
+		NsStArea:     XmpNsStArea,
+		CreatorTool:  XmpToolkit,
+		MetadataDate: time.Now().UTC().Format(time.RFC3339),
+		Title:        newXmpLang(s.Title),
+		Description:  newXmpLang(s.Description),
+		Rights:       newXmpLang(s.Copyright),
+		UsageTerms:   newXmpLang(s.License),
+		Subject:      newXmpBag(txt.UniqueWords(append([]string{}, s.Keywords...))),
+		DocumentID:   s.DocumentID,
+	}
+
+	if s.Artist != "" {
+		d.Creator = &xmpSeq{Seq: xmpList{Li: []string{s.Artist}}}
+	}
+
+	// Star rating, -1 means rejected. Favorites without rating are exported with the highest rating.
+	if s.Rating != RatingMin {
+		d.Rating = strconv.Itoa(s.Rating)
+	} else if s.Favorite {
+		d.Rating = strconv.Itoa(RatingMax)
+	}
+
+	// Color labels are written as capitalized names, as in Lightroom and Bridge.
+	if label := NormalizeColorLabel(s.ColorLabel); label != "" {
+		d.Label = txt.UpperFirst(label)
+	}
+
+	if s.DateCreated() != "" {
+		d.DateCreated = s.DateCreated()
+		d.DateTimeOriginal = d.DateCreated
+	}
+
+	if s.Lat != 0 || s.Lng != 0 {
+		d.GPSLatitude = xmpGps(s.Lat, "N", "S")
+		d.GPSLongitude = xmpGps(s.Lng, "E", "W")
+
+		if s.Altitude < 0 {
+			d.GPSAltitude = fmt.Sprintf("%d/1", -s.Altitude)
+			d.GPSAltitudeRef = "1"
+		} else if s.Altitude > 0 {
+			d.GPSAltitude = fmt.Sprintf("%d/1", s.Altitude)
+			d.GPSAltitudeRef = "0"
+		}
+	}
+
+	d.PersonInImage = newXmpBag(txt.UniqueNames(s.People))
+
+	if len(s.Regions) > 0 {
+		r := &xmpRegions{
+			ParseType:           "Resource",
+			AppliedToDimensions: xmpDimensions{W: s.Width, H: s.Height, Unit: "pixel"},
+		}
+
+		for _, region := range s.Regions {
+			regionType := region.Type
+
+			if regionType == "" {
+				regionType = RegionFace
+			}
+
+			// MWG regions use the center point of the area.
+			r.RegionList.Bag.Li = append(r.RegionList.Bag.Li, xmpRegionItem{Description: xmpRegion{
+				Name: region.Name,
+				Type: regionType,
+				Area: xmpArea{
+					X:    xmpFloat(region.X + region.W/2),
+					Y:    xmpFloat(region.Y + region.H/2),
+					W:    xmpFloat(region.W),
+					H:    xmpFloat(region.H),
+					Unit: "normalized",
+				},
+			}})
+		}
+
+		d.Regions = r
+	}
+
+	return xmpMeta{
+		NsX:     XmpNsMeta,
+		Toolkit: XmpToolkit,
+		RDF: xmpRDF{
+			NsRdf:       XmpNsRdf,
+			Description: d,
+		},
+	}
+}
+
+// Bytes returns the XMP sidecar file content.
+func (s *XmpSidecar) Bytes() ([]byte, error) {
+	out, err := xml.MarshalIndent(s.document(), "", " ")
+
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var b bytes.Buffer
+
+	b.WriteString(xmpPacketBegin)
+	b.Write(out)
+	b.WriteString(xmpPacketEnd)
+
+	return b.Bytes(), nil
+}
+
+// XmpWritable checks if an XMP sidecar file does not exist yet or was created by PhotoPrism,
+// so that it can be replaced. Files created by other applications must be updated with Merge.
+func XmpWritable(fileName string) bool {
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		return true
+	}
+
+	doc := XmpDocument{}
+
+	if err := doc.Load(fileName); err != nil {
+		return false
+	}
+
+	return doc.Toolkit() == XmpToolkit
+}
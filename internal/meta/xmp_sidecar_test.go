@@ -0,0 +1,177 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestXmpSidecar_Bytes(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		s := XmpSidecar{
+			DocumentID:  "7c3ab8fd-e8c2-4e2b-9d4b-4f0e0c1a1d2b",
+			Title:       "Night Shift / Berlin / 2020",
+			Description: "Example file for development",
+			Artist:      "Michael Mayer",
+			Copyright:   "This is an (edited) legal notice",
+			License:     "CC BY-SA 4.0",
+			Keywords:    Keywords{"desk", "coffee", "computer", "coffee"},
+			People:      []string{"Jens Mander", "Corn McCornface"},
+			TakenAt:     time.Date(2020, 1, 1, 16, 28, 23, 0, time.UTC),
+			TimeZone:    "Europe/Berlin",
+			Lat:         52.459690,
+			Lng:         13.321832,
+			Altitude:    34,
+			Favorite:    true,
+			ColorLabel:  ColorLabelGreen,
+			Width:       3648,
+			Height:      2736,
+			Regions: Regions{
+				{Name: "Jens Mander", Type: RegionFace, X: 0.1, Y: 0.2, W: 0.1, H: 0.15},
+				{Name: "Corn McCornface", X: 0.5, Y: 0.4, W: 0.2, H: 0.25},
+			},
+		}
+
+		b, err := s.Bytes()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.True(t, strings.HasPrefix(out, "<?xpacket begin="))
+		assert.Contains(t, out, `x:xmptk="PhotoPrism"`)
+		assert.Contains(t, out, `<rdf:li xml:lang="x-default">Night Shift / Berlin / 2020</rdf:li>`)
+		assert.Contains(t, out, `<photoshop:DateCreated>2020-01-01T17:28:23+01:00</photoshop:DateCreated>`)
+		assert.Contains(t, out, `<exif:GPSLatitude>52,27.581`)
+		assert.Contains(t, out, `<xmp:Rating>5</xmp:Rating>`)
+		assert.Contains(t, out, `<xmp:Label>Green</xmp:Label>`)
+		assert.Contains(t, out, `mwg-rs:Name="Jens Mander" mwg-rs:Type="Face"`)
+		assert.Contains(t, out, `stArea:x="0.150000" stArea:y="0.275000" stArea:w="0.100000" stArea:h="0.150000"`)
+
+		fileName := filepath.Join(t.TempDir(), "sidecar.xmp")
+
+		if err = os.WriteFile(fileName, b, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Night Shift / Berlin / 2020", data.Title)
+		assert.Equal(t, "Example file for development", data.Description)
+		assert.Equal(t, "Michael Mayer", data.Artist)
+		assert.Equal(t, "This is an (edited) legal notice", data.Copyright)
+		assert.Equal(t, Keywords{"coffee", "computer", "desk"}, data.Keywords)
+		assert.Equal(t, s.TakenAt, data.TakenAt.UTC())
+		assert.InEpsilon(t, 52.459690, data.Lat, 0.00001)
+		assert.InEpsilon(t, 13.321832, data.Lng, 0.00001)
+		assert.Equal(t, 34, data.Altitude)
+
+		doc := XmpDocument{}
+
+		if err = doc.Load(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, XmpToolkit, doc.Toolkit())
+		assert.True(t, doc.Favorite())
+		assert.Equal(t, []string{"Jens Mander", "Corn McCornface"}, doc.People())
+		assert.True(t, XmpWritable(fileName))
+	})
+	t.Run("RatingRoundTrip", func(t *testing.T) {
+		s := XmpSidecar{Title: "Not a Favorite", Rating: 3, ColorLabel: ColorLabelPurple}
+
+		b, err := s.Bytes()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(t.TempDir(), "rating.xmp")
+
+		if err = os.WriteFile(fileName, b, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := Sidecar(fileName, fs.XmpFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, ColorLabelPurple, data.ColorLabel)
+	})
+	t.Run("FavoriteRating", func(t *testing.T) {
+		s := XmpSidecar{Favorite: true, Rating: 3}
+
+		b, err := s.Bytes()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), `<xmp:Rating>3</xmp:Rating>`)
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		s := XmpSidecar{Rating: RatingRejected}
+
+		b, err := s.Bytes()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), `<xmp:Rating>-1</xmp:Rating>`)
+		assert.NotContains(t, string(b), `xmp:Label`)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		s := XmpSidecar{}
+
+		b, err := s.Bytes()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.NotContains(t, out, "dc:title")
+		assert.NotContains(t, out, "exif:GPSLatitude")
+		assert.NotContains(t, out, "mwg-rs:Regions")
+	})
+	t.Run("SouthWest", func(t *testing.T) {
+		s := XmpSidecar{Lat: -33.8688, Lng: -151.2093, Altitude: -10}
+
+		b, err := s.Bytes()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := string(b)
+
+		assert.Contains(t, out, "<exif:GPSLatitude>33,52.128")
+		assert.Contains(t, out, "<exif:GPSAltitudeRef>1</exif:GPSAltitudeRef>")
+	})
+}
+
+func TestXmpWritable(t *testing.T) {
+	t.Run("NotExists", func(t *testing.T) {
+		assert.True(t, XmpWritable(filepath.Join(t.TempDir(), "missing.xmp")))
+	})
+	t.Run("Photoshop", func(t *testing.T) {
+		assert.False(t, XmpWritable("testdata/photoshop.xmp"))
+	})
+}
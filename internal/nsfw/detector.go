@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
@@ -16,6 +17,7 @@ import (
 // Detector uses TensorFlow to label drawing, hentai, neutral, porn and sexy images.
 type Detector struct {
 	model     *tf.SavedModel
+	backend   inference.Model
 	modelPath string
 	modelTags []string
 	labels    []string
@@ -27,6 +29,16 @@ func New(modelPath string) *Detector {
 	return &Detector{modelPath: modelPath, modelTags: []string{"serve"}}
 }
 
+// Classes are the names of the detected categories in the order of the model output.
+var Classes = []string{"drawing", "hentai", "neutral", "porn", "sexy"}
+
+// WithBackend uses another model for detection, e.g. on a local inference server, instead of
+// the built-in TensorFlow model. It must return scores for the category names in Classes.
+func (t *Detector) WithBackend(model inference.Model) *Detector {
+	t.backend = model
+	return t
+}
+
 // File returns matching labels for a jpeg media file.
 func (t *Detector) File(filename string) (result Labels, err error) {
 	if fs.MimeType(filename) != "image/jpeg" {
@@ -44,6 +56,28 @@ func (t *Detector) File(filename string) (result Labels, err error) {
 
 // Labels returns matching labels for a jpeg media string.
 func (t *Detector) Labels(img []byte) (result Labels, err error) {
+	var output inference.Result
+
+	if t.backend != nil {
+		output, err = t.backend.Infer(img)
+	} else {
+		output, err = t.Infer(img)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	// Return best labels
+	result = t.getLabels(output.Scores(Classes))
+
+	log.Tracef("nsfw: image classified as %+v", result)
+
+	return result, nil
+}
+
+// Infer runs the TensorFlow model and returns the scores of all categories for a jpeg media string.
+func (t *Detector) Infer(img []byte) (result inference.Result, err error) {
 	if err := t.loadModel(); err != nil {
 		return result, err
 	}
@@ -73,10 +107,11 @@ func (t *Detector) Labels(img []byte) (result Labels, err error) {
 		return result, fmt.Errorf("nsfw: inference failed, no output")
 	}
 
-	// Return best labels
-	result = t.getLabels(output[0].Value().([][]float32)[0])
-
-	log.Tracef("nsfw: image classified as %+v", result)
+	for i, p := range output[0].Value().([][]float32)[0] {
+		if i < len(Classes) {
+			result.Labels = append(result.Labels, inference.Label{Name: Classes[i], Score: p})
+		}
+	}
 
 	return result, nil
 }
@@ -1,6 +1,8 @@
 package nsfw
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,6 +10,8 @@ import (
 
 	"github.com/photoprism/photoprism/pkg/fastwalk"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/inference"
 )
 
 var modelPath, _ = filepath.Abs("../../assets/nsfw")
@@ -121,3 +125,25 @@ func TestNSFW(t *testing.T) {
 	assert.Equal(t, false, drawing.NSFW(ThresholdHigh))
 	assert.Equal(t, true, max.NSFW(ThresholdHigh))
 }
+
+func TestDetector_WithBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"labels": [{"name": "neutral", "score": 0.1}, {"name": "porn", "score": 0.9}]}`))
+	}))
+
+	defer server.Close()
+
+	d := New(modelPath).WithBackend(inference.NewHttp(server.URL))
+
+	result, err := d.Labels([]byte("jpeg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, float32(0.9), result.Porn)
+	assert.Equal(t, float32(0.1), result.Neutral)
+	assert.Equal(t, float32(0), result.Sexy)
+	assert.False(t, result.IsSafe())
+}
@@ -0,0 +1,229 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+// Leeway is the accepted clock skew when checking token timestamps.
+var Leeway = time.Minute
+
+// Claims represents the verified claims of an ID token.
+type Claims struct {
+	Issuer            string                 `json:"iss"`
+	Subject           string                 `json:"sub"`
+	Nonce             string                 `json:"nonce"`
+	Email             string                 `json:"email"`
+	EmailVerified     bool                   `json:"email_verified"`
+	Name              string                 `json:"name"`
+	PreferredUsername string                 `json:"preferred_username"`
+	Raw               map[string]interface{} `json:"-"`
+}
+
+// ID returns a unique user identifier that includes the issuer.
+func (c Claims) ID() string {
+	return c.Issuer + "#" + c.Subject
+}
+
+// Values returns the string values of a claim, e.g. a list of groups.
+func (c Claims) Values(name string) (result []string) {
+	switch v := c.Raw[name].(type) {
+	case string:
+		for _, s := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' }) {
+			result = append(result, s)
+		}
+	case []interface{}:
+		for _, s := range v {
+			if str, ok := s.(string); ok && str != "" {
+				result = append(result, str)
+			}
+		}
+	}
+
+	return result
+}
+
+// rolePriority lists user roles from most to least privileged.
+var rolePriority = []acl.Role{acl.RoleAdmin, acl.RoleEditor, acl.RoleViewer, acl.RoleGuest}
+
+// Role returns the most privileged role mapped from the claim values, or the default role.
+func (c Claims) Role(claim string, roles map[string]string, defaultRole string) string {
+	found := make(map[string]bool)
+
+	for _, v := range c.Values(claim) {
+		if r, ok := roles[v]; ok {
+			found[r] = true
+		}
+	}
+
+	for _, r := range rolePriority {
+		if found[r.String()] {
+			return r.String()
+		}
+	}
+
+	return defaultRole
+}
+
+// Verify checks the signature and claims of an ID token and returns the claims.
+func (p *Provider) Verify(idToken, nonce string) (*Claims, error) {
+	parts := strings.Split(idToken, ".")
+
+	if len(parts) != 3 {
+		return nil, errors.New("oidc: malformed id token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	if b, err := base64.RawURLEncoding.DecodeString(parts[0]); err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	} else if err = json.Unmarshal(b, &header); err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	key, err := p.key(header.Kid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	claims := &Claims{}
+
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	} else if err = json.Unmarshal(payload, &claims.Raw); err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	now := time.Now()
+
+	if claims.Issuer != p.Issuer {
+		return nil, fmt.Errorf("oidc: unexpected issuer %s", claims.Issuer)
+	} else if claims.Subject == "" {
+		return nil, errors.New("oidc: subject is empty")
+	} else if !claims.hasAudience(p.ClientID) {
+		return nil, errors.New("oidc: invalid audience")
+	} else if exp := claims.time("exp"); exp.IsZero() || now.After(exp.Add(Leeway)) {
+		return nil, errors.New("oidc: id token expired")
+	} else if iat := claims.time("iat"); !iat.IsZero() && iat.After(now.Add(Leeway)) {
+		return nil, errors.New("oidc: id token issued in the future")
+	} else if claims.Nonce != nonce {
+		return nil, errors.New("oidc: invalid nonce")
+	}
+
+	return claims, nil
+}
+
+// hasAudience checks if the token was issued for the specified client.
+func (c Claims) hasAudience(clientID string) bool {
+	switch aud := c.Raw["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, v := range aud {
+			if v == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// time returns the value of a numeric date claim.
+func (c Claims) time(name string) time.Time {
+	if v, ok := c.Raw[name].(float64); ok {
+		return time.Unix(int64(v), 0)
+	}
+
+	return time.Time{}
+}
+
+// verifySignature checks the token signature for the supported algorithms.
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var h crypto.Hash
+
+	switch alg {
+	case "RS256", "ES256":
+		h = crypto.SHA256
+	case "RS384", "ES384":
+		h = crypto.SHA384
+	case "RS512", "ES512":
+		h = crypto.SHA512
+	default:
+		return fmt.Errorf("oidc: unsupported signing algorithm %s", alg)
+	}
+
+	var digest []byte
+
+	switch h {
+	case crypto.SHA256:
+		sum := sha256.Sum256(signed)
+		digest = sum[:]
+	case crypto.SHA384:
+		sum := sha512.Sum384(signed)
+		digest = sum[:]
+	default:
+		sum := sha512.Sum512(signed)
+		digest = sum[:]
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if alg[0] != 'R' {
+			return errors.New("oidc: algorithm does not match key type")
+		} else if err := rsa.VerifyPKCS1v15(k, h, digest, sig); err != nil {
+			return errors.New("oidc: invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+
+		if alg[0] != 'E' {
+			return errors.New("oidc: algorithm does not match key type")
+		} else if len(sig) != 2*size {
+			return errors.New("oidc: invalid signature")
+		}
+
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("oidc: invalid signature")
+		}
+	default:
+		return errors.New("oidc: unsupported key type")
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// JwksRefresh is the minimum interval between two key set requests.
+var JwksRefresh = time.Minute
+
+// Jwk represents a JSON web key.
+type Jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// PublicKey returns the public key or an error if the key type is not supported.
+func (k Jwk) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+// key returns the signing key with the specified id, the key set is fetched again if the key is unknown.
+func (p *Provider) key(kid string) (crypto.PublicKey, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	} else if kid == "" && len(p.keys) == 1 {
+		for _, k = range p.keys {
+			return k, nil
+		}
+	}
+
+	if time.Since(p.keysFetchedAt) < JwksRefresh {
+		return nil, fmt.Errorf("oidc: unknown signing key %s", kid)
+	}
+
+	if err := p.fetchKeys(); err != nil {
+		return nil, err
+	}
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	} else if kid == "" && len(p.keys) == 1 {
+		for _, k = range p.keys {
+			return k, nil
+		}
+	}
+
+	return nil, fmt.Errorf("oidc: unknown signing key %s", kid)
+}
+
+// fetchKeys loads the provider's current key set.
+func (p *Provider) fetchKeys() error {
+	p.keysFetchedAt = time.Now()
+
+	resp, err := httpClient().Get(p.JwksURL)
+
+	if err != nil {
+		return fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: key set request failed with status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []Jwk `json:"keys"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("oidc: %s", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		if pub, err := k.PublicKey(); err != nil {
+			log.Debugf("oidc: %s (key %s)", err, k.Kid)
+		} else {
+			keys[k.Kid] = pub
+		}
+	}
+
+	p.keys = keys
+
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+Package oidc implements single sign-on with OpenID Connect identity providers.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package oidc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// DiscoveryPath is the well-known path of the OpenID provider configuration.
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// Timeout is the maximum duration of requests to the identity provider.
+var Timeout = 15 * time.Second
+
+func httpClient() *http.Client {
+	return &http.Client{Timeout: Timeout}
+}
@@ -0,0 +1,25 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// RandomString returns a URL-safe random string, e.g. for use as state, nonce, or PKCE code verifier.
+func RandomString() string {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		log.Errorf("oidc: %s", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Challenge returns the S256 PKCE code challenge for a verifier.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,149 @@
+package oidc
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provider represents an OpenID Connect identity provider and the client credentials used to access it.
+type Provider struct {
+	Issuer        string   `json:"issuer"`
+	AuthURL       string   `json:"authorization_endpoint"`
+	TokenURL      string   `json:"token_endpoint"`
+	UserInfoURL   string   `json:"userinfo_endpoint"`
+	JwksURL       string   `json:"jwks_uri"`
+	ClientID      string   `json:"-"`
+	ClientSecret  string   `json:"-"`
+	RedirectURL   string   `json:"-"`
+	Scopes        []string `json:"-"`
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+	mutex         sync.Mutex
+}
+
+// Token represents a token endpoint response.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	IDToken      string `json:"id_token"`
+}
+
+// NewProvider fetches the provider configuration from the issuer's discovery endpoint.
+func NewProvider(issuer, clientID, clientSecret, redirectURL string, scopes []string) (*Provider, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	if issuer == "" {
+		return nil, errors.New("oidc: issuer is empty")
+	} else if clientID == "" {
+		return nil, errors.New("oidc: client id is empty")
+	}
+
+	resp, err := httpClient().Get(issuer + DiscoveryPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery failed with status %d", resp.StatusCode)
+	}
+
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(p); err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	if strings.TrimRight(p.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc: issuer %s does not match %s", p.Issuer, issuer)
+	} else if p.AuthURL == "" || p.TokenURL == "" || p.JwksURL == "" {
+		return nil, errors.New("oidc: incomplete provider configuration")
+	}
+
+	if len(p.Scopes) == 0 {
+		p.Scopes = []string{"openid"}
+	}
+
+	return p, nil
+}
+
+// AuthCodeURL returns the URL of the provider's login page.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", p.ClientID)
+	v.Set("redirect_uri", p.RedirectURL)
+	v.Set("scope", strings.Join(p.Scopes, " "))
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", Challenge(verifier))
+	v.Set("code_challenge_method", "S256")
+
+	if strings.Contains(p.AuthURL, "?") {
+		return p.AuthURL + "&" + v.Encode()
+	}
+
+	return p.AuthURL + "?" + v.Encode()
+}
+
+// Exchange redeems an authorization code for tokens.
+func (p *Provider) Exchange(code, verifier string) (*Token, error) {
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", p.RedirectURL)
+	v.Set("code_verifier", verifier)
+
+	req, err := http.NewRequest(http.MethodPost, p.TokenURL, strings.NewReader(v.Encode()))
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	resp, err := httpClient().Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: token request failed with status %d", resp.StatusCode)
+	}
+
+	t := &Token{}
+
+	if err = json.Unmarshal(body, t); err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	} else if t.IDToken == "" {
+		return nil, errors.New("oidc: token response contains no id token")
+	}
+
+	return t, nil
+}
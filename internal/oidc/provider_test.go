@@ -0,0 +1,246 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testIssuer is a minimal identity provider for testing.
+type testIssuer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ti := &testIssuer{key: key}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 ti.URL,
+			"authorization_endpoint": ti.URL + "/authorize",
+			"token_endpoint":         ti.URL + "/token",
+			"jwks_uri":               ti.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("code") != "valid-code" || r.Form.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     ti.sign(t, ti.claims),
+		})
+	})
+
+	ti.Server = httptest.NewServer(mux)
+
+	return ti
+}
+
+func (ti *testIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, ti.key, crypto.SHA256, digest[:])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (ti *testIssuer) validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":            ti.URL,
+		"sub":            "1234",
+		"aud":            "photoprism",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "nonce",
+		"email":          "jens@example.com",
+		"email_verified": true,
+		"name":           "Jens Mander",
+		"groups":         []string{"family", "photo-admins"},
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	ti := newTestIssuer(t)
+	defer ti.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		p, err := NewProvider(ti.URL+"/", "photoprism", "secret", "http://localhost:2342/api/v1/oidc/redirect", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ti.URL, p.Issuer)
+		assert.Equal(t, ti.URL+"/token", p.TokenURL)
+		assert.Equal(t, []string{"openid"}, p.Scopes)
+	})
+	t.Run("NoClient", func(t *testing.T) {
+		_, err := NewProvider(ti.URL, "", "", "", nil)
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewProvider(ti.URL+"/missing", "photoprism", "", "", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	p := &Provider{
+		AuthURL:     "https://accounts.example.com/authorize",
+		ClientID:    "photoprism",
+		RedirectURL: "http://localhost:2342/api/v1/oidc/redirect",
+		Scopes:      []string{"openid", "email"},
+	}
+
+	u, err := url.Parse(p.AuthCodeURL("state", "nonce", "verifier"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := u.Query()
+
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "photoprism", q.Get("client_id"))
+	assert.Equal(t, "openid email", q.Get("scope"))
+	assert.Equal(t, "state", q.Get("state"))
+	assert.Equal(t, "nonce", q.Get("nonce"))
+	assert.Equal(t, Challenge("verifier"), q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	ti := newTestIssuer(t)
+	defer ti.Close()
+
+	ti.claims = ti.validClaims()
+
+	p, err := NewProvider(ti.URL, "photoprism", "secret", "http://localhost:2342/api/v1/oidc/redirect", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		token, err := p.Exchange("valid-code", "verifier")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		claims, err := p.Verify(token.IDToken, "nonce")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "1234", claims.Subject)
+		assert.Equal(t, ti.URL+"#1234", claims.ID())
+		assert.Equal(t, "jens@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, []string{"family", "photo-admins"}, claims.Values("groups"))
+		assert.Equal(t, "admin", claims.Role("groups", map[string]string{"family": "viewer", "photo-admins": "admin"}, "guest"))
+		assert.Equal(t, "guest", claims.Role("roles", map[string]string{"family": "viewer"}, "guest"))
+	})
+	t.Run("InvalidCode", func(t *testing.T) {
+		_, err := p.Exchange("invalid-code", "verifier")
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_Verify(t *testing.T) {
+	ti := newTestIssuer(t)
+	defer ti.Close()
+
+	p, err := NewProvider(ti.URL, "photoprism", "secret", "", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("InvalidNonce", func(t *testing.T) {
+		_, err := p.Verify(ti.sign(t, ti.validClaims()), "other")
+		assert.EqualError(t, err, "oidc: invalid nonce")
+	})
+	t.Run("InvalidAudience", func(t *testing.T) {
+		claims := ti.validClaims()
+		claims["aud"] = []string{"other"}
+		_, err := p.Verify(ti.sign(t, claims), "nonce")
+		assert.EqualError(t, err, "oidc: invalid audience")
+	})
+	t.Run("Expired", func(t *testing.T) {
+		claims := ti.validClaims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err := p.Verify(ti.sign(t, claims), "nonce")
+		assert.EqualError(t, err, "oidc: id token expired")
+	})
+	t.Run("WrongIssuer", func(t *testing.T) {
+		claims := ti.validClaims()
+		claims["iss"] = "https://accounts.example.com"
+		_, err := p.Verify(ti.sign(t, claims), "nonce")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSignature", func(t *testing.T) {
+		token := ti.sign(t, ti.validClaims())
+		parts := strings.Split(token, ".")
+		payload, _ := json.Marshal(map[string]interface{}{"iss": ti.URL, "sub": "admin"})
+		parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+		_, err := p.Verify(strings.Join(parts, "."), "nonce")
+		assert.EqualError(t, err, "oidc: invalid signature")
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := p.Verify("foo.bar", "nonce")
+		assert.Error(t, err)
+	})
+}
+
+func TestChallenge(t *testing.T) {
+	// Example from RFC 7636, Appendix B.
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+	assert.Len(t, RandomString(), 43)
+	assert.NotEqual(t, RandomString(), RandomString())
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/karrick/godirwalk"
 
@@ -48,6 +49,7 @@ func (c *Convert) Start(path string, force bool) (err error) {
 	}
 
 	defer mutex.MainWorker.Stop()
+	defer jobDuration.Since(time.Now(), "convert")
 
 	jobs := make(chan ConvertJob)
 
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -20,6 +21,8 @@ func DeletePhoto(p entity.Photo, mediaFiles bool, originals bool) (numFiles int,
 		return 0, err
 	}
 
+	search.SemanticRemove(p.ID)
+
 	if mediaFiles {
 		numFiles = DeleteFiles(files, originals)
 	}
@@ -85,6 +85,16 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("markers: found no missing subjects [%s]", time.Since(start))
 	}
 
+	// Add known faces for markers named in metadata.
+	start = time.Now()
+	if affected, err := query.CreateMarkerFaces(); err != nil {
+		log.Errorf("markers: %s (create faces)", err)
+	} else if affected > 0 {
+		log.Infof("markers: added %d known faces from metadata [%s]", affected, time.Since(start))
+	} else {
+		log.Debugf("markers: found no metadata markers without faces [%s]", time.Since(start))
+	}
+
 	// Resolve collisions of different subject's faces.
 	start = time.Now()
 	if c, r, err := query.ResolveFaceCollisions(); err != nil {
@@ -132,6 +142,18 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("faces: updated %s, recognized %s, %d unknown [%s]", english.Plural(int(matches.Updated), "marker", "markers"), english.Plural(int(matches.Recognized), "face", "faces"), matches.Unknown, time.Since(start))
 	}
 
+	// Cluster and match pet faces?
+	if w.conf.PetsUrl() != "" {
+		start = time.Now()
+		if pets, matches, err := w.Pets(opt); err != nil {
+			log.Errorf("faces: %s (pets)", err)
+		} else if n := len(pets); n > 0 || matches.Updated > 0 {
+			log.Infof("faces: added %s, updated %s, recognized %s [%s]", english.Plural(n, "pet cluster", "pet clusters"), english.Plural(int(matches.Updated), "marker", "markers"), english.Plural(int(matches.Recognized), "pet", "pets"), time.Since(start))
+		} else {
+			log.Debugf("faces: found no new pets [%s]", time.Since(start))
+		}
+	}
+
 	// Remove unused people.
 	start = time.Now()
 	if count, err := entity.DeleteOrphanPeople(); err != nil {
@@ -36,53 +36,58 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 	} else if samples := len(embeddings); samples < opt.SampleThreshold() {
 		log.Debugf("faces: at least %d samples needed for clustering", opt.SampleThreshold())
 		return added, nil
-	} else {
-		var c clusters.HardClusterer
+	}
 
-		// See https://dl.photoprism.app/research/ for research on face clustering algorithms.
-		if c, err = clusters.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.Workers(), clusters.EuclideanDist); err != nil {
-			return added, err
-		} else if err = c.Learn(embeddings.Float64()); err != nil {
-			return added, err
-		}
+	return w.cluster(embeddings, face.ClusterCore, face.ClusterDist, entity.NewFace)
+}
 
-		sizes := c.Sizes()
+// cluster groups similar embeddings and adds a face for each new cluster.
+func (w *Faces) cluster(embeddings face.Embeddings, core int, dist float64, newFace func(subjUID, faceSrc string, embeddings face.Embeddings) *entity.Face) (added entity.Faces, err error) {
+	var c clusters.HardClusterer
 
-		if len(sizes) > 0 {
-			log.Infof("faces: found %s", english.Plural(len(sizes), "new cluster", "new clusters"))
-		} else {
-			log.Debugf("faces: found no new clusters")
-		}
+	// See https://dl.photoprism.app/research/ for research on face clustering algorithms.
+	if c, err = clusters.DBSCAN(core, dist, w.conf.Workers(), clusters.EuclideanDist); err != nil {
+		return added, err
+	} else if err = c.Learn(embeddings.Float64()); err != nil {
+		return added, err
+	}
 
-		results := make([]face.Embeddings, len(sizes))
+	sizes := c.Sizes()
 
-		for i := range sizes {
-			results[i] = face.Embeddings{}
-		}
+	if len(sizes) > 0 {
+		log.Infof("faces: found %s", english.Plural(len(sizes), "new cluster", "new clusters"))
+	} else {
+		log.Debugf("faces: found no new clusters")
+	}
+
+	results := make([]face.Embeddings, len(sizes))
 
-		guesses := c.Guesses()
+	for i := range sizes {
+		results[i] = face.Embeddings{}
+	}
 
-		for i, n := range guesses {
-			if n < 1 {
-				continue
-			}
+	guesses := c.Guesses()
 
-			results[n-1] = append(results[n-1], embeddings[i])
+	for i, n := range guesses {
+		if n < 1 {
+			continue
 		}
 
-		for _, cluster := range results {
-			if f := entity.NewFace("", entity.SrcAuto, cluster); f == nil {
-				log.Errorf("faces: face should not be nil - possible bug")
-			} else if f.SkipMatching() {
-				log.Infof("faces: skipped cluster %s, embedding not distinct enough", f.ID)
-			} else if err := f.Create(); err == nil {
-				added = append(added, *f)
-				log.Debugf("faces: added cluster %s based on %s, radius %f", f.ID, english.Plural(f.Samples, "sample", "samples"), f.SampleRadius)
-			} else if err := f.Updates(entity.Values{"UpdatedAt": entity.TimeStamp()}); err != nil {
-				log.Errorf("faces: %s", err)
-			} else {
-				log.Debugf("faces: updated cluster %s", f.ID)
-			}
+		results[n-1] = append(results[n-1], embeddings[i])
+	}
+
+	for _, cluster := range results {
+		if f := newFace("", entity.SrcAuto, cluster); f == nil {
+			log.Errorf("faces: face should not be nil - possible bug")
+		} else if f.SkipMatching() {
+			log.Infof("faces: skipped cluster %s, embedding not distinct enough", f.ID)
+		} else if err := f.Create(); err == nil {
+			added = append(added, *f)
+			log.Debugf("faces: added cluster %s based on %s, radius %f", f.ID, english.Plural(f.Samples, "sample", "samples"), f.SampleRadius)
+		} else if err := f.Updates(entity.Values{"UpdatedAt": entity.TimeStamp()}); err != nil {
+			log.Errorf("faces: %s", err)
+		} else {
+			log.Debugf("faces: updated cluster %s", f.ID)
 		}
 	}
 
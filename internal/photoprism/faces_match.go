@@ -86,17 +86,22 @@ func (w *Faces) Match(opt FacesOptions) (result FacesMatchResult, err error) {
 
 // MatchFaces matches markers against a slice of faces.
 func (w *Faces) MatchFaces(faces entity.Faces, force bool, matchedBefore *time.Time) (result FacesMatchResult, err error) {
+	return w.matchMarkers(entity.MarkerFace, faces, force, matchedBefore)
+}
+
+// matchMarkers matches markers of the specified type against a slice of faces.
+func (w *Faces) matchMarkers(markerType string, faces entity.Faces, force bool, matchedBefore *time.Time) (result FacesMatchResult, err error) {
 	matched := 0
 	limit := 500
-	max := query.CountMarkers(entity.MarkerFace)
+	max := query.CountMarkers(markerType)
 
 	for {
 		var markers entity.Markers
 
 		if force {
-			markers, err = query.FaceMarkers(limit, matched)
+			markers, err = query.MarkersByType(markerType, limit, matched)
 		} else {
-			markers, err = query.UnmatchedFaceMarkers(limit, 0, matchedBefore)
+			markers, err = query.UnmatchedMarkers(markerType, limit, 0, matchedBefore)
 		}
 
 		if err != nil {
@@ -115,7 +120,7 @@ func (w *Faces) MatchFaces(faces entity.Faces, force bool, matchedBefore *time.T
 			}
 
 			// Skip invalid markers.
-			if marker.MarkerInvalid || marker.MarkerType != entity.MarkerFace || len(marker.EmbeddingsJSON) == 0 {
+			if marker.MarkerInvalid || marker.MarkerType != markerType || len(marker.EmbeddingsJSON) == 0 {
 				continue
 			}
 
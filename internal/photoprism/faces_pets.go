@@ -0,0 +1,68 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Pets clusters pet face embeddings and matches pet markers, so that individual pets can be named and found like people.
+func (w *Faces) Pets(opt FacesOptions) (added entity.Faces, result FacesMatchResult, err error) {
+	if w.conf.PetsUrl() == "" {
+		return added, result, fmt.Errorf("pet recognition is disabled")
+	}
+
+	// Cluster pet face embeddings that do not belong to a cluster yet.
+	if embeddings, err := query.MarkerEmbeddings(entity.MarkerPet, false, true, face.PetClusterSizeThreshold, face.PetClusterScoreThreshold); err != nil {
+		return added, result, err
+	} else if samples := len(embeddings); samples < opt.SampleThreshold() {
+		log.Debugf("faces: at least %d pet samples needed for clustering", opt.SampleThreshold())
+	} else if added, err = w.cluster(embeddings, face.PetClusterCore, face.PetClusterDist, entity.NewPetFace); err != nil {
+		return added, result, err
+	}
+
+	matchedAt := entity.TimePointer()
+
+	// Match unmatched pet markers with existing clusters.
+	if opt.Force || query.CountUnmatchedMarkers(entity.MarkerPet) > 0 {
+		faces, err := query.PetFaces(false, false)
+
+		if err != nil {
+			return added, result, err
+		}
+
+		if r, err := w.matchMarkers(entity.MarkerPet, faces, opt.Force, nil); err != nil {
+			return added, result, err
+		} else {
+			result.Add(r)
+		}
+	}
+
+	// Match new clusters with previously matched pet markers.
+	if unmatchedFaces, err := query.PetFaces(false, true); err != nil {
+		log.Error(err)
+	} else if len(unmatchedFaces) > 0 {
+		if r, err := w.matchMarkers(entity.MarkerPet, unmatchedFaces, false, matchedAt); err != nil {
+			return added, result, err
+		} else {
+			result.Add(r)
+		}
+
+		for _, m := range unmatchedFaces {
+			if err := m.Matched(); err != nil {
+				log.Warnf("faces: %s (update match timestamp)", err)
+			}
+		}
+	}
+
+	// Update remaining markers based on previous matches.
+	if m, err := query.MatchPetMarkers(); err != nil {
+		return added, result, err
+	} else {
+		result.Recognized += m
+	}
+
+	return added, result, nil
+}
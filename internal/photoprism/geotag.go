@@ -0,0 +1,153 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/track"
+)
+
+// GeotagMaxGapDefault is the default max time difference between a picture and the nearest track point.
+const GeotagMaxGapDefault = 5 * time.Minute
+
+// GeotagOptions represents options for assigning positions from track logs.
+type GeotagOptions struct {
+	Offset   time.Duration  // Camera clock offset, i.e. the camera time minus the GPS time.
+	TimeZone *time.Location // Time zone of pictures without time zone information, UTC if nil.
+	MaxGap   time.Duration  // Max time difference to the nearest track point.
+}
+
+// GeotagOptionsDefault returns the default geotag options.
+func GeotagOptionsDefault() GeotagOptions {
+	return GeotagOptions{MaxGap: GeotagMaxGapDefault}
+}
+
+// TakenAt returns the corrected UTC time when the picture was taken.
+func (o GeotagOptions) TakenAt(p entity.Photo) time.Time {
+	t := p.TakenAt
+
+	// Local time without time zone information?
+	if p.TimeZone == "" && o.TimeZone != nil {
+		l := p.TakenAtLocal
+		t = time.Date(l.Year(), l.Month(), l.Day(), l.Hour(), l.Minute(), l.Second(), l.Nanosecond(), o.TimeZone)
+	}
+
+	return t.Add(-1 * o.Offset).UTC()
+}
+
+// ImportTrack adds the points of a GPX, KML or GeoJSON track log to the index. Tracks are identified
+// by the file hash, so that files with the same name in different folders don't replace each other.
+func ImportTrack(fileName, name string) (*entity.Track, error) {
+	points, err := track.Read(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.SaveTrack(name, fs.Hash(fileName), points)
+}
+
+// Geotag represents a worker that assigns positions from track logs to pictures without location.
+type Geotag struct {
+	conf *config.Config
+}
+
+// NewGeotag returns a new Geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	instance := &Geotag{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start assigns positions from track logs to pictures taken at the same time and returns the number of updated pictures.
+func (w *Geotag) Start(opt GeotagOptions) (updated int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("geotag: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		log.Warnf("geotag: %s", err)
+		return 0, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	if opt.MaxGap <= 0 {
+		opt.MaxGap = GeotagMaxGapDefault
+	}
+
+	limit := 1000
+	offset := 0
+
+	for {
+		photos, err := query.PhotosWithoutLocation(limit, offset)
+
+		if err != nil {
+			return updated, err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		for _, p := range photos {
+			if mutex.MainWorker.Canceled() {
+				return updated, nil
+			}
+
+			pos, ok := entity.TrackPosition(opt.TakenAt(p), opt.MaxGap)
+
+			if !ok {
+				continue
+			}
+
+			lat, lng := p.PhotoLat, p.PhotoLng
+			p.SetPosition(pos, entity.SrcTrack, false)
+
+			if p.PhotoLat == lat && p.PhotoLng == lng {
+				continue
+			}
+
+			if err := p.Save(); err != nil {
+				log.Errorf("geotag: %s in %s", err, p.String())
+				continue
+			}
+
+			updated++
+
+			log.Debugf("geotag: %s is at %f, %f", p.String(), p.PhotoLat, p.PhotoLng)
+
+			if w.conf.BackupYaml() {
+				if err := p.SaveAsYaml(p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())); err != nil {
+					log.Errorf("geotag: %s in %s (update yaml)", err, clean.Log(p.PhotoName))
+				}
+			}
+		}
+
+		offset += limit
+	}
+
+	if updated > 0 {
+		log.Infof("geotag: updated the location of %s", english.Plural(updated, "picture", "pictures"))
+
+		if err := entity.UpdateCounts(); err != nil {
+			log.Warnf("geotag: %s (update counts)", err)
+		}
+	} else {
+		log.Infof("geotag: found no pictures taken along a track")
+	}
+
+	return updated, nil
+}
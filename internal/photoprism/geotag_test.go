@@ -0,0 +1,115 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestGeotagOptions_TakenAt(t *testing.T) {
+	local := time.Date(2021, 6, 1, 14, 10, 0, 0, time.UTC)
+	utc := time.Date(2021, 6, 1, 12, 10, 0, 0, time.UTC)
+
+	t.Run("TimeZone", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Berlin")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opt := GeotagOptions{TimeZone: loc}
+		photo := entity.Photo{TakenAt: local, TakenAtLocal: local}
+
+		assert.Equal(t, utc, opt.TakenAt(photo))
+	})
+	t.Run("Offset", func(t *testing.T) {
+		opt := GeotagOptions{Offset: 90 * time.Second}
+		photo := entity.Photo{TakenAt: utc.Add(90 * time.Second), TimeZone: "Europe/Berlin"}
+
+		assert.Equal(t, utc, opt.TakenAt(photo))
+	})
+}
+
+func TestImportTrack(t *testing.T) {
+	fileName := fs.Abs("../../pkg/track/testdata/track.gpx")
+
+	t.Run("Success", func(t *testing.T) {
+		m, err := ImportTrack(fileName, "testdata/track.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "testdata/track.gpx", m.TrackName)
+		assert.Less(t, 0, m.PointCount)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("SameName", func(t *testing.T) {
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dir := t.TempDir()
+		first := filepath.Join(dir, "2003", "track.gpx")
+		second := filepath.Join(dir, "2004", "track.gpx")
+		copied := filepath.Join(dir, "backup.gpx")
+
+		for name, b := range map[string][]byte{first: data, second: append(data, '\n'), copied: data} {
+			if err = os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+				t.Fatal(err)
+			} else if err = os.WriteFile(name, b, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		a, err := ImportTrack(first, filepath.Base(first))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer a.Delete()
+
+		b, err := ImportTrack(second, filepath.Base(second))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer b.Delete()
+
+		// Files with the same name in different folders are different tracks.
+		assert.NotEqual(t, a.ID, b.ID)
+
+		// The same file is only added once, even if it is imported with a different name.
+		c, err := ImportTrack(copied, "2003/track.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, a.ID, c.ID)
+	})
+}
+
+func TestGeotag_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewGeotag(conf)
+
+	if _, err := w.Start(GeotagOptionsDefault()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"runtime"
 	"runtime/debug"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/karrick/godirwalk"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -19,6 +22,7 @@ import (
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
+	"github.com/photoprism/photoprism/pkg/track"
 )
 
 // Import represents an importer that can copy/move MediaFiles to the originals directory.
@@ -80,6 +84,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	}
 
 	defer mutex.MainWorker.Stop()
+	defer jobDuration.Since(time.Now(), "import")
 
 	if err := ind.tensorFlow.Init(); err != nil {
 		log.Errorf("import: %s", err.Error())
@@ -115,6 +120,12 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		log.Infof(`import: ignored "%s"`, fs.RelName(fileName, importPath))
 	}
 
+	exclude := make(map[string]bool, len(opt.Exclude))
+
+	for _, dir := range opt.Exclude {
+		exclude[filepath.Join(importPath, dir)] = true
+	}
+
 	err := godirwalk.Walk(importPath, &godirwalk.Options{
 		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
 			return godirwalk.SkipNode
@@ -133,6 +144,10 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 			isDir, _ := info.IsDirOrSymlinkToDir()
 			isSymlink := info.IsSymlink()
 
+			if isDir && exclude[fileName] {
+				return filepath.SkipDir
+			}
+
 			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
 				if !isDir || result == filepath.SkipDir {
 					return result
@@ -153,6 +168,21 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 			done[fileName] = fs.Found
 
+			// Add track logs, which can be used to geotag pictures without location.
+			if track.Supported(fileName) {
+				relName := fs.RelName(fileName, importPath)
+
+				if t, err := ImportTrack(fileName, relName); err != nil {
+					log.Warnf("import: %s in %s (track)", err, clean.Log(relName))
+				} else {
+					log.Infof("import: added track %s with %s", clean.Log(relName), english.Plural(t.PointCount, "point", "points"))
+				}
+
+				done[fileName] = fs.Processed
+
+				return nil
+			}
+
 			if !media.MainFile(fileName) {
 				return nil
 			}
@@ -274,12 +304,18 @@ func (imp *Import) Cancel() {
 
 // DestinationFilename returns the destination filename of a MediaFile to be imported.
 func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
+	return imp.DestinationFilenameIn("", mainFile, mediaFile)
+}
+
+// DestinationFilenameIn returns the destination filename of a media file in an originals subfolder.
+func (imp *Import) DestinationFilenameIn(destFolder string, mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
 	fileName := mainFile.CanonicalName()
 	fileExtension := mediaFile.Extension()
 	dateCreated := mainFile.DateCreated()
 
 	if !mediaFile.IsSidecar() {
-		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil {
+		// Files in other user folders are ignored, so that all users can import a copy.
+		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil && (destFolder == "" || strings.HasPrefix(f.FileName, destFolder+"/")) {
 			existingFilename := FileName(f.FileRoot, f.FileName)
 			if fs.FileExists(existingFilename) {
 				return existingFilename, fmt.Errorf("%s is identical to %s (sha1 %s)", clean.Log(filepath.Base(mediaFile.FileName())), clean.Log(f.FileName), mediaFile.Hash())
@@ -290,7 +326,7 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 	}
 
 	//	Mon Jan 2 15:04:05 -0700 MST 2006
-	pathName := filepath.Join(imp.originalsPath(), dateCreated.Format("2006/01"))
+	pathName := filepath.Join(imp.originalsPath(), destFolder, dateCreated.Format("2006/01"))
 
 	iteration := 0
 
@@ -3,6 +3,8 @@ package photoprism
 type ImportOptions struct {
 	Albums                 []string
 	Path                   string
+	DestFolder             string   // Originals subfolder to import into, e.g. the folder of a user.
+	Exclude                []string // Import subfolders to skip, e.g. the folders of other users.
 	Move                   bool
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
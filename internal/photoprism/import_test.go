@@ -51,6 +51,14 @@ func TestImport_DestinationFilename(t *testing.T) {
 	}
 
 	assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
+
+	fileName, err = imp.DestinationFilenameIn("alice", rawFile, rawFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, conf.OriginalsPath()+"/alice/2019/07/20190705_153230_C167C6FD.cr2", fileName)
 }
 
 func TestImport_Start(t *testing.T) {
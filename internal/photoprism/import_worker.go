@@ -57,7 +57,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 		for _, f := range related.Files {
 			relFileName := f.RelName(impPath)
 
-			if destFileName, err := imp.DestinationFilename(related.Main, f); err == nil {
+			if destFileName, err := imp.DestinationFilenameIn(impOpt.DestFolder, related.Main, f); err == nil {
 				destDir := filepath.Dir(destFileName)
 
 				if fs.PathExists(destDir) {
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/karrick/godirwalk"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -29,11 +31,15 @@ type Index struct {
 	tensorFlow   *classify.TensorFlow
 	nsfwDetector *nsfw.Detector
 	faceNet      *face.Net
+	semantic     inference.Model
+	objects      inference.Model
+	pets         inference.Model
 	convert      *Convert
 	files        *Files
 	photos       *Photos
 	findFaces    bool
 	findLabels   bool
+	findText     bool
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
@@ -53,6 +59,19 @@ func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector
 		photos:       photos,
 		findFaces:    !conf.DisableFaces(),
 		findLabels:   !conf.DisableClassification(),
+		findText:     conf.OCREnabled(),
+	}
+
+	if url := conf.SemanticUrl(); url != "" {
+		i.semantic = inference.NewHttp(url)
+	}
+
+	if url := conf.ObjectsUrl(); url != "" {
+		i.objects = inference.NewHttp(url)
+	}
+
+	if url := conf.PetsUrl(); url != "" {
+		i.pets = inference.NewHttp(url)
 	}
 
 	return i
@@ -103,6 +122,7 @@ func (ind *Index) Start(o IndexOptions) fs.Done {
 	}
 
 	defer mutex.MainWorker.Stop()
+	defer jobDuration.Since(time.Now(), "index")
 
 	if err := ind.tensorFlow.Init(); err != nil {
 		log.Errorf("index: %s", err.Error())
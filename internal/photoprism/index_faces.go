@@ -41,6 +41,8 @@ func (ind *Index) Faces(jpeg *MediaFile, expected int) face.Faces {
 
 	faces, err := ind.faceNet.Detect(thumbName, Config().FaceSize(), true, expected)
 
+	inferenceDuration.Since(start, "faces")
+
 	if err != nil {
 		log.Debugf("%s in %s", err, clean.Log(jpeg.BaseName()))
 	}
@@ -1,10 +1,12 @@
 package photoprism
 
 import (
+	"os"
 	"sort"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
@@ -41,6 +43,8 @@ func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
 		labels = append(labels, imageLabels...)
 	}
 
+	inferenceDuration.Since(start, "labels")
+
 	// Sort by priority and uncertainty
 	sort.Sort(labels)
 
@@ -64,3 +68,43 @@ func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
 
 	return results
 }
+
+// Embedding returns the image embedding of a JPEG for semantic search, or nil if it is not enabled.
+func (ind *Index) Embedding(jpeg *MediaFile) []float32 {
+	if ind.semantic == nil {
+		return nil
+	}
+
+	start := time.Now()
+
+	result, err := inferThumb(ind.semantic, jpeg, thumb.Fit720)
+
+	inferenceDuration.Since(start, "embedding")
+
+	if err != nil {
+		log.Warnf("index: %s in %s (embedding)", err, clean.Log(jpeg.BaseName()))
+		return nil
+	} else if len(result.Embeddings) == 0 {
+		log.Warnf("index: no embedding returned for %s", clean.Log(jpeg.BaseName()))
+		return nil
+	}
+
+	return result.Embeddings[0]
+}
+
+// inferThumb runs inference with a model on a thumbnail of the JPEG.
+func inferThumb(model inference.Model, jpeg *MediaFile, size thumb.Name) (result inference.Result, err error) {
+	fileName, err := jpeg.Thumbnail(Config().ThumbCachePath(), size)
+
+	if err != nil {
+		return result, err
+	}
+
+	img, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return model.Infer(img)
+}
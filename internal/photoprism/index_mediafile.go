@@ -49,6 +49,8 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	photo := entity.NewPhoto(o.Stack)
 	metaData := meta.New()
 	labels := classify.Labels{}
+	labelPaths, labelPathSrc := []string{}, entity.SrcMeta
+	var embedding []float32
 	stripSequence := Config().Settings().StackSequences() && o.Stack
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo(stripSequence)
@@ -333,6 +335,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 				file.AddFaces(faces)
 			}
 
+			// Add named regions and people from metadata, e.g. as tagged in Lightroom or Google Photos.
+			if metaData := m.MetaData(); metaData.Error == nil {
+				file.AddRegions(metaData.Regions, entity.SrcMeta)
+				file.AddPeople(metaData.People, entity.SrcMeta)
+			}
+
 			// Any new markers?
 			if file.UnsavedMarkers() {
 				// Add matching labels.
@@ -350,9 +358,37 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		}
 	}
 
-	// Reset file perceptive diff and chroma percent.
+	// Detect objects such as pets, cars, and text?
+	if o.FacesOnly || ind.objects == nil || !file.FilePrimary {
+		// Skip.
+	} else if markers := file.Markers(); markers == nil {
+		log.Errorf("index: failed loading markers for %s", logName)
+	} else if markers.DetectedObjectCount() == 0 {
+		file.AddObjects(ind.Objects(m))
+	}
+
+	// Detect pet faces, so that individual pets can be recognized?
+	if o.FacesOnly || ind.pets == nil || !file.FilePrimary {
+		// Skip.
+	} else if markers := file.Markers(); markers == nil {
+		log.Errorf("index: failed loading markers for %s", logName)
+	} else if markers.DetectedPetCount() == 0 {
+		file.AddPets(ind.Pets(m))
+	}
+
+	// Reset file perceptive diff, chroma percent, and perceptual hash.
 	file.FileDiff = -1
 	file.FileChroma = -1
+	file.FilePHash = ""
+
+	// Perceptual hash of the primary file, RAW, or HEIC image for finding near-duplicates.
+	if !file.FilePrimary && !m.IsRaw() && !m.IsHEIF() {
+		// Skip.
+	} else if h, err := m.PerceptualHash(Config().ThumbCachePath()); err != nil {
+		log.Debugf("index: %s in %s (perceptual hash)", err, logName)
+	} else {
+		file.FilePHash = h.Hex()
+	}
 
 	// Handle file types.
 	switch {
@@ -410,10 +446,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			}
 		}
 	case m.IsXMP():
-		if metaData, err := meta.XMP(m.FileName()); err == nil {
+		if metaData, err := meta.Sidecar(m.FileName(), m.FileType()); err == nil {
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcXmp)
 			photo.SetDescription(metaData.Description, entity.SrcXmp)
+			photo.SetRating(metaData.Rating, entity.SrcXmp)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcXmp)
+			labelPaths, labelPathSrc = metaData.Hierarchy, entity.SrcXmp
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcXmp)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcXmp)
 
@@ -425,6 +464,34 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			details.SetCopyright(metaData.Copyright, entity.SrcXmp)
 			details.SetLicense(metaData.License, entity.SrcXmp)
 			details.SetSoftware(metaData.Software, entity.SrcXmp)
+
+			// Add named regions and people to the primary file.
+			if !ind.findFaces || !photoExists {
+				// Skip.
+			} else if primary, err := photo.PrimaryFile(); err != nil {
+				log.Debugf("index: %s while finding primary file for %s", err, logName)
+			} else if n := primary.AddRegions(metaData.Regions, entity.SrcXmp) + primary.AddPeople(metaData.People, entity.SrcXmp); n == 0 {
+				// Nothing to save.
+			} else if _, err := primary.SaveMarkers(); err != nil {
+				log.Errorf("index: %s while saving markers for %s", err, logName)
+			} else {
+				log.Infof("index: added %d names from %s", n, logName)
+			}
+		} else {
+			log.Warn(err.Error())
+			file.FileError = err.Error()
+		}
+	case m.IsAAE():
+		if metaData, err := meta.Sidecar(m.FileName(), m.FileType()); err == nil {
+			// Embedded metadata takes precedence over the editor app.
+			details.SetSoftware(metaData.Software, entity.SrcAae)
+
+			// Remember when the picture was edited, unless it was edited again later.
+			if editedAt := metaData.EditedAt; editedAt.IsZero() {
+				// Do nothing.
+			} else if photo.EditedAt == nil || photo.EditedAt.Before(editedAt) {
+				photo.EditedAt = &editedAt
+			}
 		} else {
 			log.Warn(err.Error())
 			file.FileError = err.Error()
@@ -434,6 +501,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
+			labelPaths, labelPathSrc = metaData.Hierarchy, entity.SrcMeta
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -503,6 +573,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		if metaData := m.MetaData(); metaData.Error == nil {
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
+			labelPaths, labelPathSrc = metaData.Hierarchy, entity.SrcMeta
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -617,11 +690,17 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			}
 		}
 
+		// Compute image embedding for semantic search?
+		embedding = ind.Embedding(m)
+
 		// Read metadata from embedded Exif and JSON sidecar file, if exists.
 		if metaData := m.MetaData(); metaData.Error == nil {
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
+			labelPaths, labelPathSrc = metaData.Hierarchy, entity.SrcMeta
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -650,6 +729,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 
 		locKeywords, locLabels = photo.UpdateLocation()
 		labels = append(labels, locLabels...)
+
+		// Recognize text in scans, screenshots, signs, and other pictures with text once metadata has been read?
+		if !ind.findText || o.FacesOnly || (details.HasText() && !fileChanged) {
+			// Skip.
+		} else if HasText(&photo, labels, file.Markers(), fileName) {
+			details.SetText(ind.Text(m), entity.SrcOCR)
+		}
 	}
 
 	if photo.UnknownLocation() {
@@ -721,6 +807,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	}
 
 	photo.AddLabels(labels)
+	photo.AddLabelPaths(labelPaths, labelPathSrc)
+
+	if len(embedding) > 0 {
+		if err := entity.NewPhotoEmbedding(photo.ID, embedding).Save(); err != nil {
+			log.Errorf("index: %s in %s (save embedding)", err, logName)
+		}
+	}
 
 	file.PhotoID = photo.ID
 	result.PhotoID = photo.ID
@@ -0,0 +1,59 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/inference"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Objects returns the objects detected in a JPEG, such as pets, cars, and text.
+func (ind *Index) Objects(jpeg *MediaFile) inference.Objects {
+	if ind.objects == nil || jpeg == nil {
+		return inference.Objects{}
+	}
+
+	start := time.Now()
+
+	result, err := inferThumb(ind.objects, jpeg, thumb.Fit720)
+
+	inferenceDuration.Since(start, "objects")
+
+	if err != nil {
+		log.Warnf("index: %s in %s (objects)", err, clean.Log(jpeg.BaseName()))
+		return inference.Objects{}
+	}
+
+	if l := len(result.Objects); l > 0 {
+		log.Infof("index: found %s in %s [%s]", english.Plural(l, "object", "objects"), clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return result.Objects
+}
+
+// Pets returns the pet faces detected in a JPEG, including embeddings for recognizing individual pets.
+func (ind *Index) Pets(jpeg *MediaFile) inference.Objects {
+	if ind.pets == nil || jpeg == nil {
+		return inference.Objects{}
+	}
+
+	start := time.Now()
+
+	result, err := inferThumb(ind.pets, jpeg, thumb.Fit720)
+
+	inferenceDuration.Since(start, "pets")
+
+	if err != nil {
+		log.Warnf("index: %s in %s (pets)", err, clean.Log(jpeg.BaseName()))
+		return inference.Objects{}
+	}
+
+	if l := len(result.Objects); l > 0 {
+		log.Infof("index: found %s in %s [%s]", english.Plural(l, "pet face", "pet faces"), clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return result.Objects
+}
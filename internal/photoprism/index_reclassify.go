@@ -0,0 +1,59 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Reclassify classifies the primary file of a photo again and replaces the labels from the image classifier,
+// so that changed label rules are applied. Labels removed by a user are not added again.
+func (ind *Index) Reclassify(photo *entity.Photo) (labels classify.Labels, err error) {
+	if !ind.findLabels {
+		return labels, errors.New("classification is disabled")
+	}
+
+	file, err := photo.PrimaryFile()
+
+	if err != nil {
+		return labels, err
+	}
+
+	fileName := FileName(file.FileRoot, file.FileName)
+
+	if !fs.FileExists(fileName) {
+		return labels, fmt.Errorf("%s not found", clean.Log(file.FileName))
+	}
+
+	m, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return labels, err
+	} else if !m.IsJpeg() {
+		return labels, fmt.Errorf("%s is not a jpeg", clean.Log(file.FileName))
+	}
+
+	labels = ind.Labels(m)
+
+	// Labels from face detection have the same source.
+	labels = append(labels, file.Markers().Labels()...)
+
+	if err = photo.RemoveLabelsBySource(classify.SrcImage); err != nil {
+		return labels, err
+	}
+
+	photo.AddLabels(labels)
+
+	// Update generated titles, which are based on labels.
+	if err = photo.UpdateTitle(photo.ClassifyLabels()); err == nil {
+		if err = photo.Save(); err != nil {
+			return labels, err
+		}
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,39 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+)
+
+func TestIndex_Reclassify(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+
+	t.Run("FileNotFound", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Pointer("Photo15")
+
+		_, err := ind.Reclassify(photo)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoPrimaryFile", func(t *testing.T) {
+		photo := entity.NewPhoto(false)
+
+		_, err := ind.Reclassify(&photo)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TextLabels contains label and category names of pictures that are likely to contain text.
+var TextLabels = map[string]bool{
+	"book":       true,
+	"document":   true,
+	"info":       true,
+	"letter":     true,
+	"receipt":    true,
+	"screenshot": true,
+	"sign":       true,
+	"text":       true,
+}
+
+// HasText tests if a picture is likely to contain text, e.g. because it is a scan, a screenshot, or shows a sign.
+func HasText(photo *entity.Photo, labels classify.Labels, markers *entity.Markers, fileName string) bool {
+	if photo != nil && photo.PhotoScan {
+		return true
+	}
+
+	if strings.Contains(strings.ToLower(fileName), "screenshot") {
+		return true
+	}
+
+	for _, l := range labels {
+		if TextLabels[strings.ToLower(l.Name)] {
+			return true
+		}
+
+		for _, c := range l.Categories {
+			if TextLabels[strings.ToLower(c)] {
+				return true
+			}
+		}
+	}
+
+	if markers == nil {
+		return false
+	}
+
+	for _, m := range *markers {
+		if m.MarkerType == entity.MarkerObject && !m.MarkerInvalid && TextLabels[strings.ToLower(m.MarkerName)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Text returns the text recognized in a JPEG with Tesseract OCR.
+func (ind *Index) Text(jpeg *MediaFile) string {
+	if !ind.findText || jpeg == nil {
+		return ""
+	}
+
+	start := time.Now()
+
+	text, err := ind.ocr(jpeg.FileName())
+
+	inferenceDuration.Since(start, "ocr")
+
+	if err != nil {
+		log.Warnf("index: %s in %s (ocr)", err, clean.Log(jpeg.BaseName()))
+		return ""
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+
+	if text != "" {
+		log.Infof("index: recognized %d characters of text in %s [%s]", len(text), clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return text
+}
+
+// ocr runs Tesseract OCR on the specified image file and returns the recognized text.
+func (ind *Index) ocr(fileName string) (string, error) {
+	cmd := exec.Command(ind.conf.OCRBin(), fileName, "stdout", "-l", ind.conf.OCRLang(), "quiet")
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	// Limit the number of threads, as files are already indexed in parallel.
+	cmd.Env = append(os.Environ(), "OMP_THREAD_LIMIT=1")
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run OCR command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return "", errors.New(strings.TrimSpace(stderr.String()))
+		} else {
+			return "", err
+		}
+	}
+
+	return out.String(), nil
+}
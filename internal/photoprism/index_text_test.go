@@ -0,0 +1,34 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestHasText(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		assert.True(t, HasText(&entity.Photo{PhotoScan: true}, nil, nil, "letter.jpg"))
+	})
+	t.Run("Screenshot", func(t *testing.T) {
+		assert.True(t, HasText(&entity.Photo{}, nil, nil, "2022/Screenshot 2022-01-01.png"))
+	})
+	t.Run("Label", func(t *testing.T) {
+		assert.True(t, HasText(&entity.Photo{}, classify.Labels{{Name: "sign"}}, nil, "IMG_1234.jpg"))
+		assert.True(t, HasText(&entity.Photo{}, classify.Labels{{Name: "menu", Categories: []string{"info"}}}, nil, "IMG_1234.jpg"))
+	})
+	t.Run("Marker", func(t *testing.T) {
+		markers := entity.Markers{{MarkerType: entity.MarkerObject, MarkerName: "Text"}}
+		assert.True(t, HasText(&entity.Photo{}, nil, &markers, "IMG_1234.jpg"))
+
+		invalid := entity.Markers{{MarkerType: entity.MarkerObject, MarkerName: "Text", MarkerInvalid: true}}
+		assert.False(t, HasText(&entity.Photo{}, nil, &invalid, "IMG_1234.jpg"))
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.False(t, HasText(&entity.Photo{}, classify.Labels{{Name: "cat", Categories: []string{"animal"}}}, nil, "IMG_1234.jpg"))
+		assert.False(t, HasText(nil, nil, nil, ""))
+	})
+}
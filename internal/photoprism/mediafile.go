@@ -798,6 +798,11 @@ func (m *MediaFile) IsXMP() bool {
 	return m.FileType() == fs.XmpFile
 }
 
+// IsAAE returns true if this is an Apple image edits sidecar file.
+func (m *MediaFile) IsAAE() bool {
+	return m.FileType() == fs.AaeFile
+}
+
 // InOriginals checks if the file is stored in the 'originals' folder.
 func (m *MediaFile) InOriginals() bool {
 	return m.Root() == entity.RootOriginals
@@ -0,0 +1,6 @@
+package photoprism
+
+import "github.com/photoprism/photoprism/pkg/metrics"
+
+var jobDuration = metrics.NewHistogram("photoprism_job_duration_seconds", "Duration of index, import and convert runs.", metrics.JobBuckets, "job")
+var inferenceDuration = metrics.NewHistogram("photoprism_inference_duration_seconds", "Duration of face detection and image classification per file.", metrics.DefaultBuckets, "model")
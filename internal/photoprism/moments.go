@@ -254,6 +254,16 @@ func (w *Moments) Start() (err error) {
 		}
 	}
 
+	// Trips away from home.
+	if err := NewTrips(w.conf).Start(); err != nil {
+		log.Errorf("moments: %s (update trips)", err.Error())
+	}
+
+	// Places visited.
+	if err := NewTimeline(w.conf).Start(); err != nil {
+		log.Errorf("moments: %s (update visits)", err.Error())
+	}
+
 	if err := query.UpdateFolderDates(); err != nil {
 		log.Errorf("moments: %s (update folder dates)", err.Error())
 	}
@@ -0,0 +1,32 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// PerceptualHash returns the perceptual hash of an image for finding near-duplicates,
+// other formats such as RAW and HEIC are hashed using their JPEG version.
+func (m *MediaFile) PerceptualHash(thumbPath string) (h phash.Hash, err error) {
+	if !m.IsImage() {
+		return h, fmt.Errorf("%s is not an image", clean.Log(m.BaseName()))
+	}
+
+	jpeg, err := m.Jpeg()
+
+	if err != nil {
+		return h, err
+	}
+
+	img, err := jpeg.Resample(thumbPath, thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, clean.Log(m.BaseName()))
+		return h, err
+	}
+
+	return phash.FromImage(img), nil
+}
@@ -0,0 +1,47 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	hash := func(t *testing.T, fileName string) phash.Hash {
+		m, err := NewMediaFile(conf.ExamplesPath() + "/" + fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := m.PerceptualHash(conf.ThumbCachePath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return h
+	}
+
+	t.Run("Copy", func(t *testing.T) {
+		assert.LessOrEqual(t, hash(t, "IMG_4120.JPG").Distance(hash(t, "IMG_4120 copy.JPG")), 2)
+	})
+	t.Run("Different", func(t *testing.T) {
+		assert.Greater(t, hash(t, "IMG_4120.JPG").Distance(hash(t, "cat_brown.jpg")), 10)
+	})
+	t.Run("NotImage", func(t *testing.T) {
+		m, err := NewMediaFile(conf.ExamplesPath() + "/blue-go-video.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = m.PerceptualHash(conf.ThumbCachePath())
+		assert.Error(t, err)
+	})
+}
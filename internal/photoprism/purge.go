@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -225,6 +226,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				log.Errorf("purge: %s (delete photo)", err)
 			} else {
 				purgedPhotos[photo.PhotoUID] = true
+				search.SemanticRemove(photo.ID)
 
 				if opt.Hard {
 					log.Infof("purge: permanently removed %s", photo.String())
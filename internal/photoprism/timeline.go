@@ -0,0 +1,79 @@
+package photoprism
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+var timelineMutex = sync.Mutex{}
+var timelineUpdated time.Time
+
+// Timeline represents a worker that updates the places visited, so that they don't need to be
+// clustered on every request.
+type Timeline struct {
+	conf *config.Config
+}
+
+// NewTimeline returns a new Timeline worker.
+func NewTimeline(conf *config.Config) *Timeline {
+	instance := &Timeline{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start updates the places visited if pictures have changed since the last run.
+func (w *Timeline) Start() (err error) {
+	timelineMutex.Lock()
+	defer timelineMutex.Unlock()
+
+	started := time.Now()
+
+	// Skip update if no pictures have changed since the last run.
+	if !timelineUpdated.IsZero() {
+		if changed, err := query.VisitPhotosChanged(timelineUpdated); err != nil {
+			return err
+		} else if !changed {
+			log.Tracef("timeline: found no changes")
+			timelineUpdated = started
+			return nil
+		}
+	}
+
+	photos, err := query.VisitPhotosAll(false)
+
+	if err != nil {
+		return err
+	}
+
+	visits, err := ClusterVisits(photos, VisitsOptionsDefault())
+
+	if err != nil {
+		return err
+	}
+
+	result := make(entity.Visits, len(visits))
+
+	for i, v := range visits {
+		result[i] = v.Entity()
+	}
+
+	added, removed, err := entity.UpdateVisits(result)
+
+	if err != nil {
+		return err
+	}
+
+	timelineUpdated = started
+
+	log.Debugf("timeline: found %s, %d added, %d removed [%s]", english.Plural(len(result), "visit", "visits"), added, removed, time.Since(started))
+
+	return nil
+}
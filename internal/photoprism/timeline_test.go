@@ -0,0 +1,46 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestTimeline_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewTimeline(conf)
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Skips the update if no pictures have changed.
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	var before, after []uint
+
+	if err := entity.UnscopedDb().Model(&entity.Visit{}).Order("id").Pluck("id", &before).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Unchanged visits are kept when all pictures are clustered again.
+	timelineUpdated = time.Time{}
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.UnscopedDb().Model(&entity.Visit{}).Order("id").Pluck("id", &after).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, after)
+	assert.Equal(t, before, after)
+}
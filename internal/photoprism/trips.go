@@ -0,0 +1,347 @@
+package photoprism
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/list"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TripsMaxGap is the max time between two pictures taken on the same trip.
+var TripsMaxGap = 72 * time.Hour
+
+// TripsMinPhotos is the min number of pictures taken on a trip.
+var TripsMinPhotos = 3
+
+var tripsMutex = sync.Mutex{}
+var tripsUpdated time.Time
+var tripsHome geo.Position
+
+// Trips represents a worker that creates trip albums based on time and location gaps.
+type Trips struct {
+	conf *config.Config
+}
+
+// NewTrips returns a new Trips worker.
+func NewTrips(conf *config.Config) *Trips {
+	instance := &Trips{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start detects trips in pictures that have changed since the last run and updates the trip albums,
+// trips that are no longer detected are removed.
+func (w *Trips) Start() (err error) {
+	tripsMutex.Lock()
+	defer tripsMutex.Unlock()
+
+	started := time.Now()
+	from := time.Time{}
+
+	// Only check pictures taken around the time of changed pictures after the first run.
+	if !tripsUpdated.IsZero() {
+		takenAt, found, err := query.TripPhotosChanged(tripsUpdated)
+
+		if err != nil {
+			return err
+		} else if !found {
+			log.Tracef("trips: found no changes")
+			tripsUpdated = started
+			return nil
+		}
+
+		from = takenAt.Add(-1 * TripsMaxGap)
+
+		if t := entity.FindTripByTime(from, takenAt); t != nil && t.StartedAt.Before(from) {
+			from = t.StartedAt
+		}
+	}
+
+	photos, err := query.TripPhotosAfter(from)
+
+	if err != nil {
+		return err
+	}
+
+	positions := photos.Positions()
+	home := w.conf.HomeLocation()
+
+	// Estimate home location if not configured.
+	if home.Lat == 0 && home.Lng == 0 {
+		if from.IsZero() || tripsHome.Lat == 0 && tripsHome.Lng == 0 {
+			tripsHome = geo.EstimateHome(positions)
+		}
+
+		home = tripsHome
+	}
+
+	if home.Lat == 0 && home.Lng == 0 {
+		log.Debugf("trips: unknown home location")
+		tripsUpdated = started
+		return nil
+	}
+
+	opt := geo.TripOptions{
+		Home:    home,
+		MinKm:   w.conf.TripDistance(),
+		MaxGap:  TripsMaxGap,
+		MinSize: TripsMinPhotos,
+	}
+
+	homeCountry := tripsHomeCountry(photos, positions, opt)
+
+	trips := geo.Trips(positions, opt)
+
+	for _, t := range trips {
+		if err := w.save(t, photos[t.Start:t.End], homeCountry); err != nil {
+			log.Errorf("trips: %s", err)
+		}
+	}
+
+	if err := w.removeStale(from, trips); err != nil {
+		log.Errorf("trips: %s", err)
+	}
+
+	tripsUpdated = started
+
+	return nil
+}
+
+// save creates or updates the album of a detected trip.
+func (w *Trips) save(t geo.Trip, photos query.TripPhotos, homeCountry string) error {
+	start, end := t.StartTime(), t.EndTime()
+
+	var trip *entity.Trip
+	var album *entity.Album
+	var merge []entity.Trip
+
+	// Find existing trips that overlap with the detected trip, the earliest one is kept.
+	for _, found := range entity.FindTripsByTime(start, end) {
+		m := found
+
+		if a := m.Album(); a == nil {
+			log.Debugf("trips: removing trip %d without album", m.ID)
+			logWarn("trips", m.Delete())
+		} else if a.Deleted() {
+			log.Tracef("trips: %s was deleted", clean.Log(a.AlbumTitle))
+			return nil
+		} else if trip == nil {
+			trip, album = &m, a
+		} else {
+			merge = append(merge, m)
+		}
+	}
+
+	// Merge the other trips, e.g. if a picture now bridges the gap between them.
+	for i := range merge {
+		log.Infof("trips: merging trip %d into %s", merge[i].ID, clean.Log(album.AlbumTitle))
+
+		if err := trip.Merge(&merge[i]); err != nil {
+			return err
+		}
+	}
+
+	title, state := tripTitle(photos, homeCountry, start)
+	slug := txt.Slug(fmt.Sprintf("%s %s", title, start.Format("2006-01-02")))
+
+	if trip == nil {
+		if album = entity.NewTripAlbum(title, slug, start); album == nil {
+			return fmt.Errorf("failed to create trip %s", clean.Log(title))
+		}
+
+		if err := album.Create(); err != nil {
+			return err
+		}
+
+		trip = entity.NewTrip(album.AlbumUID)
+
+		log.Infof("trips: added %s", clean.Log(album.AlbumTitle))
+	} else {
+		if err := album.UpdateSlug(title, slug); err != nil {
+			return err
+		}
+
+		log.Debugf("trips: updated %s", clean.Log(album.AlbumTitle))
+	}
+
+	trip.StartedAt = start.UTC()
+	trip.EndedAt = end.UTC()
+	trip.TripKm = int(t.Km())
+	trip.SetRoute(t.Route(entity.TripRouteMax))
+
+	trip.Countries = []string{}
+
+	for _, p := range photos {
+		trip.AddCountries(p.PhotoCountry)
+	}
+
+	uids := photos.UIDs()
+
+	// Remove pictures that no longer belong to the trip.
+	if err := trip.RemovePhotosExcept(uids); err != nil {
+		return err
+	}
+
+	// Add pictures that are not in the album yet, keeping pictures removed by the user hidden.
+	for _, uid := range uids {
+		entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(uid, album.AlbumUID))
+	}
+
+	if err := trip.UpdatePhotoCount(); err != nil {
+		return err
+	}
+
+	if err := trip.Save(); err != nil {
+		return err
+	}
+
+	// Update album location.
+	values := entity.Values{
+		"album_location": txt.Clip(tripCountryNames(trip.Countries), txt.ClipDefault),
+		"album_state":    state,
+		"album_year":     start.Year(),
+		"album_month":    int(start.Month()),
+		"album_day":      start.Day(),
+	}
+
+	if len(trip.Countries) > 0 {
+		values["album_country"] = trip.Countries[0]
+	}
+
+	return album.Updates(values)
+}
+
+// removeStale deletes the trips after the specified time that don't overlap with a detected trip,
+// e.g. because pictures have been deleted or their location has changed. Trips with an album
+// deleted by the user are kept, so that they are not created again.
+func (w *Trips) removeStale(from time.Time, detected []geo.Trip) error {
+	for _, found := range entity.FindTripsAfter(from) {
+		m := found
+
+		if tripsOverlap(m, detected) {
+			continue
+		}
+
+		a := m.Album()
+
+		if a != nil && a.Deleted() {
+			continue
+		}
+
+		if a != nil {
+			log.Infof("trips: removing %s", clean.Log(a.AlbumTitle))
+		} else {
+			log.Debugf("trips: removing trip %d without album", m.ID)
+		}
+
+		if err := m.DeletePermanently(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tripsOverlap tests if the trip overlaps with one of the detected trips.
+func tripsOverlap(m entity.Trip, detected []geo.Trip) bool {
+	for _, t := range detected {
+		if !t.StartTime().After(m.EndedAt) && !t.EndTime().Before(m.StartedAt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tripsHomeCountry returns the most common country of pictures taken close to home.
+func tripsHomeCountry(photos query.TripPhotos, positions []geo.Position, opt geo.TripOptions) string {
+	counts := make(map[string]int)
+	result := ""
+
+	for i, p := range positions {
+		if geo.Km(opt.Home, p) >= opt.MinKm {
+			continue
+		}
+
+		c := photos[i].PhotoCountry
+
+		if c == "" || c == entity.UnknownID {
+			continue
+		}
+
+		if counts[c]++; counts[c] > counts[result] {
+			result = c
+		}
+	}
+
+	return result
+}
+
+// tripTitle returns the trip title based on the countries visited, or the most common state for
+// domestic trips, followed by the month and year.
+func tripTitle(photos query.TripPhotos, homeCountry string, start time.Time) (title, state string) {
+	var countries []string
+	states := make(map[string]int)
+
+	for _, p := range photos {
+		if p.PhotoCountry != "" && p.PhotoCountry != entity.UnknownID && p.PhotoCountry != homeCountry {
+			countries = append(countries, p.PhotoCountry)
+		}
+
+		if s := clean.State(p.PlaceState, p.PhotoCountry); s != "" {
+			if states[s]++; states[s] > states[state] {
+				state = s
+			}
+		}
+	}
+
+	var location string
+
+	if len(countries) > 0 {
+		location = tripCountryNames(countries)
+		state = ""
+	} else if state != "" {
+		location = state
+	} else if homeCountry != "" {
+		location = maps.CountryName(homeCountry)
+	} else {
+		location = "Trip"
+	}
+
+	return fmt.Sprintf("%s / %s", location, start.Format("January 2006")), state
+}
+
+// tripCountryNames returns up to three unique country names in the order visited, e.g. "France, Italy & Spain".
+func tripCountryNames(codes []string) string {
+	var names []string
+
+	for _, code := range codes {
+		if name := maps.CountryName(code); name != "" && !list.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) > 3 {
+		names = names[:3]
+	}
+
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " & " + names[len(names)-1]
+	}
+}
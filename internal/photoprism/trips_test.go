@@ -0,0 +1,147 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestTrips_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewTrips(conf)
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs incrementally.
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrips_Save(t *testing.T) {
+	w := NewTrips(config.TestConfig())
+	start := time.Date(1990, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	photos := query.TripPhotos{
+		{PhotoUID: entity.PhotoFixtures.Get("Photo01").PhotoUID, TakenAt: start, PhotoLat: 41.9, PhotoLng: 12.49, PhotoCountry: "it"},
+		{PhotoUID: entity.PhotoFixtures.Get("Photo03").PhotoUID, TakenAt: start.Add(24 * time.Hour), PhotoLat: 43.77, PhotoLng: 11.25, PhotoCountry: "it"},
+		{PhotoUID: entity.PhotoFixtures.Get("Photo04").PhotoUID, TakenAt: start.Add(48 * time.Hour), PhotoLat: 45.44, PhotoLng: 12.32, PhotoCountry: "it"},
+	}
+
+	trip := geo.Trip{Start: 0, End: len(photos), Positions: photos.Positions()}
+
+	// Saving the same trip again must not change the photo count.
+	for i := 0; i < 2; i++ {
+		if err := w.save(trip, photos, "de"); err != nil {
+			t.Fatal(err)
+		}
+
+		found := entity.FindTripByTime(trip.StartTime(), trip.EndTime())
+
+		if found == nil {
+			t.Fatal("trip should not be nil")
+		}
+
+		assert.Equal(t, 3, found.PhotoCount)
+		assert.Equal(t, []string{"it"}, found.Countries)
+	}
+}
+
+func TestTrips_RemoveStale(t *testing.T) {
+	w := NewTrips(config.TestConfig())
+	start := time.Date(1991, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	photos := query.TripPhotos{
+		{PhotoUID: entity.PhotoFixtures.Get("Photo01").PhotoUID, TakenAt: start, PhotoLat: 48.85, PhotoLng: 2.35, PhotoCountry: "fr"},
+		{PhotoUID: entity.PhotoFixtures.Get("Photo03").PhotoUID, TakenAt: start.Add(24 * time.Hour), PhotoLat: 45.76, PhotoLng: 4.83, PhotoCountry: "fr"},
+		{PhotoUID: entity.PhotoFixtures.Get("Photo04").PhotoUID, TakenAt: start.Add(48 * time.Hour), PhotoLat: 43.30, PhotoLng: 5.37, PhotoCountry: "fr"},
+	}
+
+	trip := geo.Trip{Start: 0, End: len(photos), Positions: photos.Positions()}
+
+	if err := w.save(trip, photos, "de"); err != nil {
+		t.Fatal(err)
+	}
+
+	found := entity.FindTripByTime(trip.StartTime(), trip.EndTime())
+
+	if found == nil {
+		t.Fatal("trip should not be nil")
+	}
+
+	// Trips that are still detected, or that are before the scanned range, are kept.
+	if err := w.removeStale(start, []geo.Trip{trip}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.removeStale(start.Add(72*time.Hour), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, entity.FindTrip(found.AlbumUID))
+
+	// Trips that are no longer detected are removed with their album and pictures.
+	if err := w.removeStale(start, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, entity.FindTrip(found.AlbumUID))
+	assert.Nil(t, found.Album())
+
+	var count int
+
+	if err := entity.UnscopedDb().Model(&entity.PhotoAlbum{}).Where("album_uid = ?", found.AlbumUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+}
+
+func TestTripTitle(t *testing.T) {
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Abroad", func(t *testing.T) {
+		photos := query.TripPhotos{
+			{PhotoCountry: "fr", PlaceState: "Île-de-France"},
+			{PhotoCountry: "de", PlaceState: "Bavaria"},
+			{PhotoCountry: "it", PlaceState: "Lazio"},
+		}
+
+		title, state := tripTitle(photos, "de", start)
+
+		assert.Equal(t, "France & Italy / June 2021", title)
+		assert.Equal(t, "", state)
+	})
+	t.Run("Domestic", func(t *testing.T) {
+		photos := query.TripPhotos{
+			{PhotoCountry: "de", PlaceState: "Bavaria"},
+			{PhotoCountry: "de", PlaceState: "Bavaria"},
+			{PhotoCountry: "de", PlaceState: "Thuringia"},
+		}
+
+		title, state := tripTitle(photos, "de", start)
+
+		assert.Equal(t, "Bayern / June 2021", title)
+		assert.Equal(t, "Bayern", state)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		title, _ := tripTitle(query.TripPhotos{{PhotoCountry: "zz"}}, "", start)
+
+		assert.Equal(t, "Trip / June 2021", title)
+	})
+}
+
+func TestTripCountryNames(t *testing.T) {
+	assert.Equal(t, "", tripCountryNames(nil))
+	assert.Equal(t, "Italy", tripCountryNames([]string{"it", "it"}))
+	assert.Equal(t, "Italy, France & Spain", tripCountryNames([]string{"it", "fr", "es", "pt"}))
+}
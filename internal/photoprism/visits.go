@@ -0,0 +1,423 @@
+package photoprism
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// VisitsRadius is the max distance in km between neighboring pictures taken at the same place.
+var VisitsRadius = 0.5
+
+// VisitsCore is the min number of pictures within VisitsRadius that form the core of a place.
+var VisitsCore = 2
+
+// VisitsMaxGap is the max time between two pictures taken during the same visit.
+var VisitsMaxGap = 12 * time.Hour
+
+// VisitsOptions represents visit search options.
+type VisitsOptions struct {
+	Query   string        // Place, country, or point of interest name.
+	Radius  float64       // Max distance in km between neighboring pictures.
+	MaxGap  time.Duration // Max time between two pictures of the same visit.
+	Public  bool          // Skip private pictures.
+	Reverse bool          // Sort by arrival in descending order.
+}
+
+// VisitsOptionsDefault returns the default visit search options.
+func VisitsOptionsDefault() VisitsOptions {
+	return VisitsOptions{
+		Radius: VisitsRadius,
+		MaxGap: VisitsMaxGap,
+	}
+}
+
+// Visit represents pictures taken at the same place without a longer break.
+type Visit struct {
+	Name        string    `json:"Name"`
+	Category    string    `json:"Category"`
+	PlaceID     string    `json:"PlaceID"`
+	Label       string    `json:"Label"`
+	City        string    `json:"City"`
+	State       string    `json:"State"`
+	Country     string    `json:"Country"`
+	CountryName string    `json:"CountryName"`
+	Lat         float64   `json:"Lat"`
+	Lng         float64   `json:"Lng"`
+	Arrival     time.Time `json:"Arrival"`
+	Departure   time.Time `json:"Departure"`
+	PhotoCount  int       `json:"PhotoCount"`
+	PhotoUIDs   []string  `json:"PhotoUIDs"`
+	PhotoIDs    []uint    `json:"-"`
+}
+
+// Visits represents a list of visits.
+type Visits []Visit
+
+// Title returns the point of interest name, or the place label if unknown.
+func (v Visit) Title() string {
+	if v.Name != "" {
+		return v.Name
+	} else if v.Label != "" {
+		return v.Label
+	}
+
+	return v.CountryName
+}
+
+// Entity returns the visit as entity so that it can be saved.
+func (v Visit) Entity() entity.Visit {
+	return entity.Visit{
+		VisitName:     v.Name,
+		VisitCategory: v.Category,
+		PlaceID:       v.PlaceID,
+		PlaceLabel:    v.Label,
+		PlaceCity:     v.City,
+		PlaceState:    v.State,
+		VisitCountry:  v.Country,
+		VisitLat:      v.Lat,
+		VisitLng:      v.Lng,
+		ArrivedAt:     v.Arrival.UTC(),
+		DepartedAt:    v.Departure.UTC(),
+		PhotoIDs:      v.PhotoIDs,
+	}
+}
+
+// Matches tests if the visit name, place, or country contains the search string.
+func (v Visit) Matches(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if s == "" {
+		return true
+	} else if strings.EqualFold(v.Country, s) {
+		return true
+	}
+
+	for _, name := range []string{v.Name, v.Label, v.City, v.State, v.CountryName} {
+		if name != "" && strings.Contains(strings.ToLower(name), s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindVisits returns the places where pictures have been taken, sorted by arrival time.
+func FindVisits(opt VisitsOptions) (Visits, error) {
+	photos, err := query.VisitPhotosAll(opt.Public)
+
+	if err != nil {
+		return Visits{}, err
+	}
+
+	visits, err := ClusterVisits(photos, opt)
+
+	if err != nil {
+		return visits, err
+	}
+
+	result := make(Visits, 0, len(visits))
+
+	for _, v := range visits {
+		if v.Matches(opt.Query) {
+			result = append(result, v)
+		}
+	}
+
+	if opt.Reverse {
+		sort.SliceStable(result, func(i, j int) bool {
+			return result[i].Arrival.After(result[j].Arrival)
+		})
+	}
+
+	return result, nil
+}
+
+// ClusterVisits groups pictures by place using DBSCAN and splits each place into visits based on
+// time gaps. The pictures must be sorted by time.
+func ClusterVisits(photos query.VisitPhotos, opt VisitsOptions) (result Visits, err error) {
+	result = Visits{}
+
+	if len(photos) == 0 {
+		return result, nil
+	}
+
+	if opt.Radius <= 0 {
+		opt.Radius = VisitsRadius
+	}
+
+	if opt.MaxGap <= 0 {
+		opt.MaxGap = VisitsMaxGap
+	}
+
+	places := visitsPlaces(photos, opt.Radius, VisitsCore)
+
+	// Split places into visits.
+	for _, place := range places {
+		start := 0
+
+		for i := 1; i <= len(place); i++ {
+			if i < len(place) && photos[place[i]].TakenAt.Sub(photos[place[i-1]].TakenAt) <= opt.MaxGap {
+				continue
+			}
+
+			result = append(result, newVisit(photos, place[start:i]))
+			start = i
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Arrival.Equal(result[j].Arrival) {
+			return result[i].Departure.Before(result[j].Departure)
+		}
+
+		return result[i].Arrival.Before(result[j].Arrival)
+	})
+
+	return result, nil
+}
+
+// newVisit creates a visit from the pictures with the specified indexes.
+func newVisit(photos query.VisitPhotos, indexes []int) (v Visit) {
+	names := make(map[string]int)
+	places := make(map[string]int)
+	countries := make(map[string]int)
+
+	var name, place, country string
+
+	v.PhotoCount = len(indexes)
+	v.PhotoUIDs = make([]string, len(indexes))
+	v.PhotoIDs = make([]uint, len(indexes))
+
+	for i, n := range indexes {
+		p := photos[n]
+
+		v.PhotoUIDs[i] = p.PhotoUID
+		v.PhotoIDs[i] = p.PhotoID
+		v.Lat += float64(p.PhotoLat)
+		v.Lng += float64(p.PhotoLng)
+
+		if p.CellName != "" {
+			if names[p.CellName]++; names[p.CellName] > names[name] {
+				name = p.CellName
+				v.Category = p.CellCategory
+			}
+		}
+
+		if p.PlaceID != "" && p.PlaceID != entity.UnknownID {
+			if places[p.PlaceID]++; places[p.PlaceID] > places[place] {
+				place = p.PlaceID
+				v.Label = p.PlaceLabel
+				v.City = p.PlaceCity
+				v.State = p.PlaceState
+			}
+		}
+
+		if p.PhotoCountry != "" && p.PhotoCountry != entity.UnknownID {
+			if countries[p.PhotoCountry]++; countries[p.PhotoCountry] > countries[country] {
+				country = p.PhotoCountry
+			}
+		}
+	}
+
+	v.Name = name
+	v.PlaceID = place
+	v.Country = country
+	v.Lat = v.Lat / float64(len(indexes))
+	v.Lng = v.Lng / float64(len(indexes))
+	v.Arrival = photos[indexes[0]].TakenAt
+	v.Departure = photos[indexes[len(indexes)-1]].TakenAt
+
+	if country != "" {
+		v.CountryName = maps.CountryName(country)
+	}
+
+	return v
+}
+
+// visitsCell represents a cell of the grid used to find pictures within the radius.
+type visitsCell struct {
+	X, Y, Z int64
+}
+
+// visitsPlaces groups pictures by place using DBSCAN, pictures that don't belong to a cluster are
+// places of their own. Border pictures that are close to more than one cluster belong to the cluster
+// found first, and don't connect these clusters.
+//
+// clusters.DBSCAN is not used because it compares all pairs of pictures and keeps lists of neighbors,
+// which takes quadratic time and memory if many pictures have been taken at the same place, e.g. at home.
+// Instead, neighbors are found with a grid of cubes in Earth-centered coordinates, so that distances don't
+// depend on the longitude and there is no boundary at ±180°. All pictures in the same cube are within
+// the radius, so that pictures only need to be compared with pictures in nearby cubes.
+//
+// The indexes of each place are sorted, so that places keep the time order of the pictures.
+func visitsPlaces(photos query.VisitPhotos, radius float64, core int) (result [][]int) {
+	n := len(photos)
+
+	if n == 0 {
+		return result
+	} else if radius <= 0 {
+		radius = VisitsRadius
+	}
+
+	// The diagonal of a cube is shorter than the radius, and pictures within the radius
+	// are at most two cubes away in each direction.
+	side := radius / 2
+
+	cellOf := make([]visitsCell, n)
+	cells := make(map[visitsCell][]int)
+
+	for i, p := range photos {
+		lat := float64(p.PhotoLat) * math.Pi / 180
+		lng := float64(p.PhotoLng) * math.Pi / 180
+
+		c := visitsCell{
+			X: int64(math.Floor(geo.EarthRadiusKm * math.Cos(lat) * math.Cos(lng) / side)),
+			Y: int64(math.Floor(geo.EarthRadiusKm * math.Cos(lat) * math.Sin(lng) / side)),
+			Z: int64(math.Floor(geo.EarthRadiusKm * math.Sin(lat) / side)),
+		}
+
+		cellOf[i] = c
+		cells[c] = append(cells[c], i)
+	}
+
+	pos := func(i int) geo.Position {
+		return geo.Position{Lat: float64(photos[i].PhotoLat), Lng: float64(photos[i].PhotoLng)}
+	}
+
+	// Pictures in the same cell are checked first.
+	neighbors := func(i int, fn func(j int) bool) {
+		c, p := cellOf[i], pos(i)
+
+		for _, d := range visitsCellOffsets {
+			for _, j := range cells[visitsCell{X: c.X + d.X, Y: c.Y + d.Y, Z: c.Z + d.Z}] {
+				if geo.Km(p, pos(j)) <= radius && !fn(j) {
+					return
+				}
+			}
+		}
+	}
+
+	// Find core pictures that have enough neighbors, including themselves.
+	isCore := make([]bool, n)
+
+	for i := range photos {
+		count := 0
+
+		neighbors(i, func(j int) bool {
+			count++
+			return count < core
+		})
+
+		isCore[i] = count >= core
+	}
+
+	parent := make([]int, n)
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	root := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	// Core pictures in the same cell are neighbors, so each cell only needs to be
+	// connected once with the cells nearby that contain a core picture within the radius.
+	coreCells := make(map[visitsCell][]int)
+
+	for i := range photos {
+		if isCore[i] {
+			coreCells[cellOf[i]] = append(coreCells[cellOf[i]], i)
+		}
+	}
+
+	for c, members := range coreCells {
+		for _, m := range members[1:] {
+			parent[root(m)] = root(members[0])
+		}
+
+		for _, d := range visitsCellOffsets[1:] {
+			other, ok := coreCells[visitsCell{X: c.X + d.X, Y: c.Y + d.Y, Z: c.Z + d.Z}]
+
+			if !ok || root(other[0]) == root(members[0]) {
+				continue
+			}
+
+		connect:
+			for _, a := range members {
+				for _, b := range other {
+					if geo.Km(pos(a), pos(b)) <= radius {
+						parent[root(b)] = root(a)
+						break connect
+					}
+				}
+			}
+		}
+	}
+
+	// Pictures that aren't core pictures belong to the place of a core picture nearby, if any.
+	place := make([]int, n)
+
+	for i := range photos {
+		place[i] = -1
+
+		if isCore[i] {
+			place[i] = root(i)
+			continue
+		}
+
+		neighbors(i, func(j int) bool {
+			if isCore[j] {
+				place[i] = root(j)
+				return false
+			}
+
+			return true
+		})
+	}
+
+	groups := make(map[int]int)
+
+	for i := range photos {
+		if place[i] < 0 {
+			result = append(result, []int{i})
+		} else if g, ok := groups[place[i]]; ok {
+			result[g] = append(result[g], i)
+		} else {
+			groups[place[i]] = len(result)
+			result = append(result, []int{i})
+		}
+	}
+
+	return result
+}
+
+// visitsCellOffsets contains the offsets of the grid cells that may contain pictures within
+// the radius, starting with the cell itself.
+var visitsCellOffsets = func() (result []visitsCell) {
+	result = append(result, visitsCell{})
+
+	for x := int64(-2); x <= 2; x++ {
+		for y := int64(-2); y <= 2; y++ {
+			for z := int64(-2); z <= 2; z++ {
+				if x != 0 || y != 0 || z != 0 {
+					result = append(result, visitsCell{X: x, Y: y, Z: z})
+				}
+			}
+		}
+	}
+
+	return result
+}()
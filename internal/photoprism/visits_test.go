@@ -0,0 +1,244 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestClusterVisits(t *testing.T) {
+	day := time.Date(2021, 6, 12, 10, 0, 0, 0, time.UTC)
+
+	lisbon := func(uid string, takenAt time.Time, lat, lng float32) query.VisitPhoto {
+		return query.VisitPhoto{
+			PhotoUID:     uid,
+			TakenAt:      takenAt,
+			PhotoLat:     lat,
+			PhotoLng:     lng,
+			PhotoCountry: "pt",
+			CellName:     "Praça do Comércio",
+			CellCategory: "square",
+			PlaceID:      "pt:lisbon",
+			PlaceLabel:   "Lisbon, Portugal",
+			PlaceCity:    "Lisbon",
+			PlaceState:   "Lisbon",
+		}
+	}
+
+	photos := query.VisitPhotos{
+		lisbon("pq1", day, 38.7075, -9.1364),
+		lisbon("pq2", day.Add(30*time.Minute), 38.7078, -9.1366),
+		lisbon("pq3", day.Add(2*time.Hour), 38.7072, -9.1361),
+		{PhotoUID: "pq4", TakenAt: day.Add(26 * time.Hour), PhotoLat: 41.1496, PhotoLng: -8.6110, PhotoCountry: "pt"},
+		lisbon("pq5", day.Add(30*24*time.Hour), 38.7076, -9.1365),
+		lisbon("pq6", day.Add(30*24*time.Hour+time.Hour), 38.7077, -9.1363),
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		result, err := ClusterVisits(photos, VisitsOptionsDefault())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 3)
+
+		assert.Equal(t, "Praça do Comércio", result[0].Title())
+		assert.Equal(t, "square", result[0].Category)
+		assert.Equal(t, "Lisbon, Portugal", result[0].Label)
+		assert.Equal(t, "Portugal", result[0].CountryName)
+		assert.Equal(t, day, result[0].Arrival)
+		assert.Equal(t, day.Add(2*time.Hour), result[0].Departure)
+		assert.Equal(t, 3, result[0].PhotoCount)
+		assert.Equal(t, []string{"pq1", "pq2", "pq3"}, result[0].PhotoUIDs)
+		assert.InDelta(t, 38.7075, result[0].Lat, 0.001)
+
+		assert.Equal(t, "Portugal", result[1].Title())
+		assert.Equal(t, 1, result[1].PhotoCount)
+		assert.Equal(t, []string{"pq4"}, result[1].PhotoUIDs)
+
+		assert.Equal(t, "Praça do Comércio", result[2].Title())
+		assert.Equal(t, 2, result[2].PhotoCount)
+		assert.Equal(t, day.Add(30*24*time.Hour), result[2].Arrival)
+	})
+	t.Run("MaxGap", func(t *testing.T) {
+		opt := VisitsOptionsDefault()
+		opt.MaxGap = time.Hour
+
+		result, err := ClusterVisits(photos, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 4)
+		assert.Equal(t, 2, result[0].PhotoCount)
+		assert.Equal(t, 1, result[1].PhotoCount)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		result, err := ClusterVisits(query.VisitPhotos{}, VisitsOptionsDefault())
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 0)
+	})
+}
+
+func TestVisit_Matches(t *testing.T) {
+	v := Visit{Name: "Praça do Comércio", Label: "Lisbon, Portugal", City: "Lisbon", Country: "pt", CountryName: "Portugal"}
+
+	assert.True(t, v.Matches(""))
+	assert.True(t, v.Matches("lisbon"))
+	assert.True(t, v.Matches("Comércio"))
+	assert.True(t, v.Matches("PT"))
+	assert.True(t, v.Matches("portugal"))
+	assert.False(t, v.Matches("Porto"))
+}
+
+func TestFindVisits(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, err := FindVisits(VisitsOptionsDefault())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+
+		for i := 1; i < len(result); i++ {
+			assert.False(t, result[i].Arrival.Before(result[i-1].Arrival))
+		}
+	})
+	t.Run("Reverse", func(t *testing.T) {
+		opt := VisitsOptionsDefault()
+		opt.Reverse = true
+
+		result, err := FindVisits(opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 1; i < len(result); i++ {
+			assert.False(t, result[i].Arrival.After(result[i-1].Arrival))
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		opt := VisitsOptionsDefault()
+		opt.Query = "xxxNotExistingPlacexxx"
+
+		result, err := FindVisits(opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+}
+
+func TestVisitsPlaces(t *testing.T) {
+	photos := query.VisitPhotos{
+		{PhotoUID: "pq1", PhotoLat: 38.7075, PhotoLng: -9.1364},
+		{PhotoUID: "pq2", PhotoLat: 41.1496, PhotoLng: -8.6110},
+		{PhotoUID: "pq3", PhotoLat: 38.7100, PhotoLng: -9.1364},
+		{PhotoUID: "pq4", PhotoLat: 38.7125, PhotoLng: -9.1364},
+		{PhotoUID: "pq5", PhotoLat: 38.7075, PhotoLng: -9.1200},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		result := visitsPlaces(photos, VisitsRadius, VisitsCore)
+
+		// Neighbors of neighbors belong to the same place.
+		assert.Equal(t, [][]int{{0, 2, 3}, {1}, {4}}, result)
+	})
+	t.Run("Large", func(t *testing.T) {
+		result := visitsPlaces(photos, 10, VisitsCore)
+
+		assert.Equal(t, [][]int{{0, 2, 3, 4}, {1}}, result)
+	})
+	t.Run("Border", func(t *testing.T) {
+		result := visitsPlaces(photos, VisitsRadius, 3)
+
+		// Only the picture in the middle has enough neighbors, the others are at the border.
+		assert.Equal(t, [][]int{{0, 2, 3}, {1}, {4}}, result)
+	})
+	t.Run("Noise", func(t *testing.T) {
+		result := visitsPlaces(photos, VisitsRadius, 4)
+
+		assert.Equal(t, [][]int{{0}, {1}, {2}, {3}, {4}}, result)
+	})
+	t.Run("Grid", func(t *testing.T) {
+		var grid query.VisitPhotos
+
+		// Pictures on a line with a distance of about 0.28 km are connected across grid cells.
+		for i := 0; i < 100; i++ {
+			grid = append(grid, query.VisitPhoto{PhotoLat: 38.7 + float32(i)*0.0025, PhotoLng: -9.1364})
+		}
+
+		result := visitsPlaces(grid, VisitsRadius, VisitsCore)
+
+		if assert.Len(t, result, 1) {
+			assert.Len(t, result[0], 100)
+		}
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		// Pictures about 0.2 km apart on both sides of the 180th meridian in Fiji.
+		fiji := query.VisitPhotos{
+			{PhotoUID: "pq1", PhotoLat: -16.7, PhotoLng: 179.999},
+			{PhotoUID: "pq2", PhotoLat: -16.7, PhotoLng: -179.999},
+			{PhotoUID: "pq3", PhotoLat: -16.7, PhotoLng: 179.9},
+		}
+
+		assert.Equal(t, [][]int{{0, 1}, {2}}, visitsPlaces(fiji, VisitsRadius, VisitsCore))
+	})
+	t.Run("Longitude", func(t *testing.T) {
+		var line query.VisitPhotos
+
+		// Pictures on a meridian far from Greenwich with a distance of about 0.28 km are connected.
+		for i := 0; i < 100; i++ {
+			line = append(line, query.VisitPhoto{PhotoLat: 60 + float32(i)*0.0025, PhotoLng: 170})
+		}
+
+		result := visitsPlaces(line, VisitsRadius, VisitsCore)
+
+		if assert.Len(t, result, 1) {
+			assert.Len(t, result[0], 100)
+		}
+	})
+	t.Run("BorderBetweenCores", func(t *testing.T) {
+		// The picture in the middle is about 0.45 km from the nearest picture of two groups that are
+		// 0.9 km apart, so that it is at the border of both, and only the nearest pictures are core pictures.
+		border := query.VisitPhotos{
+			{PhotoUID: "pq1", PhotoLat: 38.6980, PhotoLng: -9.1364},
+			{PhotoUID: "pq2", PhotoLat: 38.6990, PhotoLng: -9.1364},
+			{PhotoUID: "pq3", PhotoLat: 38.7000, PhotoLng: -9.1364},
+			{PhotoUID: "pq4", PhotoLat: 38.7040, PhotoLng: -9.1364},
+			{PhotoUID: "pq5", PhotoLat: 38.7080, PhotoLng: -9.1364},
+			{PhotoUID: "pq6", PhotoLat: 38.7090, PhotoLng: -9.1364},
+			{PhotoUID: "pq7", PhotoLat: 38.7100, PhotoLng: -9.1364},
+		}
+
+		result := visitsPlaces(border, VisitsRadius, 4)
+
+		// The border picture belongs to one place only, and doesn't connect the others.
+		if assert.Len(t, result, 2) {
+			a, b := result[0], result[1]
+
+			assert.Subset(t, a, []int{0, 1, 2})
+			assert.Subset(t, b, []int{4, 5, 6})
+			assert.Equal(t, 7, len(a)+len(b))
+		}
+	})
+	t.Run("CoreOne", func(t *testing.T) {
+		result := visitsPlaces(photos, VisitsRadius, 1)
+
+		// All pictures are core pictures, so that pictures without neighbors are places of their own.
+		assert.Equal(t, [][]int{{0, 2, 3}, {1}, {4}}, result)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Len(t, visitsPlaces(query.VisitPhotos{}, VisitsRadius, VisitsCore), 0)
+	})
+}
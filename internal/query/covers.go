@@ -256,8 +256,8 @@ func UpdateSubjectCovers() (err error) {
 	markerTable := entity.Marker{}.TableName()
 
 	condition := gorm.Expr(
-		fmt.Sprintf("%s.subj_type = ? AND thumb_src = ?", subjTable),
-		entity.SubjPerson, entity.SrcAuto)
+		fmt.Sprintf("%s.subj_type IN (?, ?) AND thumb_src = ?", subjTable),
+		entity.SubjPerson, entity.SubjPet, entity.SrcAuto)
 
 	// TODO: Avoid using private photos as subject covers.
 	// See https://github.com/photoprism/photoprism/issues/2570#issuecomment-1231690056
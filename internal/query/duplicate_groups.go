@@ -0,0 +1,220 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// DuplicateDistance is the default maximum number of different perceptual hash bits of near-duplicates.
+const DuplicateDistance = 6
+
+// DuplicateFile represents the primary file of a photo in a group of near-duplicates.
+type DuplicateFile struct {
+	PhotoID      uint   `json:"-"`
+	PhotoUID     string `json:"PhotoUID"`
+	FileUID      string `json:"UID"`
+	FileRoot     string `json:"Root"`
+	FileName     string `json:"Name"`
+	FileWidth    int    `json:"Width"`
+	FileHeight   int    `json:"Height"`
+	FileSize     int64  `json:"Size"`
+	FilePHash    string `gorm:"column:file_phash" json:"PHash"`
+	QualityScore int    `gorm:"-" json:"QualityScore"`
+	Distance     int    `gorm:"-" json:"Distance"`
+	Keep         bool   `gorm:"-" json:"Keep"`
+}
+
+// Pixels returns the image resolution in pixels.
+func (m DuplicateFile) Pixels() int {
+	return m.FileWidth * m.FileHeight
+}
+
+// DuplicateGroup represents photos whose primary files are near-duplicates,
+// the file of the suggested keeper comes first.
+type DuplicateGroup struct {
+	Keep  string          `json:"Keep"`
+	Files []DuplicateFile `json:"Files"`
+}
+
+// DuplicateGroups represents a list of near-duplicate groups.
+type DuplicateGroups []DuplicateGroup
+
+// duplicateHash represents the perceptual hash of a photo.
+type duplicateHash struct {
+	PhotoID   uint
+	FilePHash string `gorm:"column:file_phash"`
+}
+
+// NearDuplicates finds groups of photos whose primary files are perceptually identical or nearly identical,
+// e.g. resized exports or re-saved copies. The photo with the best quality score is suggested as keeper.
+// Only photos in the originals subfolder or shared albums are compared if a scope is specified. The total
+// number of groups is returned along with the requested page, so that file details are only loaded for it.
+func NearDuplicates(maxDist, count, offset int, scope string, shared []string) (result DuplicateGroups, total int, err error) {
+	var hashes []duplicateHash
+
+	// Find hashes, the primary file is preferred if other files of the same photo have one too.
+	stmt := UnscopedDb().Table("files").
+		Select("files.photo_id, files.file_phash").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_missing = 0 AND files.file_phash <> '' AND files.deleted_at IS NULL").
+		Where("files.file_primary = 1 OR files.file_type IN (?)", []string{fs.RawImage.String(), fs.ImageHEIF.String()})
+
+	if scope != "" {
+		where, values := search.ScopeCondition(scope, shared)
+		stmt = stmt.Where(where, values...)
+	}
+
+	if err = stmt.Order("files.photo_id, files.file_primary DESC").Scan(&hashes).Error; err != nil {
+		return result, 0, err
+	}
+
+	parsed := make([]phash.Hash, 0, len(hashes))
+	photoIds := make([]uint, 0, len(hashes))
+
+	for _, f := range hashes {
+		if n := len(photoIds); n > 0 && photoIds[n-1] == f.PhotoID {
+			continue
+		} else if h, err := phash.Parse(f.FilePHash); err == nil {
+			parsed = append(parsed, h)
+			photoIds = append(photoIds, f.PhotoID)
+		}
+	}
+
+	groups := phash.Groups(parsed, maxDist)
+	total = len(groups)
+
+	// Groups are ordered by their lowest photo id, so that pages are stable.
+	for _, g := range groups {
+		sort.Ints(g)
+	}
+
+	sort.Slice(groups, func(a, b int) bool {
+		return groups[a][0] < groups[b][0]
+	})
+
+	if offset > len(groups) {
+		offset = len(groups)
+	}
+
+	groups = groups[offset:]
+
+	if count > 0 && count < len(groups) {
+		groups = groups[:count]
+	}
+
+	if len(groups) == 0 {
+		return result, total, nil
+	}
+
+	// Load files and photos of the current page to compute quality scores.
+	var pageIds []uint
+
+	for _, g := range groups {
+		for _, i := range g {
+			pageIds = append(pageIds, photoIds[i])
+		}
+	}
+
+	files := make(map[uint]DuplicateFile, len(pageIds))
+	scores := make(map[uint]int, len(pageIds))
+
+	for _, ids := range chunkUints(pageIds, 1000) {
+		var found []DuplicateFile
+
+		if err = UnscopedDb().Table("files").
+			Select("files.photo_id, photos.photo_uid, files.file_uid, files.file_root, files.file_name, files.file_width, files.file_height, files.file_size, files.file_phash").
+			Joins("JOIN photos ON photos.id = files.photo_id").
+			Where("files.photo_id IN (?) AND files.file_missing = 0 AND files.file_phash <> '' AND files.deleted_at IS NULL", ids).
+			Where("files.file_primary = 1 OR files.file_type IN (?)", []string{fs.RawImage.String(), fs.ImageHEIF.String()}).
+			Order("files.photo_id, files.file_primary DESC").
+			Scan(&found).Error; err != nil {
+			return result, total, err
+		}
+
+		for _, f := range found {
+			if _, ok := files[f.PhotoID]; !ok {
+				files[f.PhotoID] = f
+			}
+		}
+
+		var photos entity.Photos
+
+		if err = UnscopedDb().Preload("Details").Where("id IN (?)", ids).Find(&photos).Error; err != nil {
+			return result, total, err
+		}
+
+		for i := range photos {
+			scores[photos[i].ID] = photos[i].QualityScore()
+		}
+	}
+
+	for _, g := range groups {
+		group := DuplicateGroup{Files: make([]DuplicateFile, 0, len(g))}
+
+		for _, i := range g {
+			if f, ok := files[photoIds[i]]; ok {
+				f.QualityScore = scores[f.PhotoID]
+				group.Files = append(group.Files, f)
+			}
+		}
+
+		if len(group.Files) < 2 {
+			continue
+		}
+
+		// Best quality first, then the highest resolution and file size.
+		sort.SliceStable(group.Files, func(a, b int) bool {
+			fa, fb := group.Files[a], group.Files[b]
+
+			if fa.QualityScore != fb.QualityScore {
+				return fa.QualityScore > fb.QualityScore
+			} else if fa.Pixels() != fb.Pixels() {
+				return fa.Pixels() > fb.Pixels()
+			}
+
+			return fa.FileSize > fb.FileSize
+		})
+
+		keeper, _ := phash.Parse(group.Files[0].FilePHash)
+
+		for j := range group.Files {
+			h, _ := phash.Parse(group.Files[j].FilePHash)
+			group.Files[j].Distance = keeper.Distance(h)
+		}
+
+		group.Files[0].Keep = true
+		group.Keep = group.Files[0].PhotoUID
+
+		result = append(result, group)
+	}
+
+	return result, total, nil
+}
+
+// chunkUints splits a list of ids into chunks of the specified size.
+func chunkUints(ids []uint, size int) (result [][]uint) {
+	for len(ids) > size {
+		result = append(result, ids[:size])
+		ids = ids[size:]
+	}
+
+	if len(ids) > 0 {
+		result = append(result, ids)
+	}
+
+	return result
+}
+
+// FilesWithoutPerceptualHash returns indexed primary, RAW, and HEIC files without perceptual hash, ordered by id.
+func FilesWithoutPerceptualHash(limit int, afterId uint) (result entity.Files, err error) {
+	err = UnscopedDb().
+		Where("file_phash = '' AND file_missing = 0 AND deleted_at IS NULL AND id > ?", afterId).
+		Where("file_primary = 1 OR file_type IN (?)", []string{fs.RawImage.String(), fs.ImageHEIF.String()}).
+		Order("id").Limit(limit).Find(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestNearDuplicates(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		groups, total, err := NearDuplicates(DuplicateDistance, 0, 0, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, groups)
+		assert.Equal(t, 0, total)
+	})
+	t.Run("Found", func(t *testing.T) {
+		hashes := map[string]string{
+			"bridge.jpg":  "e8d1d3ae4d10356f",
+			"bridge1.jpg": "e8d1d3ae4d10357e",
+			"reunion.jpg": "1a2b3c4d5e6f7081",
+		}
+
+		for name, h := range hashes {
+			f := entity.FileFixtures.Get(name)
+
+			if err := f.Update("FilePHash", h); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		defer func() {
+			for name := range hashes {
+				f := entity.FileFixtures.Get(name)
+				_ = f.Update("FilePHash", "")
+			}
+		}()
+
+		groups, total, err := NearDuplicates(DuplicateDistance, 10, 0, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, total)
+
+		if !assert.Len(t, groups, 1) {
+			return
+		}
+
+		g := groups[0]
+
+		assert.Len(t, g.Files, 2)
+		assert.True(t, g.Files[0].Keep)
+		assert.False(t, g.Files[1].Keep)
+		assert.Equal(t, g.Keep, g.Files[0].PhotoUID)
+		assert.Equal(t, 0, g.Files[0].Distance)
+		assert.Equal(t, 2, g.Files[1].Distance)
+		assert.GreaterOrEqual(t, g.Files[0].QualityScore, g.Files[1].QualityScore)
+
+		uids := []string{g.Files[0].FileUID, g.Files[1].FileUID}
+
+		assert.Contains(t, uids, entity.FileFixtures.Get("bridge.jpg").FileUID)
+		assert.Contains(t, uids, entity.FileFixtures.Get("bridge1.jpg").FileUID)
+
+		// Next page.
+		groups, total, err = NearDuplicates(DuplicateDistance, 10, 1, "", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Empty(t, groups)
+
+		// Lower distance.
+		groups, _, err = NearDuplicates(1, 0, 0, "", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, groups)
+
+		// Out of scope.
+		groups, total, err = NearDuplicates(DuplicateDistance, 0, 0, "2790", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, groups)
+	})
+}
+
+func TestFilesWithoutPerceptualHash(t *testing.T) {
+	files, err := FilesWithoutPerceptualHash(5, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, len(files), 5)
+
+	for _, f := range files {
+		assert.True(t, f.FilePrimary || f.FileType == "raw" || f.FileType == "heif")
+		assert.Empty(t, f.FilePHash)
+	}
+}
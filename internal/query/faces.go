@@ -34,9 +34,9 @@ func FacesByID(knownOnly, unmatchedOnly, inclHidden bool) (FaceMap, IDs, error)
 	return faceMap, faceIds, nil
 }
 
-// Faces returns all (known / unmatched) faces from the index.
+// Faces returns all (known / unmatched) faces of people from the index.
 func Faces(knownOnly, unmatchedOnly, inclHidden bool) (result entity.Faces, err error) {
-	stmt := Db()
+	stmt := Db().Where("face_kind <> ?", int(face.PetFace))
 
 	if knownOnly {
 		stmt = stmt.Where("subj_uid <> ''")
@@ -55,18 +55,66 @@ func Faces(knownOnly, unmatchedOnly, inclHidden bool) (result entity.Faces, err
 	return result, err
 }
 
-// ManuallyAddedFaces returns all manually added face clusters.
+// PetFaces returns all (known / unmatched) pet faces from the index.
+func PetFaces(knownOnly, unmatchedOnly bool) (result entity.Faces, err error) {
+	stmt := Db().Where("face_kind = ?", int(face.PetFace))
+
+	if knownOnly {
+		stmt = stmt.Where("subj_uid <> ''")
+	}
+
+	if unmatchedOnly {
+		stmt = stmt.Where("matched_at IS NULL")
+	}
+
+	err = stmt.Order("subj_uid, samples DESC").Find(&result).Error
+
+	return result, err
+}
+
+// ManuallyAddedFaces returns all manually added face clusters, including faces tagged in other applications.
 func ManuallyAddedFaces(hidden bool, kind face.Kind) (result entity.Faces, err error) {
 	err = Db().
 		Where("face_hidden = ?", hidden).
 		Where("face_kind <= ?", int(kind)).
-		Where("face_src = ?", entity.SrcManual).
+		Where("face_src IN (?)", []string{entity.SrcManual, entity.SrcXmp, entity.SrcMeta}).
 		Where("subj_uid <> ''").Order("subj_uid, samples DESC").
 		Find(&result).Error
 
 	return result, err
 }
 
+// CreateMarkerFaces adds known faces for markers that were named in metadata, e.g. in Lightroom,
+// so that they can be used as samples for face recognition just like manually named markers.
+func CreateMarkerFaces() (affected int64, err error) {
+	var markers entity.Markers
+
+	if err = Db().
+		Where("subj_uid <> '' AND face_id = '' AND subj_src IN (?)", []string{entity.SrcXmp, entity.SrcMeta}).
+		Where("marker_invalid = 0 AND marker_type IN (?)", []string{entity.MarkerFace, entity.MarkerPet}).
+		Where("embeddings_json <> ''").
+		Order("subj_uid").
+		Find(&markers).Error; err != nil {
+		return affected, err
+	} else if len(markers) == 0 {
+		return affected, nil
+	}
+
+	for _, m := range markers {
+		if err = m.SyncSubject(false); err != nil {
+			log.Errorf("faces: %s (create marker face)", err)
+		} else if m.FaceID == "" {
+			continue
+		} else if err = m.Updates(entity.Values{"FaceID": m.FaceID, "FaceDist": m.FaceDist}); err != nil {
+			return affected, err
+		} else {
+			affected++
+		}
+	}
+
+	return affected, nil
+}
+
 // MatchFaceMarkers matches markers with known faces.
 func MatchFaceMarkers() (affected int64, err error) {
 	faces, err := Faces(true, false, false)
@@ -75,6 +123,22 @@ func MatchFaceMarkers() (affected int64, err error) {
 		return affected, err
 	}
 
+	return matchMarkers(faces)
+}
+
+// MatchPetMarkers matches pet markers with known pet faces.
+func MatchPetMarkers() (affected int64, err error) {
+	faces, err := PetFaces(true, false)
+
+	if err != nil {
+		return affected, err
+	}
+
+	return matchMarkers(faces)
+}
+
+// matchMarkers assigns the subjects of known faces to matching markers.
+func matchMarkers(faces entity.Faces) (affected int64, err error) {
 	for _, f := range faces {
 		if res := Db().Model(&entity.Marker{}).
 			Where("marker_invalid = 0").
@@ -287,8 +351,8 @@ func RemovePeopleAndFaces() (err error) {
 		return err
 	}
 
-	// Delete all faces.
-	if err = UnscopedDb().Delete(entity.Face{}).Error; err != nil {
+	// Delete all faces of people.
+	if err = UnscopedDb().Delete(entity.Face{}, "face_kind <> ?", int(face.PetFace)).Error; err != nil {
 		return err
 	}
 
@@ -74,6 +74,13 @@ func TestManuallyAddedFaces(t *testing.T) {
 	})
 }
 
+func TestCreateMarkerFaces(t *testing.T) {
+	affected, err := CreateMarkerFaces()
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, int64(0), affected)
+}
+
 func TestMatchFaceMarkers(t *testing.T) {
 	const faceFixtureId = "mt9k3pw1wowuy444"
 
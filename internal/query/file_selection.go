@@ -8,6 +8,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/media"
 )
 
@@ -126,6 +127,12 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Subjects, f.Labels, f.Labels).
 		Group("files.id")
 
+	// Limit selection to an originals subfolder?
+	if f.Scope != "" {
+		scope, values := search.ScopeCondition(f.Scope, f.Shared)
+		s = s.Where(scope, values...)
+	}
+
 	// File size limit?
 	if o.MaxSize > 0 {
 		s = s.Where("files.file_size < ?", o.MaxSize)
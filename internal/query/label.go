@@ -2,6 +2,7 @@ package query
 
 import (
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
 )
 
 // PhotoLabel returns a photo label entity if exists.
@@ -70,3 +71,44 @@ func LabelThumbByUID(labelUID string) (file entity.File, err error) {
 
 	return file, err
 }
+
+// PhotosByLabelSource returns photos with labels from the specified source, ordered by id.
+func PhotosByLabelSource(source string, limit int, afterId uint) (result entity.Photos, err error) {
+	err = Db().
+		Where("id > ? AND id IN (SELECT photo_id FROM photos_labels WHERE label_src = ? AND uncertainty < 100)", afterId, source).
+		Order("id").Limit(limit).Find(&result).Error
+
+	return result, err
+}
+
+// LabelTree returns all nested labels and their parents as tree.
+func LabelTree() (result entity.LabelTree, err error) {
+	var labels entity.Labels
+
+	if err = Db().Where("parent_id > 0 OR id IN (SELECT parent_id FROM labels WHERE parent_id > 0 AND deleted_at IS NULL)").
+		Order("label_name").Find(&labels).Error; err != nil {
+		return result, err
+	}
+
+	return entity.NewLabelTree(labels), nil
+}
+
+// LabelChildren returns the visible labels nested below a label, limited to labels of pictures
+// in the originals folder and shared albums if a scope is specified.
+func LabelChildren(labelID uint, scope string, shared []string) (result entity.Labels, err error) {
+	if labelID == 0 {
+		return result, nil
+	}
+
+	stmt := Db().Where("parent_id = ?", labelID).
+		Where("label_priority >= 0 OR label_favorite = 1")
+
+	if scope != "" {
+		where, values := search.LabelScopeCondition(scope, shared)
+		stmt = stmt.Where(where, values...)
+	}
+
+	err = stmt.Order("label_name").Find(&result).Error
+
+	return result, err
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestLabelBySlug(t *testing.T) {
@@ -97,3 +99,77 @@ func TestPhotoLabel(t *testing.T) {
 		t.Log(r)
 	})
 }
+
+func TestPhotosByLabelSource(t *testing.T) {
+	t.Run("Image", func(t *testing.T) {
+		photos, err := PhotosByLabelSource(entity.SrcImage, 3, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+		assert.LessOrEqual(t, len(photos), 3)
+
+		next, err := PhotosByLabelSource(entity.SrcImage, 3, photos[len(photos)-1].ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range next {
+			assert.Greater(t, p.ID, photos[len(photos)-1].ID)
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		photos, err := PhotosByLabelSource("xyz", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+}
+
+func TestLabelChildren(t *testing.T) {
+	child := entity.FirstOrCreateLabelPath("Query Parent|Query Child", 0)
+	hidden := entity.FirstOrCreateLabelPath("Query Parent|Query Hidden", -1)
+
+	if child == nil || hidden == nil {
+		t.Fatal("labels should not be nil")
+	}
+
+	t.Run("All", func(t *testing.T) {
+		result, err := LabelChildren(child.ParentID, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "Query Child", result[0].LabelName)
+	})
+	t.Run("Scope", func(t *testing.T) {
+		result, err := LabelChildren(child.ParentID, "2010", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		if err := child.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := LabelChildren(child.ParentID, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
@@ -48,8 +48,13 @@ func Markers(limit, offset int, markerType string, embeddings, subjects bool, ma
 
 // UnmatchedFaceMarkers finds all currently unmatched face markers.
 func UnmatchedFaceMarkers(limit, offset int, matchedBefore *time.Time) (result entity.Markers, err error) {
+	return UnmatchedMarkers(entity.MarkerFace, limit, offset, matchedBefore)
+}
+
+// UnmatchedMarkers finds all currently unmatched markers of the specified type.
+func UnmatchedMarkers(markerType string, limit, offset int, matchedBefore *time.Time) (result entity.Markers, err error) {
 	db := Db().
-		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_type = ?", markerType).
 		Where("marker_invalid = 0").
 		Where("embeddings_json <> ''")
 
@@ -68,8 +73,13 @@ func UnmatchedFaceMarkers(limit, offset int, matchedBefore *time.Time) (result e
 
 // FaceMarkers returns all face markers sorted by id.
 func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
+	return MarkersByType(entity.MarkerFace, limit, offset)
+}
+
+// MarkersByType returns all markers of the specified type sorted by id.
+func MarkersByType(markerType string, limit, offset int) (result entity.Markers, err error) {
 	err = Db().
-		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_type = ?", markerType).
 		Order("marker_uid").Limit(limit).Offset(offset).
 		Find(&result).Error
 
@@ -78,11 +88,16 @@ func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 
 // Embeddings returns existing face embeddings.
 func Embeddings(single, unclustered bool, size, score int) (result face.Embeddings, err error) {
+	return MarkerEmbeddings(entity.MarkerFace, single, unclustered, size, score)
+}
+
+// MarkerEmbeddings returns existing embeddings of markers with the specified type.
+func MarkerEmbeddings(markerType string, single, unclustered bool, size, score int) (result face.Embeddings, err error) {
 	var col []string
 
 	stmt := Db().
 		Model(&entity.Marker{}).
-		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_type = ?", markerType).
 		Where("marker_invalid = 0").
 		Where("embeddings_json <> ''").
 		Order("marker_uid")
@@ -137,7 +152,7 @@ func RemoveNonExistentMarkerFaces() (removed int64, err error) {
 
 	res := Db().
 		Model(&entity.Marker{}).
-		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_type IN (?)", []string{entity.MarkerFace, entity.MarkerPet}).
 		Where(fmt.Sprintf("face_id <> '' AND face_id NOT IN (SELECT id FROM %s)", entity.Face{}.TableName())).
 		UpdateColumns(entity.Values{"face_id": "", "face_dist": -1.0, "matched_at": nil})
 
@@ -222,9 +237,14 @@ func ResetFaceMarkerMatches() (removed int64, err error) {
 
 // CountUnmatchedFaceMarkers counts the number of unmatched face markers in the index.
 func CountUnmatchedFaceMarkers() (n int) {
+	return CountUnmatchedMarkers(entity.MarkerFace)
+}
+
+// CountUnmatchedMarkers counts the number of unmatched markers of the specified type in the index.
+func CountUnmatchedMarkers(markerType string) (n int) {
 	q := Db().Model(&entity.Markers{}).
 		Where("matched_at IS NULL AND marker_invalid = 0 AND embeddings_json <> ''").
-		Where("marker_type = ?", entity.MarkerFace)
+		Where("marker_type = ?", markerType)
 
 	if err := q.Count(&n).Error; err != nil {
 		log.Errorf("faces: %s (count unmatched markers)", err)
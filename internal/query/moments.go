@@ -7,6 +7,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -178,6 +179,12 @@ type Moments []Moment
 
 // MomentsTime counts photos by month and year.
 func MomentsTime(threshold int, public bool) (results Moments, err error) {
+	return ScopedMomentsTime(threshold, public, "", nil)
+}
+
+// ScopedMomentsTime counts photos by month and year that are located in the originals subfolder
+// or in the albums shared with the user, an empty scope counts all photos.
+func ScopedMomentsTime(threshold int, public bool, scope string, shared []string) (results Moments, err error) {
 	db := UnscopedDb().Table("photos").
 		Select("photos.photo_year AS year, photos.photo_month AS month, COUNT(*) AS photo_count").
 		Where("photos.photo_quality >= 3 AND deleted_at IS NULL AND photos.photo_year > 0 AND photos.photo_month > 0")
@@ -187,6 +194,12 @@ func MomentsTime(threshold int, public bool) (results Moments, err error) {
 		db = db.Where("photo_private = 0")
 	}
 
+	// Limit results to the user's originals folder and shared albums?
+	if scope != "" {
+		where, values := search.ScopeCondition(scope, shared)
+		db = db.Where(where, values...)
+	}
+
 	db = db.Group("photos.photo_year, photos.photo_month").
 		Order("photos.photo_year DESC, photos.photo_month DESC").
 		Having("photo_count >= ?", threshold)
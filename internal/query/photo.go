@@ -50,6 +50,17 @@ func PhotoByUID(photoUID string) (photo entity.Photo, err error) {
 	return photo, nil
 }
 
+// PhotoIDs returns the ids of the photos with the specified UIDs, including archived photos.
+func PhotoIDs(photoUIDs []string) (ids []uint, err error) {
+	if len(photoUIDs) == 0 {
+		return ids, nil
+	}
+
+	err = UnscopedDb().Model(&entity.Photo{}).Where("photo_uid IN (?)", photoUIDs).Pluck("id", &ids).Error
+
+	return ids, err
+}
+
 // PhotoPreloadByUID returns a Photo based on the UID with all dependencies preloaded.
 func PhotoPreloadByUID(photoUID string) (photo entity.Photo, err error) {
 	if err := UnscopedDb().Where("photo_uid = ?", photoUID).
@@ -104,6 +115,22 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (ent
 	return entities, err
 }
 
+// PhotosWithoutLocation returns photos with a reliable capture time but no coordinates from metadata,
+// e.g. to assign positions from track logs. Estimated positions and positions from tracks are included.
+func PhotosWithoutLocation(limit, offset int) (entities entity.Photos, err error) {
+	err = Db().
+		Preload("Details").
+		Preload("Place").
+		Preload("Cell").
+		Preload("Cell.Place").
+		Where("place_src IN (?)", []string{entity.SrcAuto, entity.SrcEstimate, entity.SrcTrack}).
+		Where("(photo_lat = 0 AND photo_lng = 0) OR place_src <> ?", entity.SrcAuto).
+		Where("taken_src IN (?)", []string{entity.SrcMeta, entity.SrcXmp, entity.SrcManual}).
+		Order("photos.ID ASC").Limit(limit).Offset(offset).Find(&entities).Error
+
+	return entities, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
@@ -6,6 +6,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
 )
 
 // SelectedPhotos finds photos based on the given selection form, e.g. for adding them to an album.
@@ -48,6 +49,12 @@ func SelectedPhotos(f form.Selection) (results entity.Photos, err error) {
 		Select("photos.*").
 		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Subjects, f.Labels, f.Labels)
 
+	// Limit selection to an originals subfolder?
+	if f.Scope != "" {
+		scope, values := search.ScopeCondition(f.Scope, f.Shared)
+		s = s.Where(scope, values...)
+	}
+
 	if result := s.Scan(&results); result.Error != nil {
 		return results, result.Error
 	}
@@ -25,6 +25,21 @@ func TestPhotoByID(t *testing.T) {
 	})
 }
 
+func TestPhotoIDs(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		ids, err := PhotoIDs([]string{"pt9jtdre2lvl0y12", "pt9jtdre2lvl0y13", "99999"})
+
+		assert.NoError(t, err)
+		assert.Len(t, ids, 2)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		ids, err := PhotoIDs(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
+
 func TestPhotoByUID(t *testing.T) {
 	t.Run("photo found", func(t *testing.T) {
 		result, err := PhotoByUID("pt9jtdre2lvl0y12")
@@ -78,6 +93,21 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosWithoutLocation(t *testing.T) {
+	result, err := PhotosWithoutLocation(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.IsType(t, entity.Photos{}, result)
+
+	for _, p := range result {
+		assert.Contains(t, []string{entity.SrcAuto, entity.SrcEstimate, entity.SrcTrack}, p.PlaceSrc)
+		assert.True(t, p.PlaceSrc != entity.SrcAuto || !p.HasLatLng())
+	}
+}
+
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
 
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// PhotosInScope checks if all pictures with the specified UIDs are located in the originals subfolder
+// or in an album shared with the user.
+func PhotosInScope(uids []string, scope string, shared []string) bool {
+	found := make(map[string]bool, len(uids))
+	unique := make([]string, 0, len(uids))
+
+	for _, uid := range uids {
+		if uid != "" && !found[uid] {
+			found[uid] = true
+			unique = append(unique, uid)
+		}
+	}
+
+	if len(unique) == 0 {
+		return true
+	} else if scope = strings.Trim(scope, "/"); scope == "" {
+		return false
+	}
+
+	where, values := search.ScopeCondition(scope, shared)
+
+	var count int
+
+	if err := UnscopedDb().Table("photos").
+		Where("photos.photo_uid IN (?)", unique).
+		Where(where, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("scope: %s", err)
+		return false
+	}
+
+	return count == len(unique)
+}
+
+// AlbumInScope checks if the album is shared with the user, created by the user, is a folder in the
+// originals subfolder, or contains pictures in scope.
+func AlbumInScope(uid string, scope string, shared []string, user string) bool {
+	if uid == "" {
+		return false
+	} else if scope = strings.Trim(scope, "/"); scope == "" {
+		return false
+	}
+
+	where, values := search.AlbumScopeCondition(scope, shared, user)
+
+	return albumExists(uid, where, values)
+}
+
+// AlbumOwnedBy checks if the album was created by the user or is a folder in the originals subfolder,
+// so that the user may change it.
+func AlbumOwnedBy(uid string, scope string, user string) bool {
+	if uid == "" {
+		return false
+	} else if scope = strings.Trim(scope, "/"); scope == "" {
+		return false
+	}
+
+	where, values := search.AlbumOwnerCondition(scope, user)
+
+	return albumExists(uid, where, values)
+}
+
+// albumExists checks if an album with the specified UID matches the where condition.
+func albumExists(uid string, where string, values []interface{}) bool {
+	var count int
+
+	if err := UnscopedDb().Table("albums").
+		Where("albums.album_uid = ?", uid).
+		Where(where, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("scope: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// LabelInScope checks if the label is assigned to pictures in scope.
+func LabelInScope(uid string, scope string, shared []string) bool {
+	if uid == "" {
+		return false
+	} else if scope = strings.Trim(scope, "/"); scope == "" {
+		return false
+	}
+
+	where, values := search.LabelScopeCondition(scope, shared)
+
+	var count int
+
+	if err := UnscopedDb().Table("labels").
+		Where("labels.label_uid = ?", uid).
+		Where(where, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("scope: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// SubjectInScope checks if the subject is marked in pictures in scope.
+func SubjectInScope(uid string, scope string, shared []string) bool {
+	if uid == "" {
+		return false
+	} else if scope = strings.Trim(scope, "/"); scope == "" {
+		return false
+	}
+
+	where, values := search.SubjectScopeCondition(scope, shared)
+
+	var count int
+
+	if err := UnscopedDb().Table("subjects").
+		Where("subjects.subj_uid = ?", uid).
+		Where(where, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("scope: %s", err)
+		return false
+	}
+
+	return count > 0
+}
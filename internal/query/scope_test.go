@@ -0,0 +1,61 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestPhotosInScope(t *testing.T) {
+	assert.True(t, PhotosInScope(nil, "2016", nil))
+	assert.True(t, PhotosInScope([]string{"pt9jtdre2lvl0y13"}, "2016", nil))
+	assert.True(t, PhotosInScope([]string{"pt9jtdre2lvl0y13", "pt9jtdre2lvl0y13"}, "2016", nil))
+	assert.False(t, PhotosInScope([]string{"pt9jtdre2lvl0yh7"}, "2016", nil))
+	assert.False(t, PhotosInScope([]string{"pt9jtdre2lvl0y13", "pt9jtdre2lvl0yh7"}, "2016", nil))
+	assert.False(t, PhotosInScope([]string{"pt9jtdre2lvl0y13"}, "201", nil))
+	assert.False(t, PhotosInScope([]string{"pt9jtdre2lvl0y13"}, "", nil))
+	assert.True(t, PhotosInScope([]string{"pt9jtdre2lvl0yh7"}, "2016", []string{"at9lxuqxpogaaba8"}))
+}
+
+func TestAlbumInScope(t *testing.T) {
+	assert.False(t, AlbumInScope("", "2016", nil, ""))
+	assert.False(t, AlbumInScope("at9lxuqxpogaaba8", "", nil, ""))
+	assert.False(t, AlbumInScope("at9lxuqxpogaaba8", "2016", nil, ""))
+	assert.True(t, AlbumInScope("at9lxuqxpogaaba8", "2016", []string{"at9lxuqxpogaaba8"}, ""))
+	assert.True(t, AlbumInScope("at9lxuqxpogaaba8", "2790", nil, ""))
+
+	t.Run("CreatedBy", func(t *testing.T) {
+		album := entity.NewAlbum("Confined Scope", entity.AlbumDefault)
+		album.CreatedBy = "uqxetse3cy5eo9z9"
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer UnscopedDb().Delete(album)
+
+		assert.True(t, AlbumInScope(album.AlbumUID, "2016", nil, "uqxetse3cy5eo9z9"))
+		assert.False(t, AlbumInScope(album.AlbumUID, "2016", nil, "uqxetse3cy5eo9z2"))
+		assert.False(t, AlbumInScope(album.AlbumUID, "2016", nil, ""))
+	})
+}
+
+func TestAlbumOwnedBy(t *testing.T) {
+	assert.False(t, AlbumOwnedBy("", "2016", "uqxetse3cy5eo9z9"))
+	assert.False(t, AlbumOwnedBy("at9lxuqxpogaaba8", "", "uqxetse3cy5eo9z9"))
+	assert.False(t, AlbumOwnedBy("at9lxuqxpogaaba8", "2790", "uqxetse3cy5eo9z9"))
+
+	album := entity.NewAlbum("Confined Owner", entity.AlbumDefault)
+	album.CreatedBy = "uqxetse3cy5eo9z9"
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(album)
+
+	assert.True(t, AlbumOwnedBy(album.AlbumUID, "2016", "uqxetse3cy5eo9z9"))
+	assert.False(t, AlbumOwnedBy(album.AlbumUID, "2016", "uqxetse3cy5eo9z2"))
+}
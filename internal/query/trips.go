@@ -0,0 +1,76 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// TripPhoto represents the time and location of a photo for trip detection.
+type TripPhoto struct {
+	PhotoUID     string    `json:"PhotoUID"`
+	TakenAt      time.Time `json:"TakenAt"`
+	PhotoLat     float32   `json:"Lat"`
+	PhotoLng     float32   `json:"Lng"`
+	PhotoCountry string    `json:"Country"`
+	PlaceState   string    `json:"State"`
+}
+
+// TripPhotos represents a list of photos sorted by time.
+type TripPhotos []TripPhoto
+
+// Positions returns the photo positions.
+func (m TripPhotos) Positions() []geo.Position {
+	result := make([]geo.Position, len(m))
+
+	for i, p := range m {
+		result[i] = geo.Position{Time: p.TakenAt, Lat: float64(p.PhotoLat), Lng: float64(p.PhotoLng)}
+	}
+
+	return result
+}
+
+// UIDs returns the photo UIDs.
+func (m TripPhotos) UIDs() []string {
+	result := make([]string, len(m))
+
+	for i, p := range m {
+		result[i] = p.PhotoUID
+	}
+
+	return result
+}
+
+// TripPhotosAfter returns photos with a known location taken after the specified time, sorted by time.
+func TripPhotosAfter(after time.Time) (results TripPhotos, err error) {
+	err = UnscopedDb().Table("photos").
+		Select("photos.photo_uid, photos.taken_at, photos.photo_lat, photos.photo_lng, photos.photo_country, places.place_state").
+		Joins("LEFT JOIN places ON places.id = photos.place_id").
+		Where("photos.deleted_at IS NULL AND photos.photo_quality >= 0 AND photos.taken_src <> ? AND photos.taken_at >= ?", entity.SrcAuto, after.UTC()).
+		Where("(photos.photo_lat <> 0 OR photos.photo_lng <> 0) AND photos.place_src <> ?", entity.SrcEstimate).
+		Order("photos.taken_at, photos.id").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// TripPhotosChanged returns the earliest time a photo with a known location that was updated
+// after the specified time was taken, or false if no such photo exists.
+func TripPhotosChanged(since time.Time) (takenAt time.Time, found bool, err error) {
+	var results TripPhotos
+
+	err = UnscopedDb().Table("photos").
+		Select("photos.taken_at").
+		Where("photos.updated_at >= ? AND photos.taken_src <> ?", since.UTC(), entity.SrcAuto).
+		Where("(photos.photo_lat <> 0 OR photos.photo_lng <> 0)").
+		Order("photos.taken_at").
+		Limit(1).
+		Scan(&results).Error
+
+	if err != nil || len(results) == 0 {
+		return takenAt, false, err
+	}
+
+	return results[0].TakenAt, true, nil
+}
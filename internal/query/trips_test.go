@@ -0,0 +1,51 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTripPhotosAfter(t *testing.T) {
+	results, err := TripPhotosAfter(time.Time{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, len(results))
+
+	for i, p := range results {
+		assert.False(t, p.PhotoLat == 0 && p.PhotoLng == 0)
+
+		if i > 0 {
+			assert.False(t, p.TakenAt.Before(results[i-1].TakenAt))
+		}
+	}
+
+	assert.Len(t, results.Positions(), len(results))
+	assert.Len(t, results.UIDs(), len(results))
+}
+
+func TestTripPhotosChanged(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		takenAt, found, err := TripPhotosChanged(time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, found)
+		assert.False(t, takenAt.IsZero())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, found, err := TripPhotosChanged(time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, found)
+	})
+}
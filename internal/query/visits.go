@@ -0,0 +1,70 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// VisitPhoto represents the time, location and place names of a photo for visit detection.
+type VisitPhoto struct {
+	PhotoID      uint      `json:"-"`
+	PhotoUID     string    `json:"PhotoUID"`
+	TakenAt      time.Time `json:"TakenAt"`
+	PhotoLat     float32   `json:"Lat"`
+	PhotoLng     float32   `json:"Lng"`
+	PhotoCountry string    `json:"Country"`
+	CellID       string    `json:"CellID"`
+	CellName     string    `json:"CellName"`
+	CellCategory string    `json:"CellCategory"`
+	PlaceID      string    `json:"PlaceID"`
+	PlaceLabel   string    `json:"PlaceLabel"`
+	PlaceCity    string    `json:"PlaceCity"`
+	PlaceState   string    `json:"PlaceState"`
+}
+
+// VisitPhotos represents a list of photos sorted by time.
+type VisitPhotos []VisitPhoto
+
+// Coordinates returns the latitude and longitude of each photo.
+func (m VisitPhotos) Coordinates() [][]float64 {
+	result := make([][]float64, len(m))
+
+	for i, p := range m {
+		result[i] = []float64{float64(p.PhotoLat), float64(p.PhotoLng)}
+	}
+
+	return result
+}
+
+// VisitPhotosAll returns all photos with a known location, sorted by time.
+// Private photos are skipped if public is true.
+func VisitPhotosAll(public bool) (results VisitPhotos, err error) {
+	stmt := UnscopedDb().Table("photos").
+		Select("photos.id AS photo_id, photos.photo_uid, photos.taken_at, photos.photo_lat, photos.photo_lng, photos.photo_country, "+
+			"photos.cell_id, cells.cell_name, cells.cell_category, photos.place_id, "+
+			"places.place_label, places.place_city, places.place_state").
+		Joins("LEFT JOIN cells ON cells.id = photos.cell_id").
+		Joins("LEFT JOIN places ON places.id = photos.place_id").
+		Where("photos.deleted_at IS NULL AND photos.photo_quality >= 0 AND photos.taken_src <> ?", entity.SrcAuto).
+		Where("(photos.photo_lat <> 0 OR photos.photo_lng <> 0) AND photos.place_src <> ?", entity.SrcEstimate)
+
+	if public {
+		stmt = stmt.Where("photos.photo_private = 0")
+	}
+
+	err = stmt.Order("photos.taken_at, photos.id").Scan(&results).Error
+
+	return results, err
+}
+
+// VisitPhotosChanged tests if photos have been added, updated, or deleted after the specified time.
+func VisitPhotosChanged(since time.Time) (changed bool, err error) {
+	var count int
+
+	err = UnscopedDb().Table("photos").
+		Where("photos.updated_at >= ? OR photos.deleted_at >= ?", since.UTC(), since.UTC()).
+		Count(&count).Error
+
+	return count > 0, err
+}
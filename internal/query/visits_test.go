@@ -0,0 +1,67 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisitPhotosAll(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		results, err := VisitPhotosAll(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(results))
+
+		for i, p := range results {
+			assert.NotEqual(t, uint(0), p.PhotoID)
+			assert.False(t, p.PhotoLat == 0 && p.PhotoLng == 0)
+
+			if i > 0 {
+				assert.False(t, p.TakenAt.Before(results[i-1].TakenAt))
+			}
+		}
+
+		assert.Len(t, results.Coordinates(), len(results))
+	})
+	t.Run("Public", func(t *testing.T) {
+		all, err := VisitPhotosAll(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		public, err := VisitPhotosAll(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(public), len(all))
+	})
+}
+
+func TestVisitPhotosChanged(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		changed, err := VisitPhotosChanged(time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, changed)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		changed, err := VisitPhotosChanged(time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, changed)
+	})
+}
@@ -28,6 +28,12 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 		s = s.Where("albums.album_type <> 'folder' OR albums.album_path IN (SELECT photo_path FROM photos WHERE photo_quality > -1 AND deleted_at IS NULL)")
 	}
 
+	// Limit results to the user's originals folder and shared albums?
+	if f.Scope != "" {
+		where, values := AlbumScopeCondition(f.Scope, f.Shared, f.User)
+		s = s.Where(where, values...)
+	}
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
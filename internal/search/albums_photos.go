@@ -7,11 +7,19 @@ import (
 
 // AlbumPhotos returns up to count photos from an album.
 func AlbumPhotos(a entity.Album, count int, shared bool) (results PhotoResults, err error) {
+	return ScopedAlbumPhotos(a, count, shared, "", nil)
+}
+
+// ScopedAlbumPhotos returns up to count photos from an album that are located in the originals
+// subfolder or in the albums shared with the user, an empty scope returns all album photos.
+func ScopedAlbumPhotos(a entity.Album, count int, shared bool, scope string, sharedUIDs []string) (results PhotoResults, err error) {
 	frm := form.SearchPhotos{
 		Album:  a.AlbumUID,
 		Filter: a.AlbumFilter,
 		Count:  count,
 		Offset: 0,
+		Scope:  scope,
+		Shared: sharedUIDs,
 	}
 
 	if shared {
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// AuditLogs returns audit log entries, most recent first.
+func AuditLogs(f form.SearchAudit) (result entity.AuditLogs, err error) {
+	if err = f.ParseQueryString(); err != nil {
+		return result, err
+	}
+
+	s := Db().Model(&entity.AuditLog{})
+
+	if f.UID != "" {
+		s = s.Where("resource_uid = ?", f.UID)
+	}
+
+	if f.User != "" {
+		s = s.Where("actor_uid = ? OR actor_name = ?", f.User, f.User)
+	}
+
+	if f.Action != "" {
+		s = s.Where("action = ?", f.Action)
+	}
+
+	if f.Resource != "" {
+		s = s.Where("resource = ?", f.Resource)
+	}
+
+	if f.Outcome != "" {
+		s = s.Where("outcome = ?", f.Outcome)
+	}
+
+	if q := strings.TrimSpace(f.Query); len(q) >= 3 {
+		s = s.Where("message LIKE ? OR client_ip = ?", "%"+q+"%", q)
+	}
+
+	s = s.Order("created_at DESC, id DESC")
+
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if err = s.Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
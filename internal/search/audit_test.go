@@ -0,0 +1,62 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestAuditLogs(t *testing.T) {
+	entity.NewAuditLog(entity.AuditLogin, "sessions", "", entity.AuditFailure).SetClient("", "10.1.2.3").SetMessage("invalid password").Save()
+	entity.NewAuditLog("delete", "photos", "pt9jtdre2lvl0y11", entity.AuditSuccess).SetActor(entity.UserFixtures.Pointer("alice")).Save()
+
+	t.Run("All", func(t *testing.T) {
+		result, err := AuditLogs(form.SearchAudit{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(result), 2)
+	})
+	t.Run("User", func(t *testing.T) {
+		result, err := AuditLogs(form.SearchAudit{User: "alice", Action: "delete", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(result), 1)
+
+		for _, r := range result {
+			assert.Equal(t, "alice", r.ActorName)
+			assert.Equal(t, "delete", r.Action)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		result, err := AuditLogs(form.SearchAudit{Query: "outcome:failure 10.1.2.3", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(result), 1)
+		assert.Equal(t, entity.AuditLogin, result[0].Action)
+	})
+	t.Run("UID", func(t *testing.T) {
+		result, err := AuditLogs(form.SearchAudit{UID: "pt9jtdre2lvl0y11", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(result), 1)
+	})
+	t.Run("InvalidQuery", func(t *testing.T) {
+		_, err := AuditLogs(form.SearchAudit{Query: "foo:bar"})
+		assert.Error(t, err)
+	})
+}
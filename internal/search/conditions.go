@@ -2,6 +2,7 @@ package search
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -127,6 +128,23 @@ func LikeAllWords(col, s string) (wheres []string) {
 	return LikeAll(col, s, false, false)
 }
 
+// LikeAllText returns a list of where conditions matching text that contains all search words.
+func LikeAllText(col, s string) (wheres []string) {
+	if s == "" {
+		return wheres
+	}
+
+	s = strings.NewReplacer(txt.Or, txt.Space, txt.And, txt.Space).Replace(clean.SearchQuery(s))
+
+	for _, w := range txt.UniqueWords(strings.Fields(s)) {
+		if w = Like(w); w != "" {
+			wheres = append(wheres, fmt.Sprintf("%s LIKE '%%%s%%'", col, w))
+		}
+	}
+
+	return wheres
+}
+
 // LikeAllNames returns a list of where conditions matching all names.
 func LikeAllNames(cols Cols, s string) (wheres []string) {
 	if len(cols) == 0 || len(s) < 1 {
@@ -234,6 +252,47 @@ func AnyInt(col, numbers, sep string, min, max int) (where string) {
 	return strings.Join(wheres, " OR ")
 }
 
+// CompareOperators lists the supported integer comparison operators, longest first.
+var CompareOperators = []string{">=", "<=", ">", "<", "="}
+
+// CompareInt returns a where condition that compares an integer column, e.g. with ">=3",
+// or matches any of the numbers separated by |, e.g. "0|5".
+func CompareInt(col, s string, min, max int) (where string) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return ""
+	}
+
+	for _, op := range CompareOperators {
+		if !strings.HasPrefix(s, op) {
+			continue
+		}
+
+		i, err := strconv.Atoi(strings.TrimSpace(s[len(op):]))
+
+		if err != nil {
+			return ""
+		}
+
+		return fmt.Sprintf("%s %s %d", col, op, i)
+	}
+
+	var wheres []string
+
+	for _, n := range strings.Split(s, txt.Or) {
+		i, err := strconv.Atoi(strings.TrimSpace(n))
+
+		if err != nil || i < min || i > max {
+			continue
+		}
+
+		wheres = append(wheres, fmt.Sprintf("%s = %d", col, i))
+	}
+
+	return strings.Join(wheres, " OR ")
+}
+
 // OrLike returns a where condition and values for finding multiple terms combined with OR.
 func OrLike(col, s string) (where string, values []interface{}) {
 	if txt.Empty(col) || txt.Empty(s) {
@@ -197,6 +197,21 @@ func TestLikeAllWords(t *testing.T) {
 	})
 }
 
+func TestLikeAllText(t *testing.T) {
+	t.Run("Words", func(t *testing.T) {
+		if w := LikeAllText("d.text", "Invoice|Total 12.50"); len(w) == 3 {
+			assert.Equal(t, "d.text LIKE '%12.50%'", w[0])
+			assert.Equal(t, "d.text LIKE '%invoice%'", w[1])
+			assert.Equal(t, "d.text LIKE '%total%'", w[2])
+		} else {
+			t.Fatalf("unexpected result:  %#v", w)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, LikeAllText("d.text", ""))
+	})
+}
+
 func TestLikeAllNames(t *testing.T) {
 	t.Run("MultipleNames", func(t *testing.T) {
 		if w := LikeAllNames(Cols{"k.name"}, "j Mander 王"); len(w) == 1 {
@@ -325,6 +340,26 @@ func TestAnyInt(t *testing.T) {
 	})
 }
 
+func TestCompareInt(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", CompareInt("photos.photo_rating", "", -1, 5))
+	})
+	t.Run("Operators", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating >= 3", CompareInt("photos.photo_rating", ">=3", -1, 5))
+		assert.Equal(t, "photos.photo_rating > 3", CompareInt("photos.photo_rating", "> 3", -1, 5))
+		assert.Equal(t, "photos.photo_rating <= 0", CompareInt("photos.photo_rating", "<=0", -1, 5))
+		assert.Equal(t, "photos.photo_rating < -1", CompareInt("photos.photo_rating", "<-1", -1, 5))
+		assert.Equal(t, "photos.photo_rating = 2", CompareInt("photos.photo_rating", "=2", -1, 5))
+	})
+	t.Run("Any", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating = 0 OR photos.photo_rating = 5", CompareInt("photos.photo_rating", "0|5|9", -1, 5))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", CompareInt("photos.photo_rating", ">=x", -1, 5))
+		assert.Equal(t, "", CompareInt("photos.photo_rating", "a|b", -1, 5))
+	})
+}
+
 func TestOrLike(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		where, values := OrLike("k.keyword", "")
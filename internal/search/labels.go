@@ -25,6 +25,12 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		Where("labels.photo_count > 0").
 		Group("labels.id")
 
+	// Limit results to labels of pictures in the user's originals folder and shared albums?
+	if f.Scope != "" {
+		where, values := LabelScopeCondition(f.Scope, f.Shared)
+		s = s.Where(where, values...)
+	}
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/meta"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -84,6 +85,22 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 		return PhotoResults{}, 0, fmt.Errorf("invalid sort order")
 	}
 
+	// Find photos similar to a natural language description, most similar first?
+	if f.Semantic != "" {
+		matches, err := SemanticMatches(f.Semantic)
+
+		if err != nil {
+			return PhotoResults{}, 0, err
+		} else if len(matches) == 0 {
+			log.Debugf("search: found no photos similar to %s", txt.LogParamLower(f.Semantic))
+			return PhotoResults{}, 0, nil
+		}
+
+		s = s.Where("photos.id IN (?)", matches.IDs()).
+			Order(gorm.Expr(semanticOrder(matches)), true).
+			Order("files.media_id")
+	}
+
 	// Limit the result file types if hidden images/videos should not be found.
 	if !f.Hidden {
 		s = s.Where("files.file_type IN (?) OR files.file_video = 1", FileTypes)
@@ -100,12 +117,18 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 		s = s.Where("files.file_primary = 1")
 	}
 
+	// Limit results to the user's originals folder and shared albums?
+	if f.Scope != "" {
+		where, values := ScopeCondition(f.Scope, f.Shared)
+		s = s.Where(where, values...)
+	}
+
 	// Find only certain unique IDs?
 	if txt.NotEmpty(f.UID) {
 		s = s.Where("photos.photo_uid IN (?)", SplitOr(strings.ToLower(f.UID)))
 
 		// Take shortcut?
-		if f.Album == "" && f.Query == "" {
+		if f.Album == "" && f.Query == "" && f.Semantic == "" {
 			s = s.Order("files.media_id")
 
 			if result := s.Scan(&results); result.Error != nil {
@@ -135,6 +158,11 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 			for _, l := range labels {
 				labelIds = append(labelIds, l.ID)
 
+				// Include nested labels?
+				if f.Nested {
+					labelIds = append(labelIds, l.DescendantIDs()...)
+				}
+
 				Log("find categories", Db().Where("category_id = ?", l.ID).Find(&categories).Error)
 				log.Debugf("search: label %s includes %d categories", txt.LogParamLower(l.LabelName), len(categories))
 
@@ -218,11 +246,30 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 			s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 		}
 	} else if f.Query != "" {
+		// Pictures with recognized text, e.g. from OCR, that contains all search words are found as well.
+		var textWhere string
+
+		if wheres := LikeAllText("d.text", f.Query); len(wheres) > 0 {
+			textWhere = fmt.Sprintf("files.photo_id IN (SELECT d.photo_id FROM %s d WHERE %s)", entity.Details{}.TableName(), strings.Join(wheres, " AND "))
+		}
+
 		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
 
+			var keywordWheres []string
+
 			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
-				s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+				keywordWheres = append(keywordWheres, fmt.Sprintf("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (%s))", where))
+			}
+
+			if textWhere == "" {
+				for _, where := range keywordWheres {
+					s = s.Where(where)
+				}
+			} else if len(keywordWheres) > 0 {
+				s = s.Where(fmt.Sprintf("(%s) OR %s", strings.Join(keywordWheres, " AND "), textWhere))
+			} else {
+				s = s.Where(textWhere)
 			}
 		} else {
 			for _, l := range labels {
@@ -284,6 +331,25 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	}
 
+	// Filter for detected objects? Example: object:"dog|cat"
+	if txt.NotEmpty(f.Object) {
+		for _, obj := range SplitAnd(f.Object) {
+			if where, values := OrLike("m.marker_name", obj); where != "" {
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = '%s' WHERE %s)",
+					entity.Marker{}.TableName(), entity.MarkerObject, where), values...)
+			}
+		}
+	}
+
+	// Search for text recognized in pictures, e.g. with OCR? Example: text:"invoice|receipt"
+	if txt.NotEmpty(f.Text) {
+		for _, t := range SplitAnd(f.Text) {
+			if where, values := OrLike("d.text", "*"+strings.Join(SplitOr(t), "*|*")+"*"); where != "" {
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT d.photo_id FROM %s d WHERE %s)", entity.Details{}.TableName(), where), values...)
+			}
+		}
+	}
+
 	// Filter for one or more subjects?
 	if txt.NotEmpty(f.Subject) {
 		for _, subj := range SplitAnd(strings.ToLower(f.Subject)) {
@@ -366,6 +432,16 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 		s = s.Where("photos.photo_favorite = 1")
 	}
 
+	// Filter by star rating?
+	if where := CompareInt("photos.photo_rating", f.Rating, meta.RatingRejected, meta.RatingMax); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by color label?
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", SplitOr(strings.ToLower(f.ColorLabel)))
+	}
+
 	// Find scans only?
 	if f.Scan {
 		s = s.Where("photos.photo_scan = 1")
@@ -3,6 +3,7 @@ package search
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,6 +45,50 @@ func TestPhotosFilterLabel(t *testing.T) {
 		}
 		assert.Equal(t, len(photos), 2)
 	})
+	t.Run("nested", func(t *testing.T) {
+		food := entity.FirstOrCreateLabelPath("Nested Food", 0)
+		cake := entity.FindLabel("cake")
+
+		if food == nil || cake == nil {
+			t.Fatal("labels should not be nil")
+		}
+
+		if err := cake.SetParent(food); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			_ = cake.SetParent(nil)
+		}()
+
+		var f form.SearchPhotos
+
+		f.Query = "label:nested-food"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+
+		f.Nested = true
+
+		photos, _, err = Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, len(photos))
+	})
 	t.Run("cake pipe flower", func(t *testing.T) {
 		var f form.SearchPhotos
 
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/inference"
+)
+
+func TestPhotosFilterObject(t *testing.T) {
+	file := entity.FileFixtures.Get("bridge.jpg")
+	marker := entity.NewObjectMarker(inference.Object{Name: "dog", Score: 0.9, X: 0.1, Y: 0.2, W: 0.3, H: 0.4}, file)
+
+	if marker == nil {
+		t.Fatal("marker must not be nil")
+	} else if err := marker.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = entity.UnscopedDb().Delete(marker).Error
+	}()
+
+	t.Run("Dog", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "object:dog"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, file.PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("DogOrCar", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Object = "car|dog"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("DogAndCar", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Object = "dog&car"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Geo", func(t *testing.T) {
+		var f form.SearchPhotosGeo
+
+		f.Object = "dog"
+
+		photos, err := PhotosGeo(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(photos), 1)
+	})
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotosQueryRating(t *testing.T) {
+	m := entity.PhotoFixtures.Get("Photo13")
+	m.SetRating(4, entity.SrcManual)
+	m.SetColorLabel("purple", entity.SrcManual)
+
+	if err := m.Updates(entity.Values{"PhotoRating": m.PhotoRating, "RatingSrc": m.RatingSrc,
+		"PhotoColorLabel": m.PhotoColorLabel, "ColorLabelSrc": m.ColorLabelSrc}); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = m.Updates(entity.Values{"PhotoRating": 0, "RatingSrc": "", "PhotoColorLabel": "", "ColorLabelSrc": ""})
+	}()
+
+	t.Run("GreaterOrEqual", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "rating:>=4"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, m.PhotoUID, photos[0].PhotoUID)
+			assert.Equal(t, 4, photos[0].PhotoRating)
+		}
+	})
+	t.Run("Greater", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "rating:>4"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Any", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "rating:4|5"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("ColorLabel", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "colorlabel:Purple|red"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "purple", photos[0].PhotoColorLabel)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterScope(t *testing.T) {
+	t.Run("2016", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Scope = "2016"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 5)
+
+		for _, p := range photos {
+			assert.True(t, strings.HasPrefix(p.PhotoPath, "2016/"))
+		}
+	})
+	t.Run("Partial", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Scope = "201"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Shared", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Scope = "nonexistent"
+		f.Shared = []string{"at9lxuqxpogaaba8"}
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var album form.SearchPhotos
+
+		album.Album = "at9lxuqxpogaaba8"
+		album.Merged = true
+
+		albumPhotos, _, err := Photos(album)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+		assert.Equal(t, len(albumPhotos), len(photos))
+	})
+	t.Run("QueryString", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "-:2016"
+
+		assert.Error(t, f.ParseQueryString())
+		assert.Equal(t, "", f.Scope)
+	})
+}
+
+func TestPhotosGeoFilterScope(t *testing.T) {
+	var f form.SearchPhotosGeo
+
+	f.Scope = "nonexistent"
+
+	photos, err := PhotosGeo(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, photos, 0)
+}
+
+func TestAlbumsFilterScope(t *testing.T) {
+	t.Run("OutOfScope", func(t *testing.T) {
+		f := form.SearchAlbums{Count: 1000, Scope: "2016"}
+
+		results, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, a := range results {
+			assert.NotEqual(t, "at9lxuqxpogaaba8", a.AlbumUID)
+		}
+	})
+	t.Run("Shared", func(t *testing.T) {
+		f := form.SearchAlbums{Count: 1000, Scope: "2016", Shared: []string{"at9lxuqxpogaaba8"}}
+
+		results, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, a := range results {
+			if a.AlbumUID == "at9lxuqxpogaaba8" {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+}
+
+func TestLabelsFilterScope(t *testing.T) {
+	all, err := Labels(form.SearchLabels{Count: 1000, All: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scoped, err := Labels(form.SearchLabels{Count: 1000, All: true, Scope: "nonexistent"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, all)
+	assert.Empty(t, scoped)
+}
+
+func TestSubjectsFilterScope(t *testing.T) {
+	scoped, err := Subjects(form.SearchSubjects{Count: 1000, Scope: "nonexistent"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, scoped)
+}
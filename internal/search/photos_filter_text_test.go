@@ -0,0 +1,121 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterText(t *testing.T) {
+	photo := entity.PhotoFixtures.Get("Photo03")
+	details := entity.NewDetails(photo)
+	details.SetText("Invoice No. 42 Total: 12.50 EUR", entity.SrcOCR)
+
+	if res := entity.Db().Create(&details); res.Error != nil {
+		t.Fatal(res.Error)
+	} else if res.RowsAffected != 1 {
+		t.Fatalf("expected one details row, %d affected", res.RowsAffected)
+	}
+
+	t.Cleanup(func() {
+		if err := entity.UnscopedDb().Delete(entity.Details{}, "photo_id = ?", photo.ID).Error; err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Invoice", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "text:invoice"
+		f.Merged = true
+
+		// Parse query string and filter.
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, photo.PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("InvoiceOrReceipt", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Text = "receipt|12.50"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("InvoiceAndReceipt", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Text = "invoice&receipt"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "invoice total"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, photo.PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("QueryNotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "invoice receipt"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Geo", func(t *testing.T) {
+		var f form.SearchPhotosGeo
+
+		f.Text = "invoice"
+
+		photos, err := PhotosGeo(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(photos), 1)
+	})
+}
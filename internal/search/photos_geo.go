@@ -11,6 +11,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/meta"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/pluscode"
@@ -53,6 +54,12 @@ func PhotosGeo(f form.SearchPhotosGeo) (results GeoResults, err error) {
 		Where("photos.deleted_at IS NULL").
 		Where("photos.photo_lat <> 0")
 
+	// Limit results to the user's originals folder and shared albums?
+	if f.Scope != "" {
+		where, values := ScopeCondition(f.Scope, f.Shared)
+		s = s.Where(where, values...)
+	}
+
 	// Set search filters based on search terms.
 	if terms := txt.SearchTerms(f.Query); f.Query != "" && len(terms) == 0 {
 		if f.Title == "" {
@@ -177,6 +184,25 @@ func PhotosGeo(f form.SearchPhotosGeo) (results GeoResults, err error) {
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	}
 
+	// Filter for detected objects? Example: object:"dog|cat"
+	if txt.NotEmpty(f.Object) {
+		for _, obj := range SplitAnd(f.Object) {
+			if where, values := OrLike("m.marker_name", obj); where != "" {
+				s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = '%s' WHERE %s)",
+					entity.Marker{}.TableName(), entity.MarkerObject, where), values...)
+			}
+		}
+	}
+
+	// Search for text recognized in pictures, e.g. with OCR? Example: text:"invoice|receipt"
+	if txt.NotEmpty(f.Text) {
+		for _, t := range SplitAnd(f.Text) {
+			if where, values := OrLike("d.text", "*"+strings.Join(SplitOr(t), "*|*")+"*"); where != "" {
+				s = s.Where(fmt.Sprintf("photos.id IN (SELECT d.photo_id FROM %s d WHERE %s)", entity.Details{}.TableName(), where), values...)
+			}
+		}
+	}
+
 	// Filter for one or more subjects?
 	if f.Subject != "" {
 		for _, subj := range SplitAnd(strings.ToLower(f.Subject)) {
@@ -249,6 +275,16 @@ func PhotosGeo(f form.SearchPhotosGeo) (results GeoResults, err error) {
 		s = s.Where("photos.photo_favorite = 1")
 	}
 
+	// Filter by star rating?
+	if where := CompareInt("photos.photo_rating", f.Rating, meta.RatingRejected, meta.RatingMax); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by color label?
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", SplitOr(strings.ToLower(f.ColorLabel)))
+	}
+
 	// Find scans only?
 	if f.Scan {
 		s = s.Where("photos.photo_scan = 1")
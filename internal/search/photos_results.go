@@ -35,6 +35,8 @@ type Photo struct {
 	PhotoStack       int8          `json:"Stack" select:"photos.photo_stack"`
 	PhotoFavorite    bool          `json:"Favorite" select:"photos.photo_favorite"`
 	PhotoPrivate     bool          `json:"Private" select:"photos.photo_private"`
+	PhotoRating      int           `json:"Rating" select:"photos.photo_rating"`
+	PhotoColorLabel  string        `json:"ColorLabel" select:"photos.photo_color_label"`
 	PhotoIso         int           `json:"Iso" select:"photos.photo_iso"`
 	PhotoFocalLength int           `json:"FocalLength" select:"photos.photo_focal_length"`
 	PhotoFNumber     float32       `json:"FNumber" select:"photos.photo_f_number"`
@@ -0,0 +1,183 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/inference"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+// SemanticModel computes the embeddings of natural language search queries, semantic search is disabled if nil.
+var SemanticModel inference.TextModel
+
+// SemanticLimit is the maximum number of photos found by semantic search.
+var SemanticLimit = 1000
+
+// SemanticMinScore is the minimum cosine similarity of photos found by semantic search.
+var SemanticMinScore float32 = 0.2
+
+// ErrSemanticDisabled is returned if semantic search is not configured.
+var ErrSemanticDisabled = errors.New("semantic search is not configured")
+
+// semanticIndex contains the image embeddings of all photos, removed photos are not loaded again
+// unless they are restored or their embedding is updated after removal.
+var semanticIndex = struct {
+	sync.Mutex
+	vectors *vector.Index
+	updated time.Time
+	removed map[uint]time.Time
+}{vectors: vector.NewIndex(), removed: make(map[uint]time.Time)}
+
+// SemanticEnabled checks if a model is available to compute the embeddings of search queries.
+func SemanticEnabled() bool {
+	return SemanticModel != nil
+}
+
+// SemanticMatches returns the photos whose image embeddings are most similar to the text embedding of the query.
+func SemanticMatches(query string) (vector.Matches, error) {
+	if SemanticModel == nil {
+		return nil, ErrSemanticDisabled
+	}
+
+	result, err := SemanticModel.InferText(query)
+
+	if err != nil {
+		return nil, err
+	} else if len(result.Embeddings) == 0 {
+		return nil, fmt.Errorf("no embedding returned for %s", clean.LogQuote(query))
+	}
+
+	vectors, err := SemanticIndex()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vectors.Search(result.Embeddings[0], SemanticLimit, SemanticMinScore)
+}
+
+// SemanticIndex returns the in-memory index of image embeddings after loading new and updated embeddings.
+func SemanticIndex() (*vector.Index, error) {
+	semanticIndex.Lock()
+	defer semanticIndex.Unlock()
+
+	start := time.Now()
+	updated := semanticIndex.updated
+	count := 0
+
+	var lastId uint
+
+	for {
+		var rows []entity.PhotoEmbedding
+
+		// Timestamps may only have a precision of seconds, so rows with the same time are loaded again.
+		if err := UnscopedDb().Table(entity.PhotoEmbedding{}.TableName()).
+			Select("photos_embeddings.*").
+			Joins("JOIN photos ON photos.id = photos_embeddings.photo_id AND photos.deleted_at IS NULL").
+			Where("photos_embeddings.updated_at >= ? AND photos_embeddings.photo_id > ?", updated, lastId).
+			Order("photos_embeddings.photo_id").Limit(10000).Find(&rows).Error; err != nil {
+			return semanticIndex.vectors, err
+		} else if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			lastId = row.PhotoID
+
+			if row.UpdatedAt.After(semanticIndex.updated) {
+				semanticIndex.updated = row.UpdatedAt
+			}
+
+			if removedAt, ok := semanticIndex.removed[row.PhotoID]; ok && !row.UpdatedAt.After(removedAt) {
+				continue
+			}
+
+			delete(semanticIndex.removed, row.PhotoID)
+
+			v, err := row.Embedding()
+
+			if err != nil {
+				log.Warnf("search: %s in embedding of photo %d", err, row.PhotoID)
+				continue
+			}
+
+			// Embeddings of another model may have a different number of dimensions.
+			if err = semanticIndex.vectors.Add(row.PhotoID, v); err != nil {
+				log.Debugf("search: %s in embedding of photo %d", err, row.PhotoID)
+				continue
+			}
+
+			count++
+		}
+	}
+
+	if count > 0 {
+		log.Debugf("search: loaded %d embeddings [%s]", count, time.Since(start))
+	}
+
+	return semanticIndex.vectors, nil
+}
+
+// SemanticRemove removes photos from the in-memory index of image embeddings, e.g. after they have been archived or deleted.
+func SemanticRemove(ids ...uint) {
+	semanticIndex.Lock()
+	defer semanticIndex.Unlock()
+
+	removedAt := time.Now()
+
+	for _, id := range ids {
+		semanticIndex.vectors.Remove(id)
+		semanticIndex.removed[id] = removedAt
+	}
+}
+
+// SemanticRestore adds the image embeddings of restored photos to the in-memory index again.
+func SemanticRestore(ids ...uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var rows []entity.PhotoEmbedding
+
+	if err := UnscopedDb().Where("photo_id IN (?)", ids).Find(&rows).Error; err != nil {
+		return err
+	}
+
+	semanticIndex.Lock()
+	defer semanticIndex.Unlock()
+
+	for _, id := range ids {
+		delete(semanticIndex.removed, id)
+	}
+
+	for _, row := range rows {
+		if v, err := row.Embedding(); err != nil {
+			log.Warnf("search: %s in embedding of photo %d", err, row.PhotoID)
+		} else if err = semanticIndex.vectors.Add(row.PhotoID, v); err != nil {
+			log.Debugf("search: %s in embedding of photo %d", err, row.PhotoID)
+		}
+	}
+
+	return nil
+}
+
+// semanticOrder returns an ORDER BY expression that sorts photos by their rank in the matches.
+func semanticOrder(matches vector.Matches) string {
+	var b strings.Builder
+
+	b.WriteString("CASE photos.id")
+
+	for i, m := range matches {
+		b.WriteString(fmt.Sprintf(" WHEN %d THEN %d", m.ID, i))
+	}
+
+	b.WriteString(fmt.Sprintf(" ELSE %d END", len(matches)))
+
+	return b.String()
+}
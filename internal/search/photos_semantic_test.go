@@ -0,0 +1,154 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/inference"
+)
+
+// textModel returns the same embedding for all queries.
+type textModel []float32
+
+func (m textModel) InferText(text string) (inference.Result, error) {
+	return inference.Result{Embeddings: [][]float32{m}}, nil
+}
+
+func TestSemanticMatches(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		SemanticModel = nil
+
+		_, err := SemanticMatches("dog")
+
+		assert.Equal(t, ErrSemanticDisabled, err)
+		assert.False(t, SemanticEnabled())
+	})
+}
+
+func TestPhotos_Semantic(t *testing.T) {
+	photo1 := entity.PhotoFixtures.Get("Photo03")
+	photo2 := entity.PhotoFixtures.Get("Photo04")
+
+	if err := entity.NewPhotoEmbedding(photo1.ID, []float32{0.8, 0.6, 0}).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewPhotoEmbedding(photo2.ID, []float32{1, 0, 0}).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		SemanticModel = nil
+	}()
+
+	t.Run("Ranked", func(t *testing.T) {
+		SemanticModel = textModel{1, 0.1, 0}
+
+		frm := form.SearchPhotos{Semantic: "dog playing in the snow", Count: 10}
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Results contain one row per file, so photos with multiple files are found more than once.
+		if assert.GreaterOrEqual(t, len(photos), 2) {
+			assert.Equal(t, photo2.PhotoUID, photos[0].PhotoUID)
+			assert.Equal(t, photo1.PhotoUID, photos[len(photos)-1].PhotoUID)
+		}
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		SemanticModel = textModel{0, 0, 1}
+
+		frm := form.SearchPhotos{Semantic: "cat", Count: 10}
+
+		photos, _, err := Photos(frm)
+
+		assert.NoError(t, err)
+		assert.Len(t, photos, 0)
+	})
+	t.Run("ArchiveRestore", func(t *testing.T) {
+		SemanticModel = textModel{1, 0.1, 0}
+
+		frm := form.SearchPhotos{Semantic: "dog playing in the snow", Count: 10}
+		photo := entity.PhotoFixtures.Pointer("Photo04")
+
+		t.Cleanup(func() {
+			_ = photo.Restore()
+			_ = entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid = ?", photo.PhotoUID).UpdateColumn("hidden", false).Error
+		})
+
+		// Archive the photo like the batch archive endpoint does.
+		if err := photo.Archive(); err != nil {
+			t.Fatal(err)
+		}
+
+		SemanticRemove(photo.ID)
+
+		// The photo must not be found, also after the index has been updated again.
+		for i := 0; i < 2; i++ {
+			photos, _, err := Photos(frm)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, p := range photos {
+				assert.NotEqual(t, photo.PhotoUID, p.PhotoUID)
+			}
+		}
+
+		if err := photo.Restore(); err != nil {
+			t.Fatal(err)
+		} else if err = SemanticRestore(photo.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotEmpty(t, photos) {
+			assert.Equal(t, photo.PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("Removed", func(t *testing.T) {
+		SemanticModel = textModel{1, 0.1, 0}
+
+		frm := form.SearchPhotos{Semantic: "dog playing in the snow", Count: 10}
+
+		t.Cleanup(func() {
+			_ = SemanticRestore(photo2.ID)
+		})
+
+		// Removed photos are not loaded again while their embedding remains unchanged.
+		SemanticRemove(photo2.ID)
+
+		for i := 0; i < 2; i++ {
+			photos, _, err := Photos(frm)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, p := range photos {
+				assert.NotEqual(t, photo2.PhotoUID, p.PhotoUID)
+			}
+		}
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		SemanticModel = nil
+
+		frm := form.SearchPhotos{Semantic: "cat", Count: 10}
+
+		_, _, err := Photos(frm)
+
+		assert.Equal(t, ErrSemanticDisabled, err)
+	})
+}
@@ -0,0 +1,82 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// likeEscape escapes the wildcard characters of a LIKE pattern, using "|" as escape character.
+func likeEscape(s string) string {
+	return strings.NewReplacer("|", "||", "%", "|%", "_", "|_").Replace(s)
+}
+
+// ScopeCondition returns a where condition that limits photos to an originals subfolder
+// and the albums shared with the user.
+func ScopeCondition(scope string, shared []string) (where string, values []interface{}) {
+	scope = strings.Trim(scope, "/")
+
+	where = "(photos.photo_path = ? OR photos.photo_path LIKE ? ESCAPE '|')"
+	values = []interface{}{scope, likeEscape(scope) + "/%"}
+
+	if len(shared) > 0 {
+		where = "(" + where + " OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?)))"
+		values = append(values, shared)
+	}
+
+	return where, values
+}
+
+// AlbumOwnerCondition returns a where condition that limits albums to folders within the originals
+// subfolder and albums created by the user, i.e. albums the user may change.
+func AlbumOwnerCondition(scope string, user string) (where string, values []interface{}) {
+	scope = strings.Trim(scope, "/")
+
+	where = "albums.album_type = ? AND (albums.album_path = ? OR albums.album_path LIKE ? ESCAPE '|')"
+	values = []interface{}{entity.AlbumFolder, scope, likeEscape(scope) + "/%"}
+
+	if user != "" {
+		where += " OR albums.created_by = ?"
+		values = append(values, user)
+	}
+
+	return "(" + where + ")", values
+}
+
+// AlbumScopeCondition returns a where condition that limits albums to those shared with the user,
+// albums the user may change, see AlbumOwnerCondition, and albums that contain pictures in scope.
+func AlbumScopeCondition(scope string, shared []string, user string) (where string, values []interface{}) {
+	owner, values := AlbumOwnerCondition(scope, user)
+	photos, photoValues := ScopeCondition(scope, shared)
+
+	where = "(" + owner + " OR albums.album_uid IN (SELECT pa.album_uid FROM photos_albums pa JOIN photos ON photos.photo_uid = pa.photo_uid" +
+		" WHERE pa.hidden = 0 AND photos.deleted_at IS NULL AND " + photos + "))"
+	values = append(values, photoValues...)
+
+	if len(shared) > 0 {
+		where = "(" + where + " OR albums.album_uid IN (?))"
+		values = append(values, shared)
+	}
+
+	return where, values
+}
+
+// LabelScopeCondition returns a where condition that limits labels to those assigned to pictures in scope.
+func LabelScopeCondition(scope string, shared []string) (where string, values []interface{}) {
+	photos, values := ScopeCondition(scope, shared)
+
+	where = "labels.id IN (SELECT pl.label_id FROM photos_labels pl JOIN photos ON photos.id = pl.photo_id" +
+		" WHERE pl.uncertainty < 100 AND photos.deleted_at IS NULL AND " + photos + ")"
+
+	return where, values
+}
+
+// SubjectScopeCondition returns a where condition that limits subjects to those marked in pictures in scope.
+func SubjectScopeCondition(scope string, shared []string) (where string, values []interface{}) {
+	photos, values := ScopeCondition(scope, shared)
+
+	where = "subjects.subj_uid IN (SELECT m.subj_uid FROM markers m JOIN files f ON f.file_uid = m.file_uid" +
+		" JOIN photos ON photos.id = f.photo_id WHERE m.marker_invalid = 0 AND photos.deleted_at IS NULL AND " + photos + ")"
+
+	return where, values
+}
@@ -25,6 +25,12 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 	s := UnscopedDb().Table(subjTable).
 		Select(fmt.Sprintf("%s.*", subjTable))
 
+	// Limit results to subjects in pictures of the user's originals folder and shared albums?
+	if f.Scope != "" {
+		where, values := SubjectScopeCondition(f.Scope, f.Shared)
+		s = s.Where(where, values...)
+	}
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -0,0 +1,170 @@
+package search
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/maps"
+)
+
+// visitPhotosCondition returns a where condition for the pictures of a visit that may be shown.
+func visitPhotosCondition(f form.SearchVisits) (where string, values []interface{}) {
+	where = "photos.deleted_at IS NULL"
+
+	// Exclude private pictures?
+	if f.Public {
+		where += " AND photos.photo_private = 0"
+	}
+
+	// Limit results to the user's originals folder and shared albums?
+	if f.Scope != "" {
+		scope, scopeValues := ScopeCondition(f.Scope, f.Shared)
+		where += " AND " + scope
+		values = append(values, scopeValues...)
+	}
+
+	return where, values
+}
+
+// visitScoped returns an expression that uses the stored visit column if all pictures may be shown,
+// and the value computed from the pictures that may be shown otherwise.
+func visitScoped(stored, scoped string) string {
+	return "CASE WHEN pv.photo_count = pt.photo_count THEN visits." + stored + " ELSE " + scoped + " END"
+}
+
+// visitColumns contains the visit properties, computed only from pictures that may be shown if a
+// visit also contains other pictures, so that these don't reveal where and when they have been taken.
+var visitColumns = []string{
+	"visits.id",
+	visitScoped("visit_name", "COALESCE(pc.cell_name, '')") + " AS visit_name",
+	visitScoped("visit_category", "COALESCE(pc.cell_category, '')") + " AS visit_category",
+	visitScoped("place_id", "COALESCE(pp.id, '')") + " AS place_id",
+	visitScoped("place_label", "COALESCE(pp.place_label, '')") + " AS place_label",
+	visitScoped("place_city", "COALESCE(pp.place_city, '')") + " AS place_city",
+	visitScoped("place_state", "COALESCE(pp.place_state, '')") + " AS place_state",
+	visitScoped("visit_country", "COALESCE(pv.visit_country, '')") + " AS visit_country",
+	visitScoped("visit_lat", "pv.visit_lat") + " AS visit_lat",
+	visitScoped("visit_lng", "pv.visit_lng") + " AS visit_lng",
+	"visits.arrived_at",
+	"visits.departed_at",
+	"pv.photo_count",
+	"pt.photo_count AS photo_total",
+}
+
+// Visits finds the places where pictures have been taken, sorted by arrival time, and returns
+// the results for the requested page along with the total number of matching visits.
+func Visits(f form.SearchVisits) (results VisitResults, count int, err error) {
+	results = VisitResults{}
+	photos, values := visitPhotosCondition(f)
+
+	// Base query, visits without pictures that may be shown are skipped.
+	s := UnscopedDb().Table("visits").
+		Joins("JOIN (SELECT photos_visits.visit_id, COUNT(*) AS photo_count,"+
+			" MIN(photos.taken_at) AS arrived_at, MAX(photos.taken_at) AS departed_at,"+
+			" AVG(photos.photo_lat) AS visit_lat, AVG(photos.photo_lng) AS visit_lng,"+
+			" MAX(NULLIF(photos.cell_id, 'zz')) AS cell_id, MAX(NULLIF(photos.place_id, 'zz')) AS place_id,"+
+			" MAX(NULLIF(photos.photo_country, 'zz')) AS visit_country FROM photos_visits"+
+			" JOIN photos ON photos.id = photos_visits.photo_id WHERE "+photos+
+			" GROUP BY photos_visits.visit_id) AS pv ON pv.visit_id = visits.id", values...).
+		Joins("JOIN (SELECT visit_id, COUNT(*) AS photo_count FROM photos_visits GROUP BY visit_id) AS pt ON pt.visit_id = visits.id").
+		Joins("LEFT JOIN cells pc ON pc.id = pv.cell_id").
+		Joins("LEFT JOIN places pp ON pp.id = pv.place_id")
+
+	// Filter by place, country, or point of interest name.
+	if q := strings.ToLower(strings.TrimSpace(f.Query)); q != "" {
+		like := "%" + likeEscape(q) + "%"
+		countries := []string{q}
+
+		for code, name := range maps.CountryNames {
+			if strings.Contains(strings.ToLower(name), q) {
+				countries = append(countries, code)
+			}
+		}
+
+		s = s.Where(visitScoped("visit_name", "pc.cell_name")+" LIKE ? ESCAPE '|'"+
+			" OR "+visitScoped("place_label", "pp.place_label")+" LIKE ? ESCAPE '|'"+
+			" OR "+visitScoped("place_city", "pp.place_city")+" LIKE ? ESCAPE '|'"+
+			" OR "+visitScoped("place_state", "pp.place_state")+" LIKE ? ESCAPE '|'"+
+			" OR "+visitScoped("visit_country", "pv.visit_country")+" IN (?)",
+			like, like, like, like, countries)
+	}
+
+	// Count matching visits before applying offset and limit.
+	if err = s.Count(&count).Error; err != nil {
+		return results, count, err
+	} else if count == 0 {
+		return results, count, nil
+	}
+
+	arrival := visitScoped("arrived_at", "pv.arrived_at")
+	departure := visitScoped("departed_at", "pv.departed_at")
+
+	// Sort by arrival time.
+	if f.Reverse {
+		s = s.Order(arrival + " DESC, " + departure + " DESC, visits.id DESC")
+	} else {
+		s = s.Order(arrival + ", " + departure + ", visits.id")
+	}
+
+	// Limit result count.
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if err = s.Select(strings.Join(visitColumns, ", ")).Scan(&results).Error; err != nil {
+		return results, count, err
+	} else if len(results) == 0 {
+		return results, count, nil
+	}
+
+	// Add the UIDs of pictures that may be shown.
+	ids := make([]uint, len(results))
+	index := make(map[uint]int, len(results))
+
+	for i := range results {
+		ids[i] = results[i].ID
+		index[results[i].ID] = i
+		results[i].CountryName = maps.CountryName(results[i].VisitCountry)
+		results[i].PhotoUIDs = []string{}
+	}
+
+	var rows []struct {
+		VisitID  uint
+		PhotoUID string
+		TakenAt  time.Time
+	}
+
+	if err = UnscopedDb().Table("photos_visits").
+		Select("photos_visits.visit_id, photos.photo_uid, photos.taken_at").
+		Joins("JOIN photos ON photos.id = photos_visits.photo_id").
+		Where("photos_visits.visit_id IN (?)", ids).
+		Where(photos, values...).
+		Order("photos.taken_at, photos.id").
+		Scan(&rows).Error; err != nil {
+		return results, count, err
+	}
+
+	for _, r := range rows {
+		i, ok := index[r.VisitID]
+
+		if !ok {
+			continue
+		}
+
+		// Arrival and departure of visits that also contain other pictures are those of the pictures shown.
+		if results[i].PhotoCount < results[i].PhotoTotal {
+			if len(results[i].PhotoUIDs) == 0 {
+				results[i].ArrivedAt = r.TakenAt
+			}
+
+			results[i].DepartedAt = r.TakenAt
+		}
+
+		results[i].PhotoUIDs = append(results[i].PhotoUIDs, r.PhotoUID)
+	}
+
+	return results, count, nil
+}
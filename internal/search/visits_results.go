@@ -0,0 +1,27 @@
+package search
+
+import (
+	"time"
+)
+
+// Visit represents a visit search result.
+type Visit struct {
+	ID            uint      `json:"-"`
+	VisitName     string    `json:"Name"`
+	VisitCategory string    `json:"Category"`
+	PlaceID       string    `json:"PlaceID"`
+	PlaceLabel    string    `json:"Label"`
+	PlaceCity     string    `json:"City"`
+	PlaceState    string    `json:"State"`
+	VisitCountry  string    `json:"Country"`
+	CountryName   string    `json:"CountryName"`
+	VisitLat      float64   `json:"Lat"`
+	VisitLng      float64   `json:"Lng"`
+	ArrivedAt     time.Time `json:"Arrival"`
+	DepartedAt    time.Time `json:"Departure"`
+	PhotoCount    int       `json:"PhotoCount"`
+	PhotoTotal    int       `json:"-"`
+	PhotoUIDs     []string  `json:"PhotoUIDs"`
+}
+
+type VisitResults []Visit
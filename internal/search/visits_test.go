@@ -0,0 +1,159 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestVisits(t *testing.T) {
+	arrival := time.Date(2021, 6, 12, 10, 0, 0, 0, time.UTC)
+
+	visits := entity.Visits{
+		{
+			VisitName:    "Praça do Comércio",
+			PlaceLabel:   "Lisbon, Portugal",
+			PlaceCity:    "Lisbon",
+			VisitCountry: "pt",
+			ArrivedAt:    arrival,
+			DepartedAt:   arrival.Add(2 * time.Hour),
+			PhotoIDs:     []uint{entity.PhotoFixtures.Get("Photo01").ID, entity.PhotoFixtures.Get("Photo03").ID},
+		},
+		{
+			VisitName:    "Porto",
+			VisitCountry: "pt",
+			ArrivedAt:    arrival.Add(26 * time.Hour),
+			DepartedAt:   arrival.Add(26 * time.Hour),
+			PhotoIDs:     []uint{entity.PhotoFixtures.Get("Photo04").ID},
+		},
+		{
+			VisitName:    "Brandenburger Tor",
+			VisitCountry: "de",
+			ArrivedAt:    arrival.Add(48 * time.Hour),
+			DepartedAt:   arrival.Add(49 * time.Hour),
+			PhotoIDs:     []uint{entity.PhotoFixtures.Get("Photo05").ID},
+		},
+	}
+
+	if _, _, err := entity.UpdateVisits(visits); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_, _, _ = entity.UpdateVisits(nil)
+	}()
+
+	t.Run("All", func(t *testing.T) {
+		results, count, err := Visits(form.SearchVisits{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, count)
+
+		if assert.Len(t, results, 3) {
+			assert.Equal(t, "Praça do Comércio", results[0].VisitName)
+			assert.Equal(t, "Portugal", results[0].CountryName)
+			assert.Equal(t, 2, results[0].PhotoCount)
+			assert.Equal(t, []string{entity.PhotoFixtures.Get("Photo03").PhotoUID, entity.PhotoFixtures.Get("Photo01").PhotoUID}, results[0].PhotoUIDs)
+			assert.Equal(t, "Brandenburger Tor", results[2].VisitName)
+		}
+	})
+	t.Run("Reverse", func(t *testing.T) {
+		results, _, err := Visits(form.SearchVisits{Reverse: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 3) {
+			assert.Equal(t, "Brandenburger Tor", results[0].VisitName)
+		}
+	})
+	t.Run("Page", func(t *testing.T) {
+		results, count, err := Visits(form.SearchVisits{Count: 1, Offset: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, count)
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "Porto", results[0].VisitName)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		results, count, err := Visits(form.SearchVisits{Query: "Portugal"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, count)
+		assert.Len(t, results, 2)
+
+		if _, count, err = Visits(form.SearchVisits{Query: "lisbon"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, count)
+
+		if _, count, err = Visits(form.SearchVisits{Query: "DE"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, count)
+
+		if _, count, err = Visits(form.SearchVisits{Query: "100%"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+	})
+	t.Run("Public", func(t *testing.T) {
+		results, count, err := Visits(form.SearchVisits{Public: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, count)
+		assert.Len(t, results, 2)
+	})
+	t.Run("Scope", func(t *testing.T) {
+		results, count, err := Visits(form.SearchVisits{Scope: "1990"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, count)
+
+		if assert.Len(t, results, 1) {
+			photo := entity.PhotoFixtures.Get("Photo03")
+
+			assert.Equal(t, 1, results[0].PhotoCount)
+			assert.Equal(t, []string{photo.PhotoUID}, results[0].PhotoUIDs)
+
+			// Properties of pictures outside the scope must not be revealed.
+			assert.NotEqual(t, "Praça do Comércio", results[0].VisitName)
+			assert.NotEqual(t, "Lisbon, Portugal", results[0].PlaceLabel)
+			assert.Equal(t, photo.TakenAt.UTC(), results[0].ArrivedAt.UTC())
+			assert.Equal(t, photo.TakenAt.UTC(), results[0].DepartedAt.UTC())
+			assert.InDelta(t, float64(photo.PhotoLat), results[0].VisitLat, 0.0001)
+			assert.InDelta(t, float64(photo.PhotoLng), results[0].VisitLng, 0.0001)
+		}
+
+		if _, count, err = Visits(form.SearchVisits{Scope: "1990", Query: "lisbon"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+	})
+}
@@ -8,7 +8,10 @@ import (
 	"sync"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
 )
 
 var basicAuth = struct {
@@ -49,6 +52,19 @@ func BasicAuth() gin.HandlerFunc {
 
 		username, password, raw := GetCredentials(c)
 
+		// Personal access tokens can be used instead of the password, they are checked on every request
+		// so that they can be revoked.
+		if entity.IsAccessToken(password) {
+			if user := tokenUser(username, password, c.Request.Method); user != nil {
+				c.Set(gin.AuthUserKey, user.UserUID)
+			} else {
+				c.Header("WWW-Authenticate", realm)
+				c.AbortWithStatus(http.StatusUnauthorized)
+			}
+
+			return
+		}
+
 		basicAuth.mutex.Lock()
 		defer basicAuth.mutex.Unlock()
 
@@ -61,6 +77,12 @@ func BasicAuth() gin.HandlerFunc {
 
 		if user != nil {
 			invalid = user.InvalidPassword(password)
+
+			// Users with two-factor authentication must use a personal access token instead.
+			if !invalid && user.RequiresPasscode() {
+				log.Warnf("webdav: user %s has two-factor authentication enabled, please use an access token", user.String())
+				invalid = true
+			}
 		}
 
 		if user == nil || invalid {
@@ -74,3 +96,35 @@ func BasicAuth() gin.HandlerFunc {
 		c.Set(gin.AuthUserKey, user.UserUID)
 	}
 }
+
+// tokenUser returns the user if the access token belongs to them and permits the WebDAV request method.
+func tokenUser(username, secret, method string) *entity.User {
+	token := entity.FindAccessToken(secret)
+
+	if token == nil {
+		return nil
+	}
+
+	user := token.User()
+
+	if user == nil || user.Deleted() || user.UserName() != clean.Login(username) {
+		return nil
+	}
+
+	var action acl.Action
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND":
+		action = acl.ActionDownload
+	case http.MethodDelete:
+		action = acl.ActionDelete
+	default:
+		action = acl.ActionUpload
+	}
+
+	if !token.Allow(acl.ResourceFiles, action) {
+		return nil
+	}
+
+	return user
+}
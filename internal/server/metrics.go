@@ -0,0 +1,33 @@
+package server
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/pkg/metrics"
+)
+
+var httpRequests = metrics.NewCounter("photoprism_http_requests_total", "Number of HTTP requests by route and status code.", "method", "route", "code")
+var httpDuration = metrics.NewHistogram("photoprism_http_request_duration_seconds", "HTTP request duration by route.", metrics.DefaultBuckets, "method", "route")
+
+// Metrics instances a middleware for Gin that counts requests and measures their duration per route.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		// Process request
+		c.Next()
+
+		// Use the route pattern to keep the number of series small.
+		route := c.FullPath()
+
+		if route == "" {
+			route = "unmatched"
+		}
+
+		httpRequests.Inc(c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+		httpDuration.Since(start, c.Request.Method, route)
+	}
+}
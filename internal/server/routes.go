@@ -55,6 +55,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		c.HTML(http.StatusOK, "splash.tmpl", gin.H{"config": clientConfig})
 	})
 
+	// Prometheus metrics, requires a metrics token.
+	api.GetMetrics(router.Group(conf.BaseUri("")))
+
 	// JSON-REST API Version 1
 	v1 := router.Group(conf.BaseUri(config.ApiUri))
 	{
@@ -67,8 +70,19 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetSettings(v1)
 		api.SaveSettings(v1)
 		api.ChangePassword(v1)
+		api.GetUserTokens(v1)
+		api.CreateUserToken(v1)
+		api.DeleteUserToken(v1)
+		api.GetUserSessions(v1)
+		api.DeleteUserSession(v1)
+		api.DeleteUserSessions(v1)
+		api.CreateUserPasscode(v1)
+		api.ConfirmUserPasscode(v1)
+		api.DeleteUserPasscode(v1)
 		api.CreateSession(v1)
 		api.DeleteSession(v1)
+		api.OIDCLogin(v1)
+		api.OIDCRedirect(v1)
 
 		// External account management.
 		api.SearchAccounts(v1)
@@ -89,6 +103,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		// Photos.
 		api.SearchPhotos(v1)
 		api.SearchGeo(v1)
+		api.SearchVisits(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
 		api.UpdatePhoto(v1)
@@ -114,6 +129,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		// Albums.
 		api.SearchAlbums(v1)
 		api.GetAlbum(v1)
+		api.GetAlbumTrip(v1)
 		api.AlbumCover(v1)
 		api.CreateAlbum(v1)
 		api.UpdateAlbum(v1)
@@ -132,7 +148,11 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		// Labels.
 		api.SearchLabels(v1)
 		api.LabelCover(v1)
+		api.CreateLabel(v1)
 		api.UpdateLabel(v1)
+		api.DeleteLabel(v1)
+		api.GetLabelChildren(v1)
+		api.GetLabelTree(v1)
 		api.GetLabelLinks(v1)
 		api.CreateLabelLink(v1)
 		api.UpdateLabelLink(v1)
@@ -169,6 +189,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.BatchPhotosArchive(v1)
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
+		api.BatchPhotosRating(v1)
 		api.BatchPhotosDelete(v1)
 		api.BatchAlbumsDelete(v1)
 		api.BatchLabelsDelete(v1)
@@ -177,6 +198,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetSvg(v1)
 		api.GetStatus(v1)
 		api.GetErrors(v1)
+		api.GetAuditLogs(v1)
+		api.GetDuplicates(v1)
 		api.DeleteErrors(v1)
 		api.SendFeedback(v1)
 		api.Connect(v1)
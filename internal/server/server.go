@@ -38,6 +38,11 @@ func Start(ctx context.Context, conf *config.Config) {
 	// Register logger middleware.
 	router.Use(Logger(), Recovery())
 
+	// Register metrics middleware if the metrics endpoint is enabled.
+	if conf.MetricsToken() != "" {
+		router.Use(Metrics())
+	}
+
 	// Register security middleware.
 	router.Use(Security(SecurityOptions{
 		IsDevelopment:         gin.Mode() != gin.ReleaseMode || conf.Test(),
@@ -81,11 +86,31 @@ func Start(ctx context.Context, conf *config.Config) {
 		Handler: router,
 	}
 
+	// Enable HTTPS?
+	tlsConf, acmeHandler, err := tlsConfig(ctx, conf)
+
+	if err != nil {
+		log.Errorf("server: %s (tls)", err)
+		return
+	} else if tlsConf != nil && conf.TLSRedirectPort() > 0 {
+		startRedirect(ctx, conf, acmeHandler)
+	}
+
+	server.TLSConfig = tlsConf
+
 	// Start HTTP server.
 	go func() {
-		log.Infof("server: listening on %s [%s]", server.Addr, time.Since(start))
+		var err error
+
+		if tlsConf != nil {
+			log.Infof("server: listening on %s with https [%s]", server.Addr, time.Since(start))
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			log.Infof("server: listening on %s [%s]", server.Addr, time.Since(start))
+			err = server.ListenAndServe()
+		}
 
-		if err := server.ListenAndServe(); err != nil {
+		if err != nil {
 			if err == http.ErrServerClosed {
 				log.Info("server: shutdown complete")
 			} else {
@@ -97,7 +122,7 @@ func Start(ctx context.Context, conf *config.Config) {
 	// Graceful HTTP server shutdown.
 	<-ctx.Done()
 	log.Info("server: shutting down")
-	err := server.Close()
+	err = server.Close()
 	if err != nil {
 		log.Errorf("server: shutdown failed (%s)", err)
 	}
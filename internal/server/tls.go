@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/certs"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// CertReloadInterval is the interval in which certificate files are checked for changes.
+var CertReloadInterval = time.Minute
+
+// tlsConfig returns the TLS configuration of the server and a handler for ACME HTTP-01 challenges,
+// or nil if HTTPS is disabled.
+func tlsConfig(ctx context.Context, conf *config.Config) (*tls.Config, func(http.Handler) http.Handler, error) {
+	if !conf.TLSEnabled() {
+		return nil, nil, nil
+	}
+
+	// Obtain certificates automatically?
+	if conf.ACMEEnabled() {
+		m, err := acmeManager(conf)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		log.Infof("server: obtaining certificates for %s from %s", clean.Log(fmt.Sprint(conf.ACMEDomains())), clean.Log(conf.ACMEDirectory()))
+
+		// Supports TLS-ALPN-01 challenges.
+		return m.TLSConfig(), m.HTTPHandler, nil
+	}
+
+	certFile, keyFile := conf.TLSCert(), conf.TLSKey()
+
+	// Create self-signed certificate if no other certificate is configured.
+	if certFile == "" || keyFile == "" {
+		certFile = filepath.Join(conf.CertificatesPath(), "self-signed.crt")
+		keyFile = filepath.Join(conf.CertificatesPath(), "self-signed.key")
+
+		if err := certs.SelfSigned(certFile, keyFile, selfSignedHosts(conf)); err != nil {
+			return nil, nil, err
+		}
+
+		log.Infof("server: using self-signed certificate %s", clean.Log(certFile))
+	}
+
+	loader, err := certs.NewLoader(certFile, keyFile)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Reload certificate files when they change, e.g. after renewal.
+	go func() {
+		ticker := time.NewTicker(CertReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if reloaded, err := loader.Reload(); err != nil {
+					log.Warnf("server: %s (reload certificate)", err)
+				} else if reloaded {
+					log.Infof("server: reloaded certificate %s", clean.Log(certFile))
+				}
+			}
+		}
+	}()
+
+	return &tls.Config{GetCertificate: loader.GetCertificate, MinVersion: tls.VersionTLS12}, nil, nil
+}
+
+// acmeManager returns a certificate manager for the configured ACME directory.
+func acmeManager(conf *config.Config) (*autocert.Manager, error) {
+	client := &acme.Client{DirectoryURL: conf.ACMEDirectory()}
+
+	// Trust an additional CA, e.g. of a local test server like Pebble.
+	if caFile := conf.ACMECACert(); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificate in %s", clean.Log(caFile))
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+		client.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	if err := os.MkdirAll(conf.CertificatesPath(), 0700); err != nil {
+		return nil, err
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(filepath.Join(conf.CertificatesPath(), "acme")),
+		HostPolicy: autocert.HostWhitelist(conf.ACMEDomains()...),
+		Email:      conf.ACMEEmail(),
+		Client:     client,
+	}, nil
+}
+
+// selfSignedHosts returns the host names and addresses for a self-signed certificate.
+func selfSignedHosts(conf *config.Config) (hosts []string) {
+	if u, err := url.Parse(conf.SiteUrl()); err == nil && u.Hostname() != "" {
+		hosts = append(hosts, u.Hostname())
+	}
+
+	if name, err := os.Hostname(); err == nil && name != "" {
+		hosts = append(hosts, name)
+	}
+
+	hosts = append(hosts, "localhost", "127.0.0.1", "::1")
+
+	if h := conf.HttpHost(); net.ParseIP(h) != nil && !net.ParseIP(h).IsUnspecified() {
+		hosts = append(hosts, h)
+	}
+
+	return hosts
+}
+
+// redirectHandler redirects plain HTTP requests to the HTTPS port.
+func redirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Use HTTPS", http.StatusBadRequest)
+			return
+		}
+
+		host := r.Host
+
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
+// startRedirect starts a plain HTTP server that redirects to HTTPS and answers ACME HTTP-01 challenges.
+func startRedirect(ctx context.Context, conf *config.Config, acmeHandler func(http.Handler) http.Handler) {
+	handler := redirectHandler(conf.HttpPort())
+
+	if acmeHandler != nil {
+		handler = acmeHandler(handler)
+	}
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", conf.HttpHost(), conf.TLSRedirectPort()),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Infof("server: redirecting %s to https", server.Addr)
+
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("server: %s (redirect)", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		if err := server.Close(); err != nil {
+			log.Errorf("server: %s (redirect)", err)
+		}
+	}()
+}
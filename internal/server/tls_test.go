@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestTlsConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		conf := config.NewConfig(config.CliTestContext())
+
+		tlsConf, acmeHandler, err := tlsConfig(ctx, conf)
+
+		assert.NoError(t, err)
+		assert.Nil(t, tlsConf)
+		assert.Nil(t, acmeHandler)
+	})
+	t.Run("SelfSigned", func(t *testing.T) {
+		conf := config.NewConfig(config.CliTestContext())
+		conf.Options().ConfigPath = t.TempDir()
+		conf.Options().TLSSelfSigned = true
+
+		tlsConf, acmeHandler, err := tlsConfig(ctx, conf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, acmeHandler)
+
+		cert, err := tlsConf.GetCertificate(nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, cert)
+		assert.FileExists(t, conf.CertificatesPath()+"/self-signed.crt")
+	})
+	t.Run("ACME", func(t *testing.T) {
+		conf := config.NewConfig(config.CliTestContext())
+		conf.Options().ConfigPath = t.TempDir()
+		conf.Options().ACMEDomains = "photos.example.com"
+		conf.Options().ACMEDirectory = "https://localhost:14000/dir"
+
+		m, err := acmeManager(conf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "https://localhost:14000/dir", m.Client.DirectoryURL)
+		assert.NoError(t, m.HostPolicy(ctx, "photos.example.com"))
+		assert.Error(t, m.HostPolicy(ctx, "other.example.com"))
+
+		tlsConf, acmeHandler, err := tlsConfig(ctx, conf)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, acmeHandler)
+		assert.Contains(t, tlsConf.NextProtos, "acme-tls/1")
+	})
+}
+
+func TestRedirectHandler(t *testing.T) {
+	t.Run("CustomPort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		redirectHandler(2342).ServeHTTP(w, httptest.NewRequest("GET", "http://photos.local:8080/library/browse?q=cat", nil))
+
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "https://photos.local:2342/library/browse?q=cat", w.Header().Get("Location"))
+	})
+	t.Run("DefaultPort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		redirectHandler(443).ServeHTTP(w, httptest.NewRequest("GET", "http://photos.local/", nil))
+
+		assert.Equal(t, "https://photos.local/", w.Header().Get("Location"))
+	})
+	t.Run("Post", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		redirectHandler(443).ServeHTTP(w, httptest.NewRequest("POST", "http://photos.local/api/v1/session", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
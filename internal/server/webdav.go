@@ -5,12 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/net/webdav"
 
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -56,11 +58,72 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 		return
 	}
 
-	f := webdav.Dir(path)
+	// Users with their own originals folder get a separate handler for their subfolder.
+	var handlers = make(map[string]*webdav.Handler)
+	var mutex sync.Mutex
 
-	srv := &webdav.Handler{
+	userHandler := func(folder string) *webdav.Handler {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if h, ok := handlers[folder]; ok {
+			return h
+		}
+
+		dir := filepath.Join(path, folder)
+
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			log.Errorf("webdav: %s", err)
+		}
+
+		h := newWebDAVHandler(dir, router, conf)
+		handlers[folder] = h
+
+		return h
+	}
+
+	srv := newWebDAVHandler(path, router, conf)
+
+	handler := func(c *gin.Context) {
+		w := c.Writer
+		r := c.Request
+
+		if user := entity.FindUserByUID(c.GetString(gin.AuthUserKey)); user != nil && user.Confined() {
+			// Deny access if the user folder is invalid instead of granting access to all files.
+			if folder := user.UserFolder(); folder == "" {
+				log.Warnf("webdav: user %s has an invalid originals folder", clean.Log(user.UserName()))
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				userHandler(folder).ServeHTTP(w, r)
+			}
+
+			return
+		}
+
+		srv.ServeHTTP(w, r)
+	}
+
+	router.Handle(MethodHead, "/*path", handler)
+	router.Handle(MethodGet, "/*path", handler)
+	router.Handle(MethodPut, "/*path", handler)
+	router.Handle(MethodPost, "/*path", handler)
+	router.Handle(MethodPatch, "/*path", handler)
+	router.Handle(MethodDelete, "/*path", handler)
+	router.Handle(MethodOptions, "/*path", handler)
+	router.Handle(MethodMkcol, "/*path", handler)
+	router.Handle(MethodCopy, "/*path", handler)
+	router.Handle(MethodMove, "/*path", handler)
+	router.Handle(MethodLock, "/*path", handler)
+	router.Handle(MethodUnlock, "/*path", handler)
+	router.Handle(MethodPropfind, "/*path", handler)
+	router.Handle(MethodProppatch, "/*path", handler)
+}
+
+// newWebDAVHandler returns a WebDAV handler for the specified directory.
+func newWebDAVHandler(dir string, router *gin.RouterGroup, conf *config.Config) *webdav.Handler {
+	return &webdav.Handler{
 		Prefix:     router.BasePath(),
-		FileSystem: f,
+		FileSystem: webdav.Dir(dir),
 		LockSystem: webdav.NewMemLS(),
 		Logger: func(r *http.Request, err error) {
 			if err != nil {
@@ -76,11 +139,7 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 			} else {
 				// Mark uploaded files as favorite if X-Favorite HTTP header is "1".
 				if r.Method == MethodPut && r.Header.Get("X-Favorite") == "1" {
-					if router.BasePath() == conf.BaseUri(WebDAVOriginals) {
-						MarkUploadAsFavorite(filepath.Join(conf.OriginalsPath(), strings.TrimPrefix(r.URL.Path, router.BasePath())))
-					} else if router.BasePath() == conf.BaseUri(WebDAVImport) {
-						MarkUploadAsFavorite(filepath.Join(conf.ImportPath(), strings.TrimPrefix(r.URL.Path, router.BasePath())))
-					}
+					MarkUploadAsFavorite(filepath.Join(dir, strings.TrimPrefix(r.URL.Path, router.BasePath())))
 				}
 
 				switch r.Method {
@@ -98,26 +157,4 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 			}
 		},
 	}
-
-	handler := func(c *gin.Context) {
-		w := c.Writer
-		r := c.Request
-
-		srv.ServeHTTP(w, r)
-	}
-
-	router.Handle(MethodHead, "/*path", handler)
-	router.Handle(MethodGet, "/*path", handler)
-	router.Handle(MethodPut, "/*path", handler)
-	router.Handle(MethodPost, "/*path", handler)
-	router.Handle(MethodPatch, "/*path", handler)
-	router.Handle(MethodDelete, "/*path", handler)
-	router.Handle(MethodOptions, "/*path", handler)
-	router.Handle(MethodMkcol, "/*path", handler)
-	router.Handle(MethodCopy, "/*path", handler)
-	router.Handle(MethodMove, "/*path", handler)
-	router.Handle(MethodLock, "/*path", handler)
-	router.Handle(MethodUnlock, "/*path", handler)
-	router.Handle(MethodPropfind, "/*path", handler)
-	router.Handle(MethodProppatch, "/*path", handler)
 }
@@ -4,12 +4,17 @@ import (
 	"sync"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/inference"
 )
 
 var onceClassify sync.Once
 
 func initClassify() {
 	services.Classify = classify.New(Config().AssetsPath(), Config().DisableClassification())
+
+	if url := Config().ClassifyUrl(); url != "" {
+		services.Classify.WithBackend(inference.NewHttp(url))
+	}
 }
 
 func Classify() *classify.TensorFlow {
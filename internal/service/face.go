@@ -4,12 +4,17 @@ import (
 	"sync"
 
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/inference"
 )
 
 var onceFaceNet sync.Once
 
 func initFaceNet() {
 	services.FaceNet = face.NewNet(conf.FaceNetModelPath(), "", conf.DisableFaces())
+
+	if url := conf.FaceUrl(); url != "" {
+		services.FaceNet.WithBackend(inference.NewHttp(url))
+	}
 }
 
 func FaceNet() *face.Net {
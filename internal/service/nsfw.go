@@ -3,6 +3,7 @@ package service
 import (
 	"sync"
 
+	"github.com/photoprism/photoprism/internal/inference"
 	"github.com/photoprism/photoprism/internal/nsfw"
 )
 
@@ -10,6 +11,10 @@ var onceNsfwDetector sync.Once
 
 func initNsfwDetector() {
 	services.Nsfw = nsfw.New(conf.NSFWModelPath())
+
+	if url := conf.NSFWUrl(); url != "" {
+		services.Nsfw.WithBackend(inference.NewHttp(url))
+	}
 }
 
 func NsfwDetector() *nsfw.Detector {
@@ -6,12 +6,6 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
-type Saved struct {
-	User       string   `json:"user"`
-	Tokens     []string `json:"tokens"`
-	Expiration int64    `json:"expiration"`
-}
-
 // UIDs represents a slice of unique ID strings.
 type UIDs []string
 
@@ -29,10 +23,19 @@ type Data struct {
 	User   entity.User `json:"user"`   // Session user, guest or anonymous person.
 	Tokens []string    `json:"tokens"` // Slice of secret share tokens.
 	Shares UIDs        `json:"shares"` // Slice of shared entity UIDs.
+
+	AccessToken *entity.Token `json:"-"` // Personal access token, if any, that restricts permissions.
+
+	UID        string `json:"-"` // Public session identifier, e.g. for revoking it.
+	AuthMethod string `json:"-"` // Login method, e.g. password or 2fa.
+	ClientIP   string `json:"-"` // Client IP address.
+	UserAgent  string `json:"-"` // Client user agent.
 }
 
-func (s Data) Saved() Saved {
-	return Saved{User: s.User.UserUID, Tokens: s.Tokens}
+// SetClient sets the client IP and user agent that are stored with the session.
+func (s *Data) SetClient(ip, userAgent string) {
+	s.ClientIP = ip
+	s.UserAgent = userAgent
 }
 
 func (s Data) Invalid() bool {
@@ -51,6 +54,11 @@ func (s Data) NoShares() bool {
 	return len(s.Shares) == 0
 }
 
+// Restricted checks if the session was created with a personal access token.
+func (s Data) Restricted() bool {
+	return s.AccessToken != nil
+}
+
 func (s Data) HasShare(uid string) bool {
 	for _, share := range s.Shares {
 		if share == uid {
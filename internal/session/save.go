@@ -3,98 +3,78 @@ package session
 import (
 	"encoding/json"
 	"os"
-	"path"
-	"sync"
+	"path/filepath"
 	"time"
 
-	gc "github.com/patrickmn/go-cache"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
 const cacheFileName = "sessions.json"
 
-var fileMutex sync.RWMutex
+// saved represents a session stored in the JSON file used by previous versions.
+type saved struct {
+	User       string   `json:"user"`
+	Tokens     []string `json:"tokens"`
+	Expiration int64    `json:"expiration"`
+}
 
-// New returns a new session store with an optional cachePath.
+// New returns a new session store, sessions found in the cache path are moved to the database.
 func New(expiration time.Duration, cachePath string) *Session {
-	s := &Session{}
+	s := &Session{expiration: expiration}
 
-	cleanupInterval := 15 * time.Minute
+	if n, err := entity.DeleteExpiredSessions(); err != nil {
+		log.Errorf("session: %s (delete expired)", err)
+	} else if n > 0 {
+		log.Debugf("session: deleted %d expired sessions", n)
+	}
 
 	if cachePath != "" {
-		fileMutex.RLock()
-		defer fileMutex.RUnlock()
-
-		var savedItems map[string]Saved
-
-		items := make(map[string]gc.Item)
-		s.cacheFile = path.Join(cachePath, cacheFileName)
-
-		if cached, err := os.ReadFile(s.cacheFile); err != nil {
-			log.Debugf("session: %s", err)
-		} else if err := json.Unmarshal(cached, &savedItems); err != nil {
-			log.Errorf("session: %s", err)
-		} else {
-			for key, saved := range savedItems {
-				user := entity.FindUserByUID(saved.User)
-
-				if user == nil {
-					continue
-				}
-
-				var tokens []string
-				var shared []string
-
-				for _, token := range saved.Tokens {
-					links := entity.FindValidLinks(token, "")
-
-					if len(links) > 0 {
-						for _, link := range links {
-							shared = append(shared, link.LinkUID)
-						}
+		s.migrate(filepath.Join(cachePath, cacheFileName))
+	}
 
-						tokens = append(tokens, token)
-					}
-				}
+	return s
+}
 
-				data := Data{User: *user, Tokens: tokens, Shares: shared}
-				items[key] = gc.Item{Expiration: saved.Expiration, Object: data}
-			}
+// migrate moves sessions from a JSON file to the database and removes the file.
+func (s *Session) migrate(fileName string) {
+	data, err := os.ReadFile(fileName)
 
-			s.cache = gc.NewFrom(expiration, cleanupInterval, items)
-		}
+	if err != nil {
+		return
 	}
 
-	if s.cache == nil {
-		s.cache = gc.New(expiration, cleanupInterval)
+	var items map[string]saved
+
+	if err = json.Unmarshal(data, &items); err != nil {
+		log.Errorf("session: %s", err)
+		return
 	}
 
-	return s
-}
+	for id, item := range items {
+		user := entity.FindUserByUID(item.User)
 
-// Save stores all sessions in a JSON file.
-func (s *Session) Save() error {
-	if s.cacheFile == "" {
-		return nil
-	}
+		if user == nil {
+			continue
+		}
 
-	fileMutex.Lock()
-	defer fileMutex.Unlock()
+		m := entity.NewSession(id, s.expiration)
+		m.SetUser(user)
+		m.SetTokens(item.Tokens)
 
-	items := s.cache.Items()
-	savedItems := make(map[string]Saved, len(items))
+		if item.Expiration > 0 {
+			m.ExpiresAt = time.Unix(0, item.Expiration).UTC()
+		}
 
-	for key, item := range items {
-		saved := item.Object.(Data).Saved()
-		saved.Expiration = item.Expiration
-		savedItems[key] = saved
+		if m.Expired() {
+			continue
+		} else if err = m.Create(); err != nil {
+			log.Errorf("session: %s (migrate)", err)
+		}
 	}
 
-	if serialized, err := json.MarshalIndent(savedItems, "", " "); err != nil {
-		return err
-	} else if err = os.WriteFile(s.cacheFile, serialized, 0600); err != nil {
-		return err
+	if err = os.Remove(fileName); err != nil {
+		log.Errorf("session: %s", err)
+	} else {
+		log.Infof("session: moved %d sessions to the database", len(items))
 	}
-
-	return nil
 }
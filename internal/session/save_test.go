@@ -0,0 +1,36 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Migrate", func(t *testing.T) {
+		dir := t.TempDir()
+		fileName := filepath.Join(dir, cacheFileName)
+		id := NewID()
+		expired := NewID()
+		expiration := time.Now().Add(time.Hour).UnixNano()
+		data := fmt.Sprintf(`{"%s": {"user": "%s", "tokens": [], "expiration": %d}, "%s": {"user": "%s", "tokens": [], "expiration": 1}}`,
+			id, entity.Admin.UserUID, expiration, expired, entity.Admin.UserUID)
+
+		if err := os.WriteFile(fileName, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		s := New(time.Hour, dir)
+
+		assert.True(t, s.Exists(id))
+		assert.False(t, s.Exists(expired))
+		assert.Equal(t, entity.Admin.UserUID, s.Get(id).User.UserUID)
+		assert.NoFileExists(t, fileName)
+	})
+}
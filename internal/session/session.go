@@ -25,14 +25,14 @@ Additional information can be found in our Developer Guide:
 package session
 
 import (
-	gc "github.com/patrickmn/go-cache"
+	"time"
+
 	"github.com/photoprism/photoprism/internal/event"
 )
 
 var log = event.Log
 
-// Session represents a session store.
+// Session represents a session store backed by the database, so that sessions can be shared by multiple instances.
 type Session struct {
-	cacheFile string
-	cache     *gc.Cache
+	expiration time.Duration
 }
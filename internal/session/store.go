@@ -3,20 +3,26 @@ package session
 import (
 	"fmt"
 
-	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
-// Create creates a new user session.
-func (s *Session) Create(data Data) string {
+// Create creates a new user session and returns its id, the id is empty if the session could not be stored.
+func (s *Session) Create(data Data) (string, error) {
 	id := NewID()
-	s.cache.Set(id, data, gc.DefaultExpiration)
-	log.Debugf("session: created")
 
-	if err := s.Save(); err != nil {
-		log.Errorf("session: %s (create)", err)
+	m := entity.NewSession(id, s.expiration)
+	m.SetUser(&data.User)
+	m.SetTokens(data.Tokens)
+	m.SetClient(data.ClientIP, data.UserAgent)
+	m.AuthMethod = data.AuthMethod
+
+	if err := m.Create(); err != nil {
+		return "", fmt.Errorf("session: %s (create)", err)
 	}
 
-	return id
+	log.Debugf("session: created")
+
+	return id, nil
 }
 
 // Update updates the data of an existing user session.
@@ -25,47 +31,91 @@ func (s *Session) Update(id string, data Data) error {
 		return fmt.Errorf("session: empty id")
 	}
 
-	if _, found := s.cache.Get(id); !found {
+	m := entity.FindSession(id)
+
+	if m == nil {
 		return fmt.Errorf("session: %s not found (update)", id)
 	}
 
-	s.cache.Set(id, data, gc.DefaultExpiration)
+	m.SetUser(&data.User)
+	m.SetTokens(data.Tokens)
+	m.ExpiresAt = entity.TimeStamp().Add(s.expiration)
 
-	log.Debugf("session: updated")
+	if data.AuthMethod != "" {
+		m.AuthMethod = data.AuthMethod
+	}
 
-	if err := s.Save(); err != nil {
-		log.Errorf("session: %s (update)", err)
+	if data.ClientIP != "" || data.UserAgent != "" {
+		m.SetClient(data.ClientIP, data.UserAgent)
 	}
 
+	if err := m.Save(); err != nil {
+		return fmt.Errorf("session: %s (update)", err)
+	}
+
+	log.Debugf("session: updated")
+
 	return nil
 }
 
 // Delete deletes an existing user session.
 func (s *Session) Delete(id string) {
-	s.cache.Delete(id)
-	log.Debugf("session: deleted")
-
-	if err := s.Save(); err != nil {
+	if m := entity.FindSession(id); m == nil {
+		return
+	} else if err := m.Delete(); err != nil {
 		log.Errorf("session: %s (delete)", err)
+	} else {
+		log.Debugf("session: deleted")
 	}
 }
 
 // Get returns the data of an existing user session.
 func (s *Session) Get(id string) Data {
-	if id == "" {
+	m := entity.FindSession(id)
+
+	if m == nil {
 		return Data{}
 	}
 
-	if hit, ok := s.cache.Get(id); ok {
-		return hit.(Data)
+	user := entity.FindUserByUID(m.UserUID)
+
+	if user == nil || user.Deleted() {
+		if err := m.Delete(); err != nil {
+			log.Errorf("session: %s (delete)", err)
+		}
+
+		return Data{}
 	}
 
-	return Data{}
+	data := Data{
+		User:       *user,
+		UID:        m.SessUID,
+		AuthMethod: m.AuthMethod,
+		ClientIP:   m.ClientIP,
+		UserAgent:  m.UserAgent,
+	}
+
+	// Only share tokens with valid links are restored.
+	for _, token := range m.Tokens() {
+		links := entity.FindValidLinks(token, "")
+
+		if len(links) == 0 {
+			continue
+		}
+
+		for _, link := range links {
+			data.Shares = append(data.Shares, link.ShareUID)
+		}
+
+		data.Tokens = append(data.Tokens, token)
+	}
+
+	m.UpdateActivity()
+
+	return data
 }
 
 // Exists tests of a user session with the given id exists.
 func (s *Session) Exists(id string) bool {
-	_, found := s.cache.Get(id)
-
-	return found
+	return entity.FindSession(id) != nil
 }
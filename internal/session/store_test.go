@@ -9,19 +9,39 @@ import (
 )
 
 func TestSession_Create(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour, "")
 
 	data := Data{
-		User: entity.Admin,
+		User:       entity.Admin,
+		AuthMethod: entity.AuthMethodPassword,
+	}
+
+	data.SetClient("127.0.0.1", "Go-http-client/1.1")
+
+	id, err := s.Create(data)
+
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	id := s.Create(data)
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
+
+	m := entity.FindSession(id)
+
+	if m == nil {
+		t.Fatal("session not found")
+	}
+
+	assert.Equal(t, entity.Admin.UserUID, m.UserUID)
+	assert.Equal(t, entity.AuthMethodPassword, m.AuthMethod)
+	assert.Equal(t, "127.0.0.1", m.ClientIP)
+	assert.Equal(t, "Go-http-client/1.1", m.UserAgent)
+	assert.NotEqual(t, id, m.ID)
 }
 
 func TestSession_Update(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour, "")
 
 	data := Data{
 		User: entity.Admin,
@@ -38,7 +58,12 @@ func TestSession_Update(t *testing.T) {
 		t.Fatalf("update should fail for unknown session id %s", id)
 	}
 
-	newId := s.Create(data)
+	newId, err := s.Create(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	assert.Equal(t, 48, len(newId))
 
 	cachedData := s.Get(newId)
@@ -47,11 +72,11 @@ func TestSession_Update(t *testing.T) {
 		t.Fatalf("session %s should exist", newId)
 	}
 
-	assert.Equal(t, cachedData, data)
+	assert.Equal(t, data.User.UserUID, cachedData.User.UserUID)
 
 	newData := Data{
 		User:   entity.Guest,
-		Shares: UIDs{"a000000000000001"},
+		Tokens: []string{"1jxf3jfn2k"},
 	}
 
 	if err := s.Update(newId, newData); err != nil {
@@ -60,40 +85,53 @@ func TestSession_Update(t *testing.T) {
 
 	if cachedData := s.Get(newId); cachedData.Invalid() {
 		t.Fatalf("session %s should be valid", newId)
+	} else {
+		assert.Equal(t, entity.Guest.UserUID, cachedData.User.UserUID)
+		assert.Equal(t, UIDs{"at9lxuqxpogaaba8"}, cachedData.Shares)
 	}
 }
 
 func TestSession_UpdateError(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour, "")
 
 	data := Data{
 		User: entity.Admin,
 	}
 
-	id := s.Create(data)
+	id, err := s.Create(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 	newData := Data{
 		User:   entity.Guest,
 		Shares: UIDs{"a000000000000001"},
 	}
-	err := s.Update("", newData)
+	err = s.Update("", newData)
 	assert.Equal(t, "session: empty id", err.Error())
 }
 
 func TestSession_Delete(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour, "")
 	s.Delete("abc")
 }
 
 func TestSession_Get(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour, "")
 	data := Data{
 		User:   entity.Guest,
-		Shares: UIDs{"a000000000000001"},
+		Tokens: []string{"1jxf3jfn2k", "invalid"},
+	}
+
+	id, err := s.Create(data)
+
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	id := s.Create(data)
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 
@@ -105,7 +143,9 @@ func TestSession_Get(t *testing.T) {
 		t.Fatal("cachedData should be valid")
 	}
 
-	assert.Equal(t, data, cachedData)
+	assert.Equal(t, []string{"1jxf3jfn2k"}, cachedData.Tokens)
+	assert.Equal(t, UIDs{"at9lxuqxpogaaba8"}, cachedData.Shares)
+	assert.True(t, len(cachedData.UID) == 16)
 
 	s.Delete(id)
 
@@ -115,15 +155,32 @@ func TestSession_Get(t *testing.T) {
 }
 
 func TestSession_Exists(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour, "")
 	assert.False(t, s.Exists("xyz"))
 	data := Data{
 		User: entity.Guest,
 	}
-	id := s.Create(data)
+	id, err := s.Create(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 	assert.True(t, s.Exists(id))
 	s.Delete(id)
 	assert.False(t, s.Exists(id))
 }
+
+func TestSession_Expired(t *testing.T) {
+	s := New(-time.Minute, "")
+	id, err := s.Create(Data{User: entity.Admin})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, s.Exists(id))
+	assert.True(t, s.Get(id).Invalid())
+}
@@ -76,12 +76,13 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 	if fileName, err = FileName(hash, thumbPath, width, height, opts...); err != nil {
 		log.Debugf("thumb: %s in %s (get filename)", err, clean.Log(imageFilename))
 		return "", err
-	} else if fileName, err = fs.Resolve(fileName); err != nil {
-		return "", ErrNotCached
-	} else if fs.FileExists(fileName) {
+	} else if fileName, err = fs.Resolve(fileName); err == nil && fs.FileExists(fileName) {
+		cacheRequests.Inc("hit")
 		return fileName, nil
 	}
 
+	cacheRequests.Inc("miss")
+
 	return "", ErrNotCached
 }
 
@@ -0,0 +1,5 @@
+package thumb
+
+import "github.com/photoprism/photoprism/pkg/metrics"
+
+var cacheRequests = metrics.NewCounter("photoprism_thumb_cache_requests_total", "Number of thumbnail cache lookups by result.", "result")
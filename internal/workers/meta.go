@@ -31,11 +31,15 @@ func (m *Meta) originalsPath() string {
 
 // Start metadata optimization routine.
 func (m *Meta) Start(delay, interval time.Duration, force bool) (err error) {
+	var start time.Time
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("metadata: %s (panic)\nstack: %s", r, debug.Stack())
 			log.Error(err)
 		}
+
+		observeRun("meta", start, err)
 	}()
 
 	if err := mutex.MetaWorker.Start(); err != nil {
@@ -44,6 +48,8 @@ func (m *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 
 	defer mutex.MetaWorker.Stop()
 
+	start = time.Now()
+
 	log.Debugf("metadata: running face recognition")
 
 	// Run faces worker.
@@ -0,0 +1,25 @@
+package workers
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/metrics"
+)
+
+var workerRuns = metrics.NewCounter("photoprism_worker_runs_total", "Number of background worker runs by outcome.", "worker", "outcome")
+var workerDuration = metrics.NewHistogram("photoprism_worker_duration_seconds", "Duration of background worker runs.", metrics.JobBuckets, "worker")
+
+// observeRun records the outcome and duration of a worker run, a zero start time means the worker was busy.
+func observeRun(worker string, start time.Time, err error) {
+	switch {
+	case start.IsZero():
+		workerRuns.Inc(worker, "skipped")
+		return
+	case err != nil:
+		workerRuns.Inc(worker, "error")
+	default:
+		workerRuns.Inc(worker, "success")
+	}
+
+	workerDuration.Since(start, worker)
+}
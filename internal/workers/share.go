@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime/debug"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 
@@ -38,11 +39,15 @@ func (worker *Share) logError(err error) {
 
 // Start starts the share worker.
 func (worker *Share) Start() (err error) {
+	var start time.Time
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("share: %s (panic)\nstack: %s", r, debug.Stack())
 			log.Error(err)
 		}
+
+		observeRun("share", start, err)
 	}()
 
 	if err := mutex.ShareWorker.Start(); err != nil {
@@ -51,6 +56,8 @@ func (worker *Share) Start() (err error) {
 
 	defer mutex.ShareWorker.Stop()
 
+	start = time.Now()
+
 	f := form.SearchAccounts{
 		Share: true,
 	}
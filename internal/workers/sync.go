@@ -42,11 +42,15 @@ func (worker *Sync) logWarn(err error) {
 
 // Start starts the sync worker.
 func (worker *Sync) Start() (err error) {
+	var start time.Time
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("sync: %s (panic)\nstack: %s", r, debug.Stack())
 			log.Error(err)
 		}
+
+		observeRun("sync", start, err)
 	}()
 
 	if err := mutex.SyncWorker.Start(); err != nil {
@@ -55,6 +59,8 @@ func (worker *Sync) Start() (err error) {
 
 	defer mutex.SyncWorker.Stop()
 
+	start = time.Now()
+
 	f := form.SearchAccounts{
 		Sync: true,
 	}
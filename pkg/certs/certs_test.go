@@ -0,0 +1,131 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfSigned(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "certificates", "self-signed.crt")
+	keyFile := filepath.Join(dir, "certificates", "self-signed.key")
+
+	t.Run("NoHosts", func(t *testing.T) {
+		assert.Error(t, SelfSigned(certFile, keyFile, nil))
+	})
+	t.Run("Create", func(t *testing.T) {
+		if err := SelfSigned(certFile, keyFile, []string{"photos.local", "localhost", "127.0.0.1"}); err != nil {
+			t.Fatal(err)
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.local", leaf.Subject.CommonName)
+		assert.Equal(t, []string{"photos.local", "localhost"}, leaf.DNSNames)
+		assert.Len(t, leaf.IPAddresses, 1)
+		assert.NoError(t, leaf.VerifyHostname("localhost"))
+		assert.NoError(t, leaf.VerifyHostname("127.0.0.1"))
+
+		if info, err := os.Stat(keyFile); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	})
+	t.Run("Keep", func(t *testing.T) {
+		before, _ := os.ReadFile(certFile)
+
+		if err := SelfSigned(certFile, keyFile, []string{"photos.local"}); err != nil {
+			t.Fatal(err)
+		}
+
+		after, _ := os.ReadFile(certFile)
+
+		assert.Equal(t, before, after)
+	})
+}
+
+func TestLoader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewLoader(certFile, keyFile)
+		assert.Error(t, err)
+	})
+	t.Run("Reload", func(t *testing.T) {
+		if err := SelfSigned(certFile, keyFile, []string{"first.local"}); err != nil {
+			t.Fatal(err)
+		}
+
+		l, err := NewLoader(certFile, keyFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "first.local", commonName(t, l))
+
+		reloaded, err := l.Reload()
+		assert.NoError(t, err)
+		assert.False(t, reloaded)
+
+		// Replace certificate and make sure the modification time changes.
+		_ = os.Remove(certFile)
+
+		if err = SelfSigned(certFile, keyFile, []string{"second.local"}); err != nil {
+			t.Fatal(err)
+		}
+
+		later := time.Now().Add(time.Minute)
+		_ = os.Chtimes(certFile, later, later)
+
+		reloaded, err = l.Reload()
+		assert.NoError(t, err)
+		assert.True(t, reloaded)
+		assert.Equal(t, "second.local", commonName(t, l))
+
+		// Invalid files are ignored.
+		_ = os.WriteFile(certFile, []byte("invalid"), 0644)
+		later = later.Add(time.Minute)
+		_ = os.Chtimes(certFile, later, later)
+
+		reloaded, err = l.Reload()
+		assert.Error(t, err)
+		assert.False(t, reloaded)
+		assert.Equal(t, "second.local", commonName(t, l))
+	})
+}
+
+func commonName(t *testing.T, l *Loader) string {
+	cert, err := l.GetCertificate(nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return leaf.Subject.CommonName
+}
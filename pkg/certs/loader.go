@@ -0,0 +1,76 @@
+package certs
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// Loader provides the certificate from a pair of files and reloads it when the files change,
+// e.g. after they have been renewed by an external tool.
+type Loader struct {
+	certFile string
+	keyFile  string
+	mutex    sync.RWMutex
+	cert     *tls.Certificate
+	modTime  time.Time
+}
+
+// NewLoader loads the certificate and returns a new loader, or an error if the files are invalid.
+func NewLoader(certFile, keyFile string) (*Loader, error) {
+	l := &Loader{certFile: certFile, keyFile: keyFile}
+
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// GetCertificate returns the current certificate, it can be used as tls.Config.GetCertificate callback.
+func (l *Loader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.cert, nil
+}
+
+// Reload loads the certificate again if the files have changed since they were last loaded.
+// The current certificate is kept if the new files are invalid, e.g. because they are still being written.
+func (l *Loader) Reload() (reloaded bool, err error) {
+	modTime := l.lastModified()
+
+	l.mutex.RLock()
+	changed := l.cert == nil || modTime.After(l.modTime)
+	l.mutex.RUnlock()
+
+	if !changed {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+
+	if err != nil {
+		return false, err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.cert = &cert
+	l.modTime = modTime
+
+	return true, nil
+}
+
+// lastModified returns the most recent modification time of the certificate and key files.
+func (l *Loader) lastModified() (result time.Time) {
+	for _, fileName := range []string{l.certFile, l.keyFile} {
+		if info, err := os.Stat(fileName); err == nil && info.ModTime().After(result) {
+			result = info.ModTime()
+		}
+	}
+
+	return result
+}
@@ -1,6 +1,8 @@
 package clean
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 )
@@ -55,3 +57,15 @@ func LogQuote(s string) string {
 func LogLower(s string) string {
 	return Log(strings.ToLower(s))
 }
+
+// LogHash returns a short hash of a sensitive string, e.g. a login name that may contain a mistyped
+// password, so that it can be logged without revealing its value.
+func LogHash(s string) string {
+	if s == "" {
+		return "''"
+	}
+
+	h := sha256.Sum256([]byte(s))
+
+	return Log("sha256:" + hex.EncodeToString(h[:6]))
+}
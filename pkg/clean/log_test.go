@@ -44,3 +44,16 @@ func TestLogLower(t *testing.T) {
 		assert.Equal(t, "?", LogLower("User-Agent: ${jndi:ldap://<host>:<port>/<path>}"))
 	})
 }
+
+func TestLogHash(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "''", LogHash(""))
+	})
+	t.Run("Password", func(t *testing.T) {
+		result := LogHash("Secret123!")
+		assert.Equal(t, LogHash("Secret123!"), result)
+		assert.NotContains(t, result, "Secret")
+		assert.Len(t, result, 19)
+		assert.NotEqual(t, LogHash("secret123!"), result)
+	})
+}
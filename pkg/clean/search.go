@@ -15,12 +15,20 @@ func replace(subject string, search string, replace string) string {
 	return regexp.MustCompile("(?i)"+search).ReplaceAllString(subject, replace)
 }
 
+// compareRegexp matches numeric comparisons, e.g. ">=3".
+var compareRegexp = regexp.MustCompile(`^[<>]=?-?\d+$`)
+
 // SearchString replaces search operator with default symbols.
 func SearchString(s string) string {
 	if s == "" || reject(s, MaxLength) {
 		return Empty
 	}
 
+	// Keep numeric comparisons, e.g. for "rating:>=3".
+	if compareRegexp.MatchString(s) {
+		return s
+	}
+
 	// Normalize.
 	s = strings.ReplaceAll(s, "%%", "%")
 	s = strings.ReplaceAll(s, "%", "*")
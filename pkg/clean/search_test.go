@@ -19,6 +19,12 @@ func TestSearchString(t *testing.T) {
 		q := SearchString(" Flowers in the Park ")
 		assert.Equal(t, " Flowers in the Park ", q)
 	})
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, ">=3", SearchString(">=3"))
+		assert.Equal(t, "<-1", SearchString("<-1"))
+		assert.Equal(t, "=3", SearchString(">=3>"))
+		assert.Equal(t, "foo", SearchString("<foo>"))
+	})
 }
 
 func TestSearchQuery(t *testing.T) {
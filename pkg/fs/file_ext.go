@@ -9,6 +9,7 @@ const (
 	ExtYAML = ".yml"
 	ExtJPEG = ".jpg"
 	ExtAVC  = ".avc"
+	ExtXMP  = ".xmp"
 )
 
 // Ext returns all extension of a file name including the dots.
@@ -104,6 +104,9 @@ var Extensions = FileExtensions{
 	".wmv":      VideoWMV,
 	".xmp":      XmpFile,
 	".aae":      AaeFile,
+	".gpx":      GpxFile,
+	".kml":      KmlFile,
+	".geojson":  GeoJsonFile,
 	".xml":      XmlFile,
 	".yml":      YamlFile,
 	".yaml":     YamlFile,
@@ -49,3 +49,10 @@ const (
 	MarkdownFile Type = "md"   // Markdown text sidecar file.
 	UnknownType  Type = ""     // Unknown file type.
 )
+
+// Track log file types.
+const (
+	GpxFile     Type = "gpx"     // GPS Exchange Format (XML).
+	KmlFile     Type = "kml"     // Keyhole Markup Language (XML).
+	GeoJsonFile Type = "geojson" // GeoJSON feature collection (JSON).
+)
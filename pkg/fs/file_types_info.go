@@ -32,6 +32,9 @@ var TypeInfo = map[Type]string{
 	VideoOGV:     "Ogg Media (OGG)",
 	XmpFile:      "Adobe Extensible Metadata Platform",
 	AaeFile:      "Apple Image Edits XML",
+	GpxFile:      "GPS Exchange Format (GPX)",
+	KmlFile:      "Keyhole Markup Language (KML)",
+	GeoJsonFile:  "GeoJSON",
 	XmlFile:      "Extensible Markup Language",
 	JsonFile:     "Serialized JSON Data (Exiftool, Google Photos)",
 	YamlFile:     "Serialized YAML Data (Config, Metadata)",
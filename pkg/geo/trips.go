@@ -0,0 +1,147 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// TripOptions represents trip detection options.
+type TripOptions struct {
+	Home    Position      // Home location.
+	MinKm   float64       // Min distance from home in km.
+	MaxGap  time.Duration // Max time between two positions of the same trip.
+	MinSize int           // Min number of positions per trip.
+}
+
+// Trip represents a journey away from home, i.e. the positions with index Start to End-1.
+type Trip struct {
+	Start     int
+	End       int
+	Positions []Position
+}
+
+// Trips returns consecutive positions away from home, separated by positions close to home or
+// time gaps. The positions must be sorted by time.
+func Trips(positions []Position, opt TripOptions) (result []Trip) {
+	var cur *Trip
+	var last time.Time
+
+	done := func() {
+		if cur != nil && cur.End-cur.Start >= opt.MinSize {
+			cur.Positions = positions[cur.Start:cur.End]
+			result = append(result, *cur)
+		}
+
+		cur = nil
+	}
+
+	for i, p := range positions {
+		// Ends trips when back home.
+		if Km(opt.Home, p) < opt.MinKm {
+			done()
+			continue
+		}
+
+		// Ends trips after a long time without positions.
+		if cur != nil && opt.MaxGap > 0 && p.Time.Sub(last) > opt.MaxGap {
+			done()
+		}
+
+		if cur == nil {
+			cur = &Trip{Start: i}
+		}
+
+		cur.End = i + 1
+		last = p.Time
+	}
+
+	done()
+
+	return result
+}
+
+// StartTime returns the time of the first position.
+func (t Trip) StartTime() time.Time {
+	if len(t.Positions) == 0 {
+		return time.Time{}
+	}
+
+	return t.Positions[0].Time
+}
+
+// EndTime returns the time of the last position.
+func (t Trip) EndTime() time.Time {
+	if len(t.Positions) == 0 {
+		return time.Time{}
+	}
+
+	return t.Positions[len(t.Positions)-1].Time
+}
+
+// Km returns the approximate travel distance in km.
+func (t Trip) Km() (km float64) {
+	for i := 1; i < len(t.Positions); i++ {
+		m := NewMovement(t.Positions[i-1], t.Positions[i])
+		km += m.Km()
+	}
+
+	return km
+}
+
+// Route returns up to max positions, including the first and last position.
+func (t Trip) Route(max int) []Position {
+	n := len(t.Positions)
+
+	if max < 2 || n <= max {
+		return t.Positions
+	}
+
+	result := make([]Position, max)
+	step := float64(n-1) / float64(max-1)
+
+	for i := range result {
+		result[i] = t.Positions[int(math.Round(float64(i)*step))]
+	}
+
+	return result
+}
+
+// EstimateHome returns the average of the positions in the most frequent grid cell of about 10 km.
+func EstimateHome(positions []Position) (home Position) {
+	type cell struct {
+		lat, lng int
+	}
+
+	counts := make(map[cell][]Position)
+	var best cell
+
+	for _, p := range positions {
+		if p.Lat == 0 && p.Lng == 0 {
+			continue
+		}
+
+		c := cell{lat: int(math.Floor(p.Lat * 10)), lng: int(math.Floor(p.Lng * 10))}
+		counts[c] = append(counts[c], p)
+
+		if len(counts[c]) > len(counts[best]) {
+			best = c
+		}
+	}
+
+	found := counts[best]
+
+	if len(found) == 0 {
+		return home
+	}
+
+	for _, p := range found {
+		home.Lat += p.Lat
+		home.Lng += p.Lng
+	}
+
+	home.Name = "home"
+	home.Lat = home.Lat / float64(len(found))
+	home.Lng = home.Lng / float64(len(found))
+
+	return home
+}
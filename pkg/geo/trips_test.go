@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrips(t *testing.T) {
+	home := Position{Lat: 52.52, Lng: 13.40}
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	positions := []Position{
+		{Time: start, Lat: 52.52, Lng: 13.41},                         // Berlin
+		{Time: start.Add(1 * day), Lat: 48.85, Lng: 2.35},             // Paris
+		{Time: start.Add(2 * day), Lat: 45.76, Lng: 4.83},             // Lyon
+		{Time: start.Add(3 * day), Lat: 43.30, Lng: 5.37},             // Marseille
+		{Time: start.Add(4 * day), Lat: 52.51, Lng: 13.39},            // Berlin
+		{Time: start.Add(30 * day), Lat: 41.90, Lng: 12.49},           // Rome
+		{Time: start.Add(31 * day), Lat: 43.77, Lng: 11.25},           // Florence
+		{Time: start.Add(40 * day), Lat: 45.44, Lng: 12.33},           // Venice
+		{Time: start.Add(40*day + time.Hour), Lat: 45.43, Lng: 12.34}, // Venice
+		{Time: start.Add(41 * day), Lat: 48.14, Lng: 11.58},           // Munich
+	}
+
+	t.Run("Gaps", func(t *testing.T) {
+		result := Trips(positions, TripOptions{Home: home, MinKm: 100, MaxGap: 3 * day, MinSize: 2})
+
+		if len(result) != 3 {
+			t.Fatalf("expected 3 trips, found %d", len(result))
+		}
+
+		assert.Equal(t, 1, result[0].Start)
+		assert.Equal(t, 4, result[0].End)
+		assert.Len(t, result[0].Positions, 3)
+		assert.Equal(t, start.Add(day), result[0].StartTime())
+		assert.Equal(t, start.Add(3*day), result[0].EndTime())
+		assert.InDelta(t, 700, result[0].Km(), 100)
+
+		assert.Equal(t, 5, result[1].Start)
+		assert.Equal(t, 7, result[1].End)
+
+		assert.Equal(t, 7, result[2].Start)
+		assert.Equal(t, 10, result[2].End)
+	})
+	t.Run("MinSize", func(t *testing.T) {
+		result := Trips(positions, TripOptions{Home: home, MinKm: 100, MaxGap: 3 * day, MinSize: 3})
+
+		assert.Len(t, result, 2)
+	})
+	t.Run("NoGap", func(t *testing.T) {
+		result := Trips(positions, TripOptions{Home: home, MinKm: 100, MinSize: 1})
+
+		assert.Len(t, result, 2)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Len(t, Trips(nil, TripOptions{Home: home, MinKm: 100}), 0)
+	})
+}
+
+func TestTrip_Route(t *testing.T) {
+	trip := Trip{Positions: make([]Position, 10)}
+
+	for i := range trip.Positions {
+		trip.Positions[i].Lat = float64(i)
+	}
+
+	route := trip.Route(4)
+
+	assert.Len(t, route, 4)
+	assert.Equal(t, float64(0), route[0].Lat)
+	assert.Equal(t, float64(3), route[1].Lat)
+	assert.Equal(t, float64(9), route[3].Lat)
+	assert.Len(t, trip.Route(20), 10)
+	assert.Len(t, trip.Route(0), 10)
+}
+
+func TestEstimateHome(t *testing.T) {
+	t.Run("Berlin", func(t *testing.T) {
+		home := EstimateHome([]Position{
+			{Lat: 52.521, Lng: 13.401},
+			{Lat: 48.85, Lng: 2.35},
+			{Lat: 52.523, Lng: 13.403},
+			{},
+		})
+
+		assert.Equal(t, "home", home.Name)
+		assert.InDelta(t, 52.522, home.Lat, 0.0001)
+		assert.InDelta(t, 13.402, home.Lng, 0.0001)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, Position{}, EstimateHome(nil))
+	})
+}
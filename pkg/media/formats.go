@@ -35,6 +35,9 @@ var Formats = map[fs.Type]Type{
 	fs.XmpFile:      Sidecar,
 	fs.XmlFile:      Sidecar,
 	fs.AaeFile:      Sidecar,
+	fs.GpxFile:      Sidecar,
+	fs.KmlFile:      Sidecar,
+	fs.GeoJsonFile:  Sidecar,
 	fs.YamlFile:     Sidecar,
 	fs.TextFile:     Sidecar,
 	fs.JsonFile:     Sidecar,
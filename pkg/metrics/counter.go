@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Counter represents a cumulative metric that only increases, optionally partitioned by labels.
+type Counter struct {
+	vec
+	mutex  sync.Mutex
+	values map[string]float64
+}
+
+// NewCounter creates a counter and adds it to the default registry.
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{vec: vec{name: name, help: help, labels: labels}, values: make(map[string]float64)}
+	Default.Register(c)
+	return c
+}
+
+// Inc increments the counter for the label values by one.
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add increments the counter for the label values, negative values are ignored.
+func (c *Counter) Add(v float64, values ...string) {
+	if v < 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[c.key(values)] += v
+}
+
+// Value returns the current value for the label values.
+func (c *Counter) Value(values ...string) float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.values[c.key(values)]
+}
+
+// Write writes the counter in the text exposition format.
+func (c *Counter) Write(w io.Writer) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return writeSamples(w, c.vec, "counter", c.values)
+}
+
+// writeSamples writes the header and one sample per label set.
+func writeSamples(w io.Writer, v vec, kind string, values map[string]float64) error {
+	if err := v.header(w, kind); err != nil {
+		return err
+	}
+
+	keys := make(map[string]struct{}, len(values))
+
+	for k := range values {
+		keys[k] = struct{}{}
+	}
+
+	for _, k := range sortedKeys(keys) {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", v.name, v.labelString(k), formatFloat(values[k])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
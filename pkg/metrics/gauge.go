@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"io"
+	"sync"
+)
+
+// Gauge represents a metric that can go up and down, optionally partitioned by labels.
+type Gauge struct {
+	vec
+	mutex  sync.Mutex
+	values map[string]float64
+}
+
+// NewGauge creates a gauge and adds it to the default registry.
+func NewGauge(name, help string, labels ...string) *Gauge {
+	g := &Gauge{vec: vec{name: name, help: help, labels: labels}, values: make(map[string]float64)}
+	Default.Register(g)
+	return g
+}
+
+// Set sets the gauge for the label values.
+func (g *Gauge) Set(v float64, values ...string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.values[g.key(values)] = v
+}
+
+// Value returns the current value for the label values.
+func (g *Gauge) Value(values ...string) float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.values[g.key(values)]
+}
+
+// Write writes the gauge in the text exposition format.
+func (g *Gauge) Write(w io.Writer) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return writeSamples(w, g.vec, "gauge", g.values)
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds suitable for request and inference durations.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// JobBuckets are the upper bounds in seconds suitable for background jobs that may run for hours.
+var JobBuckets = []float64{1, 5, 15, 60, 300, 900, 1800, 3600, 7200, 14400}
+
+// Histogram samples observations in configurable buckets, optionally partitioned by labels.
+type Histogram struct {
+	vec
+	buckets []float64
+	mutex   sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+// histogramSeries stores the observations of a single label set.
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram creates a histogram and adds it to the default registry, DefaultBuckets are used if buckets is empty.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	b := make([]float64, len(buckets))
+	copy(b, buckets)
+	sort.Float64s(b)
+
+	h := &Histogram{vec: vec{name: name, help: help, labels: labels}, buckets: b, series: make(map[string]*histogramSeries)}
+	Default.Register(h)
+	return h
+}
+
+// Observe adds an observation for the label values.
+func (h *Histogram) Observe(v float64, values ...string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	k := h.key(values)
+	s, ok := h.series[k]
+
+	if !ok {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[k] = s
+	}
+
+	for i, upper := range h.buckets {
+		if v <= upper {
+			s.counts[i]++
+		}
+	}
+
+	s.count++
+	s.sum += v
+}
+
+// Since observes the number of seconds elapsed since start.
+func (h *Histogram) Since(start time.Time, values ...string) {
+	h.Observe(time.Since(start).Seconds(), values...)
+}
+
+// Count returns the number of observations for the label values.
+func (h *Histogram) Count(values ...string) uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if s, ok := h.series[h.key(values)]; ok {
+		return s.count
+	}
+
+	return 0
+}
+
+// Write writes the histogram in the text exposition format.
+func (h *Histogram) Write(w io.Writer) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.header(w, "histogram"); err != nil {
+		return err
+	}
+
+	keys := make(map[string]struct{}, len(h.series))
+
+	for k := range h.series {
+		keys[k] = struct{}{}
+	}
+
+	for _, k := range sortedKeys(keys) {
+		s := h.series[k]
+
+		for i, upper := range h.buckets {
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(k, "le", formatFloat(upper)), s.counts[i]); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(k, "le", "+Inf"), s.count); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "%s_sum%s %s\n%s_count%s %d\n", h.name, h.labelString(k), formatFloat(s.sum), h.name, h.labelString(k), s.count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
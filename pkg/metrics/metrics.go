@@ -0,0 +1,203 @@
+/*
+Package metrics provides counters, gauges and histograms in the Prometheus text exposition format.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric writes its samples in the text exposition format.
+type Metric interface {
+	Name() string
+	Write(w io.Writer) error
+}
+
+// Registry represents a set of metrics that are exported together.
+type Registry struct {
+	mutex   sync.Mutex
+	metrics map[string]Metric
+}
+
+// Default is the registry used by NewCounter, NewGauge, and NewHistogram.
+var Default = NewRegistry()
+
+// NewRegistry returns a new, empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]Metric)}
+}
+
+// Register adds a metric to the registry, an existing metric with the same name is replaced.
+func (r *Registry) Register(m Metric) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.metrics[m.Name()] = m
+}
+
+// Write writes all metrics sorted by name.
+func (r *Registry) Write(w io.Writer) error {
+	r.mutex.Lock()
+
+	names := make([]string, 0, len(r.metrics))
+
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	list := make([]Metric, len(names))
+
+	for i, name := range names {
+		list[i] = r.metrics[name]
+	}
+
+	r.mutex.Unlock()
+
+	for _, m := range list {
+		if err := m.Write(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Text returns all metrics as string.
+func (r *Registry) Text() string {
+	var b bytes.Buffer
+
+	_ = r.Write(&b)
+
+	return b.String()
+}
+
+// vec stores the label names of a metric and maps label values to series keys.
+type vec struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// Name returns the metric name.
+func (v vec) Name() string {
+	return v.name
+}
+
+// key returns the series key for the label values, missing values are empty.
+func (v vec) key(values []string) string {
+	if len(values) > len(v.labels) {
+		values = values[:len(v.labels)]
+	}
+
+	return strings.Join(values, "\xff")
+}
+
+// header writes the HELP and TYPE lines.
+func (v vec) header(w io.Writer, kind string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, escapeHelp(v.help), v.name, kind)
+	return err
+}
+
+// labelString returns the label pairs of a series, including optional extra pairs.
+func (v vec) labelString(key string, extra ...string) string {
+	var values []string
+
+	if len(v.labels) > 0 {
+		values = strings.Split(key, "\xff")
+	}
+
+	var pairs []string
+
+	for i, l := range v.labels {
+		var value string
+
+		if i < len(values) {
+			value = values[i]
+		}
+
+		pairs = append(pairs, l+"=\""+escapeLabel(value)+"\"")
+	}
+
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"=\""+escapeLabel(extra[i+1])+"\"")
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// sortedKeys returns the map keys in ascending order.
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// formatFloat returns a sample value as string.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// escapeHelp escapes backslashes and line feeds in help texts.
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+// escapeLabel escapes backslashes, line feeds and double quotes in label values.
+func escapeLabel(s string) string {
+	return labelReplacer.Replace(s)
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	c := NewCounter("test_requests_total", "Number of test requests.", "method", "code")
+
+	c.Inc("GET", "200")
+	c.Inc("GET", "200")
+	c.Add(3, "POST", "400")
+	c.Add(-1, "POST", "400")
+
+	assert.Equal(t, float64(2), c.Value("GET", "200"))
+	assert.Equal(t, float64(3), c.Value("POST", "400"))
+	assert.Equal(t, float64(0), c.Value("PUT", "200"))
+
+	var b strings.Builder
+
+	if err := c.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# HELP test_requests_total Number of test requests.
+# TYPE test_requests_total counter
+test_requests_total{method="GET",code="200"} 2
+test_requests_total{method="POST",code="400"} 3
+`
+
+	assert.Equal(t, expected, b.String())
+}
+
+func TestGauge(t *testing.T) {
+	g := NewGauge("test_items", "Number of \"test\" items.\nSecond line.", "type")
+
+	g.Set(5, `photo\"s`)
+	g.Set(7, "videos")
+	g.Set(4, "videos")
+
+	assert.Equal(t, float64(4), g.Value("videos"))
+
+	text := Default.Text()
+
+	assert.Contains(t, text, "# HELP test_items Number of \"test\" items.\\nSecond line.\n# TYPE test_items gauge\n")
+	assert.Contains(t, text, `test_items{type="photo\\\"s"} 5`)
+	assert.Contains(t, text, `test_items{type="videos"} 4`)
+}
+
+func TestHistogram(t *testing.T) {
+	t.Run("Labels", func(t *testing.T) {
+		h := NewHistogram("test_duration_seconds", "Test duration.", []float64{1, 0.1}, "job")
+
+		h.Observe(0.05, "index")
+		h.Observe(0.5, "index")
+		h.Observe(2, "index")
+		h.Since(time.Now(), "import")
+
+		assert.Equal(t, uint64(3), h.Count("index"))
+		assert.Equal(t, uint64(1), h.Count("import"))
+		assert.Equal(t, uint64(0), h.Count("convert"))
+
+		var b strings.Builder
+
+		if err := h.Write(&b); err != nil {
+			t.Fatal(err)
+		}
+
+		text := b.String()
+
+		assert.Contains(t, text, "# TYPE test_duration_seconds histogram\n")
+		assert.Contains(t, text, `test_duration_seconds_bucket{job="index",le="0.1"} 1`)
+		assert.Contains(t, text, `test_duration_seconds_bucket{job="index",le="1"} 2`)
+		assert.Contains(t, text, `test_duration_seconds_bucket{job="index",le="+Inf"} 3`)
+		assert.Contains(t, text, `test_duration_seconds_sum{job="index"} 2.55`)
+		assert.Contains(t, text, `test_duration_seconds_count{job="index"} 3`)
+		assert.Contains(t, text, `test_duration_seconds_count{job="import"} 1`)
+	})
+	t.Run("NoLabels", func(t *testing.T) {
+		h := NewHistogram("test_plain_seconds", "Plain.", nil)
+
+		h.Observe(0.2)
+
+		var b strings.Builder
+
+		if err := h.Write(&b); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, b.String(), "test_plain_seconds_bucket{le=\"0.25\"} 1\n")
+		assert.Contains(t, b.String(), "test_plain_seconds_count 1\n")
+	})
+}
+
+func TestRegistry_Write(t *testing.T) {
+	r := NewRegistry()
+	b := &Counter{vec: vec{name: "b_total", help: "B."}, values: map[string]float64{"": 1}}
+	a := &Gauge{vec: vec{name: "a", help: "A."}, values: map[string]float64{"": 2}}
+
+	r.Register(b)
+	r.Register(a)
+
+	assert.Equal(t, "# HELP a A.\n# TYPE a gauge\na 2\n# HELP b_total B.\n# TYPE b_total counter\nb_total 1\n", r.Text())
+}
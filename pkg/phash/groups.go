@@ -0,0 +1,88 @@
+package phash
+
+// Groups returns the indexes of hashes that are within the maximum distance of each other,
+// directly or through other hashes. Only groups with at least two hashes are returned.
+//
+// Candidates are found by splitting the hashes into maxDist + 1 parts: if two hashes differ in
+// at most maxDist bits, at least one part must be identical, so not all pairs need to be compared.
+func Groups(hashes []Hash, maxDist int) (result [][]int) {
+	if len(hashes) < 2 {
+		return result
+	}
+
+	if maxDist < 0 {
+		maxDist = 0
+	} else if maxDist > 63 {
+		maxDist = 63
+	}
+
+	parent := make([]int, len(hashes))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+
+	find = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	union := func(a, b int) {
+		if ra, rb := find(a), find(b); ra != rb {
+			if ra < rb {
+				parent[rb] = ra
+			} else {
+				parent[ra] = rb
+			}
+		}
+	}
+
+	parts := maxDist + 1
+
+	for p := 0; p < parts; p++ {
+		from := p * 64 / parts
+		to := (p + 1) * 64 / parts
+		mask := (uint64(1)<<uint(to-from) - 1) << uint(from)
+
+		buckets := make(map[uint64][]int)
+
+		for i, h := range hashes {
+			k := uint64(h) & mask
+			buckets[k] = append(buckets[k], i)
+		}
+
+		for _, list := range buckets {
+			for i := 0; i < len(list); i++ {
+				for j := i + 1; j < len(list); j++ {
+					a, b := list[i], list[j]
+
+					if find(a) != find(b) && hashes[a].Distance(hashes[b]) <= maxDist {
+						union(a, b)
+					}
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+
+	for i := range hashes {
+		r := find(i)
+		groups[r] = append(groups[r], i)
+	}
+
+	// Keep the order of the first hash in each group.
+	for i := range hashes {
+		if g := groups[i]; len(g) > 1 {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,159 @@
+/*
+Package phash provides perceptual image hashes to find near-duplicate images, e.g. resized or re-compressed copies.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// sampleSize is the width and height of the grayscale image the hash is computed from.
+	sampleSize = 32
+	// hashSize is the width and height of the low frequency DCT coefficients used for the hash.
+	hashSize = 8
+)
+
+// Hash represents a 64-bit perceptual image hash.
+type Hash uint64
+
+// FromImage computes the DCT-based perceptual hash of an image, it is robust against
+// resizing, re-compression, and small color or brightness changes.
+func FromImage(img image.Image) Hash {
+	small := imaging.Resize(img, sampleSize, sampleSize, imaging.Box)
+
+	var pixels [sampleSize][sampleSize]float64
+
+	for y := 0; y < sampleSize; y++ {
+		for x := 0; x < sampleSize; x++ {
+			c := small.NRGBAAt(x, y)
+			pixels[y][x] = 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+		}
+	}
+
+	coeffs := dct(pixels)
+
+	values := make([]float64, 0, hashSize*hashSize)
+
+	for y := 0; y < hashSize; y++ {
+		for x := 0; x < hashSize; x++ {
+			values = append(values, coeffs[y][x])
+		}
+	}
+
+	// The median excludes the DC coefficient, which only reflects the average brightness.
+	sorted := make([]float64, len(values)-1)
+	copy(sorted, values[1:])
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var h Hash
+
+	for i, v := range values {
+		if v > median {
+			h |= 1 << uint(len(values)-1-i)
+		}
+	}
+
+	return h
+}
+
+// dct returns the low frequency coefficients of the two-dimensional discrete cosine transform.
+func dct(pixels [sampleSize][sampleSize]float64) (result [hashSize][hashSize]float64) {
+	var rows [sampleSize][hashSize]float64
+
+	for y := 0; y < sampleSize; y++ {
+		for u := 0; u < hashSize; u++ {
+			var sum float64
+
+			for x := 0; x < sampleSize; x++ {
+				sum += pixels[y][x] * cosines[u][x]
+			}
+
+			rows[y][u] = sum
+		}
+	}
+
+	for v := 0; v < hashSize; v++ {
+		for u := 0; u < hashSize; u++ {
+			var sum float64
+
+			for y := 0; y < sampleSize; y++ {
+				sum += rows[y][u] * cosines[v][y]
+			}
+
+			result[v][u] = sum
+		}
+	}
+
+	return result
+}
+
+// cosines caches the DCT basis functions.
+var cosines = func() (c [hashSize][sampleSize]float64) {
+	for u := 0; u < hashSize; u++ {
+		for x := 0; x < sampleSize; x++ {
+			c[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * sampleSize))
+		}
+	}
+
+	return c
+}()
+
+// Distance returns the number of different bits, 0 means the images are perceptually identical.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// Hex returns the hash as hexadecimal string with a fixed length of 16 characters.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// String implements the Stringer interface.
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+// Parse returns the hash from a hexadecimal string.
+func Parse(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	return Hash(v), nil
+}
@@ -0,0 +1,148 @@
+package phash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage returns a synthetic image with a blurred pattern of random blocks, the seed changes the pattern.
+func testImage(width, height int, seed int64) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	rnd := rand.New(rand.NewSource(seed))
+
+	const blocks = 12
+
+	var values [blocks][blocks]color.NRGBA
+
+	for y := range values {
+		for x := range values[y] {
+			values[y][x] = color.NRGBA{R: uint8(rnd.Intn(200) + 20), G: uint8(rnd.Intn(200) + 20), B: uint8(rnd.Intn(200) + 20), A: 255}
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, values[y*blocks/height][x*blocks/width])
+		}
+	}
+
+	return imaging.Blur(img, 4)
+}
+
+// recompress encodes the image as JPEG with a low quality and decodes it again.
+func recompress(t *testing.T, img image.Image) image.Image {
+	var b bytes.Buffer
+
+	if err := jpeg.Encode(&b, img, &jpeg.Options{Quality: 40}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := jpeg.Decode(&b)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestFromImage(t *testing.T) {
+	original := testImage(800, 600, 1)
+	h := FromImage(original)
+
+	t.Run("Resized", func(t *testing.T) {
+		assert.LessOrEqual(t, h.Distance(FromImage(imaging.Resize(original, 320, 0, imaging.Lanczos))), 2)
+	})
+	t.Run("Recompressed", func(t *testing.T) {
+		assert.LessOrEqual(t, h.Distance(FromImage(recompress(t, imaging.Resize(original, 400, 0, imaging.Linear)))), 4)
+	})
+	t.Run("Brighter", func(t *testing.T) {
+		assert.LessOrEqual(t, h.Distance(FromImage(imaging.AdjustBrightness(original, 10))), 4)
+	})
+	t.Run("Different", func(t *testing.T) {
+		assert.Greater(t, h.Distance(FromImage(testImage(800, 600, 3))), 10)
+		assert.Greater(t, h.Distance(FromImage(imaging.Rotate180(original))), 10)
+	})
+}
+
+func TestHash_Hex(t *testing.T) {
+	h := Hash(0x00ff00ff00ff00ff)
+
+	assert.Equal(t, "00ff00ff00ff00ff", h.Hex())
+	assert.Equal(t, "00ff00ff00ff00ff", h.String())
+
+	parsed, err := Parse(h.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, h, parsed)
+
+	_, err = Parse("xyz")
+	assert.Error(t, err)
+
+	_, err = Parse("zzzzzzzzzzzzzzzz")
+	assert.Error(t, err)
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(42).Distance(42))
+	assert.Equal(t, 1, Hash(0).Distance(1))
+	assert.Equal(t, 64, Hash(0).Distance(math.MaxUint64))
+}
+
+func TestGroups(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, Groups(nil, 4))
+		assert.Empty(t, Groups([]Hash{1}, 4))
+	})
+	t.Run("Chain", func(t *testing.T) {
+		hashes := []Hash{
+			0x0000000000000000,
+			0xffffffffffffffff,
+			0x0000000000000007, // 3 bits from 0
+			0xfffffffffffffffe, // 1 bit from 1
+			0x000000000000003f, // 3 bits from 2
+			0x00000000ffff0000,
+		}
+
+		assert.Equal(t, [][]int{{0, 2, 4}, {1, 3}}, Groups(hashes, 3))
+		assert.Equal(t, [][]int{{1, 3}}, Groups(hashes, 1))
+		assert.Empty(t, Groups(hashes, 0))
+	})
+	t.Run("Identical", func(t *testing.T) {
+		assert.Equal(t, [][]int{{0, 1}}, Groups([]Hash{5, 5}, 0))
+	})
+	t.Run("BruteForce", func(t *testing.T) {
+		var hashes []Hash
+
+		for i := 0; i < 200; i++ {
+			hashes = append(hashes, Hash(uint64(i*i*2654435761)%(1<<20)))
+		}
+
+		for _, dist := range []int{2, 5} {
+			seen := make(map[int]int)
+
+			for g, group := range Groups(hashes, dist) {
+				for _, i := range group {
+					seen[i] = g + 1
+				}
+			}
+
+			for i := range hashes {
+				for j := i + 1; j < len(hashes); j++ {
+					if hashes[i].Distance(hashes[j]) <= dist {
+						assert.NotZero(t, seen[i])
+						assert.Equal(t, seen[i], seen[j])
+					}
+				}
+			}
+		}
+	})
+}
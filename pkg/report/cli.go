@@ -10,6 +10,8 @@ func CliFormat(ctx *cli.Context) Format {
 		return TSV
 	case ctx.Bool("csv"):
 		return CSV
+	case ctx.Bool("json"):
+		return JSON
 	default:
 		return Default
 	}
@@ -28,4 +30,8 @@ var CliFlags = []cli.Flag{
 		Name:  "tsv, t",
 		Usage: "export as tab separated values",
 	},
+	cli.BoolFlag{
+		Name:  "json, j",
+		Usage: "export as JSON array of objects",
+	},
 }
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JsonExport returns the report as JSON array of objects with the column names as keys, in column order.
+func JsonExport(rows [][]string, cols []string) (string, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("[")
+
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		buf.WriteString("\n  {")
+
+		for j, col := range cols {
+			var val string
+
+			if j < len(row) {
+				val = row[j]
+			}
+
+			k, err := json.Marshal(col)
+
+			if err != nil {
+				return "", err
+			}
+
+			v, err := json.Marshal(val)
+
+			if err != nil {
+				return "", err
+			}
+
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+
+			buf.Write(k)
+			buf.WriteString(": ")
+			buf.Write(v)
+		}
+
+		buf.WriteString("}")
+	}
+
+	if len(rows) > 0 {
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("]\n")
+
+	return buf.String(), nil
+}
@@ -14,6 +14,8 @@ func Render(rows [][]string, cols []string, format Format) (string, error) {
 		return CsvExport(rows, cols, ';')
 	case TSV:
 		return CsvExport(rows, cols, '\t')
+	case JSON:
+		return JsonExport(rows, cols)
 	case Markdown:
 		return MarkdownTable(rows, cols, "", true), nil
 	case Default:
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -49,6 +50,30 @@ func TestTable(t *testing.T) {
 
 		assert.Contains(t, result, "Col1\tCol2\nfoo\tbar, abc, abc")
 	})
+	t.Run("JsonExport", func(t *testing.T) {
+		result, err := Render([][]string{{"foo", "b\"ar"}, {"baz"}}, cols, JSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "[\n  {\"Col1\": \"foo\", \"Col2\": \"b\\\"ar\"},\n  {\"Col1\": \"baz\", \"Col2\": \"\"}\n]\n", result)
+
+		var decoded []map[string]string
+
+		if err = json.Unmarshal([]byte(result), &decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "b\"ar", decoded[0]["Col2"])
+	})
+	t.Run("JsonEmpty", func(t *testing.T) {
+		result, err := Render([][]string{}, cols, JSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "[]\n", result)
+	})
 	t.Run("Invalid", func(t *testing.T) {
 		_, err := Render(rows, cols, Format("invalid"))
 
@@ -0,0 +1,26 @@
+package rnd
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const secretChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// GenerateSecret returns a random string of lowercase letters and numbers of the specified length, e.g. for use as access token.
+func GenerateSecret(length int) string {
+	result := make([]byte, length)
+	max := big.NewInt(int64(len(secretChars)))
+
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+
+		if err != nil {
+			panic(err)
+		}
+
+		result[i] = secretChars[n.Int64()]
+	}
+
+	return string(result)
+}
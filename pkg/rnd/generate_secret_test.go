@@ -0,0 +1,15 @@
+package rnd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	s := GenerateSecret(32)
+	assert.Len(t, s, 32)
+	assert.True(t, IsAlnum(s))
+	assert.NotEqual(t, s, GenerateSecret(32))
+	assert.Equal(t, "", GenerateSecret(0))
+}
@@ -0,0 +1,136 @@
+/*
+Package totp implements time-based one-time passwords as specified in RFC 6238.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Digits     = 6
+	Period     = 30
+	SecretSize = 20
+)
+
+// Skew is the number of periods before and after the current time in which a code is still accepted.
+var Skew int64 = 1
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random shared secret encoded as base32 string.
+func GenerateSecret() string {
+	b := make([]byte, SecretSize)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return encoding.EncodeToString(b)
+}
+
+// Counter returns the time step number for the specified time.
+func Counter(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code returns the one-time password for a secret and time step number.
+func Code(secret string, counter int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "=")))
+
+	if err != nil {
+		return "", fmt.Errorf("totp: invalid secret")
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate checks the code against the secret and returns the matching time step number,
+// codes for time steps up to and including last are rejected to prevent replay attacks.
+func Validate(secret, code string, t time.Time, last int64) (counter int64, ok bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	now := Counter(t)
+
+	for c := now - Skew; c <= now+Skew; c++ {
+		if c <= last {
+			continue
+		}
+
+		if expected, err := Code(secret, c); err != nil {
+			return 0, false
+		} else if hmac.Equal([]byte(expected), []byte(code)) {
+			return c, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns the provisioning URI for authenticator apps, usually displayed as QR code.
+func URI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(Period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
@@ -0,0 +1,89 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the base32 encoded test secret "12345678901234567890" from RFC 6238, Appendix B.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	t.Run("RFC6238", func(t *testing.T) {
+		vectors := map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		}
+
+		for ts, expected := range vectors {
+			code, err := Code(rfcSecret, Counter(time.Unix(ts, 0)))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, expected, code)
+		}
+	})
+	t.Run("InvalidSecret", func(t *testing.T) {
+		_, err := Code("!!!", 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, _ := Code(rfcSecret, Counter(now))
+
+	t.Run("Valid", func(t *testing.T) {
+		counter, ok := Validate(rfcSecret, code, now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, Counter(now), counter)
+	})
+	t.Run("Skew", func(t *testing.T) {
+		_, ok := Validate(rfcSecret, code, now.Add(Period*time.Second), 0)
+		assert.True(t, ok)
+		_, ok = Validate(rfcSecret, code, now.Add(3*Period*time.Second), 0)
+		assert.False(t, ok)
+	})
+	t.Run("Replay", func(t *testing.T) {
+		_, ok := Validate(rfcSecret, code, now, Counter(now))
+		assert.False(t, ok)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := Validate(rfcSecret, "000000", now, 0)
+		assert.False(t, ok)
+		_, ok = Validate(rfcSecret, "123", now, 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	s := GenerateSecret()
+	assert.Len(t, s, 32)
+	assert.NotEqual(t, s, GenerateSecret())
+
+	_, err := Code(s, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("PhotoPrism", "admin", rfcSecret))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/PhotoPrism:admin", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "PhotoPrism", u.Query().Get("issuer"))
+}
@@ -0,0 +1,73 @@
+package track
+
+import (
+	"github.com/tidwall/gjson"
+)
+
+// ParseGeoJSON returns the timestamped points in a GeoJSON feature collection. Times of line strings are
+// read from the "coordTimes" or "times" property, as written by common converters, times of points from
+// the "time" or "timestamp" property.
+func ParseGeoJSON(data []byte) (points Points, err error) {
+	doc := gjson.ParseBytes(data)
+
+	var features []gjson.Result
+
+	switch doc.Get("type").String() {
+	case "FeatureCollection":
+		features = doc.Get("features").Array()
+	case "Feature":
+		features = []gjson.Result{doc}
+	}
+
+	add := func(coords, times []gjson.Result) {
+		for i, c := range coords {
+			if i >= len(times) {
+				break
+			}
+
+			if c := c.Array(); len(c) >= 2 {
+				var alt float64
+
+				if len(c) > 2 {
+					alt = c[2].Float()
+				}
+
+				points.Add(parseTime(times[i].String()), c[1].Float(), c[0].Float(), alt)
+			}
+		}
+	}
+
+	for _, f := range features {
+		props := f.Get("properties")
+		times := props.Get("coordTimes")
+
+		if !times.Exists() {
+			times = props.Get("times")
+		}
+
+		coords := f.Get("geometry.coordinates")
+
+		switch f.Get("geometry.type").String() {
+		case "Point":
+			t := props.Get("time")
+
+			if !t.Exists() {
+				t = props.Get("timestamp")
+			}
+
+			add([]gjson.Result{coords}, []gjson.Result{t})
+		case "LineString":
+			add(coords.Array(), times.Array())
+		case "MultiLineString":
+			lines, lineTimes := coords.Array(), times.Array()
+
+			for i := range lines {
+				if i < len(lineTimes) {
+					add(lines[i].Array(), lineTimes[i].Array())
+				}
+			}
+		}
+	}
+
+	return points, nil
+}
@@ -0,0 +1,56 @@
+package track
+
+import (
+	"encoding/xml"
+)
+
+// gpxPoint represents a GPX track, route or way point.
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+// gpxDocument represents a GPX 1.0 or 1.1 document, see https://www.topografix.com/gpx.asp.
+type gpxDocument struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// ParseGPX returns the timestamped points of all tracks, routes and way points in a GPX document.
+func ParseGPX(data []byte) (points Points, err error) {
+	doc := gpxDocument{}
+
+	if err = xml.Unmarshal(data, &doc); err != nil {
+		return points, err
+	}
+
+	add := func(list []gpxPoint) {
+		for _, p := range list {
+			points.Add(parseTime(p.Time), p.Lat, p.Lon, p.Ele)
+		}
+	}
+
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			add(seg.Points)
+		}
+	}
+
+	for _, rte := range doc.Routes {
+		add(rte.Points)
+	}
+
+	add(doc.Waypoints)
+
+	return points, nil
+}
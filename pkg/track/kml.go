@@ -0,0 +1,116 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseKML returns the points of gx:Track elements and of placemarks with a timestamp in a KML document,
+// see https://developers.google.com/kml/documentation/kmlreference.
+func ParseKML(data []byte) (points Points, err error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	var whens, coords []string
+	var placemarkTime time.Time
+	var placemarkCoords string
+	var path []string
+
+	for {
+		token, tokenErr := d.Token()
+
+		if tokenErr == io.EOF {
+			break
+		} else if tokenErr != nil {
+			return points, tokenErr
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+
+			switch name {
+			case "Placemark":
+				placemarkTime, placemarkCoords = time.Time{}, ""
+			case "Track":
+				whens, coords = nil, nil
+			case "when", "coord", "coordinates":
+				var s string
+
+				if err = d.DecodeElement(&s, &t); err != nil {
+					return points, err
+				}
+
+				parent := ""
+
+				if len(path) > 0 {
+					parent = path[len(path)-1]
+				}
+
+				switch {
+				case name == "when" && parent == "Track":
+					whens = append(whens, s)
+				case name == "when" && parent == "TimeStamp":
+					placemarkTime = parseTime(s)
+				case name == "coord":
+					coords = append(coords, s)
+				case name == "coordinates" && parent == "Point":
+					placemarkCoords = s
+				}
+
+				continue
+			}
+
+			path = append(path, name)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "Track":
+				for i := range whens {
+					if i >= len(coords) {
+						break
+					}
+
+					// gx:coord values are separated by spaces.
+					if lng, lat, alt, ok := kmlCoord(coords[i], " "); ok {
+						points.Add(parseTime(whens[i]), lat, lng, alt)
+					}
+				}
+			case "Placemark":
+				if lng, lat, alt, ok := kmlCoord(placemarkCoords, ","); ok {
+					points.Add(placemarkTime, lat, lng, alt)
+				}
+			}
+
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+	}
+
+	return points, nil
+}
+
+// kmlCoord parses a KML coordinate, the longitude comes first and the altitude is optional.
+func kmlCoord(s, sep string) (lng, lat, alt float64, ok bool) {
+	values := strings.Split(strings.TrimSpace(s), sep)
+
+	if len(values) < 2 {
+		return 0, 0, 0, false
+	}
+
+	lng, lngErr := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+	if lngErr != nil || latErr != nil {
+		return 0, 0, 0, false
+	}
+
+	if len(values) > 2 {
+		alt, _ = strconv.ParseFloat(strings.TrimSpace(values[2]), 64)
+	}
+
+	return lng, lat, alt, true
+}
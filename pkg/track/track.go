@@ -0,0 +1,193 @@
+/*
+Package track reads GPS track logs in GPX, KML and GeoJSON format.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package track
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// Types maps supported track log file types to their parsers.
+var Types = map[fs.Type]func(data []byte) (Points, error){
+	fs.GpxFile:     ParseGPX,
+	fs.KmlFile:     ParseKML,
+	fs.GeoJsonFile: ParseGeoJSON,
+}
+
+// Supported tests if the file is a supported track log.
+func Supported(fileName string) bool {
+	_, ok := Types[fs.FileType(fileName)]
+	return ok
+}
+
+// Read returns the track points in a GPX, KML or GeoJSON file, sorted by time.
+func Read(fileName string) (Points, error) {
+	parse, ok := Types[fs.FileType(fileName)]
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported track log %s", fs.FileType(fileName))
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := parse(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	points.Sort()
+
+	return points, nil
+}
+
+// Points represents track points sorted by time.
+type Points []geo.Position
+
+// Add adds a point if it has a time and valid coordinates.
+func (points *Points) Add(t time.Time, lat, lng, altitude float64) {
+	if t.IsZero() || lat == 0 && lng == 0 || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return
+	}
+
+	*points = append(*points, geo.Position{Time: t.UTC(), Lat: lat, Lng: lng, Altitude: altitude})
+}
+
+// Sort sorts the points by time.
+func (points Points) Sort() {
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+}
+
+// Start returns the time of the first point.
+func (points Points) Start() time.Time {
+	if len(points) == 0 {
+		return time.Time{}
+	}
+
+	return points[0].Time
+}
+
+// End returns the time of the last point.
+func (points Points) End() time.Time {
+	if len(points) == 0 {
+		return time.Time{}
+	}
+
+	return points[len(points)-1].Time
+}
+
+// Position returns the position at the specified time, interpolated between the nearest points if
+// both are no more than maxGap away, or the closest point otherwise. The result is not ok if the
+// nearest point is more than maxGap away.
+func (points Points) Position(t time.Time, maxGap time.Duration) (pos geo.Position, ok bool) {
+	n := len(points)
+
+	if n == 0 || t.IsZero() {
+		return pos, false
+	}
+
+	t = t.UTC()
+	i := sort.Search(n, func(i int) bool { return !points[i].Time.Before(t) })
+
+	switch {
+	case i < n && points[i].Time.Equal(t):
+		pos = points[i]
+		pos.Accuracy = 5
+	case i == 0:
+		if points[0].Time.Sub(t) > maxGap {
+			return pos, false
+		}
+
+		pos = points[0]
+	case i == n:
+		if t.Sub(points[n-1].Time) > maxGap {
+			return pos, false
+		}
+
+		pos = points[n-1]
+	default:
+		m := geo.NewMovement(points[i-1], points[i])
+
+		if t.Sub(m.Start.Time) > maxGap || m.End.Time.Sub(t) > maxGap {
+			if pos = m.Closest(t); absDuration(pos.Time.Sub(t)) > maxGap {
+				return pos, false
+			}
+		} else {
+			f := t.Sub(m.Start.Time).Seconds() / m.Seconds()
+			lat, lng := m.Deg()
+
+			pos = geo.Position{
+				Lat:      m.Start.Lat + f*lat,
+				Lng:      m.Start.Lng + f*lng,
+				Altitude: m.EstimateAltitude(t),
+				Accuracy: m.EstimateAccuracy(t),
+			}
+		}
+	}
+
+	if pos.Accuracy == 0 {
+		pos.Accuracy = 5
+	}
+
+	pos.Name = "track"
+	pos.Time = t
+	pos.Estimate = false
+
+	return pos, true
+}
+
+// absDuration returns the absolute value of a duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+// parseTime parses a track point timestamp, times without time zone are assumed to be UTC.
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+
+	return time.Time{}
+}
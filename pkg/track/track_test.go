@@ -0,0 +1,90 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported("testdata/track.gpx"))
+	assert.True(t, Supported("testdata/track.KML"))
+	assert.True(t, Supported("testdata/track.geojson"))
+	assert.False(t, Supported("testdata/track.json"))
+}
+
+func TestRead(t *testing.T) {
+	for _, fileName := range []string{"testdata/track.gpx", "testdata/track.kml", "testdata/track.geojson"} {
+		t.Run(fileName, func(t *testing.T) {
+			points, err := Read(fileName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if assert.Len(t, points, 4) {
+				assert.Equal(t, time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC), points.Start())
+				assert.Equal(t, time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC), points.End())
+				assert.InDelta(t, 52.5163, points[0].Lat, 0.00001)
+				assert.InDelta(t, 13.3777, points[0].Lng, 0.00001)
+				assert.Equal(t, float64(34), points[0].Altitude)
+				assert.Equal(t, float64(40), points[3].Altitude)
+			}
+		})
+	}
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Read("testdata/track.txt")
+		assert.Error(t, err)
+	})
+}
+
+func TestPoints_Position(t *testing.T) {
+	points, err := Read("testdata/track.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Exact", func(t *testing.T) {
+		pos, ok := points.Position(time.Date(2021, 6, 1, 12, 10, 0, 0, time.UTC), time.Minute)
+		assert.True(t, ok)
+		assert.Equal(t, 52.5186, pos.Lat)
+		assert.Equal(t, 13.3761, pos.Lng)
+		assert.False(t, pos.Estimate)
+	})
+	t.Run("Interpolated", func(t *testing.T) {
+		pos, ok := points.Position(time.Date(2021, 6, 1, 14, 5, 0, 0, time.FixedZone("CEST", 7200)), 15*time.Minute)
+		assert.True(t, ok)
+		assert.InDelta(t, 52.51745, pos.Lat, 0.000001)
+		assert.InDelta(t, 13.3769, pos.Lng, 0.000001)
+		assert.Equal(t, float64(35), pos.Altitude)
+		assert.Equal(t, time.Date(2021, 6, 1, 12, 5, 0, 0, time.UTC), pos.Time)
+	})
+	t.Run("Gap", func(t *testing.T) {
+		pos, ok := points.Position(time.Date(2021, 6, 1, 12, 23, 0, 0, time.UTC), 5*time.Minute)
+		assert.True(t, ok)
+		assert.Equal(t, 52.52, pos.Lat)
+		assert.Equal(t, 13.38, pos.Lng)
+
+		_, ok = points.Position(time.Date(2021, 6, 1, 12, 25, 0, 0, time.UTC), 4*time.Minute)
+		assert.False(t, ok)
+
+		// Interpolated if both points are no more than maxGap away.
+		pos, ok = points.Position(time.Date(2021, 6, 1, 12, 5, 0, 0, time.UTC), 5*time.Minute)
+		assert.True(t, ok)
+		assert.InDelta(t, 52.51745, pos.Lat, 0.000001)
+	})
+	t.Run("Outside", func(t *testing.T) {
+		_, ok := points.Position(time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC), 30*time.Minute)
+		assert.False(t, ok)
+
+		pos, ok := points.Position(time.Date(2021, 6, 1, 12, 40, 0, 0, time.UTC), 30*time.Minute)
+		assert.True(t, ok)
+		assert.Equal(t, 13.404, pos.Lng)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := Points{}.Position(time.Now(), time.Hour)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,252 @@
+package vector
+
+import (
+	"container/heap"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ParallelSize is the minimum number of vectors that are searched in parallel.
+var ParallelSize = 10000
+
+// Match represents a search result with its cosine similarity score.
+type Match struct {
+	ID    uint
+	Score float32
+}
+
+// Matches represents a list of search results, sorted by score in descending order.
+type Matches []Match
+
+// IDs returns the result IDs.
+func (m Matches) IDs() []uint {
+	result := make([]uint, len(m))
+
+	for i := range m {
+		result[i] = m[i].ID
+	}
+
+	return result
+}
+
+// Index is an exhaustive nearest-neighbor index for cosine similarity, safe for concurrent use.
+//
+// Vectors are normalized and quantized to 8-bit integers, so that 200,000 vectors with 512
+// dimensions need about 100 MB of memory and can be searched in well under a second on a CPU.
+// Scores are exact to about two decimal places.
+type Index struct {
+	mutex sync.RWMutex
+	dim   int
+	ids   []uint
+	data  []int8
+	pos   map[uint]int
+}
+
+// NewIndex returns a new, empty index.
+func NewIndex() *Index {
+	return &Index{pos: make(map[uint]int)}
+}
+
+// Len returns the number of vectors in the index.
+func (x *Index) Len() int {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	return len(x.ids)
+}
+
+// Dim returns the number of vector dimensions, or 0 if the index is empty.
+func (x *Index) Dim() int {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	return x.dim
+}
+
+// Add adds a vector to the index or replaces the existing vector with the same id.
+// All vectors must have the same number of dimensions.
+func (x *Index) Add(id uint, v []float32) error {
+	n := Normalize(v)
+
+	if n == nil {
+		return ErrZero
+	}
+
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if len(x.ids) == 0 {
+		x.dim = len(n)
+	} else if len(n) != x.dim {
+		return ErrDimension
+	}
+
+	q := quantize(n)
+
+	if i, ok := x.pos[id]; ok {
+		copy(x.data[i*x.dim:(i+1)*x.dim], q)
+		return nil
+	}
+
+	x.pos[id] = len(x.ids)
+	x.ids = append(x.ids, id)
+	x.data = append(x.data, q...)
+
+	return nil
+}
+
+// Remove removes the vector with the specified id, if it exists.
+func (x *Index) Remove(id uint) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	i, ok := x.pos[id]
+
+	if !ok {
+		return
+	}
+
+	last := len(x.ids) - 1
+
+	// Move the last vector into the free position.
+	if i != last {
+		x.ids[i] = x.ids[last]
+		x.pos[x.ids[i]] = i
+		copy(x.data[i*x.dim:(i+1)*x.dim], x.data[last*x.dim:])
+	}
+
+	delete(x.pos, id)
+	x.ids = x.ids[:last]
+	x.data = x.data[:last*x.dim]
+}
+
+// Search returns up to k vectors with the highest cosine similarity to the query vector,
+// and a score of at least minScore.
+func (x *Index) Search(query []float32, k int, minScore float32) (Matches, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if len(x.ids) == 0 || k < 1 {
+		return Matches{}, nil
+	} else if len(query) != x.dim {
+		return nil, ErrDimension
+	}
+
+	q := Normalize(query)
+
+	if q == nil {
+		return nil, ErrZero
+	}
+
+	// Scale the query, so that scores are cosine similarities.
+	for i := range q {
+		q[i] /= math.MaxInt8
+	}
+
+	n := len(x.ids)
+	workers := 1
+
+	if n >= ParallelSize {
+		workers = runtime.NumCPU()
+	}
+
+	chunk := (n + workers - 1) / workers
+	results := make([]Matches, workers)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		from, to := w*chunk, (w+1)*chunk
+
+		if to > n {
+			to = n
+		}
+
+		if from >= to {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(w, from, to int) {
+			defer wg.Done()
+			results[w] = x.search(q, from, to, k, minScore)
+		}(w, from, to)
+	}
+
+	wg.Wait()
+
+	var result Matches
+
+	for _, r := range results {
+		result = append(result, r...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score == result[j].Score {
+			return result[i].ID < result[j].ID
+		}
+
+		return result[i].Score > result[j].Score
+	})
+
+	if len(result) > k {
+		result = result[:k]
+	}
+
+	return result, nil
+}
+
+// search returns the best matches of the vectors in the specified range, the caller must hold the lock.
+func (x *Index) search(q []float32, from, to, k int, minScore float32) Matches {
+	h := &matchHeap{}
+
+	for i := from; i < to; i++ {
+		v := x.data[i*x.dim : (i+1)*x.dim]
+
+		var score float32
+
+		for j, value := range v {
+			score += q[j] * float32(value)
+		}
+
+		if score < minScore {
+			continue
+		} else if h.Len() < k {
+			heap.Push(h, Match{ID: x.ids[i], Score: score})
+		} else if score > (*h)[0].Score {
+			(*h)[0] = Match{ID: x.ids[i], Score: score}
+			heap.Fix(h, 0)
+		}
+	}
+
+	return Matches(*h)
+}
+
+// quantize converts a normalized vector to 8-bit integers.
+func quantize(v []float32) []int8 {
+	result := make([]int8, len(v))
+
+	for i, x := range v {
+		result[i] = int8(math.Round(float64(x) * math.MaxInt8))
+	}
+
+	return result
+}
+
+// matchHeap is a min-heap of matches by score.
+type matchHeap []Match
+
+func (h matchHeap) Len() int            { return len(h) }
+func (h matchHeap) Less(i, j int) bool  { return h[i].Score < h[j].Score }
+func (h matchHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *matchHeap) Push(x interface{}) { *h = append(*h, x.(Match)) }
+func (h *matchHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	m := old[n-1]
+	*h = old[:n-1]
+	return m
+}
@@ -0,0 +1,172 @@
+package vector
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomVector(r *rand.Rand, dim int) []float32 {
+	v := make([]float32, dim)
+
+	for i := range v {
+		v[i] = float32(r.NormFloat64())
+	}
+
+	return v
+}
+
+func TestIndex_Add(t *testing.T) {
+	x := NewIndex()
+
+	assert.NoError(t, x.Add(1, []float32{1, 0, 0}))
+	assert.NoError(t, x.Add(2, []float32{0, 1, 0}))
+	assert.Equal(t, 2, x.Len())
+	assert.Equal(t, 3, x.Dim())
+
+	// Replace existing vector.
+	assert.NoError(t, x.Add(1, []float32{0, 0, 1}))
+	assert.Equal(t, 2, x.Len())
+
+	assert.ErrorIs(t, x.Add(3, []float32{1, 0}), ErrDimension)
+	assert.ErrorIs(t, x.Add(3, []float32{0, 0, 0}), ErrZero)
+
+	result, err := x.Search([]float32{0, 0, 2}, 1, 0)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, uint(1), result[0].ID)
+		assert.InDelta(t, 1, result[0].Score, 0.01)
+	}
+}
+
+func TestIndex_Remove(t *testing.T) {
+	x := NewIndex()
+
+	for id := uint(1); id <= 3; id++ {
+		assert.NoError(t, x.Add(id, []float32{float32(id), 1}))
+	}
+
+	x.Remove(1)
+	x.Remove(5)
+
+	assert.Equal(t, 2, x.Len())
+
+	result, err := x.Search([]float32{1, 1}, 10, -1)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uint{2, 3}, result.IDs())
+
+	// Removed vectors can be added again.
+	assert.NoError(t, x.Add(1, []float32{1, 1}))
+
+	result, err = x.Search([]float32{1, 1}, 1, -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, result.IDs())
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		result, err := NewIndex().Search([]float32{1, 2}, 5, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("Exact", func(t *testing.T) {
+		r := rand.New(rand.NewSource(42))
+		x := NewIndex()
+		vectors := make(map[uint][]float32)
+
+		for id := uint(1); id <= 2000; id++ {
+			vectors[id] = randomVector(r, 64)
+			assert.NoError(t, x.Add(id, vectors[id]))
+		}
+
+		query := randomVector(r, 64)
+
+		var expected Matches
+
+		for id, v := range vectors {
+			expected = append(expected, Match{ID: id, Score: Cosine(query, v)})
+		}
+
+		sort.Slice(expected, func(i, j int) bool { return expected[i].Score > expected[j].Score })
+
+		result, err := x.Search(query, 10, -1)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 10)
+
+		for i := range result {
+			assert.InDelta(t, expected[i].Score, result[i].Score, 0.02)
+		}
+
+		// Quantization may swap neighbors with almost the same score.
+		assert.Subset(t, expected[:15].IDs(), result.IDs())
+	})
+	t.Run("Parallel", func(t *testing.T) {
+		r := rand.New(rand.NewSource(7))
+		x := NewIndex()
+		size := ParallelSize
+
+		ParallelSize = 100
+		defer func() { ParallelSize = size }()
+
+		for id := uint(1); id <= 1000; id++ {
+			assert.NoError(t, x.Add(id, randomVector(r, 16)))
+		}
+
+		target := randomVector(r, 16)
+		assert.NoError(t, x.Add(5000, target))
+
+		result, err := x.Search(target, 3, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, uint(5000), result[0].ID)
+		assert.GreaterOrEqual(t, result[0].Score, result[1].Score)
+		assert.GreaterOrEqual(t, result[1].Score, result[2].Score)
+	})
+	t.Run("MinScore", func(t *testing.T) {
+		x := NewIndex()
+
+		assert.NoError(t, x.Add(1, []float32{1, 0}))
+		assert.NoError(t, x.Add(2, []float32{0, 1}))
+		assert.NoError(t, x.Add(3, []float32{-1, 0}))
+
+		result, err := x.Search([]float32{1, 0.1}, 10, 0.5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1}, result.IDs())
+	})
+	t.Run("Dimension", func(t *testing.T) {
+		x := NewIndex()
+
+		assert.NoError(t, x.Add(1, []float32{1, 0}))
+
+		_, err := x.Search([]float32{1, 0, 0}, 1, 0)
+
+		assert.ErrorIs(t, err, ErrDimension)
+	})
+}
+
+func BenchmarkIndex_Search(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x := NewIndex()
+
+	for id := uint(1); id <= 200000; id++ {
+		_ = x.Add(id, randomVector(r, 512))
+	}
+
+	query := randomVector(r, 512)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Search(query, 1000, 0)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Package vector provides an in-memory index to find the most similar embedding vectors by cosine similarity.
+
+Copyright (c) 2018 - 2022 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package vector
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var (
+	ErrDimension = errors.New("vector dimensions do not match")
+	ErrZero      = errors.New("vector length must not be zero")
+)
+
+// Norm returns the euclidean length of a vector.
+func Norm(v []float32) float64 {
+	var sum float64
+
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Normalize returns a copy of the vector with a length of 1, or nil if the length is 0.
+func Normalize(v []float32) []float32 {
+	n := Norm(v)
+
+	if n == 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+		return nil
+	}
+
+	result := make([]float32, len(v))
+
+	for i, x := range v {
+		result[i] = float32(float64(x) / n)
+	}
+
+	return result
+}
+
+// Cosine returns the cosine similarity of two vectors, between -1 and 1.
+func Cosine(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, na, nb float64
+
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	return float32(dot / (math.Sqrt(na) * math.Sqrt(nb)))
+}
+
+// Encode returns the vector in little-endian binary format, 4 bytes per value.
+func Encode(v []float32) []byte {
+	result := make([]byte, len(v)*4)
+
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(result[i*4:], math.Float32bits(x))
+	}
+
+	return result
+}
+
+// Decode returns a vector from its little-endian binary format.
+func Decode(b []byte) ([]float32, error) {
+	if len(b)%4 != 0 {
+		return nil, errors.New("invalid vector length")
+	}
+
+	result := make([]float32, len(b)/4)
+
+	for i := range result {
+		result[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[i*4:]))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, []float32{0.6, 0.8}, Normalize([]float32{3, 4}))
+	assert.InDelta(t, 1, Norm(Normalize([]float32{1, 2, 3, 4})), 0.00001)
+	assert.Nil(t, Normalize([]float32{0, 0}))
+	assert.Nil(t, Normalize(nil))
+}
+
+func TestCosine(t *testing.T) {
+	assert.InDelta(t, 1, Cosine([]float32{1, 2}, []float32{2, 4}), 0.00001)
+	assert.InDelta(t, 0, Cosine([]float32{1, 0}, []float32{0, 3}), 0.00001)
+	assert.InDelta(t, -1, Cosine([]float32{1, 1}, []float32{-1, -1}), 0.00001)
+	assert.Equal(t, float32(0), Cosine([]float32{1}, []float32{1, 2}))
+	assert.Equal(t, float32(0), Cosine([]float32{0, 0}, []float32{1, 2}))
+}
+
+func TestEncode(t *testing.T) {
+	v := []float32{0.25, -1.5, 3e-7, 0}
+
+	b := Encode(v)
+
+	assert.Len(t, b, 16)
+
+	result, err := Decode(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, v, result)
+
+	_, err = Decode([]byte{1, 2, 3})
+
+	assert.Error(t, err)
+}